@@ -0,0 +1,12 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2021-Present The Zarf Authors
+
+// Package zarfagent embeds the Zarf agent chart so that it can be rendered without a checkout of the repository.
+package zarfagent
+
+import "embed"
+
+// Chart holds the files of the agent chart, the chart itself is in the chart directory.
+//
+//go:embed all:chart
+var Chart embed.FS
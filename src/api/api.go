@@ -28,6 +28,14 @@ type VersionRequirement struct {
 	Reason  string
 }
 
+// VerifiedGitRef records a git ref whose signature was verified during package assembly.
+type VerifiedGitRef struct {
+	Repo   string
+	Ref    string
+	Hash   string
+	Signer string
+}
+
 // BuildData contains version-neutral build metadata recorded during package assembly.
 type BuildData struct {
 	Hostname            string
@@ -36,6 +44,8 @@ type BuildData struct {
 	Timestamp           string
 	Version             string
 	RegistryOverrides   map[string]string
+	ImageMirrors        map[string]string
+	VerifiedGitRefs     []VerifiedGitRef
 	Flavor              string
 	Signed              *bool
 	VersionRequirements []VersionRequirement
@@ -213,10 +223,12 @@ func (p *PackageDefinition) SetBuildData(buildData BuildData) {
 	p.pkg.Build.Timestamp = buildData.Timestamp
 	p.pkg.Build.Version = buildData.Version
 	p.pkg.Build.RegistryOverrides = maps.Clone(buildData.RegistryOverrides)
+	p.pkg.Build.ImageMirrors = maps.Clone(buildData.ImageMirrors)
 	p.pkg.Build.Flavor = buildData.Flavor
 	p.pkg.Build.Signed = cloneBool(buildData.Signed)
 	p.pkg.Build.ProvenanceFiles = slices.Clone(buildData.ProvenanceFiles)
 	p.pkg.Build.VersionRequirements = versionRequirementsToInternal(buildData.VersionRequirements)
+	p.pkg.Build.VerifiedGitRefs = verifiedGitRefsToInternal(buildData.VerifiedGitRefs)
 	p.pkg.Metadata.AggregateChecksum = buildData.AggregateChecksum
 	p.pkg.Build.AggregateChecksum = buildData.AggregateChecksum
 }
@@ -267,6 +279,22 @@ func versionRequirementsToInternal(requirements []VersionRequirement) []internal
 	return converted
 }
 
+func verifiedGitRefsToInternal(refs []VerifiedGitRef) []internaltypes.VerifiedGitRef {
+	if len(refs) == 0 {
+		return nil
+	}
+	converted := make([]internaltypes.VerifiedGitRef, len(refs))
+	for i, ref := range refs {
+		converted[i] = internaltypes.VerifiedGitRef{
+			Repo:   ref.Repo,
+			Ref:    ref.Ref,
+			Hash:   ref.Hash,
+			Signer: ref.Signer,
+		}
+	}
+	return converted
+}
+
 func cloneBool(value *bool) *bool {
 	if value == nil {
 		return nil
@@ -33,6 +33,9 @@ type ZarfComponent struct {
 	// Helm charts to install during package deploy.
 	Charts []ZarfChart `json:"charts,omitempty"`
 
+	// [alpha] Push this component's Helm charts as OCI artifacts into the Zarf registry on package deploy.
+	MirrorCharts bool `json:"mirrorCharts,omitempty"`
+
 	// [Deprecated] Datasets to inject into a container in the target cluster.
 	DataInjections []ZarfDataInjection `json:"dataInjections,omitempty" jsonschema_extras:"deprecated=true"`
 
@@ -48,6 +51,18 @@ type ZarfComponent struct {
 	// List of git repos to include in the package.
 	Repos []string `json:"repos,omitempty"`
 
+	// [alpha] Git repos from repos whose submodules are cloned at their pinned commits and mirrored recursively.
+	RecursiveSubmodules []string `json:"recursiveSubmodules,omitempty"`
+
+	// [alpha] Git repos from repos that are packaged without their Git LFS objects. Files tracked by LFS are pushed as pointer files.
+	SkipLFSRepos []string `json:"skipLFSRepos,omitempty"`
+
+	// [alpha] Signature requirements for git repos from repos. Packaging fails unless every packaged ref is signed by an allowed signer.
+	VerifyRepos []ZarfRepoVerify `json:"verifyRepos,omitempty"`
+
+	// [alpha] Packages from language ecosystems to download on package create and publish to the artifact server on deploy.
+	Artifacts []ZarfArtifact `json:"artifacts,omitempty"`
+
 	// [Deprecated] (replaced by actions) Custom commands to run before or after package deployment. This will be removed in Zarf v1.0.0.
 	DeprecatedScripts DeprecatedZarfComponentScripts `json:"scripts,omitempty" jsonschema_extras:"deprecated=true"`
 
@@ -82,6 +97,52 @@ type ImageArchive struct {
 	Images []string `json:"images"`
 }
 
+// ZarfRepoVerify defines the signers allowed to sign the packaged refs of a git repo.
+type ZarfRepoVerify struct {
+	// The git repo from repos to verify.
+	Repo string `json:"repo"`
+	// Local paths to armored GPG public keys or SSH authorized_keys files allowed to sign the repo.
+	Keys []string `json:"keys,omitempty"`
+	// Gitsign (Sigstore keyless) identities allowed to sign the repo.
+	Identities []ZarfSigstoreIdentity `json:"identities,omitempty"`
+}
+
+// ZarfSigstoreIdentity is a Sigstore keyless signing identity.
+type ZarfSigstoreIdentity struct {
+	// The certificate subject, such as an email address or workflow URI.
+	Subject string `json:"subject"`
+	// The OIDC issuer that authenticated the subject.
+	Issuer string `json:"issuer"`
+}
+
+// ArtifactEcosystem identifies the package ecosystem of an artifact.
+type ArtifactEcosystem string
+
+const (
+	// ArtifactEcosystemNpm is an npm package.
+	ArtifactEcosystemNpm ArtifactEcosystem = "npm"
+	// ArtifactEcosystemPyPI is a Python package from a PyPI index.
+	ArtifactEcosystemPyPI ArtifactEcosystem = "pypi"
+	// ArtifactEcosystemMaven is a Maven artifact.
+	ArtifactEcosystemMaven ArtifactEcosystem = "maven"
+	// ArtifactEcosystemHelm is a Helm chart from a chart repository.
+	ArtifactEcosystemHelm ArtifactEcosystem = "helm"
+	// ArtifactEcosystemGeneric is a single file.
+	ArtifactEcosystemGeneric ArtifactEcosystem = "generic"
+)
+
+// ZarfArtifact defines a package from a language ecosystem to mirror to the artifact server.
+type ZarfArtifact struct {
+	// The package ecosystem.
+	Ecosystem ArtifactEcosystem `json:"ecosystem" jsonschema:"enum=npm,enum=pypi,enum=maven,enum=helm,enum=generic"`
+	// The package coordinates: an npm package (@scope/name), a PyPI project, a Maven groupId:artifactId, a Helm chart or a generic package name.
+	Name string `json:"name"`
+	// The version of the package.
+	Version string `json:"version"`
+	// The registry to download from, defaulting to the public registry of the ecosystem. Required for helm (the chart repository) and generic (the file to download) artifacts.
+	URL string `json:"url,omitempty"`
+}
+
 // NamespacedObjectKindReference is a reference to a specific resource in a namespace using its kind and API version.
 type NamespacedObjectKindReference struct {
 	// API Version of the resource
@@ -101,10 +162,11 @@ func (c ZarfComponent) RequiresCluster() bool {
 	hasCharts := len(c.Charts) > 0
 	hasManifests := len(c.Manifests) > 0
 	hasRepos := len(c.Repos) > 0
+	hasArtifacts := len(c.Artifacts) > 0
 	hasDataInjections := len(c.DataInjections) > 0
 	hasHealthChecks := len(c.HealthChecks) > 0
 
-	if hasImageArchives || hasImages || hasCharts || hasManifests || hasRepos || hasDataInjections || hasHealthChecks {
+	if hasImageArchives || hasImages || hasCharts || hasManifests || hasRepos || hasArtifacts || hasDataInjections || hasHealthChecks {
 		return true
 	}
 
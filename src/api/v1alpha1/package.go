@@ -246,6 +246,10 @@ type ZarfBuildData struct {
 	Migrations []string `json:"migrations,omitempty"`
 	// Any registry domains that were overridden on package create when pulling images.
 	RegistryOverrides map[string]string `json:"registryOverrides,omitempty"`
+	// Any images that were pulled from a registry mirror on package create, mapped to the mirror reference they were pulled from.
+	ImageMirrors map[string]string `json:"imageMirrors,omitempty"`
+	// Git refs whose signatures were verified on package create.
+	VerifiedGitRefs []ZarfVerifiedGitRef `json:"verifiedGitRefs,omitempty"`
 	// Whether this package was created with differential components.
 	Differential bool `json:"differential,omitempty"`
 	// Version of a previously built package used as the basis for creating this differential package.
@@ -288,6 +292,18 @@ type ZarfValues struct {
 	Schema string `json:"schema,omitempty"`
 }
 
+// ZarfVerifiedGitRef records a git ref whose signature was verified on package create.
+type ZarfVerifiedGitRef struct {
+	// The git repo the ref belongs to
+	Repo string `json:"repo"`
+	// The verified ref
+	Ref string `json:"ref"`
+	// The commit or tag object hash that was signed
+	Hash string `json:"hash"`
+	// The signer that produced the signature
+	Signer string `json:"signer"`
+}
+
 // VersionRequirement specifies minimum version requirements for the package
 type VersionRequirement struct {
 	// The minimum version of Zarf required to use this package
@@ -28,7 +28,7 @@ func (c Component) GetImages() []string {
 
 // RequiresCluster returns true if the component requires a cluster connection to deploy.
 func (c Component) RequiresCluster() bool {
-	return len(c.Images) > 0 || len(c.Charts) > 0 || len(c.Manifests) > 0 || len(c.Repositories) > 0
+	return len(c.Images) > 0 || len(c.Charts) > 0 || len(c.Manifests) > 0 || len(c.Repositories) > 0 || len(c.Artifacts) > 0
 }
 
 // ComponentTarget filters a component to only apply for a given local OS at deploy time.
@@ -259,6 +259,56 @@ type Repository struct {
 	URL string `json:"url"`
 	// The Git reference to mirror. Optional; when unset, all branches and tags are mirrored.
 	Ref *GitRef `json:"ref,omitempty"`
+	// [alpha] Set to recursive to clone the repository's submodules at their pinned commits and mirror them alongside it.
+	Submodules string `json:"submodules,omitempty" jsonschema:"enum=recursive"`
+	// [alpha] Package the repository without its Git LFS objects. Files tracked by LFS are pushed as pointer files.
+	SkipLFS bool `json:"skipLFS,omitempty"`
+	// [alpha] Signers allowed to sign the packaged refs. Packaging fails unless every packaged ref is signed by an allowed signer.
+	Verify *RepositoryVerify `json:"verify,omitempty"`
+}
+
+// RepositoryVerify defines the signers allowed to sign the packaged refs of a git repository.
+type RepositoryVerify struct {
+	// Local paths to armored GPG public keys or SSH authorized_keys files allowed to sign the repository.
+	Keys []string `json:"keys,omitempty"`
+	// Gitsign (Sigstore keyless) identities allowed to sign the repository.
+	Identities []SigstoreIdentity `json:"identities,omitempty"`
+}
+
+// SigstoreIdentity is a Sigstore keyless signing identity.
+type SigstoreIdentity struct {
+	// The certificate subject, such as an email address or workflow URI.
+	Subject string `json:"subject"`
+	// The OIDC issuer that authenticated the subject.
+	Issuer string `json:"issuer"`
+}
+
+// ArtifactEcosystem identifies the package ecosystem of an artifact.
+type ArtifactEcosystem string
+
+const (
+	// ArtifactEcosystemNpm is an npm package.
+	ArtifactEcosystemNpm ArtifactEcosystem = "npm"
+	// ArtifactEcosystemPyPI is a Python package from a PyPI index.
+	ArtifactEcosystemPyPI ArtifactEcosystem = "pypi"
+	// ArtifactEcosystemMaven is a Maven artifact.
+	ArtifactEcosystemMaven ArtifactEcosystem = "maven"
+	// ArtifactEcosystemHelm is a Helm chart from a chart repository.
+	ArtifactEcosystemHelm ArtifactEcosystem = "helm"
+	// ArtifactEcosystemGeneric is a single file.
+	ArtifactEcosystemGeneric ArtifactEcosystem = "generic"
+)
+
+// Artifact defines a package from a language ecosystem to mirror to the artifact server.
+type Artifact struct {
+	// The package ecosystem.
+	Ecosystem ArtifactEcosystem `json:"ecosystem" jsonschema:"enum=npm,enum=pypi,enum=maven,enum=helm,enum=generic"`
+	// The package coordinates: an npm package (@scope/name), a PyPI project, a Maven groupId:artifactId, a Helm chart or a generic package name.
+	Name string `json:"name"`
+	// The version of the package.
+	Version string `json:"version"`
+	// The registry to download from, defaulting to the public registry of the ecosystem. Required for helm (the chart repository) and generic (the file to download) artifacts.
+	URL string `json:"url,omitempty"`
 }
 
 // StateAccessKey identifies a named group of sensitive state fields available in {{ .State }} Go templates.
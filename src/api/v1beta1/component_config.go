@@ -31,6 +31,8 @@ type ComponentSpec struct {
 	Manifests []Manifest `json:"manifests,omitempty"`
 	// Helm charts to install during package deploy.
 	Charts []Chart `json:"charts,omitempty"`
+	// [alpha] Push this component's Helm charts as OCI artifacts into the Zarf registry on package deploy.
+	MirrorCharts bool `json:"mirrorCharts,omitempty"`
 	// Files or folders to place on disk during package deployment.
 	Files []File `json:"files,omitempty"`
 	// List of OCI images to include in the package.
@@ -39,6 +41,8 @@ type ComponentSpec struct {
 	ImageArchives []ImageArchive `json:"imageArchives,omitempty"`
 	// List of git repositories to include in the package.
 	Repositories []Repository `json:"repositories,omitempty"`
+	// [alpha] Packages from language ecosystems to download on package create and publish to the artifact server on deploy.
+	Artifacts []Artifact `json:"artifacts,omitempty"`
 	// Custom commands to run at various stages of a package lifecycle.
 	Actions ComponentActions `json:"actions,omitempty"`
 	// The Zarf CLI service this component provides, such as the registry, injector, or agent.
@@ -100,6 +100,10 @@ type BuildData struct {
 	Migrations []string `json:"migrations,omitempty"`
 	// Any registry domains that were overridden on package create when pulling images.
 	RegistryOverrides map[string]string `json:"registryOverrides,omitempty"`
+	// Any images that were pulled from a registry mirror on package create, mapped to the mirror reference they were pulled from.
+	ImageMirrors map[string]string `json:"imageMirrors,omitempty"`
+	// Git refs whose signatures were verified on package create.
+	VerifiedGitRefs []VerifiedGitRef `json:"verifiedGitRefs,omitempty"`
 	// Whether this package was created with differential components.
 	Differential bool `json:"differential,omitempty"`
 	// Version of a previously built package used as the basis for creating this differential package.
@@ -132,6 +136,18 @@ func (b *BuildData) SetOriginalAPIVersion(apiVersion string) {
 	b.originalAPIVersion = apiVersion
 }
 
+// VerifiedGitRef records a git ref whose signature was verified on package create.
+type VerifiedGitRef struct {
+	// The git repository the ref belongs to.
+	Repo string `json:"repo"`
+	// The verified ref.
+	Ref string `json:"ref"`
+	// The commit or tag object hash that was signed.
+	Hash string `json:"hash"`
+	// The signer that produced the signature.
+	Signer string `json:"signer"`
+}
+
 // VersionRequirement specifies a minimum Zarf version needed and the reason for the requirement.
 type VersionRequirement struct {
 	// The minimum version of Zarf required.
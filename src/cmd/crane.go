@@ -83,6 +83,8 @@ func newRegistryCommand() *cobra.Command {
 	}
 
 	cmd.AddCommand(newRegistryPruneCommand())
+	cmd.AddCommand(newRegistryInventoryCommand())
+	cmd.AddCommand(newRegistryTargetCommand())
 	cmd.AddCommand(newRegistryLoginCommand())
 	cmd.AddCommand(newRegistryLogoutCommand())
 	cmd.AddCommand(newRegistryCopyCommand(&craneOptions))
@@ -82,6 +82,7 @@ func newDevCommand() *cobra.Command {
 	cmd.AddCommand(newDevLintCommand(v))
 	cmd.AddCommand(newDevUpgradeSchemaCommand())
 	cmd.AddCommand(newDevTemplateCommand(v))
+	cmd.AddCommand(newDevAgentReplayCommand(v))
 
 	return cmd
 }
@@ -567,21 +568,23 @@ func (o *devInspectValuesFilesOptions) run(ctx context.Context, args []string) e
 }
 
 type devDeployOptions struct {
-	createSetPkgTmpl   map[string]string
-	deploySetVariables map[string]string
-	valuesFiles        []string
-	setValues          map[string]string
-	registryOverrides  []string
-	flavor             string
-	registryURL        string
-	takeOwnership      bool
-	timeout            time.Duration
-	retries            int
-	optionalComponents string
-	noYOLO             bool
-	connected          bool
-	ociConcurrency     int
-	skipVersionCheck   bool
+	createSetPkgTmpl    map[string]string
+	deploySetVariables  map[string]string
+	valuesFiles         []string
+	setValues           map[string]string
+	registryOverrides   []string
+	registryMirrors     []string
+	registryConcurrency []string
+	flavor              string
+	registryURL         string
+	takeOwnership       bool
+	timeout             time.Duration
+	retries             int
+	optionalComponents  string
+	noYOLO              bool
+	connected           bool
+	ociConcurrency      int
+	skipVersionCheck    bool
 }
 
 func newDevDeployCommand(v *viper.Viper) *cobra.Command {
@@ -597,6 +600,8 @@ func newDevDeployCommand(v *viper.Viper) *cobra.Command {
 
 	cmd.Flags().StringToStringVar(&o.createSetPkgTmpl, "create-set", v.GetStringMapString(VPkgCreateSet), lang.CmdPackageCreateFlagSetPkgTmpl)
 	cmd.Flags().StringArrayVar(&o.registryOverrides, "registry-override", v.GetStringSlice(VPkgCreateRegistryOverride), lang.CmdPackageCreateFlagRegistryOverride)
+	cmd.Flags().StringArrayVar(&o.registryMirrors, "registry-mirror", v.GetStringSlice(VPkgCreateRegistryMirror), lang.CmdPackageCreateFlagRegistryMirror)
+	cmd.Flags().StringSliceVar(&o.registryConcurrency, "registry-concurrency", GetStringSlice(v, VPkgCreateRegistryConcurrency), lang.CmdPackageCreateFlagRegistryConcurrency)
 	cmd.Flags().StringVarP(&o.flavor, "flavor", "f", v.GetString(VPkgCreateFlavor), lang.CmdPackageCreateFlagFlavor)
 
 	cmd.Flags().StringVar(&o.registryURL, "registry-url", defaultRegistry, lang.CmdDevFlagRegistry)
@@ -658,23 +663,33 @@ func (o *devDeployOptions) run(cmd *cobra.Command, args []string) error {
 	if err != nil {
 		return fmt.Errorf("error parsing registry override: %w", err)
 	}
+	mirrors, err := parseRegistryMirrors(o.registryMirrors)
+	if err != nil {
+		return fmt.Errorf("error parsing registry mirror: %w", err)
+	}
+	concurrency, err := parseRegistryConcurrency(o.registryConcurrency)
+	if err != nil {
+		return fmt.Errorf("error parsing registry concurrency: %w", err)
+	}
 
 	err = packager.DevDeploy(ctx, basePath, packager.DevDeployOptions{
-		AirgapMode:         o.noYOLO || !o.connected,
-		Flavor:             o.flavor,
-		RegistryURL:        o.registryURL,
-		RegistryOverrides:  overrides,
-		CreateSetVariables: o.createSetPkgTmpl,
-		DeploySetVariables: o.deploySetVariables,
-		Values:             values,
-		OptionalComponents: o.optionalComponents,
-		Timeout:            o.timeout,
-		Retries:            o.retries,
-		OCIConcurrency:     o.ociConcurrency,
-		RemoteOptions:      defaultRemoteOptions(),
-		CachePath:          cachePath,
-		SkipVersionCheck:   o.skipVersionCheck,
-		TakeOwnership:      o.takeOwnership,
+		AirgapMode:          o.noYOLO || !o.connected,
+		Flavor:              o.flavor,
+		RegistryURL:         o.registryURL,
+		RegistryOverrides:   overrides,
+		RegistryMirrors:     mirrors,
+		RegistryConcurrency: concurrency,
+		CreateSetVariables:  o.createSetPkgTmpl,
+		DeploySetVariables:  o.deploySetVariables,
+		Values:              values,
+		OptionalComponents:  o.optionalComponents,
+		Timeout:             o.timeout,
+		Retries:             o.retries,
+		OCIConcurrency:      o.ociConcurrency,
+		RemoteOptions:       defaultRemoteOptions(),
+		CachePath:           cachePath,
+		SkipVersionCheck:    o.skipVersionCheck,
+		TakeOwnership:       o.takeOwnership,
 	})
 	var lintErr *lint.LintError
 	if errors.As(err, &lintErr) {
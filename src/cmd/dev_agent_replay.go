@@ -0,0 +1,304 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2021-Present The Zarf Authors
+
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	admissionv1 "k8s.io/api/admission/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"sigs.k8s.io/yaml"
+
+	"github.com/zarf-dev/zarf/src/config/lang"
+	"github.com/zarf-dev/zarf/src/internal/agent"
+	"github.com/zarf-dev/zarf/src/internal/agent/hooks"
+	"github.com/zarf-dev/zarf/src/internal/agent/operations"
+	"github.com/zarf-dev/zarf/src/pkg/logger"
+	"github.com/zarf-dev/zarf/src/pkg/state"
+	"github.com/zarf-dev/zarf/src/pkg/utils"
+)
+
+const (
+	agentReplayOutputPatch  = "patch"
+	agentReplayOutputObject = "object"
+)
+
+type devAgentReplayOptions struct {
+	statePath       string
+	mutationPolicy  string
+	imageValidation string
+	mutationRules   string
+	namespace       string
+	operation       string
+	ociMediaType    string
+	exclusions      []string
+	output          string
+	outputWriter    io.Writer
+}
+
+// agentReplayInput is an admission request read from a replay file.
+type agentReplayInput struct {
+	source  string
+	request *admissionv1.AdmissionRequest
+}
+
+// agentReplayEntry is the result of replaying a single admission request.
+type agentReplayEntry struct {
+	Source    string `json:"source"`
+	Kind      string `json:"kind"`
+	Namespace string `json:"namespace,omitempty"`
+	Name      string `json:"name,omitempty"`
+	agent.ReplayResult
+}
+
+func newDevAgentReplayCommand(v *viper.Viper) *cobra.Command {
+	o := &devAgentReplayOptions{outputWriter: OutputWriter}
+	cmd := &cobra.Command{
+		Use:     "agent-replay { FILE | DIRECTORY }",
+		Short:   lang.CmdDevAgentReplayShort,
+		Long:    lang.CmdDevAgentReplayLong,
+		Example: lang.CmdDevAgentReplayExample,
+		Args:    cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return o.run(cmd.Context(), args[0])
+		},
+	}
+	cmd.Flags().StringVar(&o.statePath, "state", v.GetString(VDevAgentReplayState), lang.CmdDevAgentReplayFlagState)
+	cmd.Flags().StringVar(&o.mutationPolicy, "mutation-policy", v.GetString(VDevAgentReplayMutationPolicy), lang.CmdDevAgentReplayFlagMutationPolicy)
+	cmd.Flags().StringVar(&o.imageValidation, "image-validation", v.GetString(VDevAgentReplayImageValidation), lang.CmdDevAgentReplayFlagImageValidation)
+	cmd.Flags().StringVar(&o.mutationRules, "mutation-rules", v.GetString(VDevAgentReplayMutationRules), lang.CmdDevAgentReplayFlagMutationRules)
+	cmd.Flags().StringVarP(&o.namespace, "namespace", "n", v.GetString(VDevAgentReplayNamespace), lang.CmdDevAgentReplayFlagNamespace)
+	cmd.Flags().StringVar(&o.operation, "operation", string(admissionv1.Create), lang.CmdDevAgentReplayFlagOperation)
+	cmd.Flags().StringVar(&o.ociMediaType, "oci-media-type", v.GetString(VDevAgentReplayOCIMediaType), lang.CmdDevAgentReplayFlagOCIMediaType)
+	cmd.Flags().StringSliceVar(&o.exclusions, "mutation-exclusions", GetStringSlice(v, VDevAgentReplayMutationExclusions), lang.CmdDevAgentReplayFlagMutationExclusions)
+	cmd.Flags().StringVarP(&o.output, "output", "o", agentReplayOutputPatch, lang.CmdDevAgentReplayFlagOutput)
+	return cmd
+}
+
+func (o *devAgentReplayOptions) run(ctx context.Context, path string) error {
+	l := logger.From(ctx)
+
+	opts, err := o.replayOptions()
+	if err != nil {
+		return err
+	}
+	operation := admissionv1.Operation(strings.ToUpper(o.operation))
+	if operation != admissionv1.Create && operation != admissionv1.Update {
+		return fmt.Errorf("invalid operation %q, must be %q or %q", o.operation, admissionv1.Create, admissionv1.Update)
+	}
+	if o.output != agentReplayOutputPatch && o.output != agentReplayOutputObject {
+		return fmt.Errorf("invalid output %q, must be %q or %q", o.output, agentReplayOutputPatch, agentReplayOutputObject)
+	}
+
+	files, err := agentReplayFiles(path)
+	if err != nil {
+		return err
+	}
+	var inputs []agentReplayInput
+	for _, file := range files {
+		fileInputs, namespaces, err := readAgentReplayFile(file, operation, o.namespace)
+		if err != nil {
+			return err
+		}
+		inputs = append(inputs, fileInputs...)
+		opts.Namespaces = append(opts.Namespaces, namespaces...)
+	}
+	if len(inputs) == 0 {
+		return fmt.Errorf("no admission requests or resources found in %s", path)
+	}
+
+	replayer, err := agent.NewReplayer(ctx, opts)
+	if err != nil {
+		return err
+	}
+	entries := []agentReplayEntry{}
+	for _, input := range inputs {
+		result, err := replayer.Replay(ctx, input.request)
+		if err != nil {
+			return fmt.Errorf("unable to replay %s %s from %s: %w", input.request.Kind.Kind, input.request.Name, input.source, err)
+		}
+		if !result.Allowed {
+			l.Warn("the agent rejected the resource", "source", input.source, "kind", input.request.Kind.Kind, "name", input.request.Name, "message", result.Message)
+		}
+		entries = append(entries, agentReplayEntry{
+			Source:       input.source,
+			Kind:         input.request.Kind.Kind,
+			Namespace:    input.request.Namespace,
+			Name:         input.request.Name,
+			ReplayResult: result,
+		})
+	}
+	return printAgentReplay(o.outputWriter, o.output, entries)
+}
+
+func (o *devAgentReplayOptions) replayOptions() (agent.ReplayOptions, error) {
+	opts := agent.ReplayOptions{OCIMediaType: o.ociMediaType}
+
+	for _, exclusion := range o.exclusions {
+		if exclusion != "argocd" && exclusion != "flux" {
+			return agent.ReplayOptions{}, fmt.Errorf("invalid mutation exclusion %q, must be argocd or flux", exclusion)
+		}
+	}
+	opts.MutationExclusions = o.exclusions
+
+	switch state.MutationPolicy(o.mutationPolicy) {
+	case state.MutationPolicyAll, state.MutationPolicyLabeled, state.MutationPolicyAudit:
+		opts.MutationPolicy = state.MutationPolicy(o.mutationPolicy)
+	default:
+		return agent.ReplayOptions{}, fmt.Errorf("invalid mutation policy %q, must be %q, %q or %q", o.mutationPolicy,
+			state.MutationPolicyAll, state.MutationPolicyLabeled, state.MutationPolicyAudit)
+	}
+
+	switch o.imageValidation {
+	case "disabled":
+	case string(operations.ValidationActionWarn), string(operations.ValidationActionDeny):
+		opts.ImageValidation = operations.ValidationAction(o.imageValidation)
+	default:
+		return agent.ReplayOptions{}, fmt.Errorf("invalid image validation action %q, must be disabled, %q or %q", o.imageValidation,
+			operations.ValidationActionWarn, operations.ValidationActionDeny)
+	}
+
+	if o.mutationRules != "" {
+		if _, err := os.Stat(o.mutationRules); err != nil {
+			return agent.ReplayOptions{}, fmt.Errorf("unable to access mutation rules %q: %w", o.mutationRules, err)
+		}
+		rules, err := hooks.LoadMutationRules(o.mutationRules)
+		if err != nil {
+			return agent.ReplayOptions{}, err
+		}
+		opts.Rules = rules
+	}
+
+	if o.statePath == "" {
+		s, err := state.Default()
+		if err != nil {
+			return agent.ReplayOptions{}, err
+		}
+		opts.State = s
+		return opts, nil
+	}
+	b, err := os.ReadFile(o.statePath)
+	if err != nil {
+		return agent.ReplayOptions{}, fmt.Errorf("unable to read the Zarf state %q: %w", o.statePath, err)
+	}
+	s := &state.State{}
+	if err := yaml.Unmarshal(b, s); err != nil {
+		return agent.ReplayOptions{}, fmt.Errorf("unable to parse the Zarf state %q: %w", o.statePath, err)
+	}
+	opts.State = s
+	return opts, nil
+}
+
+// agentReplayFiles returns the file at path or the JSON and YAML files directly inside of it.
+func agentReplayFiles(path string) ([]string, error) {
+	info, err := os.Stat(path)
+	if err != nil {
+		return nil, fmt.Errorf("unable to access %q: %w", path, err)
+	}
+	if !info.IsDir() {
+		return []string{path}, nil
+	}
+	dirEntries, err := os.ReadDir(path)
+	if err != nil {
+		return nil, err
+	}
+	files := []string{}
+	for _, entry := range dirEntries {
+		if entry.IsDir() {
+			continue
+		}
+		switch filepath.Ext(entry.Name()) {
+		case ".json", ".yaml", ".yml":
+			files = append(files, filepath.Join(path, entry.Name()))
+		}
+	}
+	slices.Sort(files)
+	return files, nil
+}
+
+// readAgentReplayFile reads the AdmissionReviews and resources in a file. Namespaces are returned separately as they
+// are created in the replay cluster rather than replayed.
+func readAgentReplayFile(path string, operation admissionv1.Operation, namespace string) ([]agentReplayInput, []*corev1.Namespace, error) {
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return nil, nil, err
+	}
+	objs, err := utils.SplitYAML(b)
+	if err != nil {
+		return nil, nil, fmt.Errorf("unable to read %s: %w", path, err)
+	}
+	var inputs []agentReplayInput
+	var namespaces []*corev1.Namespace
+	for _, obj := range objs {
+		switch gvk := obj.GroupVersionKind(); {
+		case gvk.Group == admissionv1.GroupName && gvk.Kind == "AdmissionReview":
+			review := &admissionv1.AdmissionReview{}
+			if err := decodeUnstructured(obj, review); err != nil {
+				return nil, nil, fmt.Errorf("unable to read the AdmissionReview in %s: %w", path, err)
+			}
+			if review.Request == nil {
+				return nil, nil, fmt.Errorf("the AdmissionReview in %s does not contain a request", path)
+			}
+			inputs = append(inputs, agentReplayInput{source: path, request: review.Request})
+		case gvk.Group == "" && gvk.Kind == "Namespace":
+			ns := &corev1.Namespace{}
+			if err := decodeUnstructured(obj, ns); err != nil {
+				return nil, nil, fmt.Errorf("unable to read the Namespace in %s: %w", path, err)
+			}
+			namespaces = append(namespaces, ns)
+		default:
+			req, err := agent.NewReplayRequest(obj, operation, namespace)
+			if err != nil {
+				return nil, nil, fmt.Errorf("unable to read %s %s in %s: %w", obj.GetKind(), obj.GetName(), path, err)
+			}
+			inputs = append(inputs, agentReplayInput{source: path, request: req})
+		}
+	}
+	return inputs, namespaces, nil
+}
+
+// decodeUnstructured decodes obj into out through JSON so that raw extensions such as the objects of an AdmissionReview are kept.
+func decodeUnstructured(obj *unstructured.Unstructured, out any) error {
+	b, err := obj.MarshalJSON()
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(b, out)
+}
+
+func printAgentReplay(w io.Writer, output string, entries []agentReplayEntry) error {
+	if output == agentReplayOutputPatch {
+		b, err := json.MarshalIndent(entries, "", "  ")
+		if err != nil {
+			return err
+		}
+		fmt.Fprintln(w, string(b))
+		return nil
+	}
+	for i, entry := range entries {
+		obj := &unstructured.Unstructured{}
+		if err := obj.UnmarshalJSON(entry.Object); err != nil {
+			return fmt.Errorf("unable to read the mutated %s %s: %w", entry.Kind, entry.Name, err)
+		}
+		b, err := yaml.Marshal(obj.Object)
+		if err != nil {
+			return err
+		}
+		if i > 0 {
+			fmt.Fprintln(w, "---")
+		}
+		fmt.Fprint(w, string(b))
+	}
+	return nil
+}
@@ -0,0 +1,79 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2021-Present The Zarf Authors
+
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestDevAgentReplay(t *testing.T) {
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "state.json"), []byte(`{"registryInfo":{"address":"registry.example.com"}}`), 0o644))
+	inputs := filepath.Join(dir, "inputs")
+	require.NoError(t, os.Mkdir(inputs, 0o755))
+	require.NoError(t, os.WriteFile(filepath.Join(inputs, "a-review.json"), []byte(`{
+  "apiVersion": "admission.k8s.io/v1",
+  "kind": "AdmissionReview",
+  "request": {
+    "uid": "1234",
+    "kind": {"group": "", "version": "v1", "kind": "Pod"},
+    "resource": {"group": "", "version": "v1", "resource": "pods"},
+    "namespace": "app",
+    "operation": "CREATE",
+    "object": {"apiVersion": "v1", "kind": "Pod", "metadata": {"name": "app"}, "spec": {"containers": [{"name": "app", "image": "nginx"}]}}
+  }
+}`), 0o644))
+	require.NoError(t, os.WriteFile(filepath.Join(inputs, "b-resources.yaml"), []byte(`apiVersion: v1
+kind: Namespace
+metadata:
+  name: ignored
+  labels:
+    zarf.dev/agent: ignore
+---
+apiVersion: v1
+kind: Pod
+metadata:
+  name: ignored
+  namespace: ignored
+spec:
+  containers:
+    - name: app
+      image: nginx
+`), 0o644))
+	require.NoError(t, os.WriteFile(filepath.Join(inputs, "notes.txt"), []byte("not replayed"), 0o644))
+
+	var out bytes.Buffer
+	o := devAgentReplayOptions{
+		statePath:       filepath.Join(dir, "state.json"),
+		mutationPolicy:  "all",
+		imageValidation: "disabled",
+		namespace:       "default",
+		operation:       "create",
+		output:          agentReplayOutputPatch,
+		outputWriter:    &out,
+	}
+	require.NoError(t, o.run(context.Background(), inputs))
+
+	var entries []agentReplayEntry
+	require.NoError(t, json.Unmarshal(out.Bytes(), &entries))
+	require.Len(t, entries, 2)
+	require.Equal(t, "app", entries[0].Namespace)
+	require.Equal(t, []string{"/mutate/pod"}, entries[0].Webhooks)
+	require.Contains(t, string(entries[0].Patch), "registry.example.com/library/nginx:latest-zarf-")
+	require.Equal(t, "ignored", entries[1].Name)
+	require.Empty(t, entries[1].Patch)
+
+	out.Reset()
+	o.output = agentReplayOutputObject
+	require.NoError(t, o.run(context.Background(), filepath.Join(inputs, "a-review.json")))
+	require.Contains(t, out.String(), "image: registry.example.com/library/nginx:latest-zarf-")
+	require.Contains(t, out.String(), "zarf-agent: patched")
+}
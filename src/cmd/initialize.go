@@ -15,6 +15,7 @@ import (
 	"path"
 	"path/filepath"
 	"runtime"
+	"slices"
 	"strings"
 	"time"
 
@@ -58,6 +59,8 @@ type initOptions struct {
 	agentTLSCertPath           string
 	agentTLSKeyPath            string
 	agentMutationPolicy        string
+	agentImagePullSecretMode   string
+	overrideLock               bool
 	packageVerifyFlags
 }
 
@@ -102,6 +105,7 @@ func newInitCommand() *cobra.Command {
 	cmd.Flags().StringVar(&o.gitServer.PushPassword, "git-push-password", v.GetString(VInitGitPushPass), lang.CmdInitFlagGitPushPass)
 	cmd.Flags().StringVar(&o.gitServer.PullUsername, "git-pull-username", v.GetString(VInitGitPullUser), lang.CmdInitFlagGitPullUser)
 	cmd.Flags().StringVar(&o.gitServer.PullPassword, "git-pull-password", v.GetString(VInitGitPullPass), lang.CmdInitFlagGitPullPass)
+	cmd.Flags().StringVar((*string)(&o.gitServer.Provider), "git-provider", v.GetString(VInitGitProvider), lang.CmdInitFlagGitProvider)
 
 	// Flags for using an external registry
 	cmd.Flags().StringVar(&o.registryInfo.Address, "registry-url", v.GetString(VInitRegistryURL), lang.CmdInitFlagRegURL)
@@ -126,7 +130,8 @@ func newInitCommand() *cobra.Command {
 	cmd.Flags().StringVar(&o.agentTLSCAPath, "agent-tls-ca", v.GetString(VInitAgentTLSCA), "Path to a PEM-encoded CA certificate for the Zarf agent")
 	cmd.Flags().StringVar(&o.agentTLSCertPath, "agent-tls-cert", v.GetString(VInitAgentTLSCert), "Path to a PEM-encoded TLS certificate for the Zarf agent")
 	cmd.Flags().StringVar(&o.agentTLSKeyPath, "agent-tls-key", v.GetString(VInitAgentTLSKey), "Path to a PEM-encoded TLS private key for the Zarf agent")
-	cmd.Flags().StringVar(&o.agentMutationPolicy, "agent-mutation-policy", v.GetString(VInitAgentMutationPolicy), `Controls agent mutation behavior: "all" mutates all resources by default, "labeled" mutates only resources labeled zarf.dev/agent: mutate`)
+	cmd.Flags().StringVar(&o.agentMutationPolicy, "agent-mutation-policy", v.GetString(VInitAgentMutationPolicy), `Controls agent mutation behavior: "all" mutates all resources by default, "labeled" mutates only resources labeled zarf.dev/agent: mutate, "audit" mutates like "labeled" and reports the mutations "all" would make to everything else`)
+	cmd.Flags().StringVar(&o.agentImagePullSecretMode, "agent-image-pull-secret-mode", v.GetString(VInitAgentImagePullSecretMode), `Controls how the agent gives pods access to the Zarf registry: "replace" replaces the image pull secrets of pods, "merge" adds the Zarf pull secret to the ones pods declare, "serviceaccount" adds it to the ServiceAccounts in Zarf-managed namespaces instead of patching pods`)
 
 	// Flags that control how a deployment proceeds
 	// Always require take-ownership flag (no viper)
@@ -136,6 +141,7 @@ func newInitCommand() *cobra.Command {
 	cmd.Flags().BoolVar(&o.forceConflicts, "force-conflicts", false, lang.CmdPackageDeployFlagForceConflicts)
 	cmd.Flags().DurationVar(&o.timeout, "timeout", v.GetDuration(VPkgDeployTimeout), lang.CmdPackageDeployFlagTimeout)
 	cmd.Flags().BoolVar(&o.skipValuesSchemaValidation, "skip-values-schema-validation", false, lang.CmdPackageDeployFlagSkipValuesSchema)
+	cmd.Flags().BoolVar(&o.overrideLock, "override-lock", false, lang.CmdFlagOverrideLock)
 
 	cmd.Flags().IntVar(&o.retries, "retries", v.GetInt(VPkgRetries), lang.CmdPackageFlagRetries)
 	cmd.Flags().IntVar(&o.ociConcurrency, "oci-concurrency", v.GetInt(VPkgOCIConcurrency), lang.CmdPackageFlagConcurrency)
@@ -255,7 +261,9 @@ func (o *initOptions) run(cmd *cobra.Command, args []string) error {
 		IsInteractive:              !o.confirm,
 		AgentTLS:                   agentTLS,
 		AgentMutationPolicy:        state.MutationPolicy(o.agentMutationPolicy),
+		AgentImagePullSecretMode:   state.ImagePullSecretMode(o.agentImagePullSecretMode),
 		SkipValuesSchemaValidation: o.skipValuesSchemaValidation,
+		OverrideLock:               o.overrideLock,
 	}
 	_, err = deploy(ctx, pkgLayout, opts, o.setVariables, o.optionalComponents)
 	if err != nil {
@@ -433,6 +441,16 @@ func (o *initOptions) validateInitFlags() error {
 		}
 	}
 
+	if o.gitServer.Provider != "" {
+		if !slices.Contains(state.GitProviders(), o.gitServer.Provider) {
+			return errors.New(lang.CmdInitErrValidateGitProv)
+		}
+		// The internal git server is always Gitea
+		if o.gitServer.Address == "" && o.gitServer.Provider != state.GitProviderGitea {
+			return errors.New(lang.CmdInitErrValidateGitInt)
+		}
+	}
+
 	// If 'registry-url' is provided, make sure they provided values for the username and password of the push user
 	if o.registryInfo.Address != "" {
 		if o.registryInfo.PushUsername == "" || o.registryInfo.PushPassword == "" {
@@ -463,10 +481,17 @@ func (o *initOptions) validateInitFlags() error {
 	}
 
 	switch state.MutationPolicy(o.agentMutationPolicy) {
-	case state.MutationPolicyAll, state.MutationPolicyLabeled:
+	case state.MutationPolicyAll, state.MutationPolicyLabeled, state.MutationPolicyAudit:
+	default:
+		return fmt.Errorf("invalid agent mutation policy %q, must be %q, %q or %q", o.agentMutationPolicy,
+			state.MutationPolicyAll, state.MutationPolicyLabeled, state.MutationPolicyAudit)
+	}
+
+	switch state.ImagePullSecretMode(o.agentImagePullSecretMode) {
+	case state.ImagePullSecretModeReplace, state.ImagePullSecretModeMerge, state.ImagePullSecretModeServiceAccount:
 	default:
-		return fmt.Errorf("invalid agent mutation policy %q, must be %q or %q", o.agentMutationPolicy,
-			state.MutationPolicyAll, state.MutationPolicyLabeled)
+		return fmt.Errorf("invalid agent image pull secret mode %q, must be %q, %q or %q", o.agentImagePullSecretMode,
+			state.ImagePullSecretModeReplace, state.ImagePullSecretModeMerge, state.ImagePullSecretModeServiceAccount)
 	}
 
 	return nil
@@ -307,36 +307,11 @@ func (o *internalCreateArtifactRegistryTokenOptions) run(cmd *cobra.Command, _ [
 		return err
 	}
 
-	// If we are setup to use an internal artifact server, create the artifact registry token
-	if s.ArtifactServer.IsInternal() {
-		tunnel, err := c.NewTunnel(state.ZarfNamespaceName, cluster.SvcResource, cluster.ZarfGitServerName, "", 0, cluster.ZarfGitServerPort)
+	// If the artifact server is served by the git server, create the artifact registry token
+	if s.GitServer.HostsArtifactServer(s.ArtifactServer) {
+		s.ArtifactServer.PushToken, err = c.UpdateInternalArtifactServerToken(ctx, s.GitServer)
 		if err != nil {
-			return err
-		}
-		_, err = tunnel.Connect(ctx)
-		if err != nil {
-			return err
-		}
-		defer tunnel.Close()
-		// tunnel is created with the default listenAddress - there will only be one endpoint until otherwise supported
-		tunnelURLs := tunnel.HTTPEndpoints()
-		if len(tunnelURLs) == 0 {
-			return fmt.Errorf("no tunnel endpoints found")
-		}
-		giteaClient, err := gitea.NewClient(tunnelURLs[0], s.GitServer.PushUsername, s.GitServer.PushPassword)
-		if err != nil {
-			return err
-		}
-		err = tunnel.Wrap(func() error {
-			tokenSha1, err := giteaClient.CreatePackageRegistryToken(ctx)
-			if err != nil {
-				return fmt.Errorf("unable to create an artifact registry token for Gitea: %w", err)
-			}
-			s.ArtifactServer.PushToken = tokenSha1
-			return nil
-		})
-		if err != nil {
-			return err
+			return fmt.Errorf("unable to create an artifact registry token: %w", err)
 		}
 		if err := c.SaveState(ctx, s); err != nil {
 			return err
@@ -16,6 +16,7 @@ import (
 	"regexp"
 	"runtime"
 	"slices"
+	"strconv"
 	"strings"
 	"time"
 
@@ -31,6 +32,7 @@ import (
 	"github.com/zarf-dev/zarf/src/config"
 	"github.com/zarf-dev/zarf/src/config/lang"
 	"github.com/zarf-dev/zarf/src/pkg/cluster"
+	"github.com/zarf-dev/zarf/src/pkg/feature"
 	"github.com/zarf-dev/zarf/src/pkg/images"
 	"github.com/zarf-dev/zarf/src/pkg/lint"
 	"github.com/zarf-dev/zarf/src/pkg/logger"
@@ -112,6 +114,8 @@ type packageCreateOptions struct {
 	skipSBOM                bool
 	maxPackageSizeMB        int
 	registryOverrides       []string
+	registryMirrors         []string
+	registryConcurrency     []string
 	signingKeyPath          string
 	signingKeyPassword      string
 	flavor                  string
@@ -154,6 +158,8 @@ func newPackageCreateCommand(v *viper.Viper) *cobra.Command {
 	cmd.Flags().BoolVar(&o.skipSBOM, "skip-sbom", v.GetBool(VPkgCreateSkipSbom), lang.CmdPackageCreateFlagSkipSbom)
 	cmd.Flags().IntVarP(&o.maxPackageSizeMB, "max-package-size", "m", v.GetInt(VPkgCreateMaxPackageSize), lang.CmdPackageCreateFlagMaxPackageSize)
 	cmd.Flags().StringSliceVar(&o.registryOverrides, "registry-override", GetStringSlice(v, VPkgCreateRegistryOverride), lang.CmdPackageCreateFlagRegistryOverride)
+	cmd.Flags().StringSliceVar(&o.registryMirrors, "registry-mirror", GetStringSlice(v, VPkgCreateRegistryMirror), lang.CmdPackageCreateFlagRegistryMirror)
+	cmd.Flags().StringSliceVar(&o.registryConcurrency, "registry-concurrency", GetStringSlice(v, VPkgCreateRegistryConcurrency), lang.CmdPackageCreateFlagRegistryConcurrency)
 	cmd.Flags().StringVarP(&o.flavor, "flavor", "f", v.GetString(VPkgCreateFlavor), lang.CmdPackageCreateFlagFlavor)
 	cmd.Flags().BoolVar(&o.skipVersionCheck, "skip-version-check", false, "Ignore version requirements when deploying the package")
 	_ = cmd.Flags().MarkHidden("skip-version-check")
@@ -222,6 +228,77 @@ func parseRegistryOverrides(overrides []string) ([]images.RegistryOverride, erro
 	return result, nil
 }
 
+// Converts registry mirrors to a structured type.
+// Mirrors for the same source keep the order they were provided in, as that is the order they are tried.
+// The sources are sorted in descending order so the longest prefix will be matched first.
+//
+// Input is of the following form:
+// []string{"docker.io=harbor.example.com/dockerhub", "docker.io=mirror.example.com", "ghcr.io=harbor.example.com/ghcr"}
+func parseRegistryMirrors(mirrors []string) ([]images.RegistryMirror, error) {
+	result := []images.RegistryMirror{}
+	for _, mapping := range mirrors {
+		source, mirror, found := strings.Cut(mapping, "=")
+		if !found {
+			return nil, fmt.Errorf("registry mirror missing '=': %s", mapping)
+		}
+
+		if source == "" {
+			return nil, fmt.Errorf("registry mirror missing source: %s", mapping)
+		}
+
+		if mirror == "" {
+			return nil, fmt.Errorf("registry mirror missing value: %s", mapping)
+		}
+
+		index := slices.IndexFunc(result, func(existing images.RegistryMirror) bool {
+			return existing.Source == source
+		})
+		if index < 0 {
+			result = append(result, images.RegistryMirror{Source: source})
+			index = len(result) - 1
+		}
+		if slices.Contains(result[index].Mirrors, mirror) {
+			return nil, fmt.Errorf("registry mirror has duplicate mirror %s for source %s", mirror, source)
+		}
+		result[index].Mirrors = append(result[index].Mirrors, mirror)
+	}
+
+	slices.SortFunc(result, func(a images.RegistryMirror, b images.RegistryMirror) int {
+		return -strings.Compare(a.Source, b.Source)
+	})
+
+	return result, nil
+}
+
+// Converts per-registry concurrency limits to a map of registry host to limit.
+//
+// Input is of the following form:
+// []string{"docker.io=2", "ghcr.io=4"}
+func parseRegistryConcurrency(limits []string) (map[string]int, error) {
+	result := make(map[string]int, len(limits))
+	for _, mapping := range limits {
+		host, value, found := strings.Cut(mapping, "=")
+		if !found {
+			return nil, fmt.Errorf("registry concurrency missing '=': %s", mapping)
+		}
+
+		if host == "" {
+			return nil, fmt.Errorf("registry concurrency missing host: %s", mapping)
+		}
+
+		limit, err := strconv.Atoi(value)
+		if err != nil || limit < 1 {
+			return nil, fmt.Errorf("registry concurrency must be a positive integer: %s", mapping)
+		}
+
+		if _, ok := result[host]; ok {
+			return nil, fmt.Errorf("registry concurrency has duplicate host: %s", host)
+		}
+		result[host] = limit
+	}
+	return result, nil
+}
+
 func (o *packageCreateOptions) run(ctx context.Context, args []string) error {
 	l := logger.From(ctx)
 	basePath, err := setBaseDirectory(args)
@@ -242,6 +319,15 @@ func (o *packageCreateOptions) run(ctx context.Context, args []string) error {
 		return fmt.Errorf("error parsing registry override: %w", err)
 	}
 	l.Debug("parsed registry overrides", "overrides", overrides)
+	mirrors, err := parseRegistryMirrors(o.registryMirrors)
+	if err != nil {
+		return fmt.Errorf("error parsing registry mirror: %w", err)
+	}
+	l.Debug("parsed registry mirrors", "mirrors", mirrors)
+	concurrency, err := parseRegistryConcurrency(o.registryConcurrency)
+	if err != nil {
+		return fmt.Errorf("error parsing registry concurrency: %w", err)
+	}
 
 	cachePath, err := getCachePath(ctx)
 	if err != nil {
@@ -250,6 +336,8 @@ func (o *packageCreateOptions) run(ctx context.Context, args []string) error {
 	opt := packager.CreateOptions{
 		Flavor:                  o.flavor,
 		RegistryOverrides:       overrides,
+		RegistryMirrors:         mirrors,
+		RegistryConcurrency:     concurrency,
 		SigningKeyPath:          o.signingKeyPath,
 		SigningKeyPassword:      o.signingKeyPassword,
 		SetVariables:            o.setVariables,
@@ -293,6 +381,12 @@ type packageDeployOptions struct {
 	skipValuesSchemaValidation bool
 	skipVersionCheck           bool
 	ociConcurrency             int
+	agentCertExpiryWarning     time.Duration
+	registryTarget             string
+	gitNoForce                 bool
+	gitOrg                     string
+	gitOrgPerPackage           bool
+	overrideLock               bool
 	packageVerifyFlags
 }
 
@@ -321,6 +415,12 @@ func newPackageDeployCommand(v *viper.Viper) *cobra.Command {
 	cmd.Flags().BoolVar(&o.connected, "connected", v.GetBool(VPkgDeployConnected), lang.CmdPackageDeployFlagConnected)
 	cmd.Flags().BoolVar(&o.forceConflicts, "force-conflicts", false, lang.CmdPackageDeployFlagForceConflicts)
 	cmd.Flags().DurationVar(&o.timeout, "timeout", v.GetDuration(VPkgDeployTimeout), lang.CmdPackageDeployFlagTimeout)
+	cmd.Flags().DurationVar(&o.agentCertExpiryWarning, "agent-cert-expiry-warning", v.GetDuration(VPkgDeployAgentCertExpiryWarning), lang.CmdPackageDeployFlagAgentCertExpiryWarning)
+	cmd.Flags().StringVar(&o.registryTarget, "registry-target", v.GetString(VPkgDeployRegistryTarget), lang.CmdPackageDeployFlagRegistryTarget)
+	cmd.Flags().BoolVar(&o.gitNoForce, "git-no-force", v.GetBool(VPkgDeployGitNoForce), lang.CmdPackageDeployFlagGitNoForce)
+	cmd.Flags().StringVar(&o.gitOrg, "git-org", v.GetString(VPkgDeployGitOrg), lang.CmdPackageDeployFlagGitOrg)
+	cmd.Flags().BoolVar(&o.gitOrgPerPackage, "git-org-per-package", v.GetBool(VPkgDeployGitOrgPerPackage), lang.CmdPackageDeployFlagGitOrgPerPackage)
+	cmd.MarkFlagsMutuallyExclusive("git-org", "git-org-per-package")
 
 	cmd.Flags().StringSliceVarP(&o.valuesFiles, "values", "v", GetStringSlice(v, VPkgDeployValues), lang.CmdPackageDeployFlagValuesFiles)
 	cmd.Flags().IntVar(&o.retries, "retries", v.GetInt(VPkgRetries), lang.CmdPackageFlagRetries)
@@ -332,6 +432,7 @@ func newPackageDeployCommand(v *viper.Viper) *cobra.Command {
 	cmd.Flags().StringVar(&o.shasum, "shasum", v.GetString(VPkgDeployShasum), lang.CmdPackageDeployFlagShasum)
 	cmd.Flags().StringVarP(&o.namespaceOverride, "namespace", "n", v.GetString(VPkgDeployNamespace), lang.CmdPackageDeployFlagNamespace)
 	cmd.Flags().BoolVar(&o.skipValuesSchemaValidation, "skip-values-schema-validation", false, lang.CmdPackageDeployFlagSkipValuesSchema)
+	cmd.Flags().BoolVar(&o.overrideLock, "override-lock", false, lang.CmdFlagOverrideLock)
 	cmd.Flags().BoolVar(&o.skipVersionCheck, "skip-version-check", false, "Ignore version requirements when deploying the package")
 	_ = cmd.Flags().MarkHidden("skip-version-check")
 	addVerifyFlags(cmd, v, &o.packageVerifyFlags)
@@ -384,6 +485,7 @@ func (o *packageDeployOptions) run(cmd *cobra.Command, args []string) (err error
 		RemoteOptions:        defaultRemoteOptions(),
 		CachePath:            cachePath,
 		Connected:            o.connected,
+		StreamImages:         feature.IsEnabled(feature.StreamPackageImages),
 	}
 	pkgLayout, err := packager.LoadPackage(ctx, packageSource, loadOpt)
 	if err != nil {
@@ -407,6 +509,12 @@ func (o *packageDeployOptions) run(cmd *cobra.Command, args []string) (err error
 		IsInteractive:              !o.confirm,
 		SkipValuesSchemaValidation: o.skipValuesSchemaValidation,
 		SkipVersionCheck:           o.skipVersionCheck,
+		AgentCertExpiryWarning:     o.agentCertExpiryWarning,
+		RegistryTarget:             o.registryTarget,
+		GitNoForce:                 o.gitNoForce,
+		GitOrganization:            o.gitOrg,
+		GitOrganizationPerPackage:  o.gitOrgPerPackage,
+		OverrideLock:               o.overrideLock,
 	}
 
 	deployedComponents, err := deploy(ctx, pkgLayout, deployOpts, o.setVariables, o.optionalComponents)
@@ -539,6 +647,7 @@ type packageMirrorResourcesOptions struct {
 	gitServer          state.GitServerInfo
 	registryInfo       state.RegistryInfo
 	ociConcurrency     int
+	gitNoForce         bool
 	packageVerifyFlags
 }
 
@@ -577,6 +686,8 @@ func newPackageMirrorResourcesCommand(v *viper.Viper) *cobra.Command {
 	cmd.Flags().StringVar(&o.gitServer.Address, "git-url", v.GetString(VInitGitURL), lang.CmdInitFlagGitURL)
 	cmd.Flags().StringVar(&o.gitServer.PushUsername, "git-push-username", v.GetString(VInitGitPushUser), lang.CmdInitFlagGitPushUser)
 	cmd.Flags().StringVar(&o.gitServer.PushPassword, "git-push-password", v.GetString(VInitGitPushPass), lang.CmdInitFlagGitPushPass)
+	cmd.Flags().StringVar((*string)(&o.gitServer.Provider), "git-provider", v.GetString(VInitGitProvider), lang.CmdInitFlagGitProvider)
+	cmd.Flags().BoolVar(&o.gitNoForce, "git-no-force", v.GetBool(VPkgDeployGitNoForce), lang.CmdPackageDeployFlagGitNoForce)
 
 	// Flags for using an external registry
 	cmd.Flags().StringVar(&o.registryInfo.Address, "registry-url", v.GetString(VInitRegistryURL), lang.CmdInitFlagRegURL)
@@ -631,6 +742,7 @@ func (o *packageMirrorResourcesOptions) run(cmd *cobra.Command, args []string) (
 		OCIConcurrency:       o.ociConcurrency,
 		RemoteOptions:        defaultRemoteOptions(),
 		CachePath:            cachePath,
+		StreamImages:         feature.IsEnabled(feature.StreamPackageImages),
 	}
 	pkgLayout, err := packager.LoadPackage(ctx, src, loadOpt)
 	if err != nil {
@@ -703,8 +815,10 @@ func (o *packageMirrorResourcesOptions) run(cmd *cobra.Command, args []string) (
 		}
 
 		mirrorOpt := packager.RepoPushOptions{
-			Cluster: c,
-			Retries: o.retries,
+			Cluster:               c,
+			Retries:               o.retries,
+			NoForce:               o.gitNoForce,
+			InsecureSkipTLSVerify: defaultRemoteOptions().InsecureSkipTLSVerify,
 		}
 		err = packager.PushReposToRepository(ctx, pkgLayout, o.gitServer, mirrorOpt)
 		if err != nil {
@@ -1404,6 +1518,7 @@ type packageRemoveOptions struct {
 	ociConcurrency     int
 	valuesFiles        []string
 	setValues          map[string]string
+	overrideLock       bool
 	packageVerifyFlags
 }
 
@@ -1426,6 +1541,7 @@ func newPackageRemoveCommand(v *viper.Viper) *cobra.Command {
 	cmd.Flags().BoolVarP(&o.confirm, "confirm", "c", false, lang.CmdPackageRemoveFlagConfirm)
 	cmd.Flags().StringVar(&o.optionalComponents, "components", v.GetString(VPkgDeployComponents), lang.CmdPackageRemoveFlagComponents)
 	cmd.Flags().StringVarP(&o.namespaceOverride, "namespace", "n", v.GetString(VPkgDeployNamespace), lang.CmdPackageRemoveFlagNamespace)
+	cmd.Flags().BoolVar(&o.overrideLock, "override-lock", false, lang.CmdFlagOverrideLock)
 	cmd.Flags().BoolVar(&o.skipVersionCheck, "skip-version-check", false, "Ignore version requirements when removing the package")
 	_ = cmd.Flags().MarkHidden("skip-version-check")
 	cmd.Flags().StringSliceVarP(&o.valuesFiles, "values", "v", []string{}, lang.CmdPackageRemoveFlagValuesFiles)
@@ -1476,6 +1592,7 @@ func (o *packageRemoveOptions) run(cmd *cobra.Command, args []string) error {
 		Timeout:           config.ZarfDefaultTimeout,
 		NamespaceOverride: o.namespaceOverride,
 		SkipVersionCheck:  o.skipVersionCheck,
+		OverrideLock:      o.overrideLock,
 		Values:            vals,
 	}
 	legacyPkg := pkg.AsV1alpha1()
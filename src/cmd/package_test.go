@@ -535,6 +535,127 @@ func TestParseRegistryOverrides(t *testing.T) {
 	}
 }
 
+func TestParseRegistryMirrors(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name     string
+		provided []string
+		expected []images.RegistryMirror
+	}{
+		{
+			name:     "no mirrors",
+			provided: nil,
+			expected: []images.RegistryMirror{},
+		},
+		{
+			name: "ordered mirrors for a single source",
+			provided: []string{
+				"docker.io=harbor.example.com/dockerhub",
+				"docker.io=mirror.example.com",
+			},
+			expected: []images.RegistryMirror{
+				{Source: "docker.io", Mirrors: []string{"harbor.example.com/dockerhub", "mirror.example.com"}},
+			},
+		},
+		{
+			name: "prefix sources sorted longest first",
+			provided: []string{
+				"docker.io=mirror.example.com",
+				"ghcr.io=harbor.example.com/ghcr",
+				"docker.io/library=harbor.example.com/library",
+			},
+			expected: []images.RegistryMirror{
+				{Source: "ghcr.io", Mirrors: []string{"harbor.example.com/ghcr"}},
+				{Source: "docker.io/library", Mirrors: []string{"harbor.example.com/library"}},
+				{Source: "docker.io", Mirrors: []string{"mirror.example.com"}},
+			},
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			result, err := parseRegistryMirrors(tc.provided)
+			require.NoError(t, err)
+			require.Equal(t, tc.expected, result)
+		})
+	}
+
+	errorTests := []struct {
+		name          string
+		provided      []string
+		errorContents string
+	}{
+		{
+			name:          "error: invalid mapping",
+			provided:      []string{"docker.io:mirror.example.com"},
+			errorContents: "registry mirror missing '='",
+		},
+		{
+			name:          "error: invalid source",
+			provided:      []string{"=mirror.example.com"},
+			errorContents: "registry mirror missing source",
+		},
+		{
+			name:          "error: invalid mirror",
+			provided:      []string{"docker.io="},
+			errorContents: "registry mirror missing value",
+		},
+		{
+			name:          "error: duplicate mirror",
+			provided:      []string{"docker.io=mirror.example.com", "docker.io=mirror.example.com"},
+			errorContents: "registry mirror has duplicate mirror mirror.example.com for source docker.io",
+		},
+	}
+	for _, tc := range errorTests {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			_, err := parseRegistryMirrors(tc.provided)
+			require.ErrorContains(t, err, tc.errorContents)
+		})
+	}
+}
+
+func TestParseRegistryConcurrency(t *testing.T) {
+	t.Parallel()
+	result, err := parseRegistryConcurrency([]string{"docker.io=2", "ghcr.io=4"})
+	require.NoError(t, err)
+	require.Equal(t, map[string]int{"docker.io": 2, "ghcr.io": 4}, result)
+
+	errorTests := []struct {
+		name          string
+		provided      []string
+		errorContents string
+	}{
+		{
+			name:          "error: invalid mapping",
+			provided:      []string{"docker.io:2"},
+			errorContents: "registry concurrency missing '='",
+		},
+		{
+			name:          "error: invalid host",
+			provided:      []string{"=2"},
+			errorContents: "registry concurrency missing host",
+		},
+		{
+			name:          "error: invalid limit",
+			provided:      []string{"docker.io=0"},
+			errorContents: "registry concurrency must be a positive integer",
+		},
+		{
+			name:          "error: duplicate host",
+			provided:      []string{"docker.io=2", "docker.io=3"},
+			errorContents: "registry concurrency has duplicate host: docker.io",
+		},
+	}
+	for _, tc := range errorTests {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			_, err := parseRegistryConcurrency(tc.provided)
+			require.ErrorContains(t, err, tc.errorContents)
+		})
+	}
+}
+
 func TestPackageInspectDocumentation(t *testing.T) {
 	t.Parallel()
 
@@ -22,6 +22,8 @@ func newToolsCommand() *cobra.Command {
 
 	cmd.AddCommand(newArchiverCommand())
 	cmd.AddCommand(newRegistryCommand())
+	cmd.AddCommand(newGitCommand())
+	cmd.AddCommand(newStateCommand())
 	cmd.AddCommand(newDeprecatedCraneCommand())
 	cmd.AddCommand(newHelmCommand())
 	cmd.AddCommand(newK9sCommand())
@@ -0,0 +1,311 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2021-Present The Zarf Authors
+
+package cmd
+
+import (
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"path"
+	"slices"
+	"strconv"
+	"time"
+
+	"github.com/AlecAivazis/survey/v2"
+	goyaml "github.com/goccy/go-yaml"
+	"github.com/spf13/cobra"
+
+	"github.com/zarf-dev/zarf/src/config/lang"
+	"github.com/zarf-dev/zarf/src/internal/gitea"
+	"github.com/zarf-dev/zarf/src/internal/gitprovider"
+	"github.com/zarf-dev/zarf/src/pkg/cluster"
+	"github.com/zarf-dev/zarf/src/pkg/logger"
+	"github.com/zarf-dev/zarf/src/pkg/message"
+	"github.com/zarf-dev/zarf/src/pkg/state"
+	"github.com/zarf-dev/zarf/src/pkg/transform"
+	"github.com/zarf-dev/zarf/src/pkg/utils"
+)
+
+func newGitCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "git",
+		Short: lang.CmdToolsGitShort,
+	}
+
+	cmd.AddCommand(newGitInventoryCommand())
+	cmd.AddCommand(newGitPruneCommand())
+
+	return cmd
+}
+
+type gitInventoryOptions struct {
+	outputFormat inventoryOutputFormat
+	outputWriter io.Writer
+}
+
+func newGitInventoryCommand() *cobra.Command {
+	o := gitInventoryOptions{
+		outputFormat: inventoryOutputFormat(outputTable),
+		outputWriter: OutputWriter,
+	}
+
+	cmd := &cobra.Command{
+		Use:     "inventory",
+		Aliases: []string{"inv"},
+		Short:   lang.CmdToolsGitInventoryShort,
+		Long:    lang.CmdToolsGitInventoryLong,
+		Example: lang.CmdToolsGitInventoryExample,
+		Args:    cobra.NoArgs,
+		RunE:    o.run,
+	}
+
+	cmd.Flags().VarP(&o.outputFormat, "output-format", "o", lang.CmdToolsGitInventoryFlagOutputFormat)
+
+	return cmd
+}
+
+// gitInventoryEntry describes a single repository on the Zarf git server.
+type gitInventoryEntry struct {
+	Owner string `json:"owner"`
+	Name  string `json:"name"`
+	Size  int64  `json:"size"`
+	// LastUpdated is when the repository was last changed, by a push or an edit of its settings.
+	LastUpdated time.Time                    `json:"lastUpdated"`
+	References  []registryInventoryReference `json:"references"`
+	Orphaned    bool                         `json:"orphaned"`
+}
+
+func (o *gitInventoryOptions) run(cmd *cobra.Command, _ []string) error {
+	ctx := cmd.Context()
+	var entries []gitInventoryEntry
+	err := withGitServer(ctx, nil, func(ctx context.Context, client *gitea.Client, zarfPackages []state.DeployedPackage) error {
+		repos, err := client.ListRepositories(ctx)
+		if err != nil {
+			return err
+		}
+		entries = gitInventory(repos, zarfPackages)
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	return printGitInventory(o.outputWriter, outputFormat(o.outputFormat), entries)
+}
+
+type gitPruneOptions struct {
+	confirm      bool
+	overrideLock bool
+}
+
+func newGitPruneCommand() *cobra.Command {
+	o := gitPruneOptions{}
+
+	cmd := &cobra.Command{
+		Use:     "prune",
+		Aliases: []string{"p"},
+		Short:   lang.CmdToolsGitPruneShort,
+		Long:    lang.CmdToolsGitPruneLong,
+		Args:    cobra.NoArgs,
+		RunE:    o.run,
+	}
+
+	// Always require confirm flag (no viper)
+	cmd.Flags().BoolVarP(&o.confirm, "confirm", "c", false, lang.CmdToolsGitPruneFlagConfirm)
+	cmd.Flags().BoolVar(&o.overrideLock, "override-lock", false, lang.CmdFlagOverrideLock)
+
+	return cmd
+}
+
+func (o *gitPruneOptions) run(cmd *cobra.Command, _ []string) error {
+	// Hold the deploy lock so that no repository is pushed between listing the deployed packages and pruning
+	lock := &cluster.DeployLockOptions{Operation: "zarf tools git prune", Override: o.overrideLock}
+	return withGitServer(cmd.Context(), lock, func(ctx context.Context, client *gitea.Client, zarfPackages []state.DeployedPackage) error {
+		return doPruneGitRepositories(ctx, client, zarfPackages, o.confirm)
+	})
+}
+
+func doPruneGitRepositories(ctx context.Context, client *gitea.Client, zarfPackages []state.DeployedPackage, confirm bool) error {
+	l := logger.From(ctx)
+
+	l.Info("finding repositories to prune")
+	repos, err := client.ListRepositories(ctx)
+	if err != nil {
+		return err
+	}
+	orphaned := []gitInventoryEntry{}
+	for _, e := range gitInventory(repos, zarfPackages) {
+		if e.Orphaned {
+			orphaned = append(orphaned, e)
+		}
+	}
+
+	if len(orphaned) == 0 {
+		l.Info("there are no repositories to prune")
+		return nil
+	}
+
+	l.Info("the following repositories will be pruned from the git server:")
+	for _, e := range orphaned {
+		l.Info(path.Join(e.Owner, e.Name))
+	}
+
+	if !confirm {
+		prompt := &survey.Confirm{
+			Message: "Continue with repository prune?",
+		}
+		if err := survey.AskOne(prompt, &confirm); err != nil {
+			return fmt.Errorf("confirm selection canceled: %w", err)
+		}
+	}
+	if confirm {
+		l.Info("pruning repositories")
+
+		for _, e := range orphaned {
+			err = client.DeleteRepository(ctx, e.Owner, e.Name)
+			if err != nil {
+				return err
+			}
+			l.Debug("repository pruned", "name", path.Join(e.Owner, e.Name))
+		}
+	}
+	return nil
+}
+
+// withGitServer runs fn with a client for the Zarf git server and the deployed packages of the cluster. When lock is
+// set the deploy lock is held while the packages are read and fn runs.
+func withGitServer(ctx context.Context, lock *cluster.DeployLockOptions, fn func(context.Context, *gitea.Client, []state.DeployedPackage) error) (err error) {
+	c, err := cluster.New(ctx)
+	if err != nil {
+		return err
+	}
+	if lock != nil {
+		lockCtx, release, lockErr := c.AcquireDeployLock(ctx, *lock)
+		if lockErr != nil {
+			return lockErr
+		}
+		ctx = lockCtx
+		defer func() {
+			err = errors.Join(err, release(context.WithoutCancel(ctx)))
+		}()
+	}
+	zarfState, err := c.LoadState(ctx)
+	if err != nil {
+		return err
+	}
+	if !zarfState.GitServer.IsConfigured() {
+		return fmt.Errorf("no git server is configured in the Zarf state")
+	}
+	zarfPackages, err := c.GetDeployedZarfPackages(ctx)
+	if err != nil {
+		return lang.ErrUnableToGetPackages
+	}
+	return c.WithGitProvider(ctx, zarfState.GitServer, func(_ string, provider gitprovider.Provider) error {
+		client, ok := provider.(*gitea.Client)
+		if !ok {
+			return fmt.Errorf("listing repositories is not supported by the %s git provider", zarfState.GitServer.EffectiveProvider())
+		}
+		return fn(ctx, client, zarfPackages)
+	})
+}
+
+// gitInventory records which deployed package components reference each repository on the git server.
+// Repositories that no deployed component references are marked as orphaned.
+func gitInventory(repos []gitea.Repository, zarfPackages []state.DeployedPackage) []gitInventoryEntry {
+	// Components record the owner/name of every repo they pushed, including submodules. Records from older
+	// deployments do not, so their repos are matched by name under any owner to avoid pruning them.
+	byPath := map[string][]registryInventoryReference{}
+	byName := map[string][]registryInventoryReference{}
+	for _, pkg := range zarfPackages {
+		deployedComponents := map[string]state.DeployedComponent{}
+		for _, depComponent := range pkg.DeployedComponents {
+			deployedComponents[depComponent.Name] = depComponent
+		}
+		for _, component := range pkg.Data.Components {
+			depComponent, ok := deployedComponents[component.Name]
+			if !ok {
+				continue
+			}
+			ref := registryInventoryReference{Package: pkg.Name, Component: component.Name}
+			if len(depComponent.GitRepos) > 0 {
+				for _, repoPath := range depComponent.GitRepos {
+					if !slices.Contains(byPath[repoPath], ref) {
+						byPath[repoPath] = append(byPath[repoPath], ref)
+					}
+				}
+				continue
+			}
+			for _, repoURL := range component.Repos {
+				name, err := transform.GitURLtoRepoName(repoURL)
+				if err != nil {
+					continue
+				}
+				if !slices.Contains(byName[name], ref) {
+					byName[name] = append(byName[name], ref)
+				}
+			}
+		}
+	}
+
+	entries := []gitInventoryEntry{}
+	for _, repo := range repos {
+		refs := slices.Clone(byPath[path.Join(repo.Owner, repo.Name)])
+		for _, ref := range byName[repo.Name] {
+			if !slices.Contains(refs, ref) {
+				refs = append(refs, ref)
+			}
+		}
+		entries = append(entries, gitInventoryEntry{
+			Owner:       repo.Owner,
+			Name:        repo.Name,
+			Size:        repo.Size,
+			LastUpdated: repo.UpdatedAt,
+			References:  refs,
+			Orphaned:    len(refs) == 0,
+		})
+	}
+	return entries
+}
+
+func printGitInventory(w io.Writer, format outputFormat, entries []gitInventoryEntry) error {
+	switch format {
+	case outputJSON:
+		output, err := json.MarshalIndent(entries, "", "  ")
+		if err != nil {
+			return err
+		}
+		fmt.Fprintln(w, string(output))
+	case outputYAML:
+		output, err := goyaml.Marshal(entries)
+		if err != nil {
+			return err
+		}
+		fmt.Fprint(w, string(output))
+	case outputCSV:
+		cw := csv.NewWriter(w)
+		records := [][]string{{"owner", "name", "size", "lastUpdated", "references", "orphaned"}}
+		for _, e := range entries {
+			records = append(records, []string{
+				e.Owner, e.Name, strconv.FormatInt(e.Size, 10), e.LastUpdated.Format(time.RFC3339),
+				formatInventoryReferences(e.References), strconv.FormatBool(e.Orphaned),
+			})
+		}
+		return cw.WriteAll(records)
+	case outputTable:
+		header := []string{"Owner", "Repository", "Size", "Last Updated", "Referenced By", "Orphaned"}
+		var data [][]string
+		for _, e := range entries {
+			data = append(data, []string{
+				e.Owner, e.Name, utils.ByteFormat(float64(e.Size), 2), e.LastUpdated.Format(time.RFC3339),
+				formatInventoryReferences(e.References), strconv.FormatBool(e.Orphaned),
+			})
+		}
+		message.TableWithWriter(w, header, data)
+	default:
+		return fmt.Errorf("unsupported output format: %s", format)
+	}
+	return nil
+}
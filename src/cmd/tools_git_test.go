@@ -0,0 +1,129 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2021-Present The Zarf Authors
+
+package cmd
+
+import (
+	"bytes"
+	"encoding/csv"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/zarf-dev/zarf/src/api/v1alpha1"
+	"github.com/zarf-dev/zarf/src/internal/gitea"
+	"github.com/zarf-dev/zarf/src/pkg/state"
+	"github.com/zarf-dev/zarf/src/test/testutil"
+)
+
+func gitInventoryPackages() []state.DeployedPackage {
+	return []state.DeployedPackage{
+		{
+			Name: "podinfo",
+			Data: v1alpha1.ZarfPackage{
+				Components: []v1alpha1.ZarfComponent{
+					{Name: "podinfo", Repos: []string{"https://github.com/stefanprodan/podinfo.git"}},
+					{Name: "not-deployed", Repos: []string{"https://github.com/zarf-dev/zarf.git"}},
+				},
+			},
+			DeployedComponents: []state.DeployedComponent{
+				// The parent repo and its submodule were pushed into the podinfo organization
+				{Name: "podinfo", GitRepos: []string{"podinfo/podinfo-1646971829", "podinfo/library-3427186390"}},
+			},
+		},
+		{
+			// Deployed before pushed repos were recorded
+			Name: "legacy",
+			Data: v1alpha1.ZarfPackage{
+				Components: []v1alpha1.ZarfComponent{
+					{Name: "flux", Repos: []string{"https://github.com/stefanprodan/podinfo.git"}},
+				},
+			},
+			DeployedComponents: []state.DeployedComponent{{Name: "flux"}},
+		},
+	}
+}
+
+func TestGitInventory(t *testing.T) {
+	t.Parallel()
+
+	updated := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+	repos := []gitea.Repository{
+		{Owner: "podinfo", Name: "podinfo-1646971829", Size: 2048, UpdatedAt: updated},
+		{Owner: "podinfo", Name: "library-3427186390", Size: 1024, UpdatedAt: updated},
+		{Owner: "zarf-git-user", Name: "podinfo-1646971829", Size: 2048, UpdatedAt: updated},
+		{Owner: "zarf-git-user", Name: "zarf-1211668992", Size: 4096, UpdatedAt: updated},
+	}
+
+	entries := gitInventory(repos, gitInventoryPackages())
+	expected := []gitInventoryEntry{
+		{
+			Owner: "podinfo", Name: "podinfo-1646971829", Size: 2048, LastUpdated: updated,
+			References: []registryInventoryReference{{Package: "podinfo", Component: "podinfo"}, {Package: "legacy", Component: "flux"}},
+		},
+		{
+			Owner: "podinfo", Name: "library-3427186390", Size: 1024, LastUpdated: updated,
+			References: []registryInventoryReference{{Package: "podinfo", Component: "podinfo"}},
+		},
+		{
+			Owner: "zarf-git-user", Name: "podinfo-1646971829", Size: 2048, LastUpdated: updated,
+			References: []registryInventoryReference{{Package: "legacy", Component: "flux"}},
+		},
+		{
+			Owner: "zarf-git-user", Name: "zarf-1211668992", Size: 4096, LastUpdated: updated,
+			Orphaned: true,
+		},
+	}
+	require.Equal(t, expected, entries)
+
+	var buf bytes.Buffer
+	err := printGitInventory(&buf, outputCSV, entries)
+	require.NoError(t, err)
+	records, err := csv.NewReader(&buf).ReadAll()
+	require.NoError(t, err)
+	require.Equal(t, []string{"owner", "name", "size", "lastUpdated", "references", "orphaned"}, records[0])
+	require.Equal(t, []string{"zarf-git-user", "zarf-1211668992", "4096", "2024-01-02T03:04:05Z", "", "true"}, records[4])
+}
+
+func TestPruneGitRepositories(t *testing.T) {
+	t.Parallel()
+	ctx := testutil.TestContext(t)
+
+	var mu sync.Mutex
+	deleted := []string{}
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == http.MethodGet && r.URL.Query().Get("page") != "1":
+			fmt.Fprint(w, `[]`)
+		case r.Method == http.MethodGet && r.URL.Path == "/api/v1/users/zarf-git-user/repos":
+			fmt.Fprint(w, `[{"name":"zarf-1211668992","owner":{"login":"zarf-git-user"},"size":1,"updated_at":"2024-01-02T03:04:05Z"}]`)
+		case r.Method == http.MethodGet && r.URL.Path == "/api/v1/user/orgs":
+			// The push user also belongs to an organization that Zarf did not create
+			fmt.Fprint(w, `[{"username":"podinfo","description":"Managed by Zarf"},{"username":"platform","description":""}]`)
+		case r.Method == http.MethodGet && r.URL.Path == "/api/v1/orgs/podinfo/repos":
+			fmt.Fprint(w, `[{"name":"podinfo-1646971829","owner":{"login":"podinfo"},"size":1,"updated_at":"2024-01-02T03:04:05Z"}]`)
+		case r.Method == http.MethodGet && r.URL.Path == "/api/v1/orgs/platform/repos":
+			fmt.Fprint(w, `[{"name":"infra","owner":{"login":"platform"},"size":1,"updated_at":"2024-01-02T03:04:05Z"}]`)
+		case r.Method == http.MethodDelete:
+			mu.Lock()
+			deleted = append(deleted, r.URL.Path)
+			mu.Unlock()
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			w.WriteHeader(http.StatusBadRequest)
+		}
+	}))
+	defer srv.Close()
+
+	client, err := gitea.NewClient(srv.URL, "zarf-git-user", "password")
+	require.NoError(t, err)
+
+	err = doPruneGitRepositories(ctx, client, gitInventoryPackages(), true)
+	require.NoError(t, err)
+	require.Equal(t, []string{"/api/v1/repos/zarf-git-user/zarf-1211668992"}, deleted)
+}
@@ -0,0 +1,358 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2021-Present The Zarf Authors
+
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"slices"
+	"strconv"
+	"strings"
+
+	goyaml "github.com/goccy/go-yaml"
+	"github.com/google/go-containerregistry/pkg/crane"
+	v1 "github.com/google/go-containerregistry/pkg/v1"
+	"github.com/google/go-containerregistry/pkg/v1/types"
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+
+	"github.com/zarf-dev/zarf/src/config/lang"
+	"github.com/zarf-dev/zarf/src/pkg/cluster"
+	"github.com/zarf-dev/zarf/src/pkg/images"
+	"github.com/zarf-dev/zarf/src/pkg/logger"
+	"github.com/zarf-dev/zarf/src/pkg/message"
+	"github.com/zarf-dev/zarf/src/pkg/state"
+	"github.com/zarf-dev/zarf/src/pkg/transform"
+	"github.com/zarf-dev/zarf/src/pkg/utils"
+)
+
+// outputCSV is only supported by the inventory commands, so that their output can be exported for audits.
+const outputCSV outputFormat = "csv"
+
+// inventoryOutputFormat is the output format of the inventory commands, which accept CSV in addition to the
+// formats every command supports.
+type inventoryOutputFormat outputFormat
+
+var _ pflag.Value = (*inventoryOutputFormat)(nil)
+
+func (o *inventoryOutputFormat) Set(s string) error {
+	if outputFormat(s) == outputCSV {
+		*o = inventoryOutputFormat(s)
+		return nil
+	}
+	f := outputFormat(*o)
+	if err := f.Set(s); err != nil {
+		return err
+	}
+	*o = inventoryOutputFormat(f)
+	return nil
+}
+
+func (o *inventoryOutputFormat) String() string {
+	return string(*o)
+}
+
+func (o *inventoryOutputFormat) Type() string {
+	return "outputFormat"
+}
+
+type registryInventoryOptions struct {
+	outputFormat  inventoryOutputFormat
+	outputWriter  io.Writer
+	insecure      bool
+	ignoreMissing bool
+}
+
+func newRegistryInventoryCommand() *cobra.Command {
+	o := registryInventoryOptions{
+		outputFormat: inventoryOutputFormat(outputTable),
+		outputWriter: OutputWriter,
+	}
+
+	cmd := &cobra.Command{
+		Use:     "inventory",
+		Aliases: []string{"inv"},
+		Short:   lang.CmdToolsRegistryInventoryShort,
+		Example: lang.CmdToolsRegistryInventoryExample,
+		Args:    cobra.NoArgs,
+		RunE:    o.run,
+	}
+
+	cmd.Flags().VarP(&o.outputFormat, "output-format", "o", lang.CmdToolsRegistryInventoryFlagOutputFormat)
+	cmd.Flags().BoolVar(&o.ignoreMissing, "ignore-missing", false, lang.CmdToolsRegistryInventoryFlagIgnoreMissing)
+	cmd.PersistentFlags().BoolVar(&o.insecure, "insecure", false, lang.CmdToolsRegistryFlagInsecure)
+
+	return cmd
+}
+
+// registryInventoryReference identifies a deployed package component that references an image.
+type registryInventoryReference struct {
+	Package   string `json:"package"`
+	Component string `json:"component"`
+}
+
+// registryInventoryEntry describes a single tag in the Zarf registry.
+type registryInventoryEntry struct {
+	Repository string                       `json:"repository"`
+	Tag        string                       `json:"tag"`
+	Digest     string                       `json:"digest"`
+	Size       int64                        `json:"size"`
+	Platforms  []string                     `json:"platforms"`
+	References []registryInventoryReference `json:"references"`
+	Orphaned   bool                         `json:"orphaned"`
+}
+
+func (o *registryInventoryOptions) run(cmd *cobra.Command, _ []string) error {
+	ctx := cmd.Context()
+	l := logger.From(ctx)
+
+	c, err := cluster.New(ctx)
+	if err != nil {
+		return err
+	}
+	options := []crane.Option{crane.WithContext(ctx)}
+	if o.insecure {
+		options = append(options, crane.Insecure)
+	}
+
+	zarfState, err := c.LoadState(ctx)
+	if err != nil {
+		return err
+	}
+	zarfPackages, err := c.GetDeployedZarfPackages(ctx)
+	if err != nil {
+		return lang.ErrUnableToGetPackages
+	}
+
+	registryEndpoint, tunnel, err := c.ConnectToZarfRegistryEndpoint(ctx, zarfState.RegistryInfo)
+	if err != nil {
+		return err
+	}
+	if zarfState.RegistryInfo.ShouldUseMTLS() {
+		t, err := getZarfRegistryMTLSTransport(ctx, c)
+		if err != nil {
+			return err
+		}
+		options = append(options, crane.WithTransport(t))
+	}
+
+	var entries []registryInventoryEntry
+	inventory := func() error {
+		entries, err = registryInventory(ctx, options, zarfState, zarfPackages, registryEndpoint, o.ignoreMissing)
+		return err
+	}
+	if tunnel != nil {
+		l.Info("opening a tunnel to the Zarf registry", "localEndpoint", registryEndpoint, "clusterAddress", zarfState.RegistryInfo.Address)
+		defer tunnel.Close()
+		err = tunnel.Wrap(inventory)
+	} else {
+		err = inventory()
+	}
+	if err != nil {
+		return err
+	}
+	return printRegistryInventory(o.outputWriter, outputFormat(o.outputFormat), entries)
+}
+
+// registryInventory lists every tag in the registry at registryEndpoint and records which deployed package
+// components reference it. Tags that no deployed component references are marked as orphaned.
+func registryInventory(ctx context.Context, options []crane.Option, s *state.State, zarfPackages []state.DeployedPackage, registryEndpoint string, ignoreMissing bool) ([]registryInventoryEntry, error) {
+	l := logger.From(ctx)
+	options = append(options, images.WithPullAuth(s.RegistryInfo))
+
+	l.Info("looking up images within deployed packages")
+	owners := map[string][]registryInventoryReference{}
+	for _, pkg := range zarfPackages {
+		deployedComponents := map[string]bool{}
+		for _, depComponent := range pkg.DeployedComponents {
+			deployedComponents[depComponent.Name] = true
+		}
+		for _, component := range pkg.Data.Components {
+			if !deployedComponents[component.Name] {
+				continue
+			}
+			for _, image := range component.GetImages() {
+				// The no checksum image always exists and shares its digest with the checksum tag
+				ref, err := transform.ImageTransformHostWithoutChecksum(registryEndpoint, image)
+				if err != nil {
+					return nil, err
+				}
+				digest, err := crane.Digest(ref, options...)
+				if err != nil {
+					if isManifestUnknownError(err) && ignoreMissing {
+						l.Warn("image manifest not found in registry, skipping", "image", ref)
+						continue
+					}
+					if isManifestUnknownError(err) {
+						return nil, fmt.Errorf("image manifest not found for %q (use --ignore-missing to skip): %w", ref, err)
+					}
+					return nil, err
+				}
+				owner := registryInventoryReference{Package: pkg.Name, Component: component.Name}
+				if !slices.Contains(owners[digest], owner) {
+					owners[digest] = append(owners[digest], owner)
+				}
+			}
+		}
+	}
+
+	l.Info("cataloging images in the registry")
+	catalog, err := crane.Catalog(registryEndpoint, options...)
+	if err != nil {
+		return nil, err
+	}
+	entries := []registryInventoryEntry{}
+	for _, repo := range catalog {
+		repoRef := fmt.Sprintf("%s/%s", registryEndpoint, repo)
+		tags, err := crane.ListTags(repoRef, options...)
+		if err != nil {
+			return nil, err
+		}
+		for _, tag := range tags {
+			taggedRef := fmt.Sprintf("%s:%s", repoRef, tag)
+			desc, err := crane.Get(taggedRef, options...)
+			if err != nil {
+				if isManifestUnknownError(err) && ignoreMissing {
+					l.Warn("image manifest not found in registry, skipping", "image", taggedRef)
+					continue
+				}
+				if isManifestUnknownError(err) {
+					return nil, fmt.Errorf("image manifest not found for %q (use --ignore-missing to skip): %w", taggedRef, err)
+				}
+				return nil, err
+			}
+			size, platforms, err := inspectRegistryManifest(repoRef, desc.MediaType, desc.Manifest, options)
+			if err != nil {
+				return nil, fmt.Errorf("unable to inspect %s: %w", taggedRef, err)
+			}
+			digest := desc.Digest.String()
+			entries = append(entries, registryInventoryEntry{
+				Repository: repo,
+				Tag:        tag,
+				Digest:     digest,
+				Size:       size,
+				Platforms:  platforms,
+				References: owners[digest],
+				Orphaned:   len(owners[digest]) == 0,
+			})
+		}
+	}
+	return entries, nil
+}
+
+// inspectRegistryManifest returns the total size of the content referenced by a manifest or index, including
+// the manifests of any children, and the platforms it provides.
+func inspectRegistryManifest(repoRef string, mediaType types.MediaType, manifest []byte, options []crane.Option) (int64, []string, error) {
+	if mediaType.IsIndex() {
+		idx, err := v1.ParseIndexManifest(bytes.NewReader(manifest))
+		if err != nil {
+			return 0, nil, err
+		}
+		var size int64
+		var platforms []string
+		for _, child := range idx.Manifests {
+			childManifest, err := crane.Manifest(fmt.Sprintf("%s@%s", repoRef, child.Digest), options...)
+			if err != nil {
+				return 0, nil, err
+			}
+			childSize, childPlatforms, err := inspectRegistryManifest(repoRef, child.MediaType, childManifest, options)
+			if err != nil {
+				return 0, nil, err
+			}
+			size += child.Size + childSize
+			if child.Platform != nil {
+				childPlatforms = []string{child.Platform.String()}
+			}
+			for _, p := range childPlatforms {
+				if !slices.Contains(platforms, p) {
+					platforms = append(platforms, p)
+				}
+			}
+		}
+		return size, platforms, nil
+	}
+
+	m, err := v1.ParseManifest(bytes.NewReader(manifest))
+	if err != nil {
+		return 0, nil, err
+	}
+	size := m.Config.Size
+	for _, layer := range m.Layers {
+		size += layer.Size
+	}
+	// Only images carry a platform in their config, other artifacts (e.g. signatures, Helm charts) do not.
+	if m.Config.MediaType != types.OCIConfigJSON && m.Config.MediaType != types.DockerConfigJSON {
+		return size, nil, nil
+	}
+	config, err := crane.PullLayer(fmt.Sprintf("%s@%s", repoRef, m.Config.Digest), options...)
+	if err != nil {
+		return 0, nil, err
+	}
+	rc, err := config.Compressed()
+	if err != nil {
+		return 0, nil, err
+	}
+	defer rc.Close()
+	cf, err := v1.ParseConfigFile(rc)
+	if err != nil {
+		return 0, nil, err
+	}
+	if p := cf.Platform(); p != nil && p.String() != "" {
+		return size, []string{p.String()}, nil
+	}
+	return size, nil, nil
+}
+
+func printRegistryInventory(w io.Writer, format outputFormat, entries []registryInventoryEntry) error {
+	switch format {
+	case outputJSON:
+		output, err := json.MarshalIndent(entries, "", "  ")
+		if err != nil {
+			return err
+		}
+		fmt.Fprintln(w, string(output))
+	case outputYAML:
+		output, err := goyaml.Marshal(entries)
+		if err != nil {
+			return err
+		}
+		fmt.Fprint(w, string(output))
+	case outputCSV:
+		cw := csv.NewWriter(w)
+		records := [][]string{{"repository", "tag", "digest", "size", "platforms", "references", "orphaned"}}
+		for _, e := range entries {
+			records = append(records, []string{
+				e.Repository, e.Tag, e.Digest, strconv.FormatInt(e.Size, 10), strings.Join(e.Platforms, " "),
+				formatInventoryReferences(e.References), strconv.FormatBool(e.Orphaned),
+			})
+		}
+		return cw.WriteAll(records)
+	case outputTable:
+		header := []string{"Repository", "Tag", "Digest", "Size", "Platforms", "Referenced By", "Orphaned"}
+		var data [][]string
+		for _, e := range entries {
+			data = append(data, []string{
+				e.Repository, e.Tag, e.Digest, utils.ByteFormat(float64(e.Size), 2), strings.Join(e.Platforms, ", "),
+				formatInventoryReferences(e.References), strconv.FormatBool(e.Orphaned),
+			})
+		}
+		message.TableWithWriter(w, header, data)
+	default:
+		return fmt.Errorf("unsupported output format: %s", format)
+	}
+	return nil
+}
+
+// formatInventoryReferences renders references as package/component pairs.
+func formatInventoryReferences(refs []registryInventoryReference) string {
+	out := make([]string, 0, len(refs))
+	for _, ref := range refs {
+		out = append(out, fmt.Sprintf("%s/%s", ref.Package, ref.Component))
+	}
+	return strings.Join(out, " ")
+}
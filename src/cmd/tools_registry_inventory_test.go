@@ -0,0 +1,151 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2021-Present The Zarf Authors
+
+package cmd
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"testing"
+
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+	"github.com/stretchr/testify/require"
+
+	"github.com/zarf-dev/zarf/src/api/v1alpha1"
+	"github.com/zarf-dev/zarf/src/pkg/state"
+	"github.com/zarf-dev/zarf/src/pkg/transform"
+	"github.com/zarf-dev/zarf/src/test/testutil"
+)
+
+func TestRegistryInventory(t *testing.T) {
+	t.Parallel()
+	ctx := testutil.TestContext(t)
+	address := testutil.SetupInMemoryRegistryDynamic(ctx, t)
+
+	// An image owned by a deployed component, with its platform in the config.
+	ownedRef, err := transform.ImageTransformHostWithoutChecksum(address, "ghcr.io/zarf-dev/podinfo:6.4.0")
+	require.NoError(t, err)
+	repo := testutil.NewRepo(t, ownedRef)
+	config := testutil.PushBlob(ctx, t, repo, ocispec.MediaTypeImageConfig, []byte(`{"os":"linux","architecture":"amd64"}`))
+	layer := testutil.PushBlob(ctx, t, repo, ocispec.MediaTypeImageLayer, []byte("layer"))
+	owned := testutil.PushManifest(ctx, t, repo, config, []ocispec.Descriptor{layer})
+	require.NoError(t, repo.Tag(ctx, owned, "6.4.0"))
+
+	// An index owned by a component that was not deployed.
+	multiRef, err := transform.ImageTransformHostWithoutChecksum(address, "ghcr.io/zarf-dev/multi:1.0.0")
+	require.NoError(t, err)
+	multiDigest := testutil.PushMultiArchIndex(ctx, t, multiRef, "1.0.0", []ocispec.Platform{
+		{OS: "linux", Architecture: "amd64"},
+		{OS: "linux", Architecture: "arm64"},
+	})
+
+	zarfPackages := []state.DeployedPackage{
+		{
+			Name: "podinfo",
+			Data: v1alpha1.ZarfPackage{
+				Components: []v1alpha1.ZarfComponent{
+					{Name: "podinfo", Images: []string{"ghcr.io/zarf-dev/podinfo:6.4.0"}},
+					{Name: "not-deployed", Images: []string{"ghcr.io/zarf-dev/multi:1.0.0"}},
+				},
+			},
+			DeployedComponents: []state.DeployedComponent{{Name: "podinfo"}},
+		},
+		{
+			Name: "other",
+			Data: v1alpha1.ZarfPackage{
+				Components: []v1alpha1.ZarfComponent{
+					{Name: "shared", Images: []string{"ghcr.io/zarf-dev/podinfo:6.4.0"}},
+				},
+			},
+			DeployedComponents: []state.DeployedComponent{{Name: "shared"}},
+		},
+	}
+
+	entries, err := registryInventory(ctx, nil, &state.State{}, zarfPackages, address, false)
+	require.NoError(t, err)
+	require.Len(t, entries, 2)
+	byRepo := map[string]registryInventoryEntry{}
+	for _, e := range entries {
+		byRepo[e.Repository] = e
+	}
+
+	podinfo := byRepo["zarf-dev/podinfo"]
+	require.Equal(t, "6.4.0", podinfo.Tag)
+	require.Equal(t, owned.Digest.String(), podinfo.Digest)
+	require.Equal(t, config.Size+layer.Size, podinfo.Size)
+	require.Equal(t, []string{"linux/amd64"}, podinfo.Platforms)
+	require.ElementsMatch(t, []registryInventoryReference{
+		{Package: "podinfo", Component: "podinfo"},
+		{Package: "other", Component: "shared"},
+	}, podinfo.References)
+	require.False(t, podinfo.Orphaned)
+
+	multi := byRepo["zarf-dev/multi"]
+	require.Equal(t, multiDigest, multi.Digest)
+	require.Equal(t, []string{"linux/amd64", "linux/arm64"}, multi.Platforms)
+	require.Empty(t, multi.References)
+	require.True(t, multi.Orphaned)
+
+	// Missing package images fail the inventory unless they are explicitly ignored.
+	zarfPackages[0].Data.Components[0].Images = append(zarfPackages[0].Data.Components[0].Images, "ghcr.io/zarf-dev/missing:1.0.0")
+	_, err = registryInventory(ctx, nil, &state.State{}, zarfPackages, address, false)
+	require.ErrorContains(t, err, "use --ignore-missing to skip")
+	_, err = registryInventory(ctx, nil, &state.State{}, zarfPackages, address, true)
+	require.NoError(t, err)
+}
+
+func TestPrintRegistryInventory(t *testing.T) {
+	t.Parallel()
+	entries := []registryInventoryEntry{
+		{
+			Repository: "zarf-dev/podinfo",
+			Tag:        "6.4.0",
+			Digest:     "sha256:abc",
+			Size:       1024,
+			Platforms:  []string{"linux/amd64", "linux/arm64"},
+			References: []registryInventoryReference{{Package: "podinfo", Component: "podinfo"}},
+		},
+		{
+			Repository: "zarf-dev/orphan",
+			Tag:        "latest",
+			Digest:     "sha256:def",
+			Orphaned:   true,
+		},
+	}
+
+	var buf bytes.Buffer
+	require.NoError(t, printRegistryInventory(&buf, outputCSV, entries))
+	records, err := csv.NewReader(&buf).ReadAll()
+	require.NoError(t, err)
+	require.Equal(t, [][]string{
+		{"repository", "tag", "digest", "size", "platforms", "references", "orphaned"},
+		{"zarf-dev/podinfo", "6.4.0", "sha256:abc", "1024", "linux/amd64 linux/arm64", "podinfo/podinfo", "false"},
+		{"zarf-dev/orphan", "latest", "sha256:def", "0", "", "", "true"},
+	}, records)
+
+	buf.Reset()
+	require.NoError(t, printRegistryInventory(&buf, outputJSON, entries))
+	var decoded []registryInventoryEntry
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &decoded))
+	require.Equal(t, entries, decoded)
+
+	buf.Reset()
+	require.NoError(t, printRegistryInventory(&buf, outputTable, entries))
+	require.Contains(t, buf.String(), "podinfo/podinfo")
+}
+
+func TestInventoryOutputFormat(t *testing.T) {
+	t.Parallel()
+
+	// CSV is only accepted by the inventory commands
+	var f outputFormat
+	require.EqualError(t, f.Set("csv"), "invalid output format: csv")
+
+	var inv inventoryOutputFormat
+	require.NoError(t, inv.Set("csv"))
+	require.Equal(t, inventoryOutputFormat(outputCSV), inv)
+	require.NoError(t, inv.Set("json"))
+	require.Equal(t, inventoryOutputFormat(outputJSON), inv)
+	require.EqualError(t, inv.Set("xml"), "invalid output format: xml")
+}
@@ -0,0 +1,233 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2021-Present The Zarf Authors
+
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"slices"
+	"strings"
+
+	"github.com/defenseunicorns/pkg/helpers/v2"
+	goyaml "github.com/goccy/go-yaml"
+	"github.com/spf13/cobra"
+
+	"github.com/zarf-dev/zarf/src/config/lang"
+	"github.com/zarf-dev/zarf/src/pkg/cluster"
+	"github.com/zarf-dev/zarf/src/pkg/logger"
+	"github.com/zarf-dev/zarf/src/pkg/message"
+	"github.com/zarf-dev/zarf/src/pkg/state"
+)
+
+func newRegistryTargetCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "target",
+		Short:   lang.CmdToolsRegistryTargetShort,
+		Long:    lang.CmdToolsRegistryTargetLong,
+		Example: lang.CmdToolsRegistryTargetExample,
+	}
+
+	cmd.AddCommand(newRegistryTargetSetCommand())
+	cmd.AddCommand(newRegistryTargetRemoveCommand())
+	cmd.AddCommand(newRegistryTargetListCommand())
+
+	return cmd
+}
+
+type registryTargetSetOptions struct {
+	target     state.RegistryTarget
+	namespaces []string
+}
+
+func newRegistryTargetSetCommand() *cobra.Command {
+	o := registryTargetSetOptions{}
+
+	cmd := &cobra.Command{
+		Use:   "set NAME",
+		Short: lang.CmdToolsRegistryTargetSetShort,
+		Args:  cobra.ExactArgs(1),
+		RunE:  o.run,
+	}
+
+	cmd.Flags().StringVar(&o.target.Address, "address", "", lang.CmdToolsRegistryTargetFlagAddress)
+	cmd.Flags().StringVar(&o.target.Prefix, "prefix", "", lang.CmdToolsRegistryTargetFlagPrefix)
+	cmd.Flags().StringVar(&o.target.PushUsername, "push-username", "", lang.CmdToolsRegistryTargetFlagPushUsername)
+	cmd.Flags().StringVar(&o.target.PushPassword, "push-password", "", lang.CmdToolsRegistryTargetFlagPushPassword)
+	cmd.Flags().StringVar(&o.target.PullUsername, "pull-username", "", lang.CmdToolsRegistryTargetFlagPullUsername)
+	cmd.Flags().StringVar(&o.target.PullPassword, "pull-password", "", lang.CmdToolsRegistryTargetFlagPullPassword)
+	cmd.Flags().StringSliceVar(&o.namespaces, "namespaces", nil, lang.CmdToolsRegistryTargetFlagNamespaces)
+
+	return cmd
+}
+
+func (o *registryTargetSetOptions) run(cmd *cobra.Command, args []string) error {
+	ctx := cmd.Context()
+	l := logger.From(ctx)
+
+	c, err := cluster.New(ctx)
+	if err != nil {
+		return err
+	}
+	s, err := c.LoadState(ctx)
+	if err != nil {
+		return err
+	}
+
+	target, err := o.apply(s, args[0], cmd.Flags().Changed("namespaces"))
+	if err != nil {
+		return err
+	}
+
+	l.Info("saving registry target", "name", target.Name, "address", target.Address, "prefix", target.Prefix)
+	if err := c.SaveState(ctx, s); err != nil {
+		return err
+	}
+	return c.UpdateZarfManagedImageSecrets(ctx, s)
+}
+
+// apply sets the target given by the flags in s and returns it. Values that are not given keep their current value so
+// that a single credential can be rotated at a time, and the mapped namespaces are only replaced when namespacesChanged.
+func (o *registryTargetSetOptions) apply(s *state.State, name string, namespacesChanged bool) (state.RegistryTarget, error) {
+	target := o.target
+	target.Name = name
+	if existing, err := s.GetRegistryTarget(target.Name); err == nil {
+		target = helpers.MergeNonZero(existing, target)
+	}
+	var namespaces []string
+	if namespacesChanged {
+		namespaces = o.namespaces
+		if namespaces == nil {
+			namespaces = []string{}
+		}
+	}
+	if err := s.SetRegistryTarget(target, namespaces); err != nil {
+		return state.RegistryTarget{}, err
+	}
+	return target, nil
+}
+
+func newRegistryTargetRemoveCommand() *cobra.Command {
+	return &cobra.Command{
+		Use:     "remove NAME",
+		Aliases: []string{"rm"},
+		Short:   lang.CmdToolsRegistryTargetRemoveShort,
+		Args:    cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := cmd.Context()
+			l := logger.From(ctx)
+
+			c, err := cluster.New(ctx)
+			if err != nil {
+				return err
+			}
+			s, err := c.LoadState(ctx)
+			if err != nil {
+				return err
+			}
+			if err := s.RemoveRegistryTarget(args[0]); err != nil {
+				return err
+			}
+
+			l.Info("removing registry target", "name", args[0])
+			if err := c.SaveState(ctx, s); err != nil {
+				return err
+			}
+			// Namespaces mapped to the target fall back to the Zarf registry, annotated namespaces are skipped until
+			// the annotation is removed.
+			return c.UpdateZarfManagedImageSecrets(ctx, s)
+		},
+	}
+}
+
+type registryTargetListOptions struct {
+	outputFormat outputFormat
+	outputWriter io.Writer
+}
+
+// registryTargetEntry describes a registry target without its credentials.
+type registryTargetEntry struct {
+	Name       string   `json:"name"`
+	Address    string   `json:"address"`
+	Prefix     string   `json:"prefix,omitempty"`
+	Namespaces []string `json:"namespaces"`
+}
+
+func newRegistryTargetListCommand() *cobra.Command {
+	o := registryTargetListOptions{
+		outputFormat: outputTable,
+		outputWriter: OutputWriter,
+	}
+
+	cmd := &cobra.Command{
+		Use:     "list",
+		Aliases: []string{"ls"},
+		Short:   lang.CmdToolsRegistryTargetListShort,
+		Args:    cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			ctx := cmd.Context()
+			c, err := cluster.New(ctx)
+			if err != nil {
+				return err
+			}
+			s, err := c.LoadState(ctx)
+			if err != nil {
+				return err
+			}
+			return printRegistryTargets(o.outputWriter, o.outputFormat, registryTargetEntries(s))
+		},
+	}
+
+	cmd.Flags().VarP(&o.outputFormat, "output-format", "o", lang.CmdToolsRegistryTargetFlagOutputFormat)
+
+	return cmd
+}
+
+// registryTargetEntries lists the registry targets in state with the namespaces mapped to each of them.
+func registryTargetEntries(s *state.State) []registryTargetEntry {
+	entries := []registryTargetEntry{}
+	for _, target := range s.RegistryTargets {
+		namespaces := []string{}
+		for namespace, name := range s.NamespaceRegistryTargets {
+			if name == target.Name {
+				namespaces = append(namespaces, namespace)
+			}
+		}
+		slices.Sort(namespaces)
+		entries = append(entries, registryTargetEntry{
+			Name:       target.Name,
+			Address:    target.Address,
+			Prefix:     target.Prefix,
+			Namespaces: namespaces,
+		})
+	}
+	return entries
+}
+
+func printRegistryTargets(w io.Writer, format outputFormat, entries []registryTargetEntry) error {
+	switch format {
+	case outputJSON:
+		output, err := json.MarshalIndent(entries, "", "  ")
+		if err != nil {
+			return err
+		}
+		fmt.Fprintln(w, string(output))
+	case outputYAML:
+		output, err := goyaml.Marshal(entries)
+		if err != nil {
+			return err
+		}
+		fmt.Fprint(w, string(output))
+	case outputTable:
+		header := []string{"Name", "Address", "Prefix", "Namespaces"}
+		var data [][]string
+		for _, e := range entries {
+			data = append(data, []string{e.Name, e.Address, e.Prefix, strings.Join(e.Namespaces, ", ")})
+		}
+		message.TableWithWriter(w, header, data)
+	default:
+		return fmt.Errorf("unsupported output format: %s", format)
+	}
+	return nil
+}
@@ -0,0 +1,152 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2021-Present The Zarf Authors
+
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/zarf-dev/zarf/src/pkg/state"
+)
+
+func TestRegistryTargetSet(t *testing.T) {
+	t.Parallel()
+
+	existing := state.RegistryTarget{
+		Name:         "tenant-a",
+		Address:      "registry.tenant-a.example.com",
+		Prefix:       "apps",
+		PushUsername: "push",
+		PushPassword: "push-password",
+		PullUsername: "pull",
+		PullPassword: "pull-password",
+	}
+
+	tests := []struct {
+		name              string
+		opts              registryTargetSetOptions
+		targetName        string
+		namespacesChanged bool
+		expectedTarget    state.RegistryTarget
+		expectedMapping   map[string]string
+		expectedErr       string
+	}{
+		{
+			name: "new target",
+			opts: registryTargetSetOptions{
+				target:     state.RegistryTarget{Address: "registry.tenant-b.example.com", PushUsername: "b", PushPassword: "b-password"},
+				namespaces: []string{"b-1", "b-2"},
+			},
+			targetName:        "tenant-b",
+			namespacesChanged: true,
+			expectedTarget:    state.RegistryTarget{Name: "tenant-b", Address: "registry.tenant-b.example.com", PushUsername: "b", PushPassword: "b-password"},
+			expectedMapping:   map[string]string{"a-1": "tenant-a", "b-1": "tenant-b", "b-2": "tenant-b"},
+		},
+		{
+			name:            "rotating a credential keeps the other values and namespaces",
+			opts:            registryTargetSetOptions{target: state.RegistryTarget{PushPassword: "rotated"}},
+			targetName:      "tenant-a",
+			expectedTarget:  state.RegistryTarget{Name: "tenant-a", Address: existing.Address, Prefix: "apps", PushUsername: "push", PushPassword: "rotated", PullUsername: "pull", PullPassword: "pull-password"},
+			expectedMapping: map[string]string{"a-1": "tenant-a"},
+		},
+		{
+			name:              "namespaces replace the mapped namespaces",
+			opts:              registryTargetSetOptions{namespaces: []string{"a-2"}},
+			targetName:        "tenant-a",
+			namespacesChanged: true,
+			expectedTarget:    existing,
+			expectedMapping:   map[string]string{"a-2": "tenant-a"},
+		},
+		{
+			name:              "empty namespaces unmap the target",
+			targetName:        "tenant-a",
+			namespacesChanged: true,
+			expectedTarget:    existing,
+			expectedMapping:   map[string]string{},
+		},
+		{
+			name:        "new target without an address",
+			opts:        registryTargetSetOptions{target: state.RegistryTarget{PushUsername: "b"}},
+			targetName:  "tenant-b",
+			expectedErr: `registry target "tenant-b" must have an address`,
+		},
+		{
+			name:        "empty name",
+			opts:        registryTargetSetOptions{target: state.RegistryTarget{Address: "registry.example.com"}},
+			expectedErr: "registry target name must be set",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			s := &state.State{
+				RegistryTargets:          []state.RegistryTarget{existing},
+				NamespaceRegistryTargets: map[string]string{"a-1": "tenant-a"},
+			}
+			target, err := tt.opts.apply(s, tt.targetName, tt.namespacesChanged)
+			if tt.expectedErr != "" {
+				require.EqualError(t, err, tt.expectedErr)
+				require.Equal(t, []state.RegistryTarget{existing}, s.RegistryTargets)
+				require.Equal(t, map[string]string{"a-1": "tenant-a"}, s.NamespaceRegistryTargets)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tt.expectedTarget, target)
+			stored, err := s.GetRegistryTarget(tt.expectedTarget.Name)
+			require.NoError(t, err)
+			require.Equal(t, tt.expectedTarget, stored)
+			require.Equal(t, tt.expectedMapping, s.NamespaceRegistryTargets)
+		})
+	}
+}
+
+func TestRegistryTargetRemoveUnknown(t *testing.T) {
+	t.Parallel()
+
+	s := &state.State{
+		RegistryTargets:          []state.RegistryTarget{{Name: "tenant-a", Address: "registry.tenant-a.example.com"}},
+		NamespaceRegistryTargets: map[string]string{"a-1": "tenant-a"},
+	}
+	require.EqualError(t, s.RemoveRegistryTarget("tenant-b"), `registry target "tenant-b" does not exist`)
+	require.Len(t, s.RegistryTargets, 1)
+
+	require.NoError(t, s.RemoveRegistryTarget("tenant-a"))
+	require.Empty(t, s.RegistryTargets)
+	require.Empty(t, s.NamespaceRegistryTargets)
+}
+
+func TestRegistryTargetList(t *testing.T) {
+	t.Parallel()
+
+	s := &state.State{
+		RegistryTargets: []state.RegistryTarget{
+			{Name: "tenant-a", Address: "registry.tenant-a.example.com", Prefix: "apps", PushPassword: "secret"},
+			{Name: "tenant-b", Address: "registry.tenant-b.example.com"},
+		},
+		NamespaceRegistryTargets: map[string]string{"a-2": "tenant-a", "a-1": "tenant-a"},
+	}
+	entries := registryTargetEntries(s)
+	expected := []registryTargetEntry{
+		{Name: "tenant-a", Address: "registry.tenant-a.example.com", Prefix: "apps", Namespaces: []string{"a-1", "a-2"}},
+		{Name: "tenant-b", Address: "registry.tenant-b.example.com", Namespaces: []string{}},
+	}
+	require.Equal(t, expected, entries)
+
+	buf := &bytes.Buffer{}
+	require.NoError(t, printRegistryTargets(buf, outputJSON, entries))
+	require.NotContains(t, buf.String(), "secret")
+	var decoded []registryTargetEntry
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &decoded))
+	require.Equal(t, expected, decoded)
+
+	buf.Reset()
+	require.NoError(t, printRegistryTargets(buf, outputTable, entries))
+	require.Contains(t, buf.String(), "a-1, a-2")
+
+	require.EqualError(t, printRegistryTargets(buf, outputFormat("xml"), entries), "unsupported output format: xml")
+}
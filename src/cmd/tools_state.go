@@ -0,0 +1,239 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2021-Present The Zarf Authors
+
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"os"
+
+	"filippo.io/age"
+	"github.com/AlecAivazis/survey/v2"
+	"github.com/defenseunicorns/pkg/helpers/v2"
+	"github.com/spf13/cobra"
+
+	"github.com/zarf-dev/zarf/src/config/lang"
+	"github.com/zarf-dev/zarf/src/pkg/cluster"
+	"github.com/zarf-dev/zarf/src/pkg/logger"
+	"github.com/zarf-dev/zarf/src/pkg/state"
+)
+
+func newStateCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "state",
+		Short: lang.CmdToolsStateShort,
+	}
+
+	cmd.AddCommand(newStateExportCommand())
+	cmd.AddCommand(newStateImportCommand())
+
+	return cmd
+}
+
+type stateExportOptions struct {
+	out            string
+	passphraseFile string
+	recipients     []string
+}
+
+func newStateExportCommand() *cobra.Command {
+	o := stateExportOptions{}
+
+	cmd := &cobra.Command{
+		Use:     "export",
+		Short:   lang.CmdToolsStateExportShort,
+		Long:    lang.CmdToolsStateExportLong,
+		Example: lang.CmdToolsStateExportExample,
+		Args:    cobra.NoArgs,
+		RunE:    o.run,
+	}
+
+	cmd.Flags().StringVar(&o.out, "out", "", lang.CmdToolsStateExportFlagOut)
+	cmd.Flags().StringVar(&o.passphraseFile, "passphrase-file", "", lang.CmdToolsStateFlagPassphraseFile)
+	cmd.Flags().StringSliceVar(&o.recipients, "recipient", []string{}, lang.CmdToolsStateExportFlagRecipient)
+	_ = cmd.MarkFlagRequired("out")
+	cmd.MarkFlagsMutuallyExclusive("passphrase-file", "recipient")
+
+	return cmd
+}
+
+func (o *stateExportOptions) run(cmd *cobra.Command, _ []string) error {
+	ctx := cmd.Context()
+	c, err := cluster.New(ctx)
+	if err != nil {
+		return err
+	}
+	backup, err := c.ExportState(ctx)
+	if err != nil {
+		return err
+	}
+	var data []byte
+	if len(o.recipients) > 0 {
+		recipients := []age.Recipient{}
+		for _, r := range o.recipients {
+			recipient, err := age.ParseX25519Recipient(r)
+			if err != nil {
+				return fmt.Errorf("unable to parse the recipient %s: %w", r, err)
+			}
+			recipients = append(recipients, recipient)
+		}
+		data, err = cluster.EncryptStateBackupToRecipients(backup, recipients)
+		if err != nil {
+			return err
+		}
+	} else {
+		passphrase, err := readStatePassphrase(o.passphraseFile, true)
+		if err != nil {
+			return err
+		}
+		data, err = cluster.EncryptStateBackup(backup, passphrase)
+		if err != nil {
+			return err
+		}
+	}
+	if err := os.WriteFile(o.out, data, helpers.ReadWriteUser); err != nil {
+		return fmt.Errorf("unable to write the state backup: %w", err)
+	}
+	logger.From(ctx).Info("exported the Zarf state", "path", o.out, "secrets", len(backup.Secrets))
+	return nil
+}
+
+type stateImportOptions struct {
+	passphraseFile string
+	identityFile   string
+	overwrite      bool
+	overrideLock   bool
+}
+
+func newStateImportCommand() *cobra.Command {
+	o := stateImportOptions{}
+
+	cmd := &cobra.Command{
+		Use:     "import FILE",
+		Short:   lang.CmdToolsStateImportShort,
+		Long:    lang.CmdToolsStateImportLong,
+		Example: lang.CmdToolsStateImportExample,
+		Args:    cobra.ExactArgs(1),
+		RunE:    o.run,
+	}
+
+	cmd.Flags().StringVar(&o.passphraseFile, "passphrase-file", "", lang.CmdToolsStateFlagPassphraseFile)
+	cmd.Flags().StringVar(&o.identityFile, "identity", "", lang.CmdToolsStateImportFlagIdentity)
+	cmd.Flags().BoolVar(&o.overwrite, "overwrite", false, lang.CmdToolsStateImportFlagOverwrite)
+	cmd.Flags().BoolVar(&o.overrideLock, "override-lock", false, lang.CmdFlagOverrideLock)
+	cmd.MarkFlagsMutuallyExclusive("passphrase-file", "identity")
+
+	return cmd
+}
+
+func (o *stateImportOptions) run(cmd *cobra.Command, args []string) (err error) {
+	ctx := cmd.Context()
+	l := logger.From(ctx)
+	data, err := os.ReadFile(args[0])
+	if err != nil {
+		return fmt.Errorf("unable to read the state backup: %w", err)
+	}
+	var backup *cluster.StateBackup
+	if o.identityFile != "" {
+		identities, err := readStateIdentities(o.identityFile)
+		if err != nil {
+			return err
+		}
+		backup, err = cluster.DecryptStateBackupWithIdentities(data, identities)
+		if err != nil {
+			return err
+		}
+	} else {
+		var passphrase []byte
+		// Backups encrypted to age recipients are refused by DecryptStateBackup, so there is no passphrase to ask for
+		if !cluster.IsAgeStateBackup(data) {
+			passphrase, err = readStatePassphrase(o.passphraseFile, false)
+			if err != nil {
+				return err
+			}
+		}
+		backup, err = cluster.DecryptStateBackup(data, passphrase)
+		if err != nil {
+			return err
+		}
+	}
+
+	timeoutCtx, cancel := context.WithTimeout(ctx, cluster.DefaultTimeout)
+	defer cancel()
+	c, err := cluster.NewWithWait(timeoutCtx)
+	if err != nil {
+		return err
+	}
+	ctx, release, err := c.AcquireDeployLock(ctx, cluster.DeployLockOptions{
+		Operation: "zarf tools state import",
+		Override:  o.overrideLock,
+	})
+	if err != nil {
+		return err
+	}
+	defer func() {
+		err = errors.Join(err, release(context.WithoutCancel(ctx)))
+	}()
+
+	err = c.ImportState(ctx, backup, cluster.ImportStateOptions{Overwrite: o.overwrite})
+	if err != nil {
+		return err
+	}
+	packages := 0
+	for _, secret := range backup.Secrets {
+		if _, ok := secret.Labels[state.ZarfPackageInfoLabel]; ok {
+			packages++
+		}
+	}
+	l.Info("imported the Zarf state", "path", args[0], "packages", packages, "exportedAt", backup.ExportedAt)
+	l.Info("run zarf init to redeploy any Zarf services that are not running, it reuses the imported state and credentials")
+	return nil
+}
+
+// readStatePassphrase reads the passphrase for a state backup from a file, or prompts for it.
+func readStatePassphrase(passphraseFile string, confirm bool) ([]byte, error) {
+	if passphraseFile != "" {
+		b, err := os.ReadFile(passphraseFile)
+		if err != nil {
+			return nil, fmt.Errorf("unable to read the passphrase file: %w", err)
+		}
+		return bytes.TrimRight(b, "\r\n"), nil
+	}
+
+	var passphrase string
+	prompt := &survey.Password{
+		Message: lang.CmdToolsStatePromptPassphrase,
+	}
+	if err := survey.AskOne(prompt, &passphrase, survey.WithValidator(survey.Required)); err != nil {
+		return nil, fmt.Errorf("unable to get the passphrase: %w", err)
+	}
+	if confirm {
+		var doubleCheck string
+		rePrompt := &survey.Password{
+			Message: lang.CmdToolsStatePromptPassphraseAgain,
+		}
+		if err := survey.AskOne(rePrompt, &doubleCheck); err != nil {
+			return nil, fmt.Errorf("unable to get the passphrase: %w", err)
+		}
+		if passphrase != doubleCheck {
+			return nil, errors.New("passphrases do not match")
+		}
+	}
+	return []byte(passphrase), nil
+}
+
+// readStateIdentities reads the age identities for a state backup from an identity file, such as one created by age-keygen.
+func readStateIdentities(identityFile string) ([]age.Identity, error) {
+	b, err := os.ReadFile(identityFile)
+	if err != nil {
+		return nil, fmt.Errorf("unable to read the identity file: %w", err)
+	}
+	identities, err := age.ParseIdentities(bytes.NewReader(b))
+	if err != nil {
+		return nil, fmt.Errorf("unable to parse the identity file: %w", err)
+	}
+	return identities, nil
+}
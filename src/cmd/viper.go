@@ -11,6 +11,7 @@ import (
 	"os"
 	"path/filepath"
 	"strings"
+	"time"
 
 	"github.com/spf13/cobra"
 	"github.com/zarf-dev/zarf/src/pkg/logger"
@@ -55,6 +56,7 @@ const (
 	VInitGitPushPass = "init.git.push_password"
 	VInitGitPullUser = "init.git.pull_username"
 	VInitGitPullPass = "init.git.pull_password"
+	VInitGitProvider = "init.git.provider"
 
 	// Init Registry config keys
 
@@ -75,10 +77,11 @@ const (
 	VInitArtifactPushUser  = "init.artifact.push_username"
 	VInitArtifactPushToken = "init.artifact.push_token"
 
-	VInitAgentTLSCA          = "init.agent.tls_ca"
-	VInitAgentTLSCert        = "init.agent.tls_cert"
-	VInitAgentTLSKey         = "init.agent.tls_key"
-	VInitAgentMutationPolicy = "init.agent.mutation_policy"
+	VInitAgentTLSCA               = "init.agent.tls_ca"
+	VInitAgentTLSCert             = "init.agent.tls_cert"
+	VInitAgentTLSKey              = "init.agent.tls_key"
+	VInitAgentMutationPolicy      = "init.agent.mutation_policy"
+	VInitAgentImagePullSecretMode = "init.agent.image_pull_secret_mode"
 
 	// Package config keys
 
@@ -108,6 +111,8 @@ const (
 	VPkgCreateSigningKeyPassword   = "package.create.signing_key_password"
 	VPkgCreateDifferential         = "package.create.differential"
 	VPkgCreateRegistryOverride     = "package.create.registry_override"
+	VPkgCreateRegistryMirror       = "package.create.registry_mirror"
+	VPkgCreateRegistryConcurrency  = "package.create.registry_concurrency"
 	VPkgCreateFlavor               = "package.create.flavor"
 	VPkgCreateWithBuildMachineInfo = "package.create.with_build_machine_info"
 
@@ -155,13 +160,28 @@ const (
 
 	// Package deploy config keys
 
-	VPkgDeployConnected = "package.deploy.connected"
+	VPkgDeployConnected              = "package.deploy.connected"
+	VPkgDeployAgentCertExpiryWarning = "package.deploy.agent_cert_expiry_warning"
+	VPkgDeployRegistryTarget         = "package.deploy.registry_target"
+	VPkgDeployGitNoForce             = "package.deploy.git_no_force"
+	VPkgDeployGitOrg                 = "package.deploy.git_org"
+	VPkgDeployGitOrgPerPackage       = "package.deploy.git_org_per_package"
 
 	// Dev deploy config keys
 
 	VDevDeployNoYolo    = "dev.deploy.no_yolo"
 	VDevDeployConnected = "dev.deploy.connected"
 
+	// Dev agent replay config keys
+
+	VDevAgentReplayState              = "dev.agent_replay.state"
+	VDevAgentReplayMutationPolicy     = "dev.agent_replay.mutation_policy"
+	VDevAgentReplayImageValidation    = "dev.agent_replay.image_validation"
+	VDevAgentReplayMutationRules      = "dev.agent_replay.mutation_rules"
+	VDevAgentReplayNamespace          = "dev.agent_replay.namespace"
+	VDevAgentReplayOCIMediaType       = "dev.agent_replay.oci_media_type"
+	VDevAgentReplayMutationExclusions = "dev.agent_replay.mutation_exclusions"
+
 	// Dev template config keys
 
 	VDevTemplateSet            = "dev.template.set"
@@ -282,6 +302,7 @@ func setDefaults(v *viper.Viper) {
 
 	// Deploy opts that are non-zero values
 	v.SetDefault(VPkgDeployTimeout, config.ZarfDefaultTimeout)
+	v.SetDefault(VPkgDeployAgentCertExpiryWarning, 30*24*time.Hour)
 
 	// Package publish opts that are non-zero values
 	v.SetDefault(VPkgPublishRetries, 1)
@@ -294,9 +315,13 @@ func setDefaults(v *viper.Viper) {
 
 	// Dev deploy defaults
 	v.SetDefault(VDevDeployConnected, true)
+	v.SetDefault(VDevAgentReplayMutationPolicy, string(state.MutationPolicyAll))
+	v.SetDefault(VDevAgentReplayImageValidation, "disabled")
+	v.SetDefault(VDevAgentReplayNamespace, "default")
 
 	// Init defaults that are non-zero values
 	v.SetDefault(VInitAgentMutationPolicy, string(state.MutationPolicyAll))
+	v.SetDefault(VInitAgentImagePullSecretMode, string(state.ImagePullSecretModeReplace))
 }
 
 // GetStringSlice returns a string slice from viper
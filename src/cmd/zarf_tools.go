@@ -228,6 +228,7 @@ func printComponentCredential(ctx context.Context, s *state.State, componentName
 type updateCredsOptions struct {
 	confirm          bool
 	forceConflicts   bool
+	overrideLock     bool
 	gitServer        state.GitServerInfo
 	registryInfo     state.RegistryInfo
 	artifactServer   state.ArtifactServerInfo
@@ -254,6 +255,7 @@ func newUpdateCredsCommand(v *viper.Viper) *cobra.Command {
 	// Always require confirm flag (no viper)
 	cmd.Flags().BoolVarP(&o.confirm, "confirm", "c", false, lang.CmdToolsUpdateCredsConfirmFlag)
 	cmd.Flags().BoolVar(&o.forceConflicts, "force-conflicts", false, lang.CmdPackageDeployFlagForceConflicts)
+	cmd.Flags().BoolVar(&o.overrideLock, "override-lock", false, lang.CmdFlagOverrideLock)
 
 	// Flags for using an external Git server
 	cmd.Flags().StringVar(&o.gitServer.Address, "git-url", v.GetString(VInitGitURL), lang.CmdInitFlagGitURL)
@@ -261,6 +263,7 @@ func newUpdateCredsCommand(v *viper.Viper) *cobra.Command {
 	cmd.Flags().StringVar(&o.gitServer.PushPassword, "git-push-password", v.GetString(VInitGitPushPass), lang.CmdInitFlagGitPushPass)
 	cmd.Flags().StringVar(&o.gitServer.PullUsername, "git-pull-username", v.GetString(VInitGitPullUser), lang.CmdInitFlagGitPullUser)
 	cmd.Flags().StringVar(&o.gitServer.PullPassword, "git-pull-password", v.GetString(VInitGitPullPass), lang.CmdInitFlagGitPullPass)
+	cmd.Flags().StringVar((*string)(&o.gitServer.Provider), "git-provider", v.GetString(VInitGitProvider), lang.CmdInitFlagGitProvider)
 
 	// Flags for using an external registry
 	cmd.Flags().StringVar(&o.registryInfo.Address, "registry-url", v.GetString(VInitRegistryURL), lang.CmdInitFlagRegURL)
@@ -292,7 +295,7 @@ func newUpdateCredsCommand(v *viper.Viper) *cobra.Command {
 	return cmd
 }
 
-func (o *updateCredsOptions) run(cmd *cobra.Command, args []string, v *viper.Viper) error {
+func (o *updateCredsOptions) run(cmd *cobra.Command, args []string, v *viper.Viper) (err error) {
 	ctx := cmd.Context()
 	l := logger.From(ctx)
 	l.Warn(lang.CmdToolsUpdateCredsDeprecated)
@@ -313,6 +316,13 @@ func (o *updateCredsOptions) run(cmd *cobra.Command, args []string, v *viper.Vip
 	if err != nil {
 		return err
 	}
+	ctx, release, err := c.AcquireDeployLock(ctx, cluster.DeployLockOptions{Operation: "zarf tools update-creds", Override: o.overrideLock})
+	if err != nil {
+		return err
+	}
+	defer func() {
+		err = errors.Join(err, release(context.WithoutCancel(ctx)))
+	}()
 
 	oldState, err := c.LoadState(ctx)
 	if err != nil {
@@ -402,11 +412,12 @@ func (o *updateCredsOptions) run(cmd *cobra.Command, args []string, v *viper.Vip
 		return err
 	}
 
-	// Update artifact token (if internal)
-	if services.Has(state.ArtifactKey) && newState.ArtifactServer.PushToken == "" && newState.ArtifactServer.IsInternal() && internalGitServerExists {
+	// Update artifact token (if served by the git server)
+	if services.Has(state.ArtifactKey) && newState.ArtifactServer.PushToken == "" && newState.GitServer.HostsArtifactServer(newState.ArtifactServer) &&
+		(internalGitServerExists || !newState.ArtifactServer.IsInternal()) {
 		newState.ArtifactServer.PushToken, err = c.UpdateInternalArtifactServerToken(ctx, oldState.GitServer)
 		if err != nil {
-			return fmt.Errorf("unable to create the new Gitea artifact token: %w", err)
+			return fmt.Errorf("unable to create the new artifact token: %w", err)
 		}
 	}
 
@@ -433,7 +444,7 @@ func (o *updateCredsOptions) run(cmd *cobra.Command, args []string, v *viper.Vip
 			l.Warn("unable to update Zarf Registry values", "error", err.Error())
 		}
 	}
-	if services.Has(state.GitKey) && newState.GitServer.IsInternal() && internalGitServerExists {
+	if services.Has(state.GitKey) && newState.GitServer.ManagesUsers() && (internalGitServerExists || !newState.GitServer.IsInternal()) {
 		err := c.UpdateInternalGitServerSecret(cmd.Context(), oldState.GitServer, newState.GitServer)
 		if err != nil {
 			return fmt.Errorf("unable to update Zarf Git Server values: %w", err)
@@ -495,19 +506,28 @@ func printCredentialUpdates(ctx context.Context, oldState *state.State, newState
 	}
 }
 
-// loadClusterAndState connects to the cluster and returns it alongside the current Zarf state.
-func loadClusterAndState(ctx context.Context) (*cluster.Cluster, *state.State, error) {
+// loadClusterAndState connects to the cluster, takes the deploy lock for the credential update of service and returns
+// the cluster alongside the current Zarf state. The returned context is cancelled when the lock is lost and the
+// returned function releases the lock.
+func loadClusterAndState(ctx context.Context, service state.ServiceKey, overrideLock bool) (context.Context, *cluster.Cluster, *state.State, func(context.Context) error, error) {
 	timeoutCtx, cancel := context.WithTimeout(ctx, cluster.DefaultTimeout)
 	defer cancel()
 	c, err := cluster.NewWithWait(timeoutCtx)
 	if err != nil {
-		return nil, nil, err
+		return nil, nil, nil, nil, err
 	}
-	s, err := c.LoadState(ctx)
+	lockCtx, release, err := c.AcquireDeployLock(ctx, cluster.DeployLockOptions{
+		Operation: fmt.Sprintf("zarf tools update-creds %s", service),
+		Override:  overrideLock,
+	})
 	if err != nil {
-		return nil, nil, err
+		return nil, nil, nil, nil, err
 	}
-	return c, s, nil
+	s, err := c.LoadState(lockCtx)
+	if err != nil {
+		return nil, nil, nil, nil, errors.Join(err, release(ctx))
+	}
+	return lockCtx, c, s, release, nil
 }
 
 // confirmCredentialUpdate prints the pending changes for a single service and prompts for
@@ -582,6 +602,7 @@ func resolveRegistryUpdate(ctx context.Context, c *cluster.Cluster, oldRegistryI
 type updateRegistryCredsOptions struct {
 	confirm        bool
 	forceConflicts bool
+	overrideLock   bool
 	registryInfo   state.RegistryInfo
 }
 
@@ -601,6 +622,7 @@ func newUpdateRegistryCredsCommand(v *viper.Viper) *cobra.Command {
 
 	cmd.Flags().BoolVarP(&o.confirm, "confirm", "c", false, lang.CmdToolsUpdateCredsConfirmFlag)
 	cmd.Flags().BoolVar(&o.forceConflicts, "force-conflicts", false, lang.CmdPackageDeployFlagForceConflicts)
+	cmd.Flags().BoolVar(&o.overrideLock, "override-lock", false, lang.CmdFlagOverrideLock)
 	cmd.Flags().StringVar(&o.registryInfo.Address, "registry-url", v.GetString(VInitRegistryURL), lang.CmdInitFlagRegURL)
 	cmd.Flags().StringVar(&o.registryInfo.PushUsername, "registry-push-username", v.GetString(VInitRegistryPushUser), lang.CmdInitFlagRegPushUser)
 	cmd.Flags().StringVar(&o.registryInfo.PushPassword, "registry-push-password", v.GetString(VInitRegistryPushPass), lang.CmdInitFlagRegPushPass)
@@ -610,12 +632,15 @@ func newUpdateRegistryCredsCommand(v *viper.Viper) *cobra.Command {
 	return cmd
 }
 
-func (o *updateRegistryCredsOptions) run(cmd *cobra.Command, _ []string, v *viper.Viper) error {
+func (o *updateRegistryCredsOptions) run(cmd *cobra.Command, _ []string, v *viper.Viper) (err error) {
 	ctx := cmd.Context()
-	c, oldState, err := loadClusterAndState(ctx)
+	ctx, c, oldState, release, err := loadClusterAndState(ctx, state.RegistryKey, o.overrideLock)
 	if err != nil {
 		return err
 	}
+	defer func() {
+		err = errors.Join(err, release(context.WithoutCancel(ctx)))
+	}()
 
 	if !oldState.RegistryInfo.IsConfigured() {
 		return errors.New("no registry is configured in the Zarf state; nothing to update")
@@ -685,8 +710,9 @@ func (o *updateRegistryCredsOptions) applyState(ctx context.Context, c *cluster.
 }
 
 type updateGitCredsOptions struct {
-	confirm   bool
-	gitServer state.GitServerInfo
+	confirm      bool
+	overrideLock bool
+	gitServer    state.GitServerInfo
 }
 
 func newUpdateGitCredsCommand(v *viper.Viper) *cobra.Command {
@@ -702,21 +728,26 @@ func newUpdateGitCredsCommand(v *viper.Viper) *cobra.Command {
 	}
 
 	cmd.Flags().BoolVarP(&o.confirm, "confirm", "c", false, lang.CmdToolsUpdateCredsConfirmFlag)
+	cmd.Flags().BoolVar(&o.overrideLock, "override-lock", false, lang.CmdFlagOverrideLock)
 	cmd.Flags().StringVar(&o.gitServer.Address, "git-url", v.GetString(VInitGitURL), lang.CmdInitFlagGitURL)
 	cmd.Flags().StringVar(&o.gitServer.PushUsername, "git-push-username", v.GetString(VInitGitPushUser), lang.CmdInitFlagGitPushUser)
 	cmd.Flags().StringVar(&o.gitServer.PushPassword, "git-push-password", v.GetString(VInitGitPushPass), lang.CmdInitFlagGitPushPass)
 	cmd.Flags().StringVar(&o.gitServer.PullUsername, "git-pull-username", v.GetString(VInitGitPullUser), lang.CmdInitFlagGitPullUser)
 	cmd.Flags().StringVar(&o.gitServer.PullPassword, "git-pull-password", v.GetString(VInitGitPullPass), lang.CmdInitFlagGitPullPass)
+	cmd.Flags().StringVar((*string)(&o.gitServer.Provider), "git-provider", v.GetString(VInitGitProvider), lang.CmdInitFlagGitProvider)
 
 	return cmd
 }
 
-func (o *updateGitCredsOptions) run(cmd *cobra.Command, _ []string) error {
+func (o *updateGitCredsOptions) run(cmd *cobra.Command, _ []string) (err error) {
 	ctx := cmd.Context()
-	c, oldState, err := loadClusterAndState(ctx)
+	ctx, c, oldState, release, err := loadClusterAndState(ctx, state.GitKey, o.overrideLock)
 	if err != nil {
 		return err
 	}
+	defer func() {
+		err = errors.Join(err, release(context.WithoutCancel(ctx)))
+	}()
 
 	if !oldState.GitServer.IsConfigured() {
 		return errors.New("no Git server is configured in the Zarf state; nothing to update")
@@ -745,7 +776,7 @@ func (o *updateGitCredsOptions) run(cmd *cobra.Command, _ []string) error {
 }
 
 func (o *updateGitCredsOptions) applyState(ctx context.Context, c *cluster.Cluster, fromState, toState *state.State) error {
-	if toState.GitServer.IsInternal() {
+	if toState.GitServer.ManagesUsers() {
 		if err := c.UpdateInternalGitServerSecret(ctx, fromState.GitServer, toState.GitServer); err != nil {
 			return fmt.Errorf("unable to update Zarf Git Server values: %w", err)
 		}
@@ -762,6 +793,8 @@ func (o *updateGitCredsOptions) applyState(ctx context.Context, c *cluster.Clust
 type updateAgentCredsOptions struct {
 	confirm          bool
 	forceConflicts   bool
+	overrideLock     bool
+	rotate           bool
 	agentTLSCAPath   string
 	agentTLSCertPath string
 	agentTLSKeyPath  string
@@ -781,6 +814,8 @@ func newUpdateAgentCredsCommand(_ *viper.Viper) *cobra.Command {
 
 	cmd.Flags().BoolVarP(&o.confirm, "confirm", "c", false, lang.CmdToolsUpdateCredsConfirmFlag)
 	cmd.Flags().BoolVar(&o.forceConflicts, "force-conflicts", false, lang.CmdPackageDeployFlagForceConflicts)
+	cmd.Flags().BoolVar(&o.overrideLock, "override-lock", false, lang.CmdFlagOverrideLock)
+	cmd.Flags().BoolVar(&o.rotate, "rotate", false, lang.CmdToolsUpdateCredsAgentFlagRotate)
 	cmd.Flags().StringVar(&o.agentTLSCAPath, "agent-tls-ca", "", "Path to a PEM-encoded CA certificate for the Zarf agent")
 	cmd.Flags().StringVar(&o.agentTLSCertPath, "agent-tls-cert", "", "Path to a PEM-encoded TLS certificate for the Zarf agent")
 	cmd.Flags().StringVar(&o.agentTLSKeyPath, "agent-tls-key", "", "Path to a PEM-encoded TLS private key for the Zarf agent")
@@ -789,12 +824,15 @@ func newUpdateAgentCredsCommand(_ *viper.Viper) *cobra.Command {
 	return cmd
 }
 
-func (o *updateAgentCredsOptions) run(cmd *cobra.Command, _ []string) error {
+func (o *updateAgentCredsOptions) run(cmd *cobra.Command, _ []string) (err error) {
 	ctx := cmd.Context()
-	c, oldState, err := loadClusterAndState(ctx)
+	ctx, c, oldState, release, err := loadClusterAndState(ctx, state.AgentKey, o.overrideLock)
 	if err != nil {
 		return err
 	}
+	defer func() {
+		err = errors.Join(err, release(context.WithoutCancel(ctx)))
+	}()
 
 	if !oldState.AgentIsConfigured() {
 		return errors.New("no agent is configured in the Zarf state; nothing to update")
@@ -817,6 +855,31 @@ func (o *updateAgentCredsOptions) run(cmd *cobra.Command, _ []string) error {
 		return fmt.Errorf("unable to update agent credentials: %w", err)
 	}
 
+	// A rotation reissues the certificate from the current CA when its key is in the state, the webhooks then keep
+	// trusting the CA and a single upgrade is enough. Otherwise the new CA is issued and the webhooks trust it alongside
+	// the previous CA before the agent switches certificates, so that pods serving either certificate are accepted.
+	var overlapState *state.State
+	if o.rotate && o.agentTLSCAPath == "" && len(oldState.AgentTLS.CAKey) > 0 {
+		reissued, err := pki.ReissuePKI(oldState.AgentTLS, state.ZarfAgentHost)
+		if err != nil {
+			return fmt.Errorf("unable to rotate agent credentials: %w", err)
+		}
+		newState.AgentTLS = reissued
+	} else if o.rotate {
+		if o.agentTLSCAPath == "" {
+			logger.From(ctx).Info("the agent CA key is not in the Zarf state, rotating to a new CA")
+		}
+		bundle, err := pki.RotationCABundle(newState.AgentTLS.CA, oldState.AgentTLS.CA)
+		if err != nil {
+			return fmt.Errorf("unable to rotate agent credentials: %w", err)
+		}
+		newState.AgentTLS.CA = bundle
+		overlap := *oldState
+		// The CA key of the previous CA does not match the first certificate of the bundle so it is not kept.
+		overlap.AgentTLS = pki.GeneratedPKI{CA: bundle, Cert: oldState.AgentTLS.Cert, Key: oldState.AgentTLS.Key}
+		overlapState = &overlap
+	}
+
 	confirm, err := confirmCredentialUpdate(ctx, oldState, newState, state.AgentKey, o.confirm)
 	if err != nil {
 		return err
@@ -826,11 +889,23 @@ func (o *updateAgentCredsOptions) run(cmd *cobra.Command, _ []string) error {
 	}
 
 	return runWithRollback(ctx, "agent",
-		func() error { return o.applyState(ctx, c, newState) },
-		func() error { return o.applyState(ctx, c, oldState) },
+		func() error { return o.applyRotation(ctx, c, overlapState, newState) },
+		func() error { return o.applyRotation(ctx, c, overlapState, oldState) },
 	)
 }
 
+// applyRotation applies s, first applying overlapState when set so that the webhooks trust both CAs before the
+// agent certificate changes.
+func (o *updateAgentCredsOptions) applyRotation(ctx context.Context, c *cluster.Cluster, overlapState, s *state.State) error {
+	if overlapState != nil {
+		logger.From(ctx).Info("trusting the previous and new agent CAs before switching the agent certificate")
+		if err := o.applyState(ctx, c, overlapState); err != nil {
+			return err
+		}
+	}
+	return o.applyState(ctx, c, s)
+}
+
 func (o *updateAgentCredsOptions) applyState(ctx context.Context, c *cluster.Cluster, s *state.State) error {
 	helmOpts := helm.InstallUpgradeOptions{
 		VariableConfig: template.GetZarfVariableConfig(ctx, !o.confirm),
@@ -147,6 +147,8 @@ $ zarf init --git-push-password={PASSWORD} --git-push-username={USERNAME} --git-
 `
 
 	CmdInitErrValidateGit      = "the 'git-push-username' and 'git-push-password' flags must be provided if the 'git-url' flag is provided"
+	CmdInitErrValidateGitProv  = "the 'git-provider' flag must be gitea, gitlab, or precreated"
+	CmdInitErrValidateGitInt   = "the 'git-provider' flag must be gitea if the 'git-url' flag is not provided"
 	CmdInitErrValidateRegistry = "the 'registry-push-username' and 'registry-push-password' flags must be provided if the 'registry-url' flag is provided"
 	CmdInitErrValidateArtifact = "the 'artifact-push-username' and 'artifact-push-token' flags must be provided if the 'artifact-url' flag is provided"
 
@@ -166,6 +168,7 @@ $ zarf init --git-push-password={PASSWORD} --git-push-username={USERNAME} --git-
 	CmdInitFlagGitPushPass = "Password for the push-user to access the git server"
 	CmdInitFlagGitPullUser = "Username for pull-only access to the git server"
 	CmdInitFlagGitPullPass = "Password for the pull-only user to access the git server"
+	CmdInitFlagGitProvider = "API used to create repositories and manage users on the git server (valid values: gitea, gitlab, precreated). Defaults to gitea for the internal git server and precreated for an external git server"
 
 	CmdInitFlagRegURL      = "External registry url address to use for this Zarf cluster"
 	CmdInitFlagRegPort     = "Port to access the internal registry. In nodeport mode this is a Kubernetes NodePort, in proxy mode it is a host port"
@@ -227,6 +230,7 @@ $ zarf init --git-push-password={PASSWORD} --git-push-username={USERNAME} --git-
 	CmdPackageFlagVerify                  = "Signature verification mode (always|if-possible|never)."
 	CmdPackageFlagSkipSignatureValidation = "[Deprecated] Skip validating the signature of the Zarf package. Use --verify=never instead."
 	CmdPackageFlagRetries                 = "Number of retries to perform for Zarf operations like git/image pushes"
+	CmdFlagOverrideLock                   = "Take the cluster-wide Zarf deploy lock even when another operation holds it. Only use this when the holder is no longer running"
 
 	CmdPackageCreateShort = "Creates a Zarf package from a given directory or the current directory"
 	CmdPackageCreateLong  = "Builds an archive of resources and dependencies defined by the 'zarf.yaml' in the specified directory.\n" +
@@ -301,6 +305,8 @@ $ zarf package mirror-resources zarf-package-my-app-amd64-1.0.0.tar.zst --repos
 	CmdPackageCreateFlagDeprecatedKeyPassword = "[Deprecated] Password to the private key file used for signing packages (use --signing-key-pass instead)"
 	CmdPackageCreateFlagDifferential          = "Build a package that only contains the differential changes from local resources and differing remote resources from the specified previously built package"
 	CmdPackageCreateFlagRegistryOverride      = "Specify a mapping of domains to override on package create when pulling images (e.g. --registry-override docker.io=dockerio-reg.enterprise.intranet)"
+	CmdPackageCreateFlagRegistryMirror        = "Specify a mirror to try before the upstream registry on package create when pulling images. Mirrors for the same source are tried in the order given (e.g. --registry-mirror docker.io=harbor.enterprise.intranet/dockerhub-proxy)"
+	CmdPackageCreateFlagRegistryConcurrency   = "Specify the maximum number of concurrent requests to a host on package create, shared by the images, charts, repos and artifacts of every component (e.g. --registry-concurrency docker.io=2)"
 	CmdPackageCreateFlagFlavor                = "The flavor of components to include in the resulting package (i.e. have a matching or empty \"only.flavor\" key)"
 	CmdPackageCreateFlagValuesFiles           = "[beta] Values files to use for templating and Helm overrides. Multiple files can be passed in as a comma separated list, and the flag can be provided multiple times."
 	CmdPackageCreateFlagWithBuildMachineInfo  = "Include build machine information (hostname and username) in the package metadata"
@@ -316,6 +322,11 @@ $ zarf package mirror-resources zarf-package-my-app-amd64-1.0.0.tar.zst --repos
 	CmdPackageDeployFlagComponents             = "Comma-separated list of components to deploy.  Adding this flag will skip the prompts for selected components.  Globbing component names with '*' and deselecting 'default' components with a leading '-' are also supported."
 	CmdPackageDeployFlagShasum                 = "Shasum of the package to deploy. Required if deploying a remote https package."
 	CmdPackageDeployFlagTimeout                = "Timeout for health checks and Helm operations such as installs and rollbacks"
+	CmdPackageDeployFlagAgentCertExpiryWarning = "Warn when the Zarf agent TLS certificate expires within this duration"
+	CmdPackageDeployFlagGitNoForce             = "Refuse to push git refs that are not a fast-forward of the refs on the git server instead of force pushing them, so commits made on the git server are never overwritten"
+	CmdPackageDeployFlagGitOrg                 = "Push the package's repos into this Gitea organization instead of the account of the git push user. The pull user is given read access to the organization and the agent rewrites the git URLs of the package's resources to it."
+	CmdPackageDeployFlagGitOrgPerPackage       = "Push the package's repos into a Gitea organization named after the package instead of the account of the git push user"
+	CmdPackageDeployFlagRegistryTarget         = "Name of the registry target to push images to instead of the Zarf registry. The namespaces the package creates or adopts are annotated to pull from it."
 	CmdPackageDeployValidateArchitectureErr    = "this package architecture is %s, but the target cluster only has the %s architecture(s). These architectures must be compatible when \"images\" are present"
 	CmdPackageDeployInvalidCLIVersionWarn      = "CLIVersion is set to '%s' which can cause issues with package creation and deployment. To avoid such issues, please set the value to the valid semantic version for this version of Zarf."
 	CmdPackageDeployFlagNamespace              = "[Alpha] Override the namespace for package deployment. Requires the package to have only one distinct namespace defined."
@@ -551,6 +562,33 @@ $ zarf package pull oci://ghcr.io/zarf-dev/packages/dos-games:1.3.0 -a skeleton`
 	CmdDevLintShort = "Lints the given package for valid schema and recommended practices"
 	CmdDevLintLong  = "Verifies the package schema, checks if any variables won't be evaluated, and checks for unpinned images/repos/files"
 
+	CmdDevAgentReplayShort = "Replays AdmissionReviews or resources through the Zarf agent without a cluster"
+	CmdDevAgentReplayLong  = "Sends the AdmissionReviews and resources in FILE, or in the JSON and YAML files in DIRECTORY, through the same\n" +
+		"webhooks the Zarf agent serves and prints the resulting JSON patches or mutated objects.\n\n" +
+		"The agent runs against an in-memory cluster holding the given Zarf state, or a default state if none is given.\n" +
+		"Namespaces in the input are created in that cluster so their labels and annotations apply to the other resources.\n" +
+		"Each resource is sent to the webhooks the agent chart registers for it with the given options.\n" +
+		"OCI artifacts are not looked up in the registry, they are reported as having the media type given by --oci-media-type."
+	CmdDevAgentReplayExample = `
+# Show the patches the agent makes to the resources in a directory
+$ zarf dev agent-replay ./manifests
+
+# Replay a captured AdmissionReview against the state of a cluster and print the mutated object
+$ zarf dev agent-replay review.json --state zarf-state.json -o object
+
+# Replay a Flux OCIRepository that points to a Helm chart
+$ zarf dev agent-replay ocirepository.yaml --oci-media-type application/vnd.cncf.helm.config.v1+json
+`
+	CmdDevAgentReplayFlagState              = "Path to a JSON or YAML Zarf state to replay against, defaults to the state of a newly initialized cluster"
+	CmdDevAgentReplayFlagMutationPolicy     = `Agent mutation policy to replay with: "all", "labeled" or "audit"`
+	CmdDevAgentReplayFlagImageValidation    = `Action of the pod image validation webhook: "disabled", "warn" or "deny"`
+	CmdDevAgentReplayFlagMutationRules      = "Path to a YAML file of agent mutation rules for resources without a built-in hook"
+	CmdDevAgentReplayFlagNamespace          = "Namespace of resources that do not set one"
+	CmdDevAgentReplayFlagOperation          = `Admission operation of resources that are not wrapped in an AdmissionReview: "CREATE" or "UPDATE"`
+	CmdDevAgentReplayFlagOCIMediaType       = "Config media type reported for every OCI artifact the agent looks up"
+	CmdDevAgentReplayFlagMutationExclusions = "GitOps tools whose resources the agent does not mutate: argocd and flux"
+	CmdDevAgentReplayFlagOutput             = `Output format: "patch" prints the JSON patch of each resource, "object" prints the mutated resources`
+
 	// zarf tools
 	CmdToolsShort = "Collection of additional tools to make airgap easier"
 
@@ -679,6 +717,94 @@ $ zarf tools registry export ghcr.io/stefanprodan/podinfo:6.4.0 podinfo.6.4.0.ta
 	CmdToolsRegistryPruneCalculate         = "Calculating images to prune"
 	CmdToolsRegistryPruneDelete            = "Deleting unused images"
 
+	CmdToolsRegistryInventoryShort             = "Lists every image in the Zarf registry with the deployed packages and components that reference it."
+	CmdToolsRegistryInventoryFlagOutputFormat  = "Prints the output in the specified format. Valid options: table, json, yaml, csv"
+	CmdToolsRegistryInventoryFlagIgnoreMissing = "Ignore missing image manifests and continue the inventory"
+	CmdToolsRegistryInventoryExample           = `
+# List the images in the Zarf registry and the packages that own them
+$ zarf tools registry inventory
+
+# Export the inventory for an audit
+$ zarf tools registry inventory -o csv > inventory.csv
+`
+
+	CmdToolsGitShort          = "Tools for managing the repositories on the Zarf git server"
+	CmdToolsGitInventoryShort = "Lists the repositories that Zarf manages on the git server with the deployed packages and components that reference them."
+	CmdToolsGitInventoryLong  = "Lists the repositories owned by the Zarf push user or by the organizations that Zarf created on the git server, " +
+		"along with the deployed packages and components that reference them.\n\n" +
+		"The last updated time is reported by the git server and changes on every push as well as on every edit of the repository settings, " +
+		"so it is not the time of the last push."
+	CmdToolsGitInventoryFlagOutputFormat = "Prints the output in the specified format. Valid options: table, json, yaml, csv"
+	CmdToolsGitInventoryExample          = `
+# List the repositories on the Zarf git server and the packages that reference them
+$ zarf tools git inventory
+
+# Export the inventory for an audit
+$ zarf tools git inventory -o csv > repositories.csv
+`
+	CmdToolsGitPruneShort = "Prunes repositories from the Zarf git server that are not referenced by any deployed Zarf packages."
+	CmdToolsGitPruneLong  = "Prunes repositories that are not referenced by any deployed Zarf packages from the git server. " +
+		"Only repositories owned by the Zarf push user or by the organizations that Zarf created are considered, " +
+		"repositories of other users and organizations are never pruned."
+	CmdToolsGitPruneFlagConfirm = "Confirm the repository prune action to prevent accidental deletions"
+
+	CmdToolsStateShort       = "Exports and imports the Zarf state of a cluster for disaster recovery"
+	CmdToolsStateExportShort = "Exports the Zarf state, deployed package records, and service credentials to an encrypted file"
+	CmdToolsStateExportLong  = "Exports the zarf-state secret, every deployed package record, the registry and agent TLS secrets, and the git server and registry credentials " +
+		"from the Zarf namespace. The file is encrypted with a key derived from a passphrase, which is prompted for unless --passphrase-file is set, " +
+		"or to the age public keys passed with --recipient so that it can be decrypted with the matching age identity."
+	CmdToolsStateExportExample = `
+# Export the Zarf state of the current cluster
+$ zarf tools state export --out zarf-state.backup
+
+# Export the Zarf state in a pipeline
+$ zarf tools state export --out zarf-state.backup --passphrase-file ./passphrase
+
+# Export the Zarf state encrypted to an age public key
+$ zarf tools state export --out zarf-state.backup --recipient age1ql3z7hjy54pw3hyww5ayyfg7zqgvc7w3j2elw8zmrj2kg5sfn9aqmcac8p
+`
+	CmdToolsStateExportFlagOut       = "Path of the encrypted file to write the Zarf state to"
+	CmdToolsStateExportFlagRecipient = "Age public key to encrypt the Zarf state to instead of a passphrase, can be repeated to encrypt to several keys"
+	CmdToolsStateFlagPassphraseFile  = "Path of a file holding the passphrase for the encrypted state, the passphrase is prompted for when this is not set"
+	CmdToolsStateImportShort         = "Imports the Zarf state from a file created by zarf tools state export"
+	CmdToolsStateImportLong          = "Restores the Zarf state, deployed package records, and service secrets into the Zarf namespace of a rebuilt cluster. " +
+		"Run zarf init afterwards to redeploy the Zarf services, it reuses the credentials in the imported state."
+	CmdToolsStateImportExample = `
+# Restore the Zarf state into a rebuilt cluster and redeploy the Zarf services
+$ zarf tools state import zarf-state.backup
+$ zarf init
+
+# Restore a Zarf state that was encrypted to an age public key
+$ zarf tools state import zarf-state.backup --identity ./key.txt
+`
+	CmdToolsStateImportFlagOverwrite   = "Replace the Zarf state and any other secrets in the backup that already exist in the cluster"
+	CmdToolsStateImportFlagIdentity    = "Path of an age identity file to decrypt a Zarf state that was exported with --recipient"
+	CmdToolsStatePromptPassphrase      = "State backup passphrase: "
+	CmdToolsStatePromptPassphraseAgain = "State backup passphrase again: "
+
+	CmdToolsRegistryTargetShort = "Manages the registry targets that namespaces can route their images to"
+	CmdToolsRegistryTargetLong  = "Registry targets are named registries, or repository prefixes within one, that are used instead of the Zarf registry for the namespaces that select them. " +
+		"A namespace selects a target with the zarf.dev/registry-target annotation or through the namespaces mapped to the target in Zarf state. " +
+		"The Zarf agent routes images to the selected target and the namespace's image pull secret holds the target's pull credentials."
+	CmdToolsRegistryTargetExample = `
+# Route the images of the tenant-a namespace to a prefix within a tenant registry
+$ zarf tools registry target set tenant-a --address registry.tenant-a.example.com --prefix zarf --push-username pusher --push-password secret --namespaces tenant-a
+
+# Push a package's images to the target when deploying it
+$ zarf package deploy zarf-package-app-amd64.tar.zst --registry-target tenant-a
+`
+	CmdToolsRegistryTargetSetShort         = "Adds or updates a registry target"
+	CmdToolsRegistryTargetRemoveShort      = "Removes a registry target and the namespaces mapped to it"
+	CmdToolsRegistryTargetListShort        = "Lists the registry targets and the namespaces mapped to them"
+	CmdToolsRegistryTargetFlagAddress      = "Address of the registry, including the port if needed"
+	CmdToolsRegistryTargetFlagPrefix       = "Repository path prefix that images are pushed under"
+	CmdToolsRegistryTargetFlagPushUsername = "Username of a user with push access to the registry"
+	CmdToolsRegistryTargetFlagPushPassword = "Password of a user with push access to the registry"
+	CmdToolsRegistryTargetFlagPullUsername = "Username of a user with pull-only access to the registry. Defaults to the push user"
+	CmdToolsRegistryTargetFlagPullPassword = "Password of a user with pull-only access to the registry. Defaults to the push user's password"
+	CmdToolsRegistryTargetFlagNamespaces   = "Namespaces to map to the target, replacing the namespaces currently mapped to it"
+	CmdToolsRegistryTargetFlagOutputFormat = "Prints the output in the specified format. Valid options: table, json, yaml"
+
 	CmdToolsRegistryFlagVerbose  = "Enable debug logs"
 	CmdToolsRegistryFlagInsecure = "Allow image references to be fetched without TLS"
 	CmdToolsRegistryFlagNonDist  = "Allow pushing non-distributable (foreign) layers"
@@ -880,15 +1006,20 @@ $ zarf tools update-creds git --git-push-username={USERNAME} --git-push-password
 `
 
 	CmdToolsUpdateCredsAgentShort   = "Updates the TLS certificates for the deployed Zarf agent"
-	CmdToolsUpdateCredsAgentLong    = "Updates the TLS certificates for the deployed Zarf agent. Certificates are autogenerated unless the --agent-tls-ca, --agent-tls-cert, and --agent-tls-key flags are provided."
+	CmdToolsUpdateCredsAgentLong    = "Updates the TLS certificates for the deployed Zarf agent. Certificates are autogenerated unless the --agent-tls-ca, --agent-tls-cert, and --agent-tls-key flags are provided. With --rotate the certificate is reissued from the current CA when Zarf generated it, otherwise the webhooks trust both the previous and the new CA while the agent pods are rolled onto the new certificate, so admission requests keep succeeding throughout."
 	CmdToolsUpdateCredsAgentExample = `
 # Autogenerate new agent TLS certificates:
 $ zarf tools update-creds agent
 
+# Rotate the agent TLS certificates without an admission outage:
+$ zarf tools update-creds agent --rotate
+
 # Provide user-managed agent TLS certificates:
 $ zarf tools update-creds agent --agent-tls-ca={CA_PATH} --agent-tls-cert={CERT_PATH} --agent-tls-key={KEY_PATH}
 `
 
+	CmdToolsUpdateCredsAgentFlagRotate = "Reissue the certificate from the current CA, or keep trusting the previous CA while the agent switches to a new one, to avoid failed admissions during the update"
+
 	CmdToolsUpdateCredsConfirmFlag          = "Confirm updating credentials without prompting"
 	CmdToolsUpdateCredsConfirmContinue      = "Continue with these changes?"
 	CmdToolsUpdateCredsUnableUpdateRegistry = "Unable to update Zarf Registry values: %s"
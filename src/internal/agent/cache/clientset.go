@@ -0,0 +1,139 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2021-Present The Zarf Authors
+
+// Package cache provides the informer and TTL caches used by the Zarf agent to keep API server and registry calls off
+// the admission path.
+package cache
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/client-go/informers"
+	"k8s.io/client-go/kubernetes"
+	typedcorev1 "k8s.io/client-go/kubernetes/typed/core/v1"
+	listerscorev1 "k8s.io/client-go/listers/core/v1"
+	toolscache "k8s.io/client-go/tools/cache"
+
+	"github.com/zarf-dev/zarf/src/internal/agent/metrics"
+	"github.com/zarf-dev/zarf/src/pkg/state"
+)
+
+const (
+	stateCacheName     = "state"
+	namespaceCacheName = "namespace"
+)
+
+// NewClientset starts shared informers for the Zarf state secret and for namespaces and returns a clientset that
+// serves Get calls for those objects from the informer caches. All other calls are passed through to client. The
+// informers run until ctx is cancelled.
+func NewClientset(ctx context.Context, client kubernetes.Interface) (kubernetes.Interface, error) {
+	stateFactory := informers.NewSharedInformerFactoryWithOptions(client, 0,
+		informers.WithNamespace(state.ZarfNamespaceName),
+		informers.WithTweakListOptions(func(opts *metav1.ListOptions) {
+			opts.FieldSelector = fields.OneTermEqualSelector("metadata.name", state.ZarfStateSecretName).String()
+		}),
+	)
+	secrets := stateFactory.Core().V1().Secrets()
+	namespaceFactory := informers.NewSharedInformerFactory(client, 0)
+	namespaces := namespaceFactory.Core().V1().Namespaces()
+
+	// Informers must be requested before the factories are started.
+	secretsInformer := secrets.Informer()
+	namespacesInformer := namespaces.Informer()
+	stateFactory.Start(ctx.Done())
+	namespaceFactory.Start(ctx.Done())
+	if !toolscache.WaitForCacheSync(ctx.Done(), secretsInformer.HasSynced, namespacesInformer.HasSynced) {
+		return nil, errors.New("failed to sync the agent state and namespace caches")
+	}
+
+	return &clientset{
+		Interface: client,
+		core: &coreV1{
+			CoreV1Interface: client.CoreV1(),
+			secrets:         secrets.Lister(),
+			namespaces:      namespaces.Lister(),
+		},
+	}, nil
+}
+
+type clientset struct {
+	kubernetes.Interface
+	core *coreV1
+}
+
+func (c *clientset) CoreV1() typedcorev1.CoreV1Interface {
+	return c.core
+}
+
+type coreV1 struct {
+	typedcorev1.CoreV1Interface
+	secrets    listerscorev1.SecretLister
+	namespaces listerscorev1.NamespaceLister
+}
+
+func (c *coreV1) Secrets(namespace string) typedcorev1.SecretInterface {
+	return &secretClient{
+		SecretInterface: c.CoreV1Interface.Secrets(namespace),
+		namespace:       namespace,
+		lister:          c.secrets,
+	}
+}
+
+func (c *coreV1) Namespaces() typedcorev1.NamespaceInterface {
+	return &namespaceClient{
+		NamespaceInterface: c.CoreV1Interface.Namespaces(),
+		lister:             c.namespaces,
+	}
+}
+
+type secretClient struct {
+	typedcorev1.SecretInterface
+	namespace string
+	lister    listerscorev1.SecretLister
+}
+
+// Get serves the Zarf state secret from the informer cache and falls back to the API server for anything else.
+func (s *secretClient) Get(ctx context.Context, name string, opts metav1.GetOptions) (*corev1.Secret, error) {
+	if s.namespace != state.ZarfNamespaceName || name != state.ZarfStateSecretName || opts.ResourceVersion != "" {
+		return s.SecretInterface.Get(ctx, name, opts)
+	}
+	secret, err := s.lister.Secrets(s.namespace).Get(name)
+	if err == nil {
+		metrics.CacheHits.WithLabelValues(stateCacheName).Inc()
+		return secret.DeepCopy(), nil
+	}
+	if !kerrors.IsNotFound(err) {
+		return nil, fmt.Errorf("failed to read the state secret from the cache: %w", err)
+	}
+	metrics.CacheMisses.WithLabelValues(stateCacheName).Inc()
+	return s.SecretInterface.Get(ctx, name, opts)
+}
+
+type namespaceClient struct {
+	typedcorev1.NamespaceInterface
+	lister listerscorev1.NamespaceLister
+}
+
+// Get serves namespaces from the informer cache. A namespace created moments ago may not have reached the cache yet,
+// so a cache miss falls back to the API server.
+func (n *namespaceClient) Get(ctx context.Context, name string, opts metav1.GetOptions) (*corev1.Namespace, error) {
+	if opts.ResourceVersion != "" {
+		return n.NamespaceInterface.Get(ctx, name, opts)
+	}
+	ns, err := n.lister.Get(name)
+	if err == nil {
+		metrics.CacheHits.WithLabelValues(namespaceCacheName).Inc()
+		return ns.DeepCopy(), nil
+	}
+	if !kerrors.IsNotFound(err) {
+		return nil, fmt.Errorf("failed to read namespace %s from the cache: %w", name, err)
+	}
+	metrics.CacheMisses.WithLabelValues(namespaceCacheName).Inc()
+	return n.NamespaceInterface.Get(ctx, name, opts)
+}
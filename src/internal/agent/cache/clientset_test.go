@@ -0,0 +1,58 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2021-Present The Zarf Authors
+
+package cache
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+
+	"github.com/zarf-dev/zarf/src/pkg/state"
+)
+
+func TestClientset(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+
+	stateSecret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: state.ZarfStateSecretName, Namespace: state.ZarfNamespaceName},
+		Data:       map[string][]byte{state.ZarfStateDataKey: []byte("{}")},
+	}
+	ns := &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "podinfo", Labels: map[string]string{"zarf.dev/agent": "ignore"}}}
+	client := fake.NewClientset(stateSecret, ns)
+
+	cs, err := NewClientset(ctx, client)
+	require.NoError(t, err)
+
+	// Lookups are served from the informer caches, so they no longer reach the API server.
+	actions := len(client.Actions())
+	secret, err := cs.CoreV1().Secrets(state.ZarfNamespaceName).Get(ctx, state.ZarfStateSecretName, metav1.GetOptions{})
+	require.NoError(t, err)
+	require.Equal(t, "{}", string(secret.Data[state.ZarfStateDataKey]))
+	got, err := cs.CoreV1().Namespaces().Get(ctx, "podinfo", metav1.GetOptions{})
+	require.NoError(t, err)
+	require.Equal(t, "ignore", got.Labels["zarf.dev/agent"])
+	require.Len(t, client.Actions(), actions)
+
+	// Updates reach the cache through the watch.
+	stateSecret.Data[state.ZarfStateDataKey] = []byte(`{"distro":"k3s"}`)
+	_, err = client.CoreV1().Secrets(state.ZarfNamespaceName).Update(ctx, stateSecret, metav1.UpdateOptions{})
+	require.NoError(t, err)
+	require.Eventually(t, func() bool {
+		secret, err := cs.CoreV1().Secrets(state.ZarfNamespaceName).Get(ctx, state.ZarfStateSecretName, metav1.GetOptions{})
+		return err == nil && string(secret.Data[state.ZarfStateDataKey]) == `{"distro":"k3s"}`
+	}, 5*time.Second, 10*time.Millisecond)
+
+	// Objects missing from the cache fall back to the API server.
+	_, err = cs.CoreV1().Namespaces().Get(ctx, "missing", metav1.GetOptions{})
+	require.Error(t, err)
+	require.Greater(t, len(client.Actions()), actions)
+}
@@ -0,0 +1,94 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2021-Present The Zarf Authors
+
+package cache
+
+import (
+	"container/list"
+	"sync"
+	"time"
+
+	"github.com/zarf-dev/zarf/src/internal/agent/metrics"
+)
+
+// TTL is a size bounded cache whose entries expire after a fixed duration. When full, the least recently used entry
+// is evicted. It is safe for concurrent use.
+type TTL[V any] struct {
+	name       string
+	ttl        time.Duration
+	maxEntries int
+	now        func() time.Time
+
+	mu      sync.Mutex
+	order   *list.List
+	entries map[string]*list.Element
+}
+
+type ttlEntry[V any] struct {
+	key     string
+	value   V
+	expires time.Time
+}
+
+// NewTTL returns a cache holding at most maxEntries values for ttl each. The name labels the cache hit and miss
+// metrics.
+func NewTTL[V any](name string, maxEntries int, ttl time.Duration) *TTL[V] {
+	return &TTL[V]{
+		name:       name,
+		ttl:        ttl,
+		maxEntries: maxEntries,
+		now:        time.Now,
+		order:      list.New(),
+		entries:    map[string]*list.Element{},
+	}
+}
+
+// Get returns the value stored for key if it has not expired.
+func (c *TTL[V]) Get(key string) (V, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if el, ok := c.entries[key]; ok {
+		entry := el.Value.(*ttlEntry[V])
+		if c.now().Before(entry.expires) {
+			c.order.MoveToFront(el)
+			metrics.CacheHits.WithLabelValues(c.name).Inc()
+			return entry.value, true
+		}
+		c.remove(el)
+	}
+	metrics.CacheMisses.WithLabelValues(c.name).Inc()
+	var zero V
+	return zero, false
+}
+
+// Set stores value for key, evicting the least recently used entry if the cache is full.
+func (c *TTL[V]) Set(key string, value V) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	expires := c.now().Add(c.ttl)
+	if el, ok := c.entries[key]; ok {
+		entry := el.Value.(*ttlEntry[V])
+		entry.value = value
+		entry.expires = expires
+		c.order.MoveToFront(el)
+		return
+	}
+	c.entries[key] = c.order.PushFront(&ttlEntry[V]{key: key, value: value, expires: expires})
+	for c.order.Len() > c.maxEntries {
+		c.remove(c.order.Back())
+	}
+}
+
+// Len returns the number of entries in the cache, including expired entries that have not been evicted yet.
+func (c *TTL[V]) Len() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.order.Len()
+}
+
+func (c *TTL[V]) remove(el *list.Element) {
+	c.order.Remove(el)
+	delete(c.entries, el.Value.(*ttlEntry[V]).key)
+}
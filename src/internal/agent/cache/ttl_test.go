@@ -0,0 +1,42 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2021-Present The Zarf Authors
+
+package cache
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestTTL(t *testing.T) {
+	t.Parallel()
+
+	now := time.Now()
+	c := NewTTL[string]("test", 2, time.Minute)
+	c.now = func() time.Time { return now }
+
+	_, ok := c.Get("a")
+	require.False(t, ok)
+
+	c.Set("a", "1")
+	c.Set("b", "2")
+	v, ok := c.Get("a")
+	require.True(t, ok)
+	require.Equal(t, "1", v)
+
+	// "b" is the least recently used entry and is evicted when the cache is full.
+	c.Set("c", "3")
+	require.Equal(t, 2, c.Len())
+	_, ok = c.Get("b")
+	require.False(t, ok)
+	_, ok = c.Get("a")
+	require.True(t, ok)
+
+	// Entries expire after the TTL.
+	now = now.Add(2 * time.Minute)
+	_, ok = c.Get("a")
+	require.False(t, ok)
+	require.Equal(t, 1, c.Len())
+}
@@ -46,11 +46,11 @@ type ApplicationSource struct {
 }
 
 // NewApplicationMutationHook creates a new instance of the ArgoCD Application mutation hook.
-func NewApplicationMutationHook(c *cluster.Cluster, mode state.MutationPolicy) operations.Hook {
-	admit := withMutationGuard(c, mode, func(ctx context.Context, r *v1.AdmissionRequest, app *Application) (*operations.Result, error) {
+func NewApplicationMutationHook(c *cluster.Cluster, mode state.MutationPolicy, cfg Config) operations.Hook {
+	admit := withMutationGuard(c, mode, cfg, argocdApplicationHook, func(ctx context.Context, r *v1.AdmissionRequest, app *Application) (*operations.Result, error) {
 		return mutateApplication(ctx, r, c, app)
 	})
-	return operations.Hook{Create: admit, Update: admit}
+	return operations.Hook{Name: argocdApplicationHook, Create: admit, Update: admit}
 }
 
 // mutateApplication mutates the repository url to point to the repository URL defined in the ZarfState.
@@ -61,6 +61,10 @@ func mutateApplication(ctx context.Context, r *v1.AdmissionRequest, c *cluster.C
 	if err != nil {
 		return nil, err
 	}
+	registryInfo, err := c.GetRegistryInfoForNamespace(ctx, s, r.Namespace)
+	if err != nil {
+		return nil, err
+	}
 
 	var urls []string
 	if app.Spec.Source != nil {
@@ -77,7 +81,7 @@ func mutateApplication(ctx context.Context, r *v1.AdmissionRequest, c *cluster.C
 	}
 
 	// Get the registry service info if this is a NodePort service to use the internal kube-dns
-	registryAddress, clusterIP, err := c.GetServiceInfoFromRegistryAddress(ctx, s.RegistryInfo)
+	registryAddress, clusterIP, err := c.GetServiceInfoFromRegistryAddress(ctx, registryInfo)
 	if err != nil {
 		return nil, err
 	}
@@ -90,7 +94,7 @@ func mutateApplication(ctx context.Context, r *v1.AdmissionRequest, c *cluster.C
 
 	patches := make([]operations.PatchOperation, 0)
 	if app.Spec.Source != nil {
-		patchedURL, err := getPatchedRepoURL(ctx, app.Spec.Source.RepoURL, registryAddress, clusterIP, s.GitServer)
+		patchedURL, err := getPatchedRepoURL(ctx, app.Spec.Source.RepoURL, registryAddress, clusterIP, s.GitServer, gitOwner(s.GitServer, app.Labels))
 		if err != nil {
 			return nil, err
 		}
@@ -99,7 +103,7 @@ func mutateApplication(ctx context.Context, r *v1.AdmissionRequest, c *cluster.C
 
 	if len(app.Spec.Sources) > 0 {
 		for idx, source := range app.Spec.Sources {
-			patchedURL, err := getPatchedRepoURL(ctx, source.RepoURL, registryAddress, clusterIP, s.GitServer)
+			patchedURL, err := getPatchedRepoURL(ctx, source.RepoURL, registryAddress, clusterIP, s.GitServer, gitOwner(s.GitServer, app.Labels))
 			if err != nil {
 				return nil, err
 			}
@@ -115,7 +119,7 @@ func mutateApplication(ctx context.Context, r *v1.AdmissionRequest, c *cluster.C
 	}, nil
 }
 
-func getPatchedRepoURL(ctx context.Context, repoURL, registryAddress, clusterIP string, gs state.GitServerInfo) (string, error) {
+func getPatchedRepoURL(ctx context.Context, repoURL, registryAddress, clusterIP string, gs state.GitServerInfo, owner string) (string, error) {
 	l := logger.From(ctx)
 
 	if helpers.IsOCIURL(repoURL) {
@@ -153,7 +157,7 @@ func getPatchedRepoURL(ctx context.Context, repoURL, registryAddress, clusterIP
 		l.Debug("skipping mutation, ArgoCD Application repoURL already points to Zarf git server", "url", repoURL)
 		return repoURL, nil
 	}
-	return mutateGitURL(ctx, repoURL, gs)
+	return mutateGitURL(ctx, repoURL, gs, owner)
 }
 
 func mutateOCIURL(ctx context.Context, repoURL, registryAddress string, isPatchedClusterIP bool) (string, error) {
@@ -183,9 +187,9 @@ func mutateOCIURL(ctx context.Context, repoURL, registryAddress string, isPatche
 	return patchedURL, nil
 }
 
-func mutateGitURL(ctx context.Context, repoURL string, gs state.GitServerInfo) (string, error) {
+func mutateGitURL(ctx context.Context, repoURL string, gs state.GitServerInfo, owner string) (string, error) {
 	l := logger.From(ctx)
-	transformedURL, err := transform.GitURL(gs.Address, repoURL, gs.PushUsername)
+	transformedURL, err := transform.GitURL(gs.Address, repoURL, owner)
 	if err != nil {
 		return "", fmt.Errorf("%s: %w", AgentErrTransformGitURL, err)
 	}
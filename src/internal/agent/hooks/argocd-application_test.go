@@ -329,7 +329,7 @@ func TestArgoAppWebhook(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			c := createTestClientWithZarfState(ctx, t, s)
-			handler := admission.NewHandler().Serve(ctx, NewApplicationMutationHook(c, state.MutationPolicyAll))
+			handler := admission.NewHandler().Serve(ctx, NewApplicationMutationHook(c, state.MutationPolicyAll, Config{}))
 			if tt.svc != nil {
 				_, err := c.Clientset.CoreV1().Services("zarf").Create(ctx, tt.svc, metav1.CreateOptions{})
 				require.NoError(t, err)
@@ -394,7 +394,7 @@ func TestArgoAppWebhookOCIWithNoGitServer(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			c := createTestClientWithZarfState(ctx, t, s)
-			handler := admission.NewHandler().Serve(ctx, NewApplicationMutationHook(c, state.MutationPolicyAll))
+			handler := admission.NewHandler().Serve(ctx, NewApplicationMutationHook(c, state.MutationPolicyAll, Config{}))
 			rr := sendAdmissionRequest(t, tt.admissionReq, handler)
 			verifyAdmission(t, rr, tt)
 		})
@@ -45,11 +45,11 @@ type GitGenerator struct {
 }
 
 // NewApplicationSetMutationHook creates a new instance of the ArgoCD ApplicationSet mutation hook.
-func NewApplicationSetMutationHook(c *cluster.Cluster, mode state.MutationPolicy) operations.Hook {
-	admit := withMutationGuard(c, mode, func(ctx context.Context, r *v1.AdmissionRequest, appSet *ApplicationSet) (*operations.Result, error) {
+func NewApplicationSetMutationHook(c *cluster.Cluster, mode state.MutationPolicy, cfg Config) operations.Hook {
+	admit := withMutationGuard(c, mode, cfg, argocdApplicationSetHook, func(ctx context.Context, r *v1.AdmissionRequest, appSet *ApplicationSet) (*operations.Result, error) {
 		return mutateApplicationSet(ctx, r, c, appSet)
 	})
-	return operations.Hook{Create: admit, Update: admit}
+	return operations.Hook{Name: argocdApplicationSetHook, Create: admit, Update: admit}
 }
 
 // mutateApplicationSet mutates the git repository urls to point to the repository URL defined in the ZarfState.
@@ -60,6 +60,10 @@ func mutateApplicationSet(ctx context.Context, r *v1.AdmissionRequest, c *cluste
 	if err != nil {
 		return nil, err
 	}
+	registryInfo, err := c.GetRegistryInfoForNamespace(ctx, s, r.Namespace)
+	if err != nil {
+		return nil, err
+	}
 
 	var urls []string
 	for _, generator := range appSet.Spec.Generators {
@@ -75,7 +79,7 @@ func mutateApplicationSet(ctx context.Context, r *v1.AdmissionRequest, c *cluste
 	}
 
 	// Get the registry service info if this is a NodePort service to use the internal kube-dns
-	registryAddress, clusterIP, err := c.GetServiceInfoFromRegistryAddress(ctx, s.RegistryInfo)
+	registryAddress, clusterIP, err := c.GetServiceInfoFromRegistryAddress(ctx, registryInfo)
 	if err != nil {
 		return nil, err
 	}
@@ -90,7 +94,7 @@ func mutateApplicationSet(ctx context.Context, r *v1.AdmissionRequest, c *cluste
 
 	for genIdx, generator := range appSet.Spec.Generators {
 		if generator.Git != nil && generator.Git.RepoURL != "" {
-			patchedURL, err := getPatchedRepoURL(ctx, generator.Git.RepoURL, registryAddress, clusterIP, s.GitServer)
+			patchedURL, err := getPatchedRepoURL(ctx, generator.Git.RepoURL, registryAddress, clusterIP, s.GitServer, gitOwner(s.GitServer, appSet.Labels))
 			if err != nil {
 				return nil, err
 			}
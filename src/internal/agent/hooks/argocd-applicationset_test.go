@@ -39,7 +39,7 @@ func TestArgoAppSetWebhook(t *testing.T) {
 		PushUsername: "a-push-user",
 	}}
 	c := createTestClientWithZarfState(ctx, t, s)
-	handler := admission.NewHandler().Serve(ctx, NewApplicationSetMutationHook(c, state.MutationPolicyAll))
+	handler := admission.NewHandler().Serve(ctx, NewApplicationSetMutationHook(c, state.MutationPolicyAll, Config{}))
 
 	tests := []admissionTest{
 		{
@@ -34,11 +34,11 @@ type AppProjectSpec struct {
 }
 
 // NewAppProjectMutationHook creates a new mutation hook for ArgoCD AppProjects.
-func NewAppProjectMutationHook(c *cluster.Cluster, mode state.MutationPolicy) operations.Hook {
-	admit := withMutationGuard(c, mode, func(ctx context.Context, r *v1.AdmissionRequest, proj *AppProject) (*operations.Result, error) {
+func NewAppProjectMutationHook(c *cluster.Cluster, mode state.MutationPolicy, cfg Config) operations.Hook {
+	admit := withMutationGuard(c, mode, cfg, argocdAppProjectHook, func(ctx context.Context, r *v1.AdmissionRequest, proj *AppProject) (*operations.Result, error) {
 		return mutateAppProject(ctx, r, c, proj)
 	})
-	return operations.Hook{Create: admit, Update: admit}
+	return operations.Hook{Name: argocdAppProjectHook, Create: admit, Update: admit}
 }
 
 // mutateAppProject mutates the sourceRepos in ArgoCD AppProject to point to the Zarf git server.
@@ -49,6 +49,10 @@ func mutateAppProject(ctx context.Context, r *v1.AdmissionRequest, c *cluster.Cl
 	if err != nil {
 		return nil, err
 	}
+	registryInfo, err := c.GetRegistryInfoForNamespace(ctx, s, r.Namespace)
+	if err != nil {
+		return nil, err
+	}
 
 	requiresGit, requiresRegistry := classifyURLSchemes(proj.Spec.SourceRepos)
 
@@ -57,7 +61,7 @@ func mutateAppProject(ctx context.Context, r *v1.AdmissionRequest, c *cluster.Cl
 		return &operations.Result{Allowed: true}, nil
 	}
 
-	registryAddress, clusterIP, err := c.GetServiceInfoFromRegistryAddress(ctx, s.RegistryInfo)
+	registryAddress, clusterIP, err := c.GetServiceInfoFromRegistryAddress(ctx, registryInfo)
 	if err != nil {
 		return nil, err
 	}
@@ -71,7 +75,7 @@ func mutateAppProject(ctx context.Context, r *v1.AdmissionRequest, c *cluster.Cl
 	patches := make([]operations.PatchOperation, 0)
 
 	for idx, repo := range proj.Spec.SourceRepos {
-		patchedURL, err := getPatchedRepoURL(ctx, repo, registryAddress, clusterIP, s.GitServer)
+		patchedURL, err := getPatchedRepoURL(ctx, repo, registryAddress, clusterIP, s.GitServer, gitOwner(s.GitServer, proj.Labels))
 		// The AppProject can also include source repositories like '*' (as in the default project),
 		// which results in an error because '*' cannot be found in Git
 		// For this reason, we will ignore these entries and only patch the Git repositories that are found
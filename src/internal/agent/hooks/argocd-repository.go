@@ -33,11 +33,11 @@ type RepoCreds struct {
 }
 
 // NewRepositorySecretMutationHook creates a new instance of the ArgoCD repository secret mutation hook.
-func NewRepositorySecretMutationHook(c *cluster.Cluster, mode state.MutationPolicy) operations.Hook {
-	admit := withMutationGuard(c, mode, func(ctx context.Context, r *v1.AdmissionRequest, secret *corev1.Secret) (*operations.Result, error) {
+func NewRepositorySecretMutationHook(c *cluster.Cluster, mode state.MutationPolicy, cfg Config) operations.Hook {
+	admit := withMutationGuard(c, mode, cfg, argocdRepositoryHook, func(ctx context.Context, r *v1.AdmissionRequest, secret *corev1.Secret) (*operations.Result, error) {
 		return mutateRepositorySecret(ctx, r, c, secret)
 	})
-	return operations.Hook{Create: admit, Update: admit}
+	return operations.Hook{Name: argocdRepositoryHook, Create: admit, Update: admit}
 }
 
 // mutateRepositorySecret mutates the git URL in the ArgoCD repository secret to point to the repository URL defined in the ZarfState.
@@ -48,6 +48,10 @@ func mutateRepositorySecret(ctx context.Context, r *v1.AdmissionRequest, c *clus
 	if err != nil {
 		return nil, err
 	}
+	registryInfo, err := c.GetRegistryInfoForNamespace(ctx, s, r.Namespace)
+	if err != nil {
+		return nil, err
+	}
 
 	url, exists := secret.Data["url"]
 	if !exists {
@@ -70,17 +74,17 @@ func mutateRepositorySecret(ctx context.Context, r *v1.AdmissionRequest, c *clus
 		"operation", r.Operation)
 
 	// Get the registry service info if this is a NodePort service to use the internal kube-dns
-	registryAddress, clusterIP, err := c.GetServiceInfoFromRegistryAddress(ctx, s.RegistryInfo)
+	registryAddress, clusterIP, err := c.GetServiceInfoFromRegistryAddress(ctx, registryInfo)
 	if err != nil {
 		return nil, err
 	}
 
-	patchedURL, err := getPatchedRepoURL(ctx, repoCreds.URL, registryAddress, clusterIP, s.GitServer)
+	patchedURL, err := getPatchedRepoURL(ctx, repoCreds.URL, registryAddress, clusterIP, s.GitServer, gitOwner(s.GitServer, secret.Labels))
 	if err != nil {
 		return nil, err
 	}
 
-	useMTLS := s.RegistryInfo.ShouldUseMTLS()
+	useMTLS := registryInfo.ShouldUseMTLS()
 	var certs pki.GeneratedPKI
 	if useMTLS && isOCIURL {
 		certs, err = c.GetRegistryClientMTLSCert(ctx)
@@ -89,7 +93,7 @@ func mutateRepositorySecret(ctx context.Context, r *v1.AdmissionRequest, c *clus
 		}
 	}
 
-	patches := populateArgoRepositoryPatchOperations(patchedURL, s.GitServer, s.RegistryInfo, isOCIURL, useMTLS, certs)
+	patches := populateArgoRepositoryPatchOperations(patchedURL, s.GitServer, registryInfo, isOCIURL, useMTLS, certs)
 	patches = append(patches, getLabelPatch(secret.Labels))
 
 	return &operations.Result{
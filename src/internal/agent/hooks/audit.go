@@ -0,0 +1,179 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2021-Present The Zarf Authors
+
+package hooks
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/zarf-dev/zarf/src/internal/agent/metrics"
+	"github.com/zarf-dev/zarf/src/internal/agent/operations"
+	"github.com/zarf-dev/zarf/src/pkg/cluster"
+	"github.com/zarf-dev/zarf/src/pkg/logger"
+	admission "k8s.io/api/admission/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/kubernetes/scheme"
+	typedcorev1 "k8s.io/client-go/kubernetes/typed/core/v1"
+	"k8s.io/client-go/tools/record"
+)
+
+const (
+	// auditEventReason is the reason set on events recorded for mutations skipped by the audit mutation policy.
+	auditEventReason = "ZarfAgentAudit"
+	// mutationEventReason is the reason set on events recorded for mutations applied by the agent.
+	mutationEventReason = "ZarfAgentMutation"
+	agentComponent      = "zarf-agent"
+
+	// maxEventMessageLen bounds event messages so that large patches do not produce oversized events.
+	maxEventMessageLen = 1024
+	sanitizedValue     = "**sanitized**"
+)
+
+// mutationChange is a single value the agent rewrote, or would have rewritten under the audit policy.
+type mutationChange struct {
+	Path   string `json:"path"`
+	Before any    `json:"before"`
+	After  any    `json:"after"`
+}
+
+// recordMutation leaves an audit trail for the patches a hook computed: a structured log entry and a Kubernetes Event
+// on the involved object. audited reports that the patches were computed under the audit policy and not applied.
+// Events are recorded asynchronously by recorder so that the audit trail never blocks or fails admission.
+func recordMutation(ctx context.Context, recorder record.EventRecorder, hook string, r *admission.AdmissionRequest, obj metav1.Object, patches []operations.PatchOperation, audited bool) {
+	l := logger.From(ctx)
+	changes := mutationChanges(r, obj, patches)
+	if len(changes) == 0 {
+		return
+	}
+	name := obj.GetName()
+	if name == "" {
+		name = obj.GetGenerateName()
+	}
+
+	if audited {
+		metrics.AuditedMutations.WithLabelValues(hook, r.Namespace).Inc()
+	}
+	l.Info("agent mutation",
+		"audit", audited,
+		"hook", hook,
+		"uid", r.UID,
+		"user", r.UserInfo.Username,
+		"resource", schema.GroupResource{Group: r.Resource.Group, Resource: r.Resource.Resource}.String(),
+		"kind", r.Kind.Kind,
+		"namespace", r.Namespace,
+		"name", name,
+		"operation", r.Operation,
+		"changes", changes,
+	)
+
+	// Events must belong to a namespace, cluster scoped resources are only logged and counted. Objects that are being
+	// created have no UID yet, often not even a name, so an event would not refer to them and is left to the log.
+	if recorder == nil || r.Namespace == "" || obj.GetUID() == "" {
+		return
+	}
+	reason := mutationEventReason
+	verb := "mutated"
+	if audited {
+		reason = auditEventReason
+		verb = "would mutate"
+	}
+	descriptions := make([]string, 0, len(changes))
+	for _, change := range changes {
+		descriptions = append(descriptions, fmt.Sprintf("%s from %s to %s", change.Path, formatChangeValue(change.Before), formatChangeValue(change.After)))
+	}
+	message := fmt.Sprintf("Zarf agent (%s) %s %s", hook, verb, strings.Join(descriptions, "; "))
+	if len(message) > maxEventMessageLen {
+		message = message[:maxEventMessageLen-3] + "..."
+	}
+
+	ref := &corev1.ObjectReference{
+		Kind:       r.Kind.Kind,
+		APIVersion: schema.GroupVersion{Group: r.Kind.Group, Version: r.Kind.Version}.String(),
+		Namespace:  r.Namespace,
+		Name:       obj.GetName(),
+		UID:        obj.GetUID(),
+	}
+	recorder.Event(ref, corev1.EventTypeNormal, reason, message)
+}
+
+// NewEventRecorder returns a recorder that sends events to the cluster in the background, rate limiting and
+// aggregating them like the Kubernetes controllers do. Recording stops when ctx is done.
+func NewEventRecorder(ctx context.Context, c *cluster.Cluster) record.EventRecorder {
+	broadcaster := record.NewBroadcaster(record.WithContext(ctx))
+	broadcaster.StartRecordingToSink(&typedcorev1.EventSinkImpl{Interface: c.Clientset.CoreV1().Events("")})
+	go func() {
+		<-ctx.Done()
+		broadcaster.Shutdown()
+	}()
+	return broadcaster.NewRecorder(scheme.Scheme, corev1.EventSource{Component: agentComponent})
+}
+
+// mutationChanges pairs each patch with the value it replaces. Patches to the object's metadata only track the
+// mutation itself and are left out, and Secret values are never recorded.
+func mutationChanges(r *admission.AdmissionRequest, obj metav1.Object, patches []operations.PatchOperation) []mutationChange {
+	var original any
+	if err := json.Unmarshal(r.Object.Raw, &original); err != nil {
+		// A request that can't be decoded is still recorded, just without the previous values.
+		original = nil
+	}
+
+	_, isSecret := obj.(*corev1.Secret)
+	changes := []mutationChange{}
+	for _, p := range patches {
+		if strings.HasPrefix(p.Path, "/metadata/") {
+			continue
+		}
+		change := mutationChange{Path: p.Path, Before: valueAtPointer(original, p.Path), After: p.Value}
+		if isSecret {
+			change.Before = sanitizedValue
+			change.After = sanitizedValue
+		}
+		changes = append(changes, change)
+	}
+	return changes
+}
+
+// valueAtPointer returns the value at a JSON pointer within a decoded JSON document, or nil if there is none.
+func valueAtPointer(doc any, pointer string) any {
+	if pointer == "" {
+		return doc
+	}
+	node := doc
+	for _, token := range strings.Split(strings.TrimPrefix(pointer, "/"), "/") {
+		token = strings.ReplaceAll(strings.ReplaceAll(token, "~1", "/"), "~0", "~")
+		switch n := node.(type) {
+		case map[string]any:
+			node = n[token]
+		case []any:
+			idx, err := strconv.Atoi(token)
+			if err != nil || idx < 0 || idx >= len(n) {
+				return nil
+			}
+			node = n[idx]
+		default:
+			return nil
+		}
+	}
+	return node
+}
+
+func formatChangeValue(v any) string {
+	switch value := v.(type) {
+	case nil:
+		return "<unset>"
+	case string:
+		return value
+	default:
+		b, err := json.Marshal(value)
+		if err != nil {
+			return fmt.Sprintf("%v", value)
+		}
+		return string(b)
+	}
+}
@@ -0,0 +1,187 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2021-Present The Zarf Authors
+
+package hooks
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+	"time"
+
+	flux "github.com/fluxcd/source-controller/api/v1"
+	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/zarf-dev/zarf/src/internal/agent/http/admission"
+	"github.com/zarf-dev/zarf/src/internal/agent/metrics"
+	"github.com/zarf-dev/zarf/src/internal/agent/operations"
+	"github.com/zarf-dev/zarf/src/pkg/state"
+	v1 "k8s.io/api/admission/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes"
+)
+
+func TestPodMutationWebhookAudit(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	s := &state.State{RegistryInfo: state.RegistryInfo{Address: "127.0.0.1:31999"}}
+	c := createTestClientWithZarfState(ctx, t, s)
+	handler := admission.NewHandler().Serve(ctx, NewPodMutationHook(c, state.MutationPolicyAudit, Config{Recorder: NewEventRecorder(ctx, c)}))
+
+	counter := metrics.AuditedMutations.WithLabelValues("pod", testNamespace)
+	before := readCounter(t, counter)
+
+	pod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: "podinfo", UID: "8ad8f7a1-6a3c-4c0e-9c39-2b0f7f6f4c11"},
+		Spec:       corev1.PodSpec{Containers: []corev1.Container{{Name: "podinfo", Image: "ghcr.io/stefanprodan/podinfo:6.4.0"}}},
+	}
+	req := createPodAdmissionRequest(t, v1.Update, pod, "")
+	req.Kind = metav1.GroupVersionKind{Version: "v1", Kind: "Pod"}
+	rr := sendAdmissionRequest(t, req, handler)
+	require.Equal(t, http.StatusOK, rr.Code)
+	var review v1.AdmissionReview
+	require.NoError(t, json.NewDecoder(rr.Body).Decode(&review))
+	require.True(t, review.Response.Allowed)
+	require.Empty(t, review.Response.Patch)
+
+	require.InDelta(t, before+1, readCounter(t, counter), 0)
+	event := waitForEvent(ctx, t, c.Clientset, "Pod")
+	require.Equal(t, auditEventReason, event.Reason)
+	require.Equal(t, "podinfo", event.InvolvedObject.Name)
+	require.Equal(t, pod.UID, event.InvolvedObject.UID)
+	require.Contains(t, event.Message, "/spec/containers/0/image")
+
+	// Pods that are being created have no UID yet, they are audited without an event.
+	created := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{GenerateName: "podinfo-"},
+		Spec:       pod.Spec,
+	}
+	rr = sendAdmissionRequest(t, createPodAdmissionRequest(t, v1.Create, created, ""), handler)
+	require.Equal(t, http.StatusOK, rr.Code)
+	require.InDelta(t, before+2, readCounter(t, counter), 0)
+
+	// Resources opted in with a label are mutated and not audited.
+	pod.Labels = map[string]string{"zarf.dev/agent": "mutate"}
+	rr = sendAdmissionRequest(t, createPodAdmissionRequest(t, v1.Create, pod, ""), handler)
+	require.Equal(t, http.StatusOK, rr.Code)
+	require.NoError(t, json.NewDecoder(rr.Body).Decode(&review))
+	require.NotEmpty(t, review.Response.Patch)
+	require.InDelta(t, before+2, readCounter(t, counter), 0)
+
+	events, err := c.Clientset.CoreV1().Events(testNamespace).List(ctx, metav1.ListOptions{})
+	require.NoError(t, err)
+	for _, e := range events.Items {
+		require.NotEmpty(t, e.InvolvedObject.UID)
+	}
+}
+
+func TestMutationAuditTrail(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	s := &state.State{
+		RegistryInfo: state.RegistryInfo{Address: "127.0.0.1:31999"},
+		GitServer:    state.GitServerInfo{Address: "https://git-server.com", PushUsername: "a-push-user"},
+	}
+	c := createTestClientWithZarfState(ctx, t, s)
+	cfg := Config{Recorder: NewEventRecorder(ctx, c)}
+
+	durations := metrics.AdmissionDuration.WithLabelValues(podHook)
+	errors := metrics.AdmissionErrors.WithLabelValues(podHook)
+	beforeDurations := readHistogramCount(t, durations)
+	beforeErrors := readCounter(t, errors)
+
+	podHandler := admission.NewHandler().Serve(ctx, NewPodMutationHook(c, state.MutationPolicyAll, cfg))
+	pod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: "podinfo", UID: "0e0d3c55-5a4c-4a0e-8f0c-1d6c8a3f2b71"},
+		Spec:       corev1.PodSpec{Containers: []corev1.Container{{Name: "podinfo", Image: "ghcr.io/stefanprodan/podinfo:6.4.0"}}},
+	}
+	req := createPodAdmissionRequest(t, v1.Update, pod, "")
+	req.Kind = metav1.GroupVersionKind{Version: "v1", Kind: "Pod"}
+	rr := sendAdmissionRequest(t, req, podHandler)
+	require.Equal(t, http.StatusOK, rr.Code)
+
+	gitHandler := admission.NewHandler().Serve(ctx, NewGitRepositoryMutationHook(c, state.MutationPolicyAll, cfg))
+	repoReq := createFluxGitRepoAdmissionRequest(t, v1.Update, &flux.GitRepository{
+		ObjectMeta: metav1.ObjectMeta{Name: "podinfo", UID: "5f3a1b8e-2c4d-4e6f-8a9b-0c1d2e3f4a5b"},
+		Spec:       flux.GitRepositorySpec{URL: "https://github.com/stefanprodan/podinfo.git"},
+	})
+	repoReq.Kind = metav1.GroupVersionKind{Group: "source.toolkit.fluxcd.io", Version: "v1", Kind: "GitRepository"}
+	rr = sendAdmissionRequest(t, repoReq, gitHandler)
+	require.Equal(t, http.StatusOK, rr.Code)
+
+	messages := map[string]string{}
+	for _, kind := range []string{"Pod", "GitRepository"} {
+		event := waitForEvent(ctx, t, c.Clientset, kind)
+		require.Equal(t, mutationEventReason, event.Reason)
+		require.Equal(t, "podinfo", event.InvolvedObject.Name)
+		messages[kind] = event.Message
+	}
+	require.Contains(t, messages["Pod"], "/spec/containers/0/image from ghcr.io/stefanprodan/podinfo:6.4.0 to 127.0.0.1:31999/stefanprodan/podinfo:6.4.0-zarf-2985051089")
+	require.Contains(t, messages["GitRepository"], "/spec/url from https://github.com/stefanprodan/podinfo.git to https://git-server.com/a-push-user/podinfo-1646971829.git")
+	require.Contains(t, messages["GitRepository"], "/spec/secretRef from <unset> to {\"name\":\"private-git-server\"}")
+
+	// Failed admissions are counted per hook.
+	pod.Spec.Containers[0].Image = "INVALID:::IMAGE"
+	rr = sendAdmissionRequest(t, createPodAdmissionRequest(t, v1.Create, pod, ""), podHandler)
+	require.Equal(t, http.StatusInternalServerError, rr.Code)
+	require.Equal(t, beforeDurations+2, readHistogramCount(t, durations))
+	require.InDelta(t, beforeErrors+1, readCounter(t, errors), 0)
+}
+
+func TestMutationChangesSanitizesSecrets(t *testing.T) {
+	t.Parallel()
+
+	secret := &corev1.Secret{Data: map[string][]byte{"password": []byte("hunter2")}}
+	raw, err := json.Marshal(secret)
+	require.NoError(t, err)
+	r := &v1.AdmissionRequest{Object: runtime.RawExtension{Raw: raw}}
+	patches := []operations.PatchOperation{
+		operations.ReplacePatchOperation("/data/password", "c2VjcmV0"),
+		operations.ReplacePatchOperation("/metadata/labels", map[string]string{"zarf-agent": "patched"}),
+	}
+	changes := mutationChanges(r, secret, patches)
+	require.Equal(t, []mutationChange{{Path: "/data/password", Before: sanitizedValue, After: sanitizedValue}}, changes)
+}
+
+// waitForEvent waits for the event recorded in the background on the object of the given kind.
+func waitForEvent(ctx context.Context, t *testing.T, clientset kubernetes.Interface, kind string) corev1.Event {
+	t.Helper()
+	var event corev1.Event
+	require.EventuallyWithT(t, func(ct *assert.CollectT) {
+		events, err := clientset.CoreV1().Events(testNamespace).List(ctx, metav1.ListOptions{})
+		require.NoError(ct, err)
+		for _, e := range events.Items {
+			if e.InvolvedObject.Kind == kind {
+				event = e
+				return
+			}
+		}
+		assert.Fail(ct, "no event for "+kind)
+	}, 5*time.Second, 10*time.Millisecond)
+	return event
+}
+
+func readHistogramCount(t *testing.T, o prometheus.Observer) uint64 {
+	t.Helper()
+	m, ok := o.(prometheus.Metric)
+	require.True(t, ok)
+	var d dto.Metric
+	require.NoError(t, m.Write(&d))
+	return d.GetHistogram().GetSampleCount()
+}
+
+func readCounter(t *testing.T, c interface{ Write(*dto.Metric) error }) float64 {
+	t.Helper()
+	var m dto.Metric
+	require.NoError(t, c.Write(&m))
+	return m.GetCounter().GetValue()
+}
@@ -9,16 +9,20 @@ import (
 	"encoding/json"
 	"fmt"
 	"net/http"
+	"time"
 
 	"github.com/defenseunicorns/pkg/helpers/v2"
 	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
 	"github.com/zarf-dev/zarf/src/config/lang"
+	"github.com/zarf-dev/zarf/src/internal/agent/cache"
 	"github.com/zarf-dev/zarf/src/internal/agent/operations"
 	"github.com/zarf-dev/zarf/src/pkg/cluster"
+	"github.com/zarf-dev/zarf/src/pkg/logger"
 	"github.com/zarf-dev/zarf/src/pkg/ocischeme"
 	"github.com/zarf-dev/zarf/src/pkg/state"
 	admission "k8s.io/api/admission/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/record"
 	"oras.land/oras-go/v2"
 	"oras.land/oras-go/v2/registry"
 	orasRemote "oras.land/oras-go/v2/registry/remote"
@@ -33,14 +37,62 @@ const (
 	AgentErrTransformOCIURL = "unable to transform the OCIRepo URL"
 )
 
+// Hook names label the logs, events and metrics of each hook.
+const (
+	podHook                  = "pod"
+	podValidationHook        = "pod-validation"
+	serviceAccountHook       = "serviceaccount"
+	fluxGitRepositoryHook    = "flux-gitrepository"
+	fluxHelmRepositoryHook   = "flux-helmrepository"
+	fluxOCIRepositoryHook    = "flux-ocirepository"
+	fluxKustomizationHook    = "flux-kustomization"
+	fluxHelmReleaseHook      = "flux-helmrelease"
+	argocdApplicationHook    = "argocd-application"
+	argocdApplicationSetHook = "argocd-applicationset"
+	argocdAppProjectHook     = "argocd-appproject"
+	argocdRepositoryHook     = "argocd-repository"
+	genericHook              = "generic"
+)
+
+// gitOwner returns the owner of a resource's repos on the git server, which is the organization that its package pushed
+// the repos into or otherwise the push user.
+func gitOwner(gs state.GitServerInfo, labels map[string]string) string {
+	if org := labels[cluster.GitOrganizationLabel]; org != "" {
+		return org
+	}
+	return gs.PushUsername
+}
+
+// Config holds the dependencies shared by the mutation hooks.
+type Config struct {
+	// Recorder records the mutations of the hooks as events on the involved objects, none are recorded when it is nil.
+	Recorder record.EventRecorder
+	// ConfigMediaType looks up the config media type of OCI artifacts, the registry is queried when it is nil.
+	ConfigMediaType ConfigMediaTypeResolver
+}
+
+// ConfigMediaTypeResolver returns the config media type of the OCI artifact at imageAddress.
+type ConfigMediaTypeResolver func(ctx context.Context, registryInfo state.RegistryInfo, transport http.RoundTripper, imageAddress string) (string, error)
+
+func (cfg Config) configMediaType() ConfigMediaTypeResolver {
+	if cfg.ConfigMediaType != nil {
+		return cfg.ConfigMediaType
+	}
+	return getManifestConfigMediaType
+}
+
 // withMutationGuard returns an AdmitFunc that unmarshals the request object,
 // checks namespace labels and ShouldMutate, then delegates to fn.
+// Applied patches are recorded through recordMutation. Under the audit policy fn
+// is also run for resources that ShouldAudit, but its patches are only recorded.
 func withMutationGuard[T any, PT interface {
 	*T
 	metav1.Object
 }](
 	c *cluster.Cluster,
 	mode state.MutationPolicy,
+	cfg Config,
+	hook string,
 	fn func(ctx context.Context, r *admission.AdmissionRequest, obj PT) (*operations.Result, error),
 ) operations.AdmitFunc {
 	return func(ctx context.Context, r *admission.AdmissionRequest) (*operations.Result, error) {
@@ -56,10 +108,23 @@ func withMutationGuard[T any, PT interface {
 				return nil, err
 			}
 		}
-		if !operations.ShouldMutate(obj.GetLabels(), nsLabels, mode) {
-			return &operations.Result{Allowed: true, PatchOps: []operations.PatchOperation{}}, nil
+		if operations.ShouldMutate(obj.GetLabels(), nsLabels, mode) {
+			result, err := fn(ctx, r, obj)
+			if err != nil {
+				return nil, err
+			}
+			recordMutation(ctx, cfg.Recorder, hook, r, obj, result.PatchOps, false)
+			return result, nil
+		}
+		if operations.ShouldAudit(obj.GetLabels(), nsLabels, mode) {
+			result, err := fn(ctx, r, obj)
+			if err != nil {
+				logger.From(ctx).Warn("audit: unable to compute mutation", "hook", hook, "namespace", r.Namespace, "error", err)
+			} else {
+				recordMutation(ctx, cfg.Recorder, hook, r, obj, result.PatchOps, true)
+			}
 		}
-		return fn(ctx, r, obj)
+		return &operations.Result{Allowed: true, PatchOps: []operations.PatchOperation{}}, nil
 	}
 }
 
@@ -99,7 +164,15 @@ func anyZarfServiceUsable(requiresGit, requiresRegistry bool, s *state.State) bo
 	return (requiresGit && s.GitServer.IsConfigured()) || (requiresRegistry && s.RegistryInfo.IsConfigured())
 }
 
-func getManifestConfigMediaType(ctx context.Context, zarfState *state.State, transport http.RoundTripper, imageAddress string) (string, error) {
+// manifestMediaTypes holds recently resolved manifest config media types so that repeated admissions of the same
+// artifact do not fetch its manifest from the registry every time.
+var manifestMediaTypes = cache.NewTTL[string]("manifest-media-type", 1024, 5*time.Minute)
+
+func getManifestConfigMediaType(ctx context.Context, registryInfo state.RegistryInfo, transport http.RoundTripper, imageAddress string) (string, error) {
+	if mediaType, ok := manifestMediaTypes.Get(imageAddress); ok {
+		return mediaType, nil
+	}
+
 	ref, err := registry.ParseReference(imageAddress)
 	if err != nil {
 		return "", err
@@ -111,14 +184,14 @@ func getManifestConfigMediaType(ctx context.Context, zarfState *state.State, tra
 		},
 		Cache: auth.NewCache(),
 		Credential: auth.StaticCredential(ref.Registry, auth.Credential{
-			Username: zarfState.RegistryInfo.PullUsername,
-			Password: zarfState.RegistryInfo.PullPassword,
+			Username: registryInfo.PullUsername,
+			Password: registryInfo.PullPassword,
 		}),
 	}
 
 	// Negotiate only when the registry's scheme isn't already known, since the
 	// negotiation itself is a probe over the same connection the real fetch depends on.
-	plainHTTP, ok := zarfState.RegistryInfo.KnownPlainHTTP()
+	plainHTTP, ok := registryInfo.KnownPlainHTTP()
 	if !ok {
 		// Reuse the same transport the real fetch will use, but stripped of any
 		// retry wrapper: probing must stay fast, not retry with backoff on every
@@ -140,6 +213,7 @@ func getManifestConfigMediaType(ctx context.Context, zarfState *state.State, tra
 		return "", fmt.Errorf("unable to unmarshal the manifest json for %s", imageAddress)
 	}
 
+	manifestMediaTypes.Set(imageAddress, manifest.Config.MediaType)
 	return manifest.Config.MediaType, nil
 }
 
@@ -135,8 +135,8 @@ func TestConfigMediaTypes(t *testing.T) {
 			url := testutil.SetupInMemoryRegistryDynamic(ctx, t)
 			populateRegistry(ctx, t, url, tt.artifact, tt.Opts)
 
-			s := &state.State{RegistryInfo: state.RegistryInfo{Address: url}}
-			mediaType, err := getManifestConfigMediaType(ctx, s, orasRetry.DefaultClient.Transport, fmt.Sprintf("%s/%s", url, tt.relRef))
+			registryInfo := state.RegistryInfo{Address: url}
+			mediaType, err := getManifestConfigMediaType(ctx, registryInfo, orasRetry.DefaultClient.Transport, fmt.Sprintf("%s/%s", url, tt.relRef))
 			require.NoError(t, err)
 			require.Equal(t, tt.expected, mediaType)
 		})
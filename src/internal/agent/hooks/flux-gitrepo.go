@@ -22,11 +22,11 @@ import (
 )
 
 // NewGitRepositoryMutationHook creates a new instance of the git repo mutation hook.
-func NewGitRepositoryMutationHook(c *cluster.Cluster, mode state.MutationPolicy) operations.Hook {
-	admit := withMutationGuard(c, mode, func(ctx context.Context, r *v1.AdmissionRequest, repo *flux.GitRepository) (*operations.Result, error) {
+func NewGitRepositoryMutationHook(c *cluster.Cluster, mode state.MutationPolicy, cfg Config) operations.Hook {
+	admit := withMutationGuard(c, mode, cfg, fluxGitRepositoryHook, func(ctx context.Context, r *v1.AdmissionRequest, repo *flux.GitRepository) (*operations.Result, error) {
 		return mutateGitRepo(ctx, r, c, repo)
 	})
-	return operations.Hook{Create: admit, Update: admit}
+	return operations.Hook{Name: fluxGitRepositoryHook, Create: admit, Update: admit}
 }
 
 // mutateGitRepo mutates the git repository url to point to the repository URL defined in the ZarfState.
@@ -62,7 +62,7 @@ func mutateGitRepo(ctx context.Context, r *v1.AdmissionRequest, c *cluster.Clust
 			"url", repo.Spec.URL,
 			"operation", r.Operation)
 	} else {
-		transformedURL, err := transform.GitURL(s.GitServer.Address, patchedURL, s.GitServer.PushUsername)
+		transformedURL, err := transform.GitURL(s.GitServer.Address, patchedURL, gitOwner(s.GitServer, repo.Labels))
 		if err != nil {
 			return nil, fmt.Errorf("%s: %w", AgentErrTransformGitURL, err)
 		}
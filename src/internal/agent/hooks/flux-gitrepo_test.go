@@ -43,7 +43,7 @@ func TestFluxMutationWebhook(t *testing.T) {
 		PushUsername: "a-push-user",
 	}}
 	c := createTestClientWithZarfState(ctx, t, s)
-	handler := admission.NewHandler().Serve(ctx, NewGitRepositoryMutationHook(c, state.MutationPolicyAll))
+	handler := admission.NewHandler().Serve(ctx, NewGitRepositoryMutationHook(c, state.MutationPolicyAll, Config{}))
 
 	tests := []admissionTest{
 		{
@@ -74,6 +74,36 @@ func TestFluxMutationWebhook(t *testing.T) {
 			},
 			code: http.StatusOK,
 		},
+		{
+			name: "should be mutated into the package's git organization",
+			admissionReq: createFluxGitRepoAdmissionRequest(t, v1.Create, &flux.GitRepository{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:   "mutate-this",
+					Labels: map[string]string{"zarf.dev/git-organization": "podinfo"},
+				},
+				Spec: flux.GitRepositorySpec{
+					URL: "https://github.com/stefanprodan/podinfo.git",
+				},
+			}),
+			patch: []operations.PatchOperation{
+				operations.ReplacePatchOperation(
+					"/spec/url",
+					"https://git-server.com/podinfo/podinfo-1646971829.git",
+				),
+				operations.AddPatchOperation(
+					"/spec/secretRef",
+					fluxmeta.LocalObjectReference{Name: config.ZarfGitServerSecretName},
+				),
+				operations.ReplacePatchOperation(
+					"/metadata/labels",
+					map[string]string{
+						"zarf-agent":                "patched",
+						"zarf.dev/git-organization": "podinfo",
+					},
+				),
+			},
+			code: http.StatusOK,
+		},
 		{
 			name: "should not mutate invalid git url",
 			admissionReq: createFluxGitRepoAdmissionRequest(t, v1.Update, &flux.GitRepository{
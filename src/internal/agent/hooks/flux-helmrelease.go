@@ -0,0 +1,76 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2021-Present The Zarf Authors
+
+// Package hooks contains the mutation hooks for the Zarf agent.
+package hooks
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/zarf-dev/zarf/src/internal/agent/operations"
+	"github.com/zarf-dev/zarf/src/pkg/cluster"
+	"github.com/zarf-dev/zarf/src/pkg/logger"
+	"github.com/zarf-dev/zarf/src/pkg/state"
+	v1 "k8s.io/api/admission/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// fluxHelmRelease holds the fields of a Flux HelmRelease that the agent mutates.
+// The chartRef and chart sourceRef of a HelmRelease name an OCIRepository, HelmChart or HelmRepository whose URL is
+// mutated by its own hook.
+type fluxHelmRelease struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+	Spec              struct {
+		PostRenderers []struct {
+			Kustomize *struct {
+				Images []fluxImage `json:"images,omitempty"`
+			} `json:"kustomize,omitempty"`
+		} `json:"postRenderers,omitempty"`
+	} `json:"spec"`
+}
+
+// NewHelmReleaseMutationHook creates a new instance of the Flux HelmRelease mutation hook.
+func NewHelmReleaseMutationHook(c *cluster.Cluster, mode state.MutationPolicy, cfg Config) operations.Hook {
+	admit := withMutationGuard(c, mode, cfg, fluxHelmReleaseHook, func(ctx context.Context, r *v1.AdmissionRequest, hr *fluxHelmRelease) (*operations.Result, error) {
+		return mutateHelmRelease(ctx, r, c, hr)
+	})
+	return operations.Hook{Name: fluxHelmReleaseHook, Create: admit, Update: admit}
+}
+
+// mutateHelmRelease rewrites the image overrides of the kustomize post-renderers of a HelmRelease to the Zarf registry.
+func mutateHelmRelease(ctx context.Context, r *v1.AdmissionRequest, c *cluster.Cluster, hr *fluxHelmRelease) (*operations.Result, error) {
+	s, err := c.LoadState(ctx)
+	if err != nil {
+		return nil, err
+	}
+	registryInfo, err := c.GetRegistryInfoForNamespace(ctx, s, r.Namespace)
+	if err != nil {
+		return nil, err
+	}
+
+	logger.From(ctx).Info("using the Zarf registry URL to mutate the Flux HelmRelease",
+		"name", hr.Name,
+		"operation", r.Operation,
+		"registry", registryInfo.Address)
+
+	var patches []operations.PatchOperation
+	for idx, postRenderer := range hr.Spec.PostRenderers {
+		if postRenderer.Kustomize == nil {
+			continue
+		}
+		path := fmt.Sprintf("/spec/postRenderers/%d/kustomize/images", idx)
+		imagePatches, err := fluxImagePatches(registryInfo.Address, path, postRenderer.Kustomize.Images)
+		if err != nil {
+			return nil, err
+		}
+		patches = append(patches, imagePatches...)
+	}
+	patches = append(patches, getLabelPatch(hr.Labels))
+
+	return &operations.Result{
+		Allowed:  true,
+		PatchOps: patches,
+	}, nil
+}
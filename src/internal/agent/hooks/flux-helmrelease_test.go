@@ -0,0 +1,72 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2021-Present The Zarf Authors
+
+package hooks
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/zarf-dev/zarf/src/internal/agent/http/admission"
+	"github.com/zarf-dev/zarf/src/internal/agent/operations"
+	"github.com/zarf-dev/zarf/src/pkg/state"
+	v1 "k8s.io/api/admission/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestFluxHelmReleaseMutationWebhook(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	s := &state.State{RegistryInfo: state.RegistryInfo{Address: "127.0.0.1:31999"}}
+	c := createTestClientWithZarfState(ctx, t, s)
+	handler := admission.NewHandler().Serve(ctx, NewHelmReleaseMutationHook(c, state.MutationPolicyAll, Config{}))
+
+	helmReleaseKind := metav1.GroupVersionKind{Group: "helm.toolkit.fluxcd.io", Version: "v2", Kind: "HelmRelease"}
+	helmRelease := func(postRenderers ...any) map[string]any {
+		return map[string]any{
+			"apiVersion": "helm.toolkit.fluxcd.io/v2",
+			"kind":       "HelmRelease",
+			"metadata":   map[string]any{"name": "podinfo"},
+			"spec": map[string]any{
+				"chartRef":      map[string]any{"kind": "OCIRepository", "name": "podinfo"},
+				"postRenderers": postRenderers,
+			},
+		}
+	}
+
+	tests := []admissionTest{
+		{
+			name: "kustomize post-renderer image overrides are mutated",
+			admissionReq: createGenericAdmissionRequest(t, v1.Create, helmReleaseKind, helmRelease(
+				map[string]any{"kustomize": map[string]any{"patches": []any{}}},
+				map[string]any{"kustomize": map[string]any{"images": []any{
+					map[string]any{"name": "podinfo", "newName": "ghcr.io/stefanprodan/podinfo", "newTag": "6.4.0"},
+				}}},
+			)),
+			patch: []operations.PatchOperation{
+				operations.ReplacePatchOperation("/spec/postRenderers/1/kustomize/images/0/newName", "127.0.0.1:31999/stefanprodan/podinfo"),
+				operations.ReplacePatchOperation("/spec/postRenderers/1/kustomize/images/0/newTag", "6.4.0-zarf-2985051089"),
+				operations.ReplacePatchOperation("/metadata/labels", map[string]string{"zarf-agent": "patched"}),
+			},
+			code: http.StatusOK,
+		},
+		{
+			name:         "releases without post-renderers are only labeled",
+			admissionReq: createGenericAdmissionRequest(t, v1.Create, helmReleaseKind, helmRelease()),
+			patch: []operations.PatchOperation{
+				operations.ReplacePatchOperation("/metadata/labels", map[string]string{"zarf-agent": "patched"}),
+			},
+			code: http.StatusOK,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			rr := sendAdmissionRequest(t, tt.admissionReq, handler)
+			verifyAdmission(t, rr, tt)
+		})
+	}
+}
@@ -23,11 +23,11 @@ import (
 )
 
 // NewHelmRepositoryMutationHook creates a new instance of the helm repo mutation hook.
-func NewHelmRepositoryMutationHook(c *cluster.Cluster, mode state.MutationPolicy) operations.Hook {
-	admit := withMutationGuard(c, mode, func(ctx context.Context, r *v1.AdmissionRequest, src *flux.HelmRepository) (*operations.Result, error) {
+func NewHelmRepositoryMutationHook(c *cluster.Cluster, mode state.MutationPolicy, cfg Config) operations.Hook {
+	admit := withMutationGuard(c, mode, cfg, fluxHelmRepositoryHook, func(ctx context.Context, r *v1.AdmissionRequest, src *flux.HelmRepository) (*operations.Result, error) {
 		return mutateHelmRepo(ctx, r, c, src)
 	})
-	return operations.Hook{Create: admit, Update: admit}
+	return operations.Hook{Name: fluxHelmRepositoryHook, Create: admit, Update: admit}
 }
 
 // mutateHelmRepo mutates the repository url to point to the repository URL defined in the ZarfState.
@@ -44,9 +44,13 @@ func mutateHelmRepo(ctx context.Context, r *v1.AdmissionRequest, c *cluster.Clus
 	if err != nil {
 		return nil, err
 	}
+	registryInfo, err := c.GetRegistryInfoForNamespace(ctx, zarfState, r.Namespace)
+	if err != nil {
+		return nil, err
+	}
 
 	// Get the registry service info if this is a NodePort service to use the internal kube-dns
-	registryAddress, clusterIP, err := c.GetServiceInfoFromRegistryAddress(ctx, zarfState.RegistryInfo)
+	registryAddress, clusterIP, err := c.GetServiceInfoFromRegistryAddress(ctx, registryInfo)
 	if err != nil {
 		return nil, err
 	}
@@ -102,7 +106,7 @@ func mutateHelmRepo(ctx context.Context, r *v1.AdmissionRequest, c *cluster.Clus
 
 	var patches []operations.PatchOperation
 
-	useMTLS := zarfState.RegistryInfo.ShouldUseMTLS()
+	useMTLS := registryInfo.ShouldUseMTLS()
 	if useMTLS {
 		_, err = c.GetRegistryClientMTLSCert(ctx)
 		if err != nil {
@@ -110,7 +114,7 @@ func mutateHelmRepo(ctx context.Context, r *v1.AdmissionRequest, c *cluster.Clus
 		}
 	}
 
-	patches = populateHelmRepoPatchOperations(patchedURL, zarfState.RegistryInfo.IsInternal(), useMTLS)
+	patches = populateHelmRepoPatchOperations(patchedURL, registryInfo.IsInternal(), useMTLS)
 	patches = append(patches, getLabelPatch(src.Labels))
 
 	return &operations.Result{
@@ -0,0 +1,120 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2021-Present The Zarf Authors
+
+// Package hooks contains the mutation hooks for the Zarf agent.
+package hooks
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/zarf-dev/zarf/src/internal/agent/operations"
+	"github.com/zarf-dev/zarf/src/pkg/cluster"
+	"github.com/zarf-dev/zarf/src/pkg/logger"
+	"github.com/zarf-dev/zarf/src/pkg/state"
+	"github.com/zarf-dev/zarf/src/pkg/transform"
+	v1 "k8s.io/api/admission/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// fluxImage is a kustomize image override as used by Flux Kustomizations and HelmRelease post-renderers.
+type fluxImage struct {
+	Name    string `json:"name"`
+	NewName string `json:"newName,omitempty"`
+	NewTag  string `json:"newTag,omitempty"`
+	Digest  string `json:"digest,omitempty"`
+}
+
+// fluxKustomization holds the fields of a Flux Kustomization that the agent mutates.
+// The sourceRef of a Kustomization names a GitRepository, OCIRepository or Bucket whose URL is mutated by its own hook.
+type fluxKustomization struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+	Spec              struct {
+		Images []fluxImage `json:"images,omitempty"`
+	} `json:"spec"`
+}
+
+// NewKustomizationMutationHook creates a new instance of the Flux Kustomization mutation hook.
+func NewKustomizationMutationHook(c *cluster.Cluster, mode state.MutationPolicy, cfg Config) operations.Hook {
+	admit := withMutationGuard(c, mode, cfg, fluxKustomizationHook, func(ctx context.Context, r *v1.AdmissionRequest, ks *fluxKustomization) (*operations.Result, error) {
+		return mutateKustomization(ctx, r, c, ks)
+	})
+	return operations.Hook{Name: fluxKustomizationHook, Create: admit, Update: admit}
+}
+
+// mutateKustomization rewrites the image overrides of a Kustomization to the Zarf registry.
+func mutateKustomization(ctx context.Context, r *v1.AdmissionRequest, c *cluster.Cluster, ks *fluxKustomization) (*operations.Result, error) {
+	s, err := c.LoadState(ctx)
+	if err != nil {
+		return nil, err
+	}
+	registryInfo, err := c.GetRegistryInfoForNamespace(ctx, s, r.Namespace)
+	if err != nil {
+		return nil, err
+	}
+
+	logger.From(ctx).Info("using the Zarf registry URL to mutate the Flux Kustomization",
+		"name", ks.Name,
+		"operation", r.Operation,
+		"registry", registryInfo.Address)
+
+	patches, err := fluxImagePatches(registryInfo.Address, "/spec/images", ks.Spec.Images)
+	if err != nil {
+		return nil, err
+	}
+	patches = append(patches, getLabelPatch(ks.Labels))
+
+	return &operations.Result{
+		Allowed:  true,
+		PatchOps: patches,
+	}, nil
+}
+
+// fluxImagePatches rewrites the newName and newTag of image overrides to the image Zarf pushed to the registry.
+// Overrides without a newName keep the image of the workload, the pod hook mutates the resulting images so they are
+// left alone. Overrides without a newTag or digest keep the tag of the workload, so only their newName is rewritten to
+// the image Zarf pushed without a checksum in its tag.
+func fluxImagePatches(registryURL, path string, images []fluxImage) ([]operations.PatchOperation, error) {
+	var patches []operations.PatchOperation
+	for idx, image := range images {
+		if image.NewName == "" {
+			continue
+		}
+		if image.NewTag == "" && image.Digest == "" {
+			replacement, err := transform.ImageTransformHostWithoutChecksum(registryURL, image.NewName)
+			if err != nil {
+				return nil, fmt.Errorf("unable to transform the image override %s: %w", image.Name, err)
+			}
+			patched, err := transform.ParseImageRef(replacement)
+			if err != nil {
+				return nil, err
+			}
+			if patched.Name != image.NewName {
+				patches = append(patches, operations.ReplacePatchOperation(fmt.Sprintf("%s/%d/newName", path, idx), patched.Name))
+			}
+			continue
+		}
+		ref := fmt.Sprintf("%s:%s", image.NewName, image.NewTag)
+		if image.Digest != "" {
+			ref = fmt.Sprintf("%s@%s", image.NewName, image.Digest)
+		}
+		replacement, err := transform.ImageTransformHost(registryURL, ref)
+		if err != nil {
+			return nil, fmt.Errorf("unable to transform the image override %s: %w", image.Name, err)
+		}
+		// Overrides that already point to the registry are returned as is
+		if replacement == ref {
+			continue
+		}
+		patched, err := transform.ParseImageRef(replacement)
+		if err != nil {
+			return nil, err
+		}
+		patches = append(patches, operations.ReplacePatchOperation(fmt.Sprintf("%s/%d/newName", path, idx), patched.Name))
+		if image.Digest == "" {
+			patches = append(patches, operations.ReplacePatchOperation(fmt.Sprintf("%s/%d/newTag", path, idx), patched.Tag))
+		}
+	}
+	return patches, nil
+}
@@ -0,0 +1,92 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2021-Present The Zarf Authors
+
+package hooks
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/zarf-dev/zarf/src/internal/agent/http/admission"
+	"github.com/zarf-dev/zarf/src/internal/agent/operations"
+	"github.com/zarf-dev/zarf/src/pkg/state"
+	v1 "k8s.io/api/admission/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestFluxKustomizationMutationWebhook(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	s := &state.State{RegistryInfo: state.RegistryInfo{Address: "127.0.0.1:31999"}}
+	c := createTestClientWithZarfState(ctx, t, s)
+	handler := admission.NewHandler().Serve(ctx, NewKustomizationMutationHook(c, state.MutationPolicyAll, Config{}))
+
+	kustomizationKind := metav1.GroupVersionKind{Group: "kustomize.toolkit.fluxcd.io", Version: "v1", Kind: "Kustomization"}
+	kustomization := func(labels map[string]any, images ...map[string]any) map[string]any {
+		imageList := []any{}
+		for _, image := range images {
+			imageList = append(imageList, image)
+		}
+		return map[string]any{
+			"apiVersion": "kustomize.toolkit.fluxcd.io/v1",
+			"kind":       "Kustomization",
+			"metadata":   map[string]any{"name": "podinfo", "labels": labels},
+			"spec": map[string]any{
+				"sourceRef": map[string]any{"kind": "OCIRepository", "name": "podinfo"},
+				"images":    imageList,
+			},
+		}
+	}
+
+	tests := []admissionTest{
+		{
+			name: "image overrides with a new name are mutated",
+			admissionReq: createGenericAdmissionRequest(t, v1.Create, kustomizationKind, kustomization(nil,
+				map[string]any{"name": "podinfo", "newName": "ghcr.io/stefanprodan/podinfo", "newTag": "6.4.0"},
+				map[string]any{"name": "nginx", "newName": "nginx", "digest": "sha256:3e1b7b2a0c7d8cbbd5e1a3e0e0f0d0f0c0b0a09080706050403020100f0e0d0c"},
+				map[string]any{"name": "redis", "newTag": "7.2"},
+				map[string]any{"name": "busybox", "newName": "docker.io/library/busybox"},
+			)),
+			patch: []operations.PatchOperation{
+				operations.ReplacePatchOperation("/spec/images/0/newName", "127.0.0.1:31999/stefanprodan/podinfo"),
+				operations.ReplacePatchOperation("/spec/images/0/newTag", "6.4.0-zarf-2985051089"),
+				operations.ReplacePatchOperation("/spec/images/1/newName", "127.0.0.1:31999/library/nginx"),
+				// Without a tag the workload's tag is kept, the image was also pushed without a checksum in its tag
+				operations.ReplacePatchOperation("/spec/images/3/newName", "127.0.0.1:31999/library/busybox"),
+				operations.ReplacePatchOperation("/metadata/labels", map[string]string{"zarf-agent": "patched"}),
+			},
+			code: http.StatusOK,
+		},
+		{
+			name: "image overrides pointing to the registry are not mutated again",
+			admissionReq: createGenericAdmissionRequest(t, v1.Update, kustomizationKind, kustomization(
+				map[string]any{"zarf-agent": "patched"},
+				map[string]any{"name": "podinfo", "newName": "127.0.0.1:31999/stefanprodan/podinfo", "newTag": "6.4.0-zarf-2985051089"},
+				map[string]any{"name": "busybox", "newName": "127.0.0.1:31999/library/busybox"},
+			)),
+			patch: []operations.PatchOperation{
+				operations.ReplacePatchOperation("/metadata/labels", map[string]string{"zarf-agent": "patched"}),
+			},
+			code: http.StatusOK,
+		},
+		{
+			name: "ignored kustomizations are not mutated",
+			admissionReq: createGenericAdmissionRequest(t, v1.Create, kustomizationKind, kustomization(
+				map[string]any{"zarf.dev/agent": "ignore"},
+				map[string]any{"name": "podinfo", "newName": "ghcr.io/stefanprodan/podinfo", "newTag": "6.4.0"},
+			)),
+			patch: nil,
+			code:  http.StatusOK,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			rr := sendAdmissionRequest(t, tt.admissionReq, handler)
+			verifyAdmission(t, rr, tt)
+		})
+	}
+}
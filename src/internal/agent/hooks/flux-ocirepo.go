@@ -31,15 +31,15 @@ const (
 )
 
 // NewOCIRepositoryMutationHook creates a new instance of the oci repo mutation hook.
-func NewOCIRepositoryMutationHook(c *cluster.Cluster, mode state.MutationPolicy) operations.Hook {
-	admit := withMutationGuard(c, mode, func(ctx context.Context, r *v1.AdmissionRequest, src *flux.OCIRepository) (*operations.Result, error) {
-		return mutateOCIRepo(ctx, r, c, src)
+func NewOCIRepositoryMutationHook(c *cluster.Cluster, mode state.MutationPolicy, cfg Config) operations.Hook {
+	admit := withMutationGuard(c, mode, cfg, fluxOCIRepositoryHook, func(ctx context.Context, r *v1.AdmissionRequest, src *flux.OCIRepository) (*operations.Result, error) {
+		return mutateOCIRepo(ctx, r, c, cfg.configMediaType(), src)
 	})
-	return operations.Hook{Create: admit, Update: admit}
+	return operations.Hook{Name: fluxOCIRepositoryHook, Create: admit, Update: admit}
 }
 
 // mutateOCIRepo mutates the oci repository url to point to the repository URL defined in the ZarfState.
-func mutateOCIRepo(ctx context.Context, r *v1.AdmissionRequest, c *cluster.Cluster, src *flux.OCIRepository) (*operations.Result, error) {
+func mutateOCIRepo(ctx context.Context, r *v1.AdmissionRequest, c *cluster.Cluster, resolveMediaType ConfigMediaTypeResolver, src *flux.OCIRepository) (*operations.Result, error) {
 	l := logger.From(ctx)
 	var (
 		patches            []operations.PatchOperation
@@ -64,9 +64,13 @@ func mutateOCIRepo(ctx context.Context, r *v1.AdmissionRequest, c *cluster.Clust
 	if err != nil {
 		return nil, err
 	}
+	registryInfo, err := c.GetRegistryInfoForNamespace(ctx, zarfState, r.Namespace)
+	if err != nil {
+		return nil, err
+	}
 
 	// Get the registry service info if this is a NodePort service to use the internal kube-dns
-	registryAddress, clusterIP, err := c.GetServiceInfoFromRegistryAddress(ctx, zarfState.RegistryInfo)
+	registryAddress, clusterIP, err := c.GetServiceInfoFromRegistryAddress(ctx, registryInfo)
 	if err != nil {
 		return nil, err
 	}
@@ -121,7 +125,7 @@ func mutateOCIRepo(ctx context.Context, r *v1.AdmissionRequest, c *cluster.Clust
 		}
 
 		var certs pki.GeneratedPKI
-		useMTLS = zarfState.RegistryInfo.ShouldUseMTLS()
+		useMTLS = registryInfo.ShouldUseMTLS()
 		if useMTLS {
 			certs, err = c.GetRegistryClientMTLSCert(ctx)
 			if err != nil {
@@ -143,7 +147,7 @@ func mutateOCIRepo(ctx context.Context, r *v1.AdmissionRequest, c *cluster.Clust
 		}
 
 		// Get the media type of the oci image
-		mediaType, err := getManifestConfigMediaType(timeoutCtx, zarfState, transport, patchedSrc)
+		mediaType, err := resolveMediaType(timeoutCtx, registryInfo, transport, patchedSrc)
 
 		// If we get an error, we fall back to existing mutation logic
 		if err != nil {
@@ -176,7 +180,7 @@ func mutateOCIRepo(ctx context.Context, r *v1.AdmissionRequest, c *cluster.Clust
 	}
 
 	l.Debug("mutating the Flux OCIRepository URL to the Zarf URL", "original", src.Spec.URL, "mutated", patchedURL)
-	patches = populateOCIRepoPatchOperations(patchedURL, zarfState.RegistryInfo.IsInternal(), useMTLS, patchedRef)
+	patches = populateOCIRepoPatchOperations(patchedURL, registryInfo.IsInternal(), useMTLS, patchedRef)
 	patches = append(patches, getLabelPatch(src.Labels))
 
 	return &operations.Result{
@@ -0,0 +1,371 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2021-Present The Zarf Authors
+
+package hooks
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/defenseunicorns/pkg/helpers/v2"
+	"github.com/distribution/reference"
+	"github.com/zarf-dev/zarf/src/config/lang"
+	"github.com/zarf-dev/zarf/src/internal/agent/operations"
+	"github.com/zarf-dev/zarf/src/pkg/cluster"
+	"github.com/zarf-dev/zarf/src/pkg/logger"
+	"github.com/zarf-dev/zarf/src/pkg/state"
+	"github.com/zarf-dev/zarf/src/pkg/transform"
+	v1 "k8s.io/api/admission/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"sigs.k8s.io/yaml"
+)
+
+// TransformType is the kind of reference found at a mutation rule's field path.
+type TransformType string
+
+const (
+	// TransformImage rewrites a container image reference to the Zarf registry, the same way pod images are.
+	TransformImage TransformType = "image"
+	// TransformGit rewrites a git repository URL to the Zarf git server.
+	TransformGit TransformType = "git"
+	// TransformOCI rewrites an oci:// URL to the Zarf registry, adding the Zarf checksum to any tag.
+	TransformOCI TransformType = "oci"
+	// TransformHelm rewrites an oci:// Helm chart URL to the Zarf registry without a checksum. Helm repositories
+	// served over HTTP(S) are left as is.
+	TransformHelm TransformType = "helm"
+)
+
+// MutationRule describes where references live in a resource the agent has no built-in hook for.
+type MutationRule struct {
+	// Group is the API group of the resource, empty for the core group.
+	Group string `json:"group"`
+	// Version is the API version of the resource, * or empty matches every version.
+	Version string `json:"version,omitempty"`
+	// Kind is the kind of the resource.
+	Kind string `json:"kind"`
+	// Resource is the plural resource name used in the webhook rules.
+	Resource string `json:"resource"`
+	// Fields are the references to transform.
+	Fields []FieldMutation `json:"fields"`
+}
+
+// FieldMutation transforms the string at Path. Paths are dot separated field names where a list is followed by [*]
+// for every element or [N] for a single one, for example spec.steps[*].image.
+type FieldMutation struct {
+	Path      string        `json:"path"`
+	Transform TransformType `json:"transform"`
+}
+
+// MutationRulesConfigMap is the ConfigMap in the Zarf namespace that holds the mutation rules under MutationRulesKey.
+const (
+	MutationRulesConfigMap = "zarf-agent-mutation-rules"
+	MutationRulesKey       = "mutation-rules.yaml"
+)
+
+// LoadMutationRules reads the mutation rules at path. A missing file means no rules are configured.
+func LoadMutationRules(path string) ([]MutationRule, error) {
+	b, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	rules, err := ParseMutationRules(b)
+	if err != nil {
+		return nil, fmt.Errorf("unable to parse mutation rules %s: %w", path, err)
+	}
+	return rules, nil
+}
+
+// ParseMutationRules parses and validates a YAML list of mutation rules.
+func ParseMutationRules(b []byte) ([]MutationRule, error) {
+	var rules []MutationRule
+	if err := yaml.UnmarshalStrict(b, &rules); err != nil {
+		return nil, err
+	}
+	for _, rule := range rules {
+		if err := rule.validate(); err != nil {
+			return nil, fmt.Errorf("invalid mutation rule for %s: %w", rule.Kind, err)
+		}
+	}
+	return rules, nil
+}
+
+func (r MutationRule) validate() error {
+	if r.Kind == "" || r.Resource == "" {
+		return errors.New("kind and resource must be set")
+	}
+	if len(r.Fields) == 0 {
+		return errors.New("at least one field must be set")
+	}
+	for _, f := range r.Fields {
+		switch f.Transform {
+		case TransformImage, TransformGit, TransformOCI, TransformHelm:
+		default:
+			return fmt.Errorf("field %s has unsupported transform %q", f.Path, f.Transform)
+		}
+		if _, err := parseFieldPath(f.Path); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Matches reports whether the rule applies to the resource in the admission request.
+func (r MutationRule) Matches(req *v1.AdmissionRequest) bool {
+	if r.Group != req.Kind.Group || r.Kind != req.Kind.Kind {
+		return false
+	}
+	return r.Version == "" || r.Version == "*" || r.Version == req.Kind.Version
+}
+
+// NewGenericMutationHook creates a new instance of the mutation hook for resources described by mutation rules.
+func NewGenericMutationHook(c *cluster.Cluster, mode state.MutationPolicy, rules []MutationRule, cfg Config) operations.Hook {
+	admit := withMutationGuard(c, mode, cfg, genericHook, func(ctx context.Context, r *v1.AdmissionRequest, obj *unstructured.Unstructured) (*operations.Result, error) {
+		return mutateGeneric(ctx, r, c, rules, obj)
+	})
+	return operations.Hook{Name: genericHook, Create: admit, Update: admit}
+}
+
+// mutateGeneric transforms the references at every field path of the rules matching the resource.
+func mutateGeneric(ctx context.Context, r *v1.AdmissionRequest, c *cluster.Cluster, rules []MutationRule, obj *unstructured.Unstructured) (*operations.Result, error) {
+	l := logger.From(ctx)
+
+	var fields []FieldMutation
+	for _, rule := range rules {
+		if rule.Matches(r) {
+			fields = append(fields, rule.Fields...)
+		}
+	}
+	if len(fields) == 0 {
+		l.Debug("no mutation rules match the resource", "kind", r.Kind.String())
+		return &operations.Result{Allowed: true}, nil
+	}
+
+	s, err := c.LoadState(ctx)
+	if err != nil {
+		return nil, err
+	}
+	registryInfo, err := c.GetRegistryInfoForNamespace(ctx, s, r.Namespace)
+	if err != nil {
+		return nil, err
+	}
+	t := &referenceTransformer{c: c, s: s, registryInfo: registryInfo, gitOwner: gitOwner(s.GitServer, obj.GetLabels())}
+
+	l.Info("using mutation rules to mutate the resource",
+		"kind", r.Kind.Kind,
+		"name", obj.GetName(),
+		"operation", r.Operation)
+
+	patches := []operations.PatchOperation{}
+	for _, f := range fields {
+		segments, err := parseFieldPath(f.Path)
+		if err != nil {
+			return nil, err
+		}
+		var walkErr error
+		walkFieldPath(obj.Object, segments, "", func(pointer, value string) {
+			if walkErr != nil || value == "" {
+				return
+			}
+			patched, err := t.transform(ctx, f.Transform, value)
+			if err != nil {
+				walkErr = fmt.Errorf("unable to transform %s at %s: %w", value, pointer, err)
+				return
+			}
+			if patched != value {
+				l.Debug("mutating the reference", "path", pointer, "original", value, "mutated", patched)
+				patches = append(patches, operations.ReplacePatchOperation(pointer, patched))
+			}
+		})
+		if walkErr != nil {
+			return nil, walkErr
+		}
+	}
+	if len(patches) == 0 {
+		return &operations.Result{Allowed: true}, nil
+	}
+	patches = append(patches, getLabelPatch(obj.GetLabels()))
+
+	return &operations.Result{
+		Allowed:  true,
+		PatchOps: patches,
+	}, nil
+}
+
+// referenceTransformer rewrites references to the Zarf services, looking up the registry service only once.
+type referenceTransformer struct {
+	c            *cluster.Cluster
+	s            *state.State
+	registryInfo state.RegistryInfo
+	gitOwner     string
+
+	serviceResolved bool
+	registryAddress string
+	clusterIP       string
+}
+
+func (t *referenceTransformer) transform(ctx context.Context, transformType TransformType, value string) (string, error) {
+	switch transformType {
+	case TransformGit:
+		if !t.s.GitServer.IsConfigured() {
+			return value, nil
+		}
+		isPatched, err := helpers.DoHostnamesMatch(t.s.GitServer.Address, value)
+		if err != nil {
+			return "", fmt.Errorf(lang.AgentErrHostnameMatch, err)
+		}
+		if isPatched {
+			return value, nil
+		}
+		u, err := transform.GitURL(t.s.GitServer.Address, value, t.gitOwner)
+		if err != nil {
+			return "", fmt.Errorf("%s: %w", AgentErrTransformGitURL, err)
+		}
+		return u.String(), nil
+	case TransformImage:
+		if !t.registryInfo.IsConfigured() {
+			return value, nil
+		}
+		return transform.ImageTransformHost(t.registryInfo.Address, value)
+	case TransformOCI, TransformHelm:
+		if !t.registryInfo.IsConfigured() || !helpers.IsOCIURL(value) {
+			return value, nil
+		}
+		if !t.serviceResolved {
+			// Get the registry service info if this is a NodePort service to use the internal kube-dns
+			var err error
+			t.registryAddress, t.clusterIP, err = t.c.GetServiceInfoFromRegistryAddress(ctx, t.registryInfo)
+			if err != nil {
+				return "", err
+			}
+			t.serviceResolved = true
+		}
+		withoutChecksum := transformType == TransformHelm
+		if t.clusterIP != "" {
+			isPatchedClusterIP, err := helpers.DoHostnamesMatch(helpers.OCIURLPrefix+t.clusterIP, value)
+			if err != nil {
+				return "", fmt.Errorf(lang.AgentErrHostnameMatch, err)
+			}
+			withoutChecksum = withoutChecksum || isPatchedClusterIP
+		}
+		return transformOCIValue(t.registryAddress, value, withoutChecksum)
+	default:
+		return "", fmt.Errorf("unsupported transform %q", transformType)
+	}
+}
+
+// transformOCIValue moves an oci:// URL to the Zarf registry. A URL without a tag or digest is treated as a
+// repository and stays one.
+func transformOCIValue(registryAddress, value string, withoutChecksum bool) (string, error) {
+	var patchedSrc string
+	var err error
+	if withoutChecksum {
+		patchedSrc, err = transform.ImageTransformHostWithoutChecksum(registryAddress, value)
+	} else {
+		patchedSrc, err = transform.ImageTransformHost(registryAddress, value)
+	}
+	if err != nil {
+		return "", fmt.Errorf("%s: %w", AgentErrTransformOCIURL, err)
+	}
+	patchedRef, err := transform.ParseImageRef(patchedSrc)
+	if err != nil {
+		return "", fmt.Errorf("%s: %w", AgentErrTransformOCIURL, err)
+	}
+
+	ref, err := reference.ParseAnyReference(strings.TrimPrefix(value, helpers.OCIURLPrefix))
+	if err != nil {
+		return "", fmt.Errorf("%s: %w", AgentErrTransformOCIURL, err)
+	}
+	_, tagged := ref.(reference.Tagged)
+	_, digested := ref.(reference.Digested)
+	if tagged || digested {
+		return helpers.OCIURLPrefix + patchedRef.Reference, nil
+	}
+	return helpers.OCIURLPrefix + patchedRef.Name, nil
+}
+
+// fieldPathSegment is a single field of a field path, optionally indexing into a list. An index of -1 selects every
+// element.
+type fieldPathSegment struct {
+	field   string
+	isList  bool
+	listIdx int
+}
+
+var fieldPathSegmentRe = regexp.MustCompile(`^([^.\[\]]+)(?:\[(\*|\d+)\])?$`)
+
+func parseFieldPath(path string) ([]fieldPathSegment, error) {
+	trimmed := strings.TrimPrefix(strings.TrimPrefix(path, "$"), ".")
+	if trimmed == "" {
+		return nil, fmt.Errorf("field path %q is empty", path)
+	}
+	var segments []fieldPathSegment
+	for _, part := range strings.Split(trimmed, ".") {
+		m := fieldPathSegmentRe.FindStringSubmatch(part)
+		if m == nil {
+			return nil, fmt.Errorf("field path %q is invalid at %q", path, part)
+		}
+		segment := fieldPathSegment{field: m[1]}
+		switch m[2] {
+		case "":
+		case "*":
+			segment.isList = true
+			segment.listIdx = -1
+		default:
+			idx, err := strconv.Atoi(m[2])
+			if err != nil {
+				return nil, fmt.Errorf("field path %q has an invalid index: %w", path, err)
+			}
+			segment.isList = true
+			segment.listIdx = idx
+		}
+		segments = append(segments, segment)
+	}
+	return segments, nil
+}
+
+// walkFieldPath calls fn with the JSON pointer and value of every string found at the field path. Missing fields and
+// values of other types are skipped.
+func walkFieldPath(node any, segments []fieldPathSegment, pointer string, fn func(pointer, value string)) {
+	if len(segments) == 0 {
+		if value, ok := node.(string); ok {
+			fn(pointer, value)
+		}
+		return
+	}
+	obj, ok := node.(map[string]any)
+	if !ok {
+		return
+	}
+	segment := segments[0]
+	child, ok := obj[segment.field]
+	if !ok {
+		return
+	}
+	pointer = pointer + "/" + escapeJSONPointer(segment.field)
+	if !segment.isList {
+		walkFieldPath(child, segments[1:], pointer, fn)
+		return
+	}
+	list, ok := child.([]any)
+	if !ok {
+		return
+	}
+	for i, item := range list {
+		if segment.listIdx >= 0 && segment.listIdx != i {
+			continue
+		}
+		walkFieldPath(item, segments[1:], fmt.Sprintf("%s/%d", pointer, i), fn)
+	}
+}
+
+func escapeJSONPointer(s string) string {
+	return strings.ReplaceAll(strings.ReplaceAll(s, "~", "~0"), "/", "~1")
+}
@@ -0,0 +1,195 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2021-Present The Zarf Authors
+
+package hooks
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/zarf-dev/zarf/src/internal/agent/http/admission"
+	"github.com/zarf-dev/zarf/src/internal/agent/operations"
+	"github.com/zarf-dev/zarf/src/pkg/state"
+	v1 "k8s.io/api/admission/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+func createGenericAdmissionRequest(t *testing.T, op v1.Operation, kind metav1.GroupVersionKind, obj map[string]any) *v1.AdmissionRequest {
+	t.Helper()
+	obj["apiVersion"] = schema.GroupVersion{Group: kind.Group, Version: kind.Version}.String()
+	obj["kind"] = kind.Kind
+	raw, err := json.Marshal(obj)
+	require.NoError(t, err)
+	return &v1.AdmissionRequest{
+		Operation: op,
+		Kind:      kind,
+		Namespace: testNamespace,
+		Object: runtime.RawExtension{
+			Raw: raw,
+		},
+	}
+}
+
+func TestGenericMutationWebhook(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	s := &state.State{
+		RegistryInfo: state.RegistryInfo{Address: "127.0.0.1:31999"},
+		GitServer: state.GitServerInfo{
+			Address:      "https://git-server.com",
+			PushUsername: "a-push-user",
+		},
+	}
+	c := createTestClientWithZarfState(ctx, t, s)
+	rules := []MutationRule{
+		{
+			Group:    "tekton.dev",
+			Version:  "v1",
+			Kind:     "Task",
+			Resource: "tasks",
+			Fields: []FieldMutation{
+				{Path: "spec.steps[*].image", Transform: TransformImage},
+				{Path: "spec.sidecars[0].image", Transform: TransformImage},
+			},
+		},
+		{
+			Group:    "cdi.kubevirt.io",
+			Kind:     "DataVolume",
+			Resource: "datavolumes",
+			Fields: []FieldMutation{
+				{Path: "spec.source.registry.url", Transform: TransformOCI},
+			},
+		},
+		{
+			Group:    "example.dev",
+			Kind:     "Pipeline",
+			Resource: "pipelines",
+			Fields: []FieldMutation{
+				{Path: "spec.repo", Transform: TransformGit},
+				{Path: "spec.chart", Transform: TransformHelm},
+			},
+		},
+	}
+	handler := admission.NewHandler().Serve(ctx, NewGenericMutationHook(c, state.MutationPolicyAll, rules, Config{}))
+
+	tests := []admissionTest{
+		{
+			name: "image fields are mutated",
+			admissionReq: createGenericAdmissionRequest(t, v1.Create, metav1.GroupVersionKind{Group: "tekton.dev", Version: "v1", Kind: "Task"}, map[string]any{
+				"metadata": map[string]any{"name": "build"},
+				"spec": map[string]any{
+					"steps": []any{
+						map[string]any{"name": "clone", "image": "alpine/git:2.45.2"},
+						map[string]any{"name": "noop"},
+						map[string]any{"name": "zarf", "image": "127.0.0.1:31999/library/busybox:1.36-zarf-2140033595"},
+					},
+					"sidecars": []any{
+						map[string]any{"name": "docker", "image": "docker:dind"},
+						map[string]any{"name": "other", "image": "nginx"},
+					},
+				},
+			}),
+			patch: []operations.PatchOperation{
+				operations.ReplacePatchOperation("/spec/steps/0/image", "127.0.0.1:31999/alpine/git:2.45.2-zarf-2739568766"),
+				operations.ReplacePatchOperation("/spec/sidecars/0/image", "127.0.0.1:31999/library/docker:dind-zarf-1958758067"),
+				operations.ReplacePatchOperation("/metadata/labels", map[string]string{"zarf-agent": "patched"}),
+			},
+			code: http.StatusOK,
+		},
+		{
+			name: "oci repository urls keep their tag",
+			admissionReq: createGenericAdmissionRequest(t, v1.Create, metav1.GroupVersionKind{Group: "cdi.kubevirt.io", Version: "v1beta1", Kind: "DataVolume"}, map[string]any{
+				"metadata": map[string]any{"name": "disk"},
+				"spec": map[string]any{
+					"source": map[string]any{"registry": map[string]any{"url": "oci://quay.io/containerdisks/fedora:40"}},
+				},
+			}),
+			patch: []operations.PatchOperation{
+				operations.ReplacePatchOperation("/spec/source/registry/url", "oci://127.0.0.1:31999/containerdisks/fedora:40-zarf-4021467769"),
+				operations.ReplacePatchOperation("/metadata/labels", map[string]string{"zarf-agent": "patched"}),
+			},
+			code: http.StatusOK,
+		},
+		{
+			name: "git and helm fields are mutated",
+			admissionReq: createGenericAdmissionRequest(t, v1.Update, metav1.GroupVersionKind{Group: "example.dev", Version: "v1", Kind: "Pipeline"}, map[string]any{
+				"metadata": map[string]any{"name": "pipeline", "labels": map[string]any{"app": "pipeline"}},
+				"spec": map[string]any{
+					"repo":  "https://github.com/stefanprodan/podinfo.git",
+					"chart": "oci://ghcr.io/stefanprodan/charts/podinfo",
+				},
+			}),
+			patch: []operations.PatchOperation{
+				operations.ReplacePatchOperation("/spec/repo", "https://git-server.com/a-push-user/podinfo-1646971829.git"),
+				operations.ReplacePatchOperation("/spec/chart", "oci://127.0.0.1:31999/stefanprodan/charts/podinfo"),
+				operations.ReplacePatchOperation("/metadata/labels", map[string]string{"app": "pipeline", "zarf-agent": "patched"}),
+			},
+			code: http.StatusOK,
+		},
+		{
+			name: "resources without a rule are not mutated",
+			admissionReq: createGenericAdmissionRequest(t, v1.Create, metav1.GroupVersionKind{Group: "tekton.dev", Version: "v1", Kind: "Pipeline"}, map[string]any{
+				"metadata": map[string]any{"name": "pipeline"},
+				"spec":     map[string]any{"steps": []any{map[string]any{"image": "alpine"}}},
+			}),
+			code: http.StatusOK,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			rr := sendAdmissionRequest(t, tt.admissionReq, handler)
+			verifyAdmission(t, rr, tt)
+		})
+	}
+}
+
+func TestLoadMutationRules(t *testing.T) {
+	t.Parallel()
+
+	rules, err := LoadMutationRules(filepath.Join(t.TempDir(), "missing.yaml"))
+	require.NoError(t, err)
+	require.Empty(t, rules)
+
+	path := filepath.Join(t.TempDir(), "mutation-rules.yaml")
+	b := []byte(`
+- group: tekton.dev
+  version: v1
+  kind: Task
+  resource: tasks
+  fields:
+    - path: spec.steps[*].image
+      transform: image
+`)
+	require.NoError(t, os.WriteFile(path, b, 0o600))
+	rules, err = LoadMutationRules(path)
+	require.NoError(t, err)
+	require.Equal(t, []MutationRule{{
+		Group:    "tekton.dev",
+		Version:  "v1",
+		Kind:     "Task",
+		Resource: "tasks",
+		Fields:   []FieldMutation{{Path: "spec.steps[*].image", Transform: TransformImage}},
+	}}, rules)
+
+	invalid := map[string]string{
+		"unknown transform": "- {kind: Task, resource: tasks, fields: [{path: spec.image, transform: npm}]}",
+		"invalid path":      "- {kind: Task, resource: tasks, fields: [{path: 'spec.steps[x].image', transform: image}]}",
+		"missing resource":  "- {kind: Task, fields: [{path: spec.image, transform: image}]}",
+		"unknown field":     "- {kind: Task, resource: tasks, paths: []}",
+	}
+	for name, content := range invalid {
+		require.NoError(t, os.WriteFile(path, []byte(content), 0o600))
+		_, err := LoadMutationRules(path)
+		require.Error(t, err, name)
+	}
+}
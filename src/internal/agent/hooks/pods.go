@@ -22,11 +22,11 @@ import (
 const annotationPrefix = "zarf.dev"
 
 // NewPodMutationHook creates a new instance of pods mutation hook.
-func NewPodMutationHook(c *cluster.Cluster, mode state.MutationPolicy) operations.Hook {
-	admit := withMutationGuard(c, mode, func(ctx context.Context, r *v1.AdmissionRequest, pod *corev1.Pod) (*operations.Result, error) {
+func NewPodMutationHook(c *cluster.Cluster, mode state.MutationPolicy, cfg Config) operations.Hook {
+	admit := withMutationGuard(c, mode, cfg, podHook, func(ctx context.Context, r *v1.AdmissionRequest, pod *corev1.Pod) (*operations.Result, error) {
 		return mutatePod(ctx, r, c, pod)
 	})
-	return operations.Hook{Create: admit, Update: admit}
+	return operations.Hook{Name: podHook, Create: admit, Update: admit}
 }
 
 func getImageAnnotationKey(ctx context.Context, containerName string) string {
@@ -68,11 +68,15 @@ func mutatePod(ctx context.Context, r *v1.AdmissionRequest, c *cluster.Cluster,
 		}, nil
 	}
 
-	state, err := c.LoadState(ctx)
+	s, err := c.LoadState(ctx)
 	if err != nil {
 		return nil, err
 	}
-	registryURL := state.RegistryInfo.Address
+	registryInfo, err := c.GetRegistryInfoForNamespace(ctx, s, r.Namespace)
+	if err != nil {
+		return nil, err
+	}
+	registryURL := registryInfo.Address
 
 	// Pods do not have a metadata.name at the time of admission if from a deployment so we don't log the name
 	l.Info("using the Zarf registry URL to mutate the Pod", "registry", registryURL)
@@ -80,8 +84,9 @@ func mutatePod(ctx context.Context, r *v1.AdmissionRequest, c *cluster.Cluster,
 	var patches []operations.PatchOperation
 
 	// Add the zarf secret to the podspec
-	zarfSecret := []corev1.LocalObjectReference{{Name: config.ZarfImagePullSecretName}}
-	patches = append(patches, operations.ReplacePatchOperation("/spec/imagePullSecrets", zarfSecret))
+	if pullSecrets, ok := podImagePullSecrets(s.AgentImagePullSecretMode, pod.Spec.ImagePullSecrets); ok {
+		patches = append(patches, operations.ReplacePatchOperation("/spec/imagePullSecrets", pullSecrets))
+	}
 
 	updatedAnnotations := pod.Annotations
 	if updatedAnnotations == nil {
@@ -138,25 +143,55 @@ func mutatePod(ctx context.Context, r *v1.AdmissionRequest, c *cluster.Cluster,
 	}, nil
 }
 
+// podImagePullSecrets returns the image pull secrets a pod should have under the given mode and whether they need to be patched.
+// In serviceaccount mode the pod is left alone as the secret is added to its ServiceAccount instead.
+func podImagePullSecrets(mode state.ImagePullSecretMode, current []corev1.LocalObjectReference) ([]corev1.LocalObjectReference, bool) {
+	switch mode {
+	case state.ImagePullSecretModeServiceAccount:
+		return nil, false
+	case state.ImagePullSecretModeMerge:
+		return withZarfImagePullSecret(current)
+	default:
+		return []corev1.LocalObjectReference{{Name: config.ZarfImagePullSecretName}}, true
+	}
+}
+
+// withZarfImagePullSecret appends the Zarf pull secret to the given references if it is missing and reports whether it was added.
+func withZarfImagePullSecret(current []corev1.LocalObjectReference) ([]corev1.LocalObjectReference, bool) {
+	for _, ref := range current {
+		if ref.Name == config.ZarfImagePullSecretName {
+			return current, false
+		}
+	}
+	merged := make([]corev1.LocalObjectReference, 0, len(current)+1)
+	merged = append(merged, current...)
+	merged = append(merged, corev1.LocalObjectReference{Name: config.ZarfImagePullSecretName})
+	return merged, true
+}
+
 // mutatePodSubresource handles pod subresource mutation
 func mutatePodSubresource(ctx context.Context, r *v1.AdmissionRequest, cluster *cluster.Cluster, pod *corev1.Pod) (*operations.Result, error) {
 	switch res := r.SubResource; res {
 	case "ephemeralcontainers":
-		return mutateEphemeralContainers(ctx, cluster, pod)
+		return mutateEphemeralContainers(ctx, cluster, r.Namespace, pod)
 	default:
 		// this likely won't be hit as the MutatingWebhookConfiguration would need to be modified - but this can help ensure they stay synchronized
 		return nil, fmt.Errorf("attempted mutation of unsupported subresource: %s", res)
 	}
 }
 
-func mutateEphemeralContainers(ctx context.Context, cluster *cluster.Cluster, pod *corev1.Pod) (*operations.Result, error) {
+func mutateEphemeralContainers(ctx context.Context, cluster *cluster.Cluster, namespace string, pod *corev1.Pod) (*operations.Result, error) {
 	l := logger.From(ctx)
 
-	state, err := cluster.LoadState(ctx)
+	s, err := cluster.LoadState(ctx)
+	if err != nil {
+		return nil, err
+	}
+	registryInfo, err := cluster.GetRegistryInfoForNamespace(ctx, s, namespace)
 	if err != nil {
 		return nil, err
 	}
-	registryURL := state.RegistryInfo.Address
+	registryURL := registryInfo.Address
 
 	// Pods do not have a metadata.name at the time of admission if from a deployment so we don't log the name
 	l.Info("using the Zarf registry URL to mutate the Pod", "registry", registryURL)
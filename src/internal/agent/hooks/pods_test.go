@@ -13,6 +13,7 @@ import (
 	"github.com/zarf-dev/zarf/src/config"
 	"github.com/zarf-dev/zarf/src/internal/agent/http/admission"
 	"github.com/zarf-dev/zarf/src/internal/agent/operations"
+	"github.com/zarf-dev/zarf/src/pkg/cluster"
 	"github.com/zarf-dev/zarf/src/pkg/state"
 	v1 "k8s.io/api/admission/v1"
 	corev1 "k8s.io/api/core/v1"
@@ -41,7 +42,7 @@ func TestPodMutationWebhook(t *testing.T) {
 
 	s := &state.State{RegistryInfo: state.RegistryInfo{Address: "127.0.0.1:31999"}}
 	c := createTestClientWithZarfState(ctx, t, s)
-	handler := admission.NewHandler().Serve(ctx, NewPodMutationHook(c, state.MutationPolicyAll))
+	handler := admission.NewHandler().Serve(ctx, NewPodMutationHook(c, state.MutationPolicyAll, Config{}))
 
 	tests := []admissionTest{
 		{
@@ -248,6 +249,208 @@ func TestPodMutationWebhook(t *testing.T) {
 		})
 	}
 }
+func TestPodMutationWebhookRegistryTarget(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+
+	s := &state.State{
+		RegistryInfo: state.RegistryInfo{Address: "127.0.0.1:31999"},
+		RegistryTargets: []state.RegistryTarget{
+			{Name: "tenant-a", Address: "registry.tenant-a.example.com", Prefix: "zarf"},
+			{Name: "tenant-b", Address: "registry.tenant-b.example.com"},
+		},
+		NamespaceRegistryTargets: map[string]string{"mapped": "tenant-b"},
+	}
+	c := createTestClientWithZarfState(ctx, t, s)
+	for _, ns := range []*corev1.Namespace{
+		{ObjectMeta: metav1.ObjectMeta{Name: "annotated", Annotations: map[string]string{cluster.RegistryTargetAnnotation: "tenant-a"}}},
+		{ObjectMeta: metav1.ObjectMeta{Name: "unknown", Annotations: map[string]string{cluster.RegistryTargetAnnotation: "missing"}}},
+		{ObjectMeta: metav1.ObjectMeta{Name: "mapped"}},
+	} {
+		_, err := c.Clientset.CoreV1().Namespaces().Create(ctx, ns, metav1.CreateOptions{})
+		require.NoError(t, err)
+	}
+	handler := admission.NewHandler().Serve(ctx, NewPodMutationHook(c, state.MutationPolicyAll, Config{}))
+
+	podImageRequest := func(namespace, image string) *v1.AdmissionRequest {
+		req := createPodAdmissionRequest(t, v1.Create, &corev1.Pod{
+			Spec: corev1.PodSpec{Containers: []corev1.Container{{Name: "nginx", Image: image}}},
+		}, "")
+		req.Namespace = namespace
+		return req
+	}
+	podRequest := func(namespace string) *v1.AdmissionRequest {
+		return podImageRequest(namespace, "nginx")
+	}
+	podImagePatch := func(original, image string) []operations.PatchOperation {
+		return []operations.PatchOperation{
+			operations.ReplacePatchOperation("/spec/imagePullSecrets", []corev1.LocalObjectReference{{Name: config.ZarfImagePullSecretName}}),
+			operations.ReplacePatchOperation("/spec/containers/0/image", image),
+			operations.ReplacePatchOperation("/metadata/labels", map[string]string{"zarf-agent": "patched"}),
+			operations.ReplacePatchOperation("/metadata/annotations", map[string]string{"zarf.dev/original-image-nginx": original}),
+		}
+	}
+	podPatch := func(image string) []operations.PatchOperation {
+		return podImagePatch("nginx", image)
+	}
+
+	tests := []admissionTest{
+		{
+			name:         "namespace annotation selects the target",
+			admissionReq: podRequest("annotated"),
+			patch:        podPatch("registry.tenant-a.example.com/zarf/library/nginx:latest-zarf-3793515731"),
+			code:         http.StatusOK,
+		},
+		{
+			name:         "images on the target host outside the prefix are moved under it",
+			admissionReq: podImageRequest("annotated", "registry.tenant-a.example.com/other-team/app:1.0.0"),
+			patch:        podImagePatch("registry.tenant-a.example.com/other-team/app:1.0.0", "registry.tenant-a.example.com/zarf/other-team/app:1.0.0-zarf-3472339015"),
+			code:         http.StatusOK,
+		},
+		{
+			name:         "images already under the prefix are kept",
+			admissionReq: podImageRequest("annotated", "registry.tenant-a.example.com/zarf/library/nginx:latest-zarf-3793515731"),
+			patch:        podImagePatch("registry.tenant-a.example.com/zarf/library/nginx:latest-zarf-3793515731", "registry.tenant-a.example.com/zarf/library/nginx:latest-zarf-3793515731"),
+			code:         http.StatusOK,
+		},
+		{
+			name:         "state mapping selects the target",
+			admissionReq: podRequest("mapped"),
+			patch:        podPatch("registry.tenant-b.example.com/library/nginx:latest-zarf-3793515731"),
+			code:         http.StatusOK,
+		},
+		{
+			name:         "other namespaces use the Zarf registry",
+			admissionReq: podRequest(testNamespace),
+			patch:        podPatch("127.0.0.1:31999/library/nginx:latest-zarf-3793515731"),
+			code:         http.StatusOK,
+		},
+		{
+			name:         "unknown target is rejected",
+			admissionReq: podRequest("unknown"),
+			errContains:  `registry target "missing" does not exist`,
+			code:         http.StatusInternalServerError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			rr := sendAdmissionRequest(t, tt.admissionReq, handler)
+			verifyAdmission(t, rr, tt)
+		})
+	}
+}
+
+func TestPodMutationWebhookImagePullSecretMode(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+
+	podRequest := func(pullSecrets ...string) *v1.AdmissionRequest {
+		var refs []corev1.LocalObjectReference
+		for _, name := range pullSecrets {
+			refs = append(refs, corev1.LocalObjectReference{Name: name})
+		}
+		return createPodAdmissionRequest(t, v1.Create, &corev1.Pod{
+			Spec: corev1.PodSpec{
+				Containers:       []corev1.Container{{Name: "nginx", Image: "nginx"}},
+				ImagePullSecrets: refs,
+			},
+		}, "")
+	}
+	podPatch := func(pullSecrets ...string) []operations.PatchOperation {
+		var patches []operations.PatchOperation
+		if pullSecrets != nil {
+			var refs []corev1.LocalObjectReference
+			for _, name := range pullSecrets {
+				refs = append(refs, corev1.LocalObjectReference{Name: name})
+			}
+			patches = append(patches, operations.ReplacePatchOperation("/spec/imagePullSecrets", refs))
+		}
+		return append(patches,
+			operations.ReplacePatchOperation("/spec/containers/0/image", "127.0.0.1:31999/library/nginx:latest-zarf-3793515731"),
+			operations.ReplacePatchOperation("/metadata/labels", map[string]string{"zarf-agent": "patched"}),
+			operations.ReplacePatchOperation("/metadata/annotations", map[string]string{"zarf.dev/original-image-nginx": "nginx"}),
+		)
+	}
+
+	tests := []struct {
+		mode state.ImagePullSecretMode
+		admissionTest
+	}{
+		{
+			mode: "",
+			admissionTest: admissionTest{
+				name:         "unset mode replaces the pull secrets",
+				admissionReq: podRequest("other-registry"),
+				patch:        podPatch(config.ZarfImagePullSecretName),
+				code:         http.StatusOK,
+			},
+		},
+		{
+			mode: state.ImagePullSecretModeReplace,
+			admissionTest: admissionTest{
+				name:         "replace mode replaces the pull secrets",
+				admissionReq: podRequest("other-registry"),
+				patch:        podPatch(config.ZarfImagePullSecretName),
+				code:         http.StatusOK,
+			},
+		},
+		{
+			mode: state.ImagePullSecretModeMerge,
+			admissionTest: admissionTest{
+				name:         "merge mode keeps existing pull secrets",
+				admissionReq: podRequest("other-registry"),
+				patch:        podPatch("other-registry", config.ZarfImagePullSecretName),
+				code:         http.StatusOK,
+			},
+		},
+		{
+			mode: state.ImagePullSecretModeMerge,
+			admissionTest: admissionTest{
+				name:         "merge mode adds the secret to pods without pull secrets",
+				admissionReq: podRequest(),
+				patch:        podPatch(config.ZarfImagePullSecretName),
+				code:         http.StatusOK,
+			},
+		},
+		{
+			mode: state.ImagePullSecretModeMerge,
+			admissionTest: admissionTest{
+				name:         "merge mode does not patch pods that already have the secret",
+				admissionReq: podRequest(config.ZarfImagePullSecretName, "other-registry"),
+				patch:        podPatch(),
+				code:         http.StatusOK,
+			},
+		},
+		{
+			mode: state.ImagePullSecretModeServiceAccount,
+			admissionTest: admissionTest{
+				name:         "serviceaccount mode leaves the pull secrets alone",
+				admissionReq: podRequest("other-registry"),
+				patch:        podPatch(),
+				code:         http.StatusOK,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			s := &state.State{
+				RegistryInfo:             state.RegistryInfo{Address: "127.0.0.1:31999"},
+				AgentImagePullSecretMode: tt.mode,
+			}
+			c := createTestClientWithZarfState(ctx, t, s)
+			handler := admission.NewHandler().Serve(ctx, NewPodMutationHook(c, state.MutationPolicyAll, Config{}))
+			rr := sendAdmissionRequest(t, tt.admissionReq, handler)
+			verifyAdmission(t, rr, tt.admissionTest)
+		})
+	}
+}
+
 func TestGetImageAnnotationKey(t *testing.T) {
 	t.Parallel()
 	tests := []struct {
@@ -0,0 +1,143 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2021-Present The Zarf Authors
+
+package hooks
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/zarf-dev/zarf/src/config/lang"
+	"github.com/zarf-dev/zarf/src/internal/agent/operations"
+	"github.com/zarf-dev/zarf/src/pkg/cluster"
+	"github.com/zarf-dev/zarf/src/pkg/logger"
+	"github.com/zarf-dev/zarf/src/pkg/transform"
+	v1 "k8s.io/api/admission/v1"
+	corev1 "k8s.io/api/core/v1"
+)
+
+// NewPodValidationHook creates a new instance of the pods validation hook.
+func NewPodValidationHook(c *cluster.Cluster, action operations.ValidationAction) operations.Hook {
+	admit := func(ctx context.Context, r *v1.AdmissionRequest) (*operations.Result, error) {
+		return validatePod(ctx, r, c, action)
+	}
+	return operations.Hook{Name: podValidationHook, Create: admit, Update: admit}
+}
+
+// validatePod checks that every image a pod references is served from the Zarf registry. Unlike the mutation hooks,
+// validation does not honor the zarf.dev/agent ignore label since it exists to catch pods that were never mutated.
+func validatePod(ctx context.Context, r *v1.AdmissionRequest, c *cluster.Cluster, action operations.ValidationAction) (*operations.Result, error) {
+	l := logger.From(ctx)
+
+	pod := &corev1.Pod{}
+	if err := json.Unmarshal(r.Object.Raw, pod); err != nil {
+		return nil, fmt.Errorf(lang.ErrUnmarshal, err)
+	}
+
+	s, err := c.LoadState(ctx)
+	if err != nil {
+		return nil, err
+	}
+	registryInfo, err := c.GetRegistryInfoForNamespace(ctx, s, r.Namespace)
+	if err != nil {
+		return nil, err
+	}
+	if !registryInfo.IsConfigured() {
+		return &operations.Result{Allowed: true}, nil
+	}
+
+	violations, err := findImageViolations(registryInfo.Address, pod, r.SubResource)
+	if err != nil {
+		return nil, err
+	}
+	// Only report images introduced by an update so that pods admitted before validation was enabled can still be
+	// updated, for example to remove finalizers.
+	if r.Operation == v1.Update && len(r.OldObject.Raw) > 0 {
+		oldPod := &corev1.Pod{}
+		if err := json.Unmarshal(r.OldObject.Raw, oldPod); err != nil {
+			return nil, fmt.Errorf(lang.ErrUnmarshal, err)
+		}
+		existing, err := findImageViolations(registryInfo.Address, oldPod, r.SubResource)
+		if err != nil {
+			return nil, err
+		}
+		violations = slices.DeleteFunc(violations, func(v string) bool { return slices.Contains(existing, v) })
+	}
+	if len(violations) == 0 {
+		return &operations.Result{Allowed: true}, nil
+	}
+
+	l.Warn("pod references images outside of the Zarf registry", "namespace", r.Namespace, "name", pod.Name, "action", action, "violations", violations)
+	if action == operations.ValidationActionDeny {
+		return &operations.Result{
+			Allowed: false,
+			Msg:     fmt.Sprintf("pod references images outside of the Zarf registry %s: %s", registryInfo.Address, strings.Join(violations, ", ")),
+		}, nil
+	}
+	warnings := make([]string, 0, len(violations))
+	for _, v := range violations {
+		warnings = append(warnings, fmt.Sprintf("%s is not served from the Zarf registry %s", v, registryInfo.Address))
+	}
+	return &operations.Result{Allowed: true, Warnings: warnings}, nil
+}
+
+func findImageViolations(registryAddress string, pod *corev1.Pod, subResource string) ([]string, error) {
+	if subResource == "ephemeralcontainers" {
+		return findEphemeralImageViolations(registryAddress, pod)
+	}
+	return findPodImageViolations(registryAddress, pod)
+}
+
+// findPodImageViolations returns a description of every container, init container, ephemeral container and image
+// volume that references an image outside of registryAddress.
+func findPodImageViolations(registryAddress string, pod *corev1.Pod) ([]string, error) {
+	var violations []string
+	for idx, container := range pod.Spec.InitContainers {
+		if err := checkImage(registryAddress, container.Image, fmt.Sprintf("spec.initContainers[%d] (%s)", idx, container.Name), &violations); err != nil {
+			return nil, err
+		}
+	}
+	for idx, container := range pod.Spec.Containers {
+		if err := checkImage(registryAddress, container.Image, fmt.Sprintf("spec.containers[%d] (%s)", idx, container.Name), &violations); err != nil {
+			return nil, err
+		}
+	}
+	ephemeral, err := findEphemeralImageViolations(registryAddress, pod)
+	if err != nil {
+		return nil, err
+	}
+	violations = append(violations, ephemeral...)
+	for idx, volume := range pod.Spec.Volumes {
+		if volume.Image == nil {
+			continue
+		}
+		if err := checkImage(registryAddress, volume.Image.Reference, fmt.Sprintf("spec.volumes[%d] (%s)", idx, volume.Name), &violations); err != nil {
+			return nil, err
+		}
+	}
+	return violations, nil
+}
+
+func findEphemeralImageViolations(registryAddress string, pod *corev1.Pod) ([]string, error) {
+	var violations []string
+	for idx, container := range pod.Spec.EphemeralContainers {
+		if err := checkImage(registryAddress, container.Image, fmt.Sprintf("spec.ephemeralContainers[%d] (%s)", idx, container.Name), &violations); err != nil {
+			return nil, err
+		}
+	}
+	return violations, nil
+}
+
+func checkImage(registryAddress, image, field string, violations *[]string) error {
+	ref, err := transform.ParseImageRef(image)
+	if err != nil {
+		return fmt.Errorf("unable to parse image %q in %s: %w", image, field, err)
+	}
+	if !strings.HasPrefix(ref.Name, strings.TrimSuffix(registryAddress, "/")+"/") {
+		*violations = append(*violations, fmt.Sprintf("%s image %s", field, image))
+	}
+	return nil
+}
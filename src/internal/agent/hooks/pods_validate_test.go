@@ -0,0 +1,126 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2021-Present The Zarf Authors
+
+package hooks
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/zarf-dev/zarf/src/internal/agent/http/admission"
+	"github.com/zarf-dev/zarf/src/internal/agent/operations"
+	"github.com/zarf-dev/zarf/src/pkg/state"
+	v1 "k8s.io/api/admission/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+func TestPodValidationWebhook(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+
+	s := &state.State{RegistryInfo: state.RegistryInfo{Address: "127.0.0.1:31999"}}
+	c := createTestClientWithZarfState(ctx, t, s)
+
+	zarfPod := &corev1.Pod{
+		Spec: corev1.PodSpec{
+			Containers:     []corev1.Container{{Name: "nginx", Image: "127.0.0.1:31999/library/nginx:latest-zarf-3793515731"}},
+			InitContainers: []corev1.Container{{Name: "init", Image: "127.0.0.1:31999/library/busybox:latest"}},
+		},
+	}
+	internetPod := &corev1.Pod{
+		Spec: corev1.PodSpec{
+			Containers:     []corev1.Container{{Name: "nginx", Image: "127.0.0.1:31999/library/nginx:latest"}},
+			InitContainers: []corev1.Container{{Name: "init", Image: "busybox"}},
+			EphemeralContainers: []corev1.EphemeralContainer{
+				{EphemeralContainerCommon: corev1.EphemeralContainerCommon{Name: "debug", Image: "ghcr.io/zarf-dev/debug:1.0.0"}},
+			},
+			Volumes: []corev1.Volume{
+				{Name: "data", VolumeSource: corev1.VolumeSource{Image: &corev1.ImageVolumeSource{Reference: "ghcr.io/zarf-dev/data:1.0.0"}}},
+			},
+		},
+	}
+
+	tests := []struct {
+		name             string
+		action           operations.ValidationAction
+		admissionReq     *v1.AdmissionRequest
+		allowed          bool
+		messageContains  []string
+		expectedWarnings int
+	}{
+		{
+			name:         "pod with only Zarf registry images is allowed",
+			action:       operations.ValidationActionDeny,
+			admissionReq: createPodAdmissionRequest(t, v1.Create, zarfPod, ""),
+			allowed:      true,
+		},
+		{
+			name:         "pod with outside images is denied",
+			action:       operations.ValidationActionDeny,
+			admissionReq: createPodAdmissionRequest(t, v1.Create, internetPod, ""),
+			allowed:      false,
+			messageContains: []string{
+				"spec.initContainers[0] (init) image busybox",
+				"spec.ephemeralContainers[0] (debug) image ghcr.io/zarf-dev/debug:1.0.0",
+				"spec.volumes[0] (data) image ghcr.io/zarf-dev/data:1.0.0",
+			},
+		},
+		{
+			name:             "pod with outside images is warned about",
+			action:           operations.ValidationActionWarn,
+			admissionReq:     createPodAdmissionRequest(t, v1.Create, internetPod, ""),
+			allowed:          true,
+			expectedWarnings: 3,
+		},
+		{
+			name:   "update without new outside images is allowed",
+			action: operations.ValidationActionDeny,
+			admissionReq: func() *v1.AdmissionRequest {
+				r := createPodAdmissionRequest(t, v1.Update, internetPod, "")
+				raw, err := json.Marshal(internetPod)
+				require.NoError(t, err)
+				r.OldObject = runtime.RawExtension{Raw: raw}
+				return r
+			}(),
+			allowed: true,
+		},
+		{
+			name:   "ephemeral container subresource only checks ephemeral containers",
+			action: operations.ValidationActionDeny,
+			admissionReq: createPodAdmissionRequest(t, v1.Update, &corev1.Pod{
+				Spec: corev1.PodSpec{
+					Containers: []corev1.Container{{Name: "nginx", Image: "nginx"}},
+					EphemeralContainers: []corev1.EphemeralContainer{
+						{EphemeralContainerCommon: corev1.EphemeralContainerCommon{Name: "debug", Image: "busybox"}},
+					},
+				},
+			}, "ephemeralcontainers"),
+			allowed:         false,
+			messageContains: []string{"spec.ephemeralContainers[0] (debug) image busybox"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			handler := admission.NewHandler().Serve(ctx, NewPodValidationHook(c, tt.action))
+			rr := sendAdmissionRequest(t, tt.admissionReq, handler)
+			require.Equal(t, http.StatusOK, rr.Code)
+
+			var review v1.AdmissionReview
+			require.NoError(t, json.NewDecoder(rr.Body).Decode(&review))
+			require.Equal(t, tt.allowed, review.Response.Allowed)
+			require.Empty(t, review.Response.Patch)
+			for _, msg := range tt.messageContains {
+				require.Contains(t, review.Response.Result.Message, msg)
+			}
+			require.NotContains(t, review.Response.Result.Message, "spec.containers[0] (nginx) image 127.0.0.1:31999")
+			require.Len(t, review.Response.Warnings, tt.expectedWarnings)
+		})
+	}
+}
@@ -0,0 +1,47 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2021-Present The Zarf Authors
+
+// Package hooks provides HTTP handlers for the mutating webhook.
+package hooks
+
+import (
+	"context"
+
+	"github.com/zarf-dev/zarf/src/internal/agent/operations"
+	"github.com/zarf-dev/zarf/src/pkg/cluster"
+	"github.com/zarf-dev/zarf/src/pkg/logger"
+	"github.com/zarf-dev/zarf/src/pkg/state"
+	v1 "k8s.io/api/admission/v1"
+	corev1 "k8s.io/api/core/v1"
+)
+
+// NewServiceAccountMutationHook creates a new instance of the ServiceAccount mutation hook.
+func NewServiceAccountMutationHook(c *cluster.Cluster, mode state.MutationPolicy, cfg Config) operations.Hook {
+	admit := withMutationGuard(c, mode, cfg, serviceAccountHook, func(ctx context.Context, r *v1.AdmissionRequest, sa *corev1.ServiceAccount) (*operations.Result, error) {
+		return mutateServiceAccount(ctx, r, c, sa)
+	})
+	return operations.Hook{Name: serviceAccountHook, Create: admit, Update: admit}
+}
+
+// mutateServiceAccount adds the Zarf pull secret to a ServiceAccount when the agent runs in serviceaccount image pull secret mode.
+func mutateServiceAccount(ctx context.Context, r *v1.AdmissionRequest, c *cluster.Cluster, sa *corev1.ServiceAccount) (*operations.Result, error) {
+	s, err := c.LoadState(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if s.AgentImagePullSecretMode != state.ImagePullSecretModeServiceAccount {
+		return &operations.Result{Allowed: true, PatchOps: []operations.PatchOperation{}}, nil
+	}
+
+	pullSecrets, ok := withZarfImagePullSecret(sa.ImagePullSecrets)
+	if !ok && sa.Labels["zarf-agent"] == "patched" {
+		return &operations.Result{Allowed: true, PatchOps: []operations.PatchOperation{}}, nil
+	}
+
+	logger.From(ctx).Info("adding the Zarf image pull secret to the ServiceAccount", "name", sa.Name, "namespace", r.Namespace)
+	patches := []operations.PatchOperation{
+		operations.ReplacePatchOperation("/imagePullSecrets", pullSecrets),
+		getLabelPatch(sa.Labels),
+	}
+	return &operations.Result{Allowed: true, PatchOps: patches}, nil
+}
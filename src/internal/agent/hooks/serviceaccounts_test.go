@@ -0,0 +1,114 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2021-Present The Zarf Authors
+
+package hooks
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/zarf-dev/zarf/src/config"
+	"github.com/zarf-dev/zarf/src/internal/agent/http/admission"
+	"github.com/zarf-dev/zarf/src/internal/agent/operations"
+	"github.com/zarf-dev/zarf/src/pkg/state"
+	v1 "k8s.io/api/admission/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+func createServiceAccountAdmissionRequest(t *testing.T, op v1.Operation, sa *corev1.ServiceAccount) *v1.AdmissionRequest {
+	t.Helper()
+	raw, err := json.Marshal(sa)
+	require.NoError(t, err)
+	return &v1.AdmissionRequest{
+		Operation: op,
+		Namespace: testNamespace,
+		Object: runtime.RawExtension{
+			Raw: raw,
+		},
+	}
+}
+
+func TestServiceAccountMutationWebhook(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+
+	s := &state.State{
+		RegistryInfo:             state.RegistryInfo{Address: "127.0.0.1:31999"},
+		AgentImagePullSecretMode: state.ImagePullSecretModeServiceAccount,
+	}
+	c := createTestClientWithZarfState(ctx, t, s)
+	handler := admission.NewHandler().Serve(ctx, NewServiceAccountMutationHook(c, state.MutationPolicyAll, Config{}))
+
+	tests := []admissionTest{
+		{
+			name: "service account without pull secrets gets the Zarf secret",
+			admissionReq: createServiceAccountAdmissionRequest(t, v1.Create, &corev1.ServiceAccount{
+				ObjectMeta: metav1.ObjectMeta{Name: "default"},
+			}),
+			patch: []operations.PatchOperation{
+				operations.ReplacePatchOperation("/imagePullSecrets", []corev1.LocalObjectReference{{Name: config.ZarfImagePullSecretName}}),
+				operations.ReplacePatchOperation("/metadata/labels", map[string]string{"zarf-agent": "patched"}),
+			},
+			code: http.StatusOK,
+		},
+		{
+			name: "existing pull secrets are kept",
+			admissionReq: createServiceAccountAdmissionRequest(t, v1.Update, &corev1.ServiceAccount{
+				ObjectMeta:       metav1.ObjectMeta{Name: "app", Labels: map[string]string{"zarf-agent": "patched"}},
+				ImagePullSecrets: []corev1.LocalObjectReference{{Name: "other-registry"}},
+			}),
+			patch: []operations.PatchOperation{
+				operations.ReplacePatchOperation("/imagePullSecrets", []corev1.LocalObjectReference{{Name: "other-registry"}, {Name: config.ZarfImagePullSecretName}}),
+				operations.ReplacePatchOperation("/metadata/labels", map[string]string{"zarf-agent": "patched"}),
+			},
+			code: http.StatusOK,
+		},
+		{
+			name: "patched service account with the secret is left alone",
+			admissionReq: createServiceAccountAdmissionRequest(t, v1.Update, &corev1.ServiceAccount{
+				ObjectMeta:       metav1.ObjectMeta{Name: "app", Labels: map[string]string{"zarf-agent": "patched"}},
+				ImagePullSecrets: []corev1.LocalObjectReference{{Name: config.ZarfImagePullSecretName}},
+			}),
+			patch: nil,
+			code:  http.StatusOK,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			rr := sendAdmissionRequest(t, tt.admissionReq, handler)
+			verifyAdmission(t, rr, tt)
+		})
+	}
+}
+
+func TestServiceAccountMutationWebhookOtherModes(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+
+	s := &state.State{
+		RegistryInfo:             state.RegistryInfo{Address: "127.0.0.1:31999"},
+		AgentImagePullSecretMode: state.ImagePullSecretModeMerge,
+	}
+	c := createTestClientWithZarfState(ctx, t, s)
+	handler := admission.NewHandler().Serve(ctx, NewServiceAccountMutationHook(c, state.MutationPolicyAll, Config{}))
+
+	tt := admissionTest{
+		name: "service accounts are not mutated outside of serviceaccount mode",
+		admissionReq: createServiceAccountAdmissionRequest(t, v1.Create, &corev1.ServiceAccount{
+			ObjectMeta: metav1.ObjectMeta{Name: "default"},
+		}),
+		patch: nil,
+		code:  http.StatusOK,
+	}
+	rr := sendAdmissionRequest(t, tt.admissionReq, handler)
+	verifyAdmission(t, rr, tt)
+}
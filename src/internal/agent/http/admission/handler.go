@@ -12,8 +12,10 @@ import (
 	"fmt"
 	"io"
 	"net/http"
+	"time"
 
 	"github.com/zarf-dev/zarf/src/config/lang"
+	"github.com/zarf-dev/zarf/src/internal/agent/metrics"
 	"github.com/zarf-dev/zarf/src/internal/agent/operations"
 	"github.com/zarf-dev/zarf/src/pkg/logger"
 	corev1 "k8s.io/api/admission/v1"
@@ -67,12 +69,15 @@ func (h *Handler) Serve(ctx context.Context, hook operations.Hook) http.HandlerF
 			return
 		}
 
+		start := time.Now()
 		result, err := hook.Execute(logger.WithContext(r.Context(), l), review.Request)
+		metrics.AdmissionDuration.WithLabelValues(hook.Name).Observe(time.Since(start).Seconds())
 		admissionMeta := metav1.TypeMeta{
 			APIVersion: corev1.SchemeGroupVersion.String(),
 			Kind:       "AdmissionReview",
 		}
 		if err != nil {
+			metrics.AdmissionErrors.WithLabelValues(hook.Name).Inc()
 			l.Error("unable to bind the webhook handler", "error", err.Error())
 			admissionResponse := corev1.AdmissionReview{
 				TypeMeta: admissionMeta,
@@ -95,9 +100,10 @@ func (h *Handler) Serve(ctx context.Context, hook operations.Hook) http.HandlerF
 		admissionResponse := corev1.AdmissionReview{
 			TypeMeta: admissionMeta,
 			Response: &corev1.AdmissionResponse{
-				UID:     review.Request.UID,
-				Allowed: result.Allowed,
-				Result:  &metav1.Status{Message: result.Msg},
+				UID:      review.Request.UID,
+				Allowed:  result.Allowed,
+				Result:   &metav1.Status{Message: result.Msg},
+				Warnings: result.Warnings,
 			},
 		}
 
@@ -0,0 +1,59 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2021-Present The Zarf Authors
+
+// Package metrics defines the Prometheus metrics exposed by the Zarf agent on /metrics.
+package metrics
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+const (
+	namespace = "zarf"
+	subsystem = "agent"
+)
+
+// AuditedMutations counts the mutations that were computed but not applied under the audit mutation policy.
+var AuditedMutations = prometheus.NewCounterVec(prometheus.CounterOpts{
+	Namespace: namespace,
+	Subsystem: subsystem,
+	Name:      "audited_mutations_total",
+	Help:      "Number of resources the agent would have mutated under the audit mutation policy.",
+}, []string{"hook", "namespace"})
+
+// CacheHits counts lookups served from one of the agent caches.
+var CacheHits = prometheus.NewCounterVec(prometheus.CounterOpts{
+	Namespace: namespace,
+	Subsystem: subsystem,
+	Name:      "cache_hits_total",
+	Help:      "Number of lookups served from an agent cache.",
+}, []string{"cache"})
+
+// CacheMisses counts lookups that were not found in one of the agent caches and went to the API server or registry.
+var CacheMisses = prometheus.NewCounterVec(prometheus.CounterOpts{
+	Namespace: namespace,
+	Subsystem: subsystem,
+	Name:      "cache_misses_total",
+	Help:      "Number of lookups that missed an agent cache.",
+}, []string{"cache"})
+
+// AdmissionDuration observes how long each hook takes to handle an admission request.
+var AdmissionDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+	Namespace: namespace,
+	Subsystem: subsystem,
+	Name:      "admission_duration_seconds",
+	Help:      "Time taken by an agent hook to handle an admission request.",
+	Buckets:   []float64{0.005, 0.01, 0.025, 0.05, 0.1, 0.25, 0.5, 1, 2.5, 5, 10},
+}, []string{"hook"})
+
+// AdmissionErrors counts the admission requests a hook failed to handle.
+var AdmissionErrors = prometheus.NewCounterVec(prometheus.CounterOpts{
+	Namespace: namespace,
+	Subsystem: subsystem,
+	Name:      "admission_errors_total",
+	Help:      "Number of admission requests an agent hook failed to handle.",
+}, []string{"hook"})
+
+func init() {
+	prometheus.MustRegister(AuditedMutations, CacheHits, CacheMisses, AdmissionDuration, AdmissionErrors)
+}
@@ -17,6 +17,7 @@ type Result struct {
 	Allowed  bool
 	Msg      string
 	PatchOps []PatchOperation
+	Warnings []string
 }
 
 // AdmitFunc defines how to process an admission request.
@@ -24,6 +25,8 @@ type AdmitFunc func(ctx context.Context, request *admission.AdmissionRequest) (*
 
 // Hook represents the set of functions for each operation in an admission webhook.
 type Hook struct {
+	// Name identifies the hook in logs and metrics.
+	Name    string
 	Create  AdmitFunc
 	Delete  AdmitFunc
 	Update  AdmitFunc
@@ -12,10 +12,14 @@ import (
 
 // PolicyFromEnv reads ZARF_AGENT_MUTATION_POLICY from the environment
 func PolicyFromEnv() state.MutationPolicy {
-	if os.Getenv("ZARF_AGENT_MUTATION_POLICY") == string(state.MutationPolicyLabeled) {
+	switch state.MutationPolicy(os.Getenv("ZARF_AGENT_MUTATION_POLICY")) {
+	case state.MutationPolicyLabeled:
 		return state.MutationPolicyLabeled
+	case state.MutationPolicyAudit:
+		return state.MutationPolicyAudit
+	default:
+		return state.MutationPolicyAll
 	}
-	return state.MutationPolicyAll
 }
 
 // ShouldMutate reports whether the agent should mutate a resource, prioritizing resource labels
@@ -30,3 +34,11 @@ func ShouldMutate(resourceLabels, nsLabels map[string]string, mode state.Mutatio
 	}
 	return mode == state.MutationPolicyAll
 }
+
+// ShouldAudit reports whether the agent should report, but not apply, the mutation of a resource.
+// Under the audit policy these are the resources that the all policy would mutate but the labels do not opt in.
+func ShouldAudit(resourceLabels, nsLabels map[string]string, mode state.MutationPolicy) bool {
+	return mode == state.MutationPolicyAudit &&
+		!ShouldMutate(resourceLabels, nsLabels, mode) &&
+		ShouldMutate(resourceLabels, nsLabels, state.MutationPolicyAll)
+}
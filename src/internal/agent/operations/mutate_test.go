@@ -48,3 +48,30 @@ func TestShouldMutate(t *testing.T) {
 		})
 	}
 }
+
+func TestShouldAudit(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name           string
+		resourceLabels map[string]string
+		nsLabels       map[string]string
+		mode           state.MutationPolicy
+		wantMutate     bool
+		wantAudit      bool
+	}{
+		{name: "audit/no labels", mode: state.MutationPolicyAudit, wantAudit: true},
+		{name: "audit/resource mutate", resourceLabels: map[string]string{"zarf.dev/agent": "mutate"}, mode: state.MutationPolicyAudit, wantMutate: true},
+		{name: "audit/namespace mutate", nsLabels: map[string]string{"zarf.dev/agent": "mutate"}, mode: state.MutationPolicyAudit, wantMutate: true},
+		{name: "audit/resource ignore", resourceLabels: map[string]string{"zarf.dev/agent": "ignore"}, mode: state.MutationPolicyAudit},
+		{name: "audit/namespace skip", nsLabels: map[string]string{"zarf.dev/agent": "skip"}, mode: state.MutationPolicyAudit},
+		{name: "all/no labels", mode: state.MutationPolicyAll, wantMutate: true},
+		{name: "labeled/no labels", mode: state.MutationPolicyLabeled},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			assert.Equal(t, tt.wantMutate, operations.ShouldMutate(tt.resourceLabels, tt.nsLabels, tt.mode))
+			assert.Equal(t, tt.wantAudit, operations.ShouldAudit(tt.resourceLabels, tt.nsLabels, tt.mode))
+		})
+	}
+}
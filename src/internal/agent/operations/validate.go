@@ -0,0 +1,26 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2021-Present The Zarf Authors
+
+package operations
+
+import (
+	"os"
+)
+
+// ValidationAction controls how the agent responds to resources that reference sources outside of Zarf.
+type ValidationAction string
+
+const (
+	// ValidationActionWarn admits the resource and returns admission warnings for each violation.
+	ValidationActionWarn ValidationAction = "warn"
+	// ValidationActionDeny rejects the resource.
+	ValidationActionDeny ValidationAction = "deny"
+)
+
+// ValidationActionFromEnv reads ZARF_AGENT_IMAGE_VALIDATION from the environment
+func ValidationActionFromEnv() ValidationAction {
+	if os.Getenv("ZARF_AGENT_IMAGE_VALIDATION") == string(ValidationActionDeny) {
+		return ValidationActionDeny
+	}
+	return ValidationActionWarn
+}
@@ -0,0 +1,431 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2021-Present The Zarf Authors
+
+package agent
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/fs"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"strings"
+
+	jsonpatch "gopkg.in/evanphx/json-patch.v4"
+	"helm.sh/helm/v4/pkg/chart/common"
+	chartutil "helm.sh/helm/v4/pkg/chart/common/util"
+	"helm.sh/helm/v4/pkg/chart/loader/archive"
+	"helm.sh/helm/v4/pkg/chart/v2/loader"
+	"helm.sh/helm/v4/pkg/engine"
+	admissionv1 "k8s.io/api/admission/v1"
+	admissionregistrationv1 "k8s.io/api/admissionregistration/v1"
+	corev1 "k8s.io/api/core/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/fake"
+	"sigs.k8s.io/yaml"
+
+	zarfagent "github.com/zarf-dev/zarf/packages/zarf-agent"
+	"github.com/zarf-dev/zarf/src/internal/agent/hooks"
+	"github.com/zarf-dev/zarf/src/internal/agent/operations"
+	"github.com/zarf-dev/zarf/src/pkg/cluster"
+	"github.com/zarf-dev/zarf/src/pkg/pki"
+	"github.com/zarf-dev/zarf/src/pkg/state"
+)
+
+// ReplayOptions configure the offline agent used to replay admission requests.
+type ReplayOptions struct {
+	// State is served to the hooks in place of the Zarf state stored in a cluster.
+	State *state.State
+	// MutationPolicy is the agent mutation policy, empty means all.
+	MutationPolicy state.MutationPolicy
+	// ImageValidation is the action of the pod validation webhook, empty disables it.
+	ImageValidation operations.ValidationAction
+	// Rules are the mutation rules served by the generic hook.
+	Rules []hooks.MutationRule
+	// Namespaces are created before any request is replayed so that their labels and annotations apply.
+	// The namespaces of replayed requests that are not given are created without labels.
+	Namespaces []*corev1.Namespace
+	// OCIMediaType is reported as the config media type of every OCI artifact instead of fetching it from the registry.
+	OCIMediaType string
+	// MutationExclusions are the GitOps tools, argocd or flux, whose resources the agent chart does not send to the agent.
+	MutationExclusions []string
+}
+
+// ReplayResult is the response of the agent to a replayed admission request.
+type ReplayResult struct {
+	// Webhooks are the paths of the webhooks the request was sent to, empty if the agent does not handle the resource.
+	Webhooks []string `json:"webhooks"`
+	Allowed  bool     `json:"allowed"`
+	Message  string   `json:"message,omitempty"`
+	Warnings []string `json:"warnings,omitempty"`
+	// Patch is the JSON patch returned by the mutating webhook.
+	Patch json.RawMessage `json:"patch,omitempty"`
+	// Object is the resource with the patch applied.
+	Object json.RawMessage `json:"-"`
+}
+
+// Replayer sends admission requests through the agent's webhooks backed by an in-memory cluster.
+type Replayer struct {
+	cluster    *cluster.Cluster
+	mux        *http.ServeMux
+	mutating   []webhook
+	validating []webhook
+}
+
+// NewReplayer creates an offline agent that serves the given state.
+func NewReplayer(ctx context.Context, opts ReplayOptions) (*Replayer, error) {
+	if opts.State == nil {
+		return nil, fmt.Errorf("a Zarf state is required to replay admission requests")
+	}
+	c := &cluster.Cluster{Clientset: fake.NewClientset()}
+	if err := c.SaveState(ctx, opts.State); err != nil {
+		return nil, err
+	}
+	if opts.State.RegistryInfo.ShouldUseMTLS() {
+		// The hooks read the client certificate before looking up OCI artifacts, which are stubbed, so any certificate will do.
+		_, clientPKI, err := pki.GenerateMTLSCerts(
+			state.ZarfRegistryMTLSCASubject,
+			state.ZarfRegistryMTLSServerHosts,
+			state.ZarfRegistryMTLSServerCommonName,
+			state.ZarfRegistryMTLSClientCommonName,
+		)
+		if err != nil {
+			return nil, err
+		}
+		secret := &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Name: state.RegistryClientTLSSecret, Namespace: state.ZarfNamespaceName},
+			Data:       state.RegistryCertSecretData(clientPKI),
+		}
+		if _, err := c.Clientset.CoreV1().Secrets(state.ZarfNamespaceName).Create(ctx, secret, metav1.CreateOptions{}); err != nil {
+			return nil, err
+		}
+	}
+	for _, ns := range opts.Namespaces {
+		if _, err := c.Clientset.CoreV1().Namespaces().Create(ctx, ns, metav1.CreateOptions{}); err != nil {
+			return nil, fmt.Errorf("unable to create namespace %s: %w", ns.Name, err)
+		}
+	}
+
+	mode := opts.MutationPolicy
+	if mode == "" {
+		mode = state.MutationPolicyAll
+	}
+	validation := opts.ImageValidation
+	if validation == "" {
+		validation = operations.ValidationActionWarn
+	}
+	mutating, validating, err := renderWebhooks(opts, mode)
+	if err != nil {
+		return nil, fmt.Errorf("unable to render the agent webhooks: %w", err)
+	}
+	cfg := hooks.Config{
+		ConfigMediaType: func(context.Context, state.RegistryInfo, http.RoundTripper, string) (string, error) {
+			return opts.OCIMediaType, nil
+		},
+	}
+	return &Replayer{
+		cluster:    c,
+		mux:        newWebhookMux(ctx, c, mode, validation, opts.Rules, cfg),
+		mutating:   mutating,
+		validating: validating,
+	}, nil
+}
+
+// NewReplayRequest creates the admission request the API server would send for the given resource.
+// Resources without a namespace are placed in the given namespace.
+func NewReplayRequest(obj *unstructured.Unstructured, operation admissionv1.Operation, namespace string) (*admissionv1.AdmissionRequest, error) {
+	if obj.GetNamespace() != "" {
+		namespace = obj.GetNamespace()
+	}
+	raw, err := obj.MarshalJSON()
+	if err != nil {
+		return nil, err
+	}
+	gvk := obj.GroupVersionKind()
+	gvr, _ := meta.UnsafeGuessKindToResource(gvk)
+	req := &admissionv1.AdmissionRequest{
+		Kind:      metav1.GroupVersionKind{Group: gvk.Group, Version: gvk.Version, Kind: gvk.Kind},
+		Resource:  metav1.GroupVersionResource{Group: gvr.Group, Version: gvr.Version, Resource: gvr.Resource},
+		Name:      obj.GetName(),
+		Namespace: namespace,
+		Operation: operation,
+		Object:    runtime.RawExtension{Raw: raw},
+	}
+	if operation == admissionv1.Update {
+		req.OldObject = runtime.RawExtension{Raw: raw}
+	}
+	return req, nil
+}
+
+// Replay sends the request to the webhooks the agent chart registers for it, in order, the same way the API server
+// would: every mutating webhook receives the object patched by the ones before it and the validating webhooks receive
+// the mutated object.
+func (r *Replayer) Replay(ctx context.Context, req *admissionv1.AdmissionRequest) (ReplayResult, error) {
+	result := ReplayResult{Webhooks: []string{}, Allowed: true, Object: req.Object.Raw}
+	namespaceLabels := labels.Set{}
+	if req.Namespace != "" {
+		ns, err := r.cluster.Clientset.CoreV1().Namespaces().Get(ctx, req.Namespace, metav1.GetOptions{})
+		if kerrors.IsNotFound(err) {
+			ns, err = r.cluster.Clientset.CoreV1().Namespaces().Create(ctx, &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: req.Namespace}}, metav1.CreateOptions{})
+		}
+		if err != nil {
+			return ReplayResult{}, err
+		}
+		namespaceLabels = labels.Merge(ns.Labels, labels.Set{corev1.LabelMetadataName: ns.Name})
+	}
+
+	var patch []json.RawMessage
+	for _, wh := range r.mutating {
+		matches, err := wh.matches(req, namespaceLabels)
+		if err != nil {
+			return ReplayResult{}, err
+		}
+		if !matches {
+			continue
+		}
+		path := wh.ClientConfig.Service.Path
+		result.Webhooks = append(result.Webhooks, path)
+		mutatingReq := req.DeepCopy()
+		mutatingReq.Object = runtime.RawExtension{Raw: result.Object}
+		resp, err := r.send(ctx, path, mutatingReq)
+		if err != nil {
+			return ReplayResult{}, err
+		}
+		result.Warnings = append(result.Warnings, resp.Warnings...)
+		if !resp.Allowed {
+			result.Allowed = false
+			if resp.Result != nil {
+				result.Message = resp.Result.Message
+			}
+			return result, nil
+		}
+		if len(resp.Patch) == 0 {
+			continue
+		}
+		decoded, err := jsonpatch.DecodePatch(resp.Patch)
+		if err != nil {
+			return ReplayResult{}, fmt.Errorf("webhook %s returned an invalid patch: %w", path, err)
+		}
+		// The API server applies patches the same way, replacing fields that are not set yet
+		mutated, err := decoded.Apply(result.Object)
+		if err != nil {
+			return ReplayResult{}, fmt.Errorf("unable to apply the patch from webhook %s: %w", path, err)
+		}
+		var ops []json.RawMessage
+		if err := json.Unmarshal(resp.Patch, &ops); err != nil {
+			return ReplayResult{}, fmt.Errorf("webhook %s returned an invalid patch: %w", path, err)
+		}
+		patch = append(patch, ops...)
+		result.Object = mutated
+	}
+	if len(patch) > 0 {
+		b, err := json.Marshal(patch)
+		if err != nil {
+			return ReplayResult{}, err
+		}
+		result.Patch = b
+	}
+
+	validationReq := req.DeepCopy()
+	validationReq.Object = runtime.RawExtension{Raw: result.Object}
+	for _, wh := range r.validating {
+		matches, err := wh.matches(validationReq, namespaceLabels)
+		if err != nil {
+			return ReplayResult{}, err
+		}
+		if !matches {
+			continue
+		}
+		path := wh.ClientConfig.Service.Path
+		result.Webhooks = append(result.Webhooks, path)
+		resp, err := r.send(ctx, path, validationReq)
+		if err != nil {
+			return ReplayResult{}, err
+		}
+		result.Warnings = append(result.Warnings, resp.Warnings...)
+		if !resp.Allowed {
+			result.Allowed = false
+			if resp.Result != nil {
+				result.Message = resp.Result.Message
+			}
+			return result, nil
+		}
+	}
+	return result, nil
+}
+
+// send posts the request to the webhook path the same way the API server would.
+func (r *Replayer) send(ctx context.Context, path string, req *admissionv1.AdmissionRequest) (*admissionv1.AdmissionResponse, error) {
+	b, err := json.Marshal(admissionv1.AdmissionReview{
+		TypeMeta: metav1.TypeMeta{APIVersion: admissionv1.SchemeGroupVersion.String(), Kind: "AdmissionReview"},
+		Request:  req,
+	})
+	if err != nil {
+		return nil, err
+	}
+	httpReq := httptest.NewRequestWithContext(ctx, http.MethodPost, path, bytes.NewReader(b))
+	httpReq.Header.Set("Content-Type", "application/json")
+	rr := httptest.NewRecorder()
+	r.mux.ServeHTTP(rr, httpReq)
+
+	var review admissionv1.AdmissionReview
+	if err := json.Unmarshal(rr.Body.Bytes(), &review); err != nil || review.Response == nil {
+		return nil, fmt.Errorf("webhook %s responded with %d: %s", path, rr.Code, strings.TrimSpace(rr.Body.String()))
+	}
+	return review.Response, nil
+}
+
+// webhook is the part of a webhook in the agent chart that decides which requests are sent to it.
+type webhook struct {
+	Name              string                                       `json:"name"`
+	NamespaceSelector *metav1.LabelSelector                        `json:"namespaceSelector,omitempty"`
+	ObjectSelector    *metav1.LabelSelector                        `json:"objectSelector,omitempty"`
+	Rules             []admissionregistrationv1.RuleWithOperations `json:"rules"`
+	ClientConfig      struct {
+		Service struct {
+			Path string `json:"path"`
+		} `json:"service"`
+	} `json:"clientConfig"`
+}
+
+// renderWebhooks renders the webhooks of the agent chart with the values Zarf deploys it with. Only the fields that
+// route requests are decoded as the caBundle is a placeholder until the chart is deployed.
+func renderWebhooks(opts ReplayOptions, mode state.MutationPolicy) ([]webhook, []webhook, error) {
+	files := []*archive.BufferedFile{}
+	err := fs.WalkDir(zarfagent.Chart, "chart", func(path string, d fs.DirEntry, err error) error {
+		if err != nil || d.IsDir() {
+			return err
+		}
+		b, err := zarfagent.Chart.ReadFile(path)
+		if err != nil {
+			return err
+		}
+		files = append(files, &archive.BufferedFile{Name: strings.TrimPrefix(path, "chart/"), Data: b})
+		return nil
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+	agentChart, err := loader.LoadFiles(files)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	// Values are round tripped through JSON so that the templates see the same types as values read from YAML
+	imageValidation := "disabled"
+	if opts.ImageValidation != "" {
+		imageValidation = string(opts.ImageValidation)
+	}
+	b, err := json.Marshal(map[string]any{
+		"mutationPolicy":      mode,
+		"imagePullSecretMode": opts.State.AgentImagePullSecretMode,
+		"mutationExclusions":  append([]string{}, opts.MutationExclusions...),
+		"mutationRules":       append([]hooks.MutationRule{}, opts.Rules...),
+		"imageValidation":     map[string]any{"action": imageValidation, "exemptNamespaces": []string{}},
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+	values := map[string]any{}
+	if err := json.Unmarshal(b, &values); err != nil {
+		return nil, nil, err
+	}
+	renderValues, err := chartutil.ToRenderValues(agentChart, values,
+		common.ReleaseOptions{Name: "zarf-agent", Namespace: state.ZarfNamespaceName}, common.DefaultCapabilities)
+	if err != nil {
+		return nil, nil, err
+	}
+	rendered, err := engine.Render(agentChart, renderValues)
+	if err != nil {
+		return nil, nil, err
+	}
+	decode := func(template string) ([]webhook, error) {
+		var cfg struct {
+			Webhooks []webhook `json:"webhooks"`
+		}
+		if err := yaml.Unmarshal([]byte(rendered[agentChart.Name()+"/templates/"+template]), &cfg); err != nil {
+			return nil, fmt.Errorf("unable to read the webhooks in %s: %w", template, err)
+		}
+		return cfg.Webhooks, nil
+	}
+	mutating, err := decode("webhook.yaml")
+	if err != nil {
+		return nil, nil, err
+	}
+	validating, err := decode("validating-webhook.yaml")
+	if err != nil {
+		return nil, nil, err
+	}
+	return mutating, validating, nil
+}
+
+// matches reports whether the API server would send the request to the webhook.
+func (w webhook) matches(req *admissionv1.AdmissionRequest, namespaceLabels labels.Set) (bool, error) {
+	if !slices.ContainsFunc(w.Rules, func(rule admissionregistrationv1.RuleWithOperations) bool { return ruleMatches(rule, req) }) {
+		return false, nil
+	}
+	// The namespace selector does not apply to cluster scoped resources
+	if req.Namespace != "" {
+		matches, err := selectorMatches(w.NamespaceSelector, namespaceLabels)
+		if err != nil || !matches {
+			return false, err
+		}
+	}
+	// The object selector matches when either the object or the old object matches
+	for _, raw := range [][]byte{req.Object.Raw, req.OldObject.Raw} {
+		if len(raw) == 0 {
+			continue
+		}
+		var obj metav1.PartialObjectMetadata
+		if err := json.Unmarshal(raw, &obj); err != nil {
+			return false, err
+		}
+		matches, err := selectorMatches(w.ObjectSelector, obj.Labels)
+		if err != nil || matches {
+			return matches, err
+		}
+	}
+	return false, nil
+}
+
+func selectorMatches(selector *metav1.LabelSelector, set labels.Set) (bool, error) {
+	if selector == nil {
+		return true, nil
+	}
+	s, err := metav1.LabelSelectorAsSelector(selector)
+	if err != nil {
+		return false, err
+	}
+	return s.Matches(set), nil
+}
+
+func ruleMatches(rule admissionregistrationv1.RuleWithOperations, req *admissionv1.AdmissionRequest) bool {
+	matchesAny := func(values []string, value string) bool {
+		return slices.Contains(values, "*") || slices.Contains(values, value)
+	}
+	operations := make([]string, 0, len(rule.Operations))
+	for _, op := range rule.Operations {
+		operations = append(operations, string(op))
+	}
+	if !matchesAny(operations, string(req.Operation)) || !matchesAny(rule.APIGroups, req.Resource.Group) || !matchesAny(rule.APIVersions, req.Resource.Version) {
+		return false
+	}
+	return slices.ContainsFunc(rule.Resources, func(resource string) bool {
+		name, subresource, _ := strings.Cut(resource, "/")
+		if name != "*" && name != req.Resource.Resource {
+			return false
+		}
+		if subresource == "" {
+			return req.SubResource == ""
+		}
+		return subresource == "*" || subresource == req.SubResource
+	})
+}
@@ -0,0 +1,175 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2021-Present The Zarf Authors
+
+package agent
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	admissionv1 "k8s.io/api/admission/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+
+	"github.com/zarf-dev/zarf/src/internal/agent/hooks"
+	"github.com/zarf-dev/zarf/src/internal/agent/operations"
+	"github.com/zarf-dev/zarf/src/pkg/state"
+	"github.com/zarf-dev/zarf/src/test/testutil"
+)
+
+func TestReplay(t *testing.T) {
+	ctx := testutil.TestContext(t)
+
+	s := &state.State{RegistryInfo: state.RegistryInfo{Address: "127.0.0.1:31999"}}
+	replayer, err := NewReplayer(ctx, ReplayOptions{
+		State:           s,
+		ImageValidation: operations.ValidationActionDeny,
+		Rules: []hooks.MutationRule{{
+			Group:    "tekton.dev",
+			Kind:     "Task",
+			Resource: "tasks",
+			Fields:   []hooks.FieldMutation{{Path: "spec.steps[*].image", Transform: hooks.TransformImage}},
+		}},
+		Namespaces: []*corev1.Namespace{{
+			ObjectMeta: metav1.ObjectMeta{Name: "ignored", Labels: map[string]string{"zarf.dev/agent": "ignore"}},
+		}},
+		MutationExclusions: []string{"flux"},
+	})
+	require.NoError(t, err)
+
+	pod := func(namespace string) *unstructured.Unstructured {
+		return &unstructured.Unstructured{Object: map[string]any{
+			"apiVersion": "v1",
+			"kind":       "Pod",
+			"metadata":   map[string]any{"name": "nginx", "namespace": namespace},
+			"spec": map[string]any{
+				"containers": []any{map[string]any{"name": "nginx", "image": "nginx"}},
+			},
+		}}
+	}
+
+	tests := []struct {
+		name             string
+		obj              *unstructured.Unstructured
+		expectedWebhooks []string
+		expectedAllowed  bool
+		expectedImage    string
+	}{
+		{
+			name:             "pods are mutated and validated",
+			obj:              pod(""),
+			expectedWebhooks: []string{"/mutate/pod", "/validate/pod"},
+			expectedAllowed:  true,
+			expectedImage:    "127.0.0.1:31999/library/nginx:latest-zarf-3793515731",
+		},
+		{
+			name:             "pods in ignored namespaces are denied by validation",
+			obj:              pod("ignored"),
+			expectedWebhooks: []string{"/validate/pod"},
+			expectedAllowed:  false,
+			expectedImage:    "nginx",
+		},
+		{
+			name: "ignored pods are denied by validation",
+			obj: func() *unstructured.Unstructured {
+				obj := pod("")
+				obj.SetLabels(map[string]string{"zarf.dev/agent": "skip"})
+				return obj
+			}(),
+			expectedWebhooks: []string{"/validate/pod"},
+			expectedAllowed:  false,
+			expectedImage:    "nginx",
+		},
+		{
+			name:             "kube-system is not sent to the webhooks",
+			obj:              pod("kube-system"),
+			expectedWebhooks: []string{},
+			expectedAllowed:  true,
+			expectedImage:    "nginx",
+		},
+		{
+			name: "service accounts are not sent to the agent outside of the serviceaccount image pull secret mode",
+			obj: &unstructured.Unstructured{Object: map[string]any{
+				"apiVersion": "v1",
+				"kind":       "ServiceAccount",
+				"metadata":   map[string]any{"name": "default"},
+			}},
+			expectedWebhooks: []string{},
+			expectedAllowed:  true,
+		},
+		{
+			name: "resources of excluded GitOps tools are not sent to the agent",
+			obj: &unstructured.Unstructured{Object: map[string]any{
+				"apiVersion": "kustomize.toolkit.fluxcd.io/v1",
+				"kind":       "Kustomization",
+				"metadata":   map[string]any{"name": "podinfo"},
+			}},
+			expectedWebhooks: []string{},
+			expectedAllowed:  true,
+		},
+		{
+			name: "resources matching a mutation rule use the generic hook",
+			obj: &unstructured.Unstructured{Object: map[string]any{
+				"apiVersion": "tekton.dev/v1",
+				"kind":       "Task",
+				"metadata":   map[string]any{"name": "build"},
+				"spec": map[string]any{
+					"steps": []any{map[string]any{"name": "build", "image": "nginx"}},
+				},
+			}},
+			expectedWebhooks: []string{"/mutate/generic"},
+			expectedAllowed:  true,
+		},
+		{
+			name: "secrets that are not ArgoCD repositories are left alone",
+			obj: &unstructured.Unstructured{Object: map[string]any{
+				"apiVersion": "v1",
+				"kind":       "Secret",
+				"metadata":   map[string]any{"name": "credentials"},
+			}},
+			expectedWebhooks: []string{},
+			expectedAllowed:  true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req, err := NewReplayRequest(tt.obj, admissionv1.Create, "default")
+			require.NoError(t, err)
+			result, err := replayer.Replay(ctx, req)
+			require.NoError(t, err)
+			require.Equal(t, tt.expectedWebhooks, result.Webhooks)
+			require.Equal(t, tt.expectedAllowed, result.Allowed)
+			if tt.expectedImage == "" {
+				return
+			}
+			mutated := &corev1.Pod{}
+			require.NoError(t, json.Unmarshal(result.Object, mutated))
+			require.Equal(t, tt.expectedImage, mutated.Spec.Containers[0].Image)
+		})
+	}
+}
+
+func TestReplayServiceAccountMode(t *testing.T) {
+	ctx := testutil.TestContext(t)
+
+	s := &state.State{
+		RegistryInfo:             state.RegistryInfo{Address: "127.0.0.1:31999"},
+		AgentImagePullSecretMode: state.ImagePullSecretModeServiceAccount,
+	}
+	replayer, err := NewReplayer(ctx, ReplayOptions{State: s})
+	require.NoError(t, err)
+
+	obj := &unstructured.Unstructured{Object: map[string]any{
+		"apiVersion": "v1",
+		"kind":       "ServiceAccount",
+		"metadata":   map[string]any{"name": "default"},
+	}}
+	req, err := NewReplayRequest(obj, admissionv1.Create, "default")
+	require.NoError(t, err)
+	result, err := replayer.Replay(ctx, req)
+	require.NoError(t, err)
+	require.Equal(t, []string{"/mutate/serviceaccount"}, result.Webhooks)
+	require.True(t, result.Allowed)
+}
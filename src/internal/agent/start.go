@@ -14,12 +14,14 @@ import (
 	"github.com/prometheus/client_golang/prometheus/promhttp"
 	"golang.org/x/sync/errgroup"
 
+	"github.com/zarf-dev/zarf/src/internal/agent/cache"
 	"github.com/zarf-dev/zarf/src/internal/agent/hooks"
 	agentHttp "github.com/zarf-dev/zarf/src/internal/agent/http"
 	"github.com/zarf-dev/zarf/src/internal/agent/http/admission"
 	"github.com/zarf-dev/zarf/src/internal/agent/operations"
 	"github.com/zarf-dev/zarf/src/pkg/cluster"
 	"github.com/zarf-dev/zarf/src/pkg/logger"
+	"github.com/zarf-dev/zarf/src/pkg/state"
 )
 
 // Heavily influenced by https://github.com/douglasmakey/admissioncontroller and
@@ -30,34 +32,63 @@ const (
 	httpPort = "8443"
 	tlsCert  = "/etc/certs/tls.crt"
 	tlsKey   = "/etc/certs/tls.key"
+
+	mutationRules = "/etc/zarf-agent/mutation-rules.yaml"
 )
 
-// StartWebhook launches the Zarf agent mutating webhook in the cluster.
-func StartWebhook(ctx context.Context, cluster *cluster.Cluster) error {
-	// Routers
-	mode := operations.PolicyFromEnv()
+// StartWebhook launches the Zarf agent mutating and validating webhooks in the cluster.
+func StartWebhook(ctx context.Context, c *cluster.Cluster) error {
+	// Serve the state secret and namespace lookups made on every admission request from informer caches
+	cachedClientset, err := cache.NewClientset(ctx, c.Clientset)
+	if err != nil {
+		return err
+	}
+	cachedCluster := &cluster.Cluster{Clientset: cachedClientset, RestConfig: c.RestConfig, Watcher: c.Watcher}
+
+	rules, err := hooks.LoadMutationRules(mutationRules)
+	if err != nil {
+		return err
+	}
+
+	// Events are sent with the uncached clientset, the cache only serves reads
+	cfg := hooks.Config{Recorder: hooks.NewEventRecorder(ctx, c)}
+	mux := newWebhookMux(ctx, cachedCluster, operations.PolicyFromEnv(), operations.ValidationActionFromEnv(), rules, cfg)
+	return startServer(ctx, httpPort, mux)
+}
+
+// newWebhookMux routes the webhook paths registered in the agent chart to their hooks.
+func newWebhookMux(ctx context.Context, c *cluster.Cluster, mode state.MutationPolicy, validation operations.ValidationAction, rules []hooks.MutationRule, cfg hooks.Config) *http.ServeMux {
 	admissionHandler := admission.NewHandler()
-	podsMutation := hooks.NewPodMutationHook(cluster, mode)
-	fluxGitRepositoryMutation := hooks.NewGitRepositoryMutationHook(cluster, mode)
-	argocdApplicationMutation := hooks.NewApplicationMutationHook(cluster, mode)
-	argocdApplicationSetMutation := hooks.NewApplicationSetMutationHook(cluster, mode)
-	argocdAppProjectMutation := hooks.NewAppProjectMutationHook(cluster, mode)
-	argocdRepositoryMutation := hooks.NewRepositorySecretMutationHook(cluster, mode)
-	fluxHelmRepositoryMutation := hooks.NewHelmRepositoryMutationHook(cluster, mode)
-	fluxOCIRepositoryMutation := hooks.NewOCIRepositoryMutationHook(cluster, mode)
+	podsMutation := hooks.NewPodMutationHook(c, mode, cfg)
+	serviceAccountMutation := hooks.NewServiceAccountMutationHook(c, mode, cfg)
+	fluxGitRepositoryMutation := hooks.NewGitRepositoryMutationHook(c, mode, cfg)
+	argocdApplicationMutation := hooks.NewApplicationMutationHook(c, mode, cfg)
+	argocdApplicationSetMutation := hooks.NewApplicationSetMutationHook(c, mode, cfg)
+	argocdAppProjectMutation := hooks.NewAppProjectMutationHook(c, mode, cfg)
+	argocdRepositoryMutation := hooks.NewRepositorySecretMutationHook(c, mode, cfg)
+	fluxHelmRepositoryMutation := hooks.NewHelmRepositoryMutationHook(c, mode, cfg)
+	fluxOCIRepositoryMutation := hooks.NewOCIRepositoryMutationHook(c, mode, cfg)
+	fluxKustomizationMutation := hooks.NewKustomizationMutationHook(c, mode, cfg)
+	fluxHelmReleaseMutation := hooks.NewHelmReleaseMutationHook(c, mode, cfg)
+	genericMutation := hooks.NewGenericMutationHook(c, mode, rules, cfg)
+	podsValidation := hooks.NewPodValidationHook(c, validation)
 
 	// Routers
 	mux := http.NewServeMux()
 	mux.Handle("/mutate/pod", admissionHandler.Serve(ctx, podsMutation))
+	mux.Handle("/mutate/serviceaccount", admissionHandler.Serve(ctx, serviceAccountMutation))
 	mux.Handle("/mutate/flux-gitrepository", admissionHandler.Serve(ctx, fluxGitRepositoryMutation))
 	mux.Handle("/mutate/flux-helmrepository", admissionHandler.Serve(ctx, fluxHelmRepositoryMutation))
 	mux.Handle("/mutate/flux-ocirepository", admissionHandler.Serve(ctx, fluxOCIRepositoryMutation))
+	mux.Handle("/mutate/flux-kustomization", admissionHandler.Serve(ctx, fluxKustomizationMutation))
+	mux.Handle("/mutate/flux-helmrelease", admissionHandler.Serve(ctx, fluxHelmReleaseMutation))
 	mux.Handle("/mutate/argocd-application", admissionHandler.Serve(ctx, argocdApplicationMutation))
 	mux.Handle("/mutate/argocd-applicationset", admissionHandler.Serve(ctx, argocdApplicationSetMutation))
 	mux.Handle("/mutate/argocd-appproject", admissionHandler.Serve(ctx, argocdAppProjectMutation))
 	mux.Handle("/mutate/argocd-repository", admissionHandler.Serve(ctx, argocdRepositoryMutation))
-
-	return startServer(ctx, httpPort, mux)
+	mux.Handle("/mutate/generic", admissionHandler.Serve(ctx, genericMutation))
+	mux.Handle("/validate/pod", admissionHandler.Serve(ctx, podsValidation))
+	return mux
 }
 
 // StartHTTPProxy launches the zarf agent proxy in the cluster.
@@ -57,6 +57,8 @@ type BuildData struct {
 	Version                    string
 	Migrations                 []string
 	RegistryOverrides          map[string]string
+	ImageMirrors               map[string]string
+	VerifiedGitRefs            []VerifiedGitRef
 	Differential               bool
 	DifferentialPackageVersion string
 	Flavor                     string
@@ -71,6 +73,14 @@ type BuildData struct {
 	DifferentialMissing []string
 }
 
+// VerifiedGitRef records a git ref whose signature was verified on package create.
+type VerifiedGitRef struct {
+	Repo   string
+	Ref    string
+	Hash   string
+	Signer string
+}
+
 // VersionRequirement specifies a minimum Zarf version needed.
 type VersionRequirement struct {
 	Version string
@@ -93,10 +103,12 @@ type Component struct {
 	Service       string
 	Manifests     []Manifest
 	Charts        []Chart
+	MirrorCharts  bool
 	Files         []File
 	Images        []Image
 	ImageArchives []ImageArchive
 	Repositories  []Repository
+	Artifacts     []Artifact
 	StateAccess   []string
 	Actions       ComponentActions
 
@@ -252,8 +264,34 @@ type OCISource struct {
 
 // Repository defines a git repository.
 type Repository struct {
-	URL string
-	Ref *GitRef
+	URL        string
+	Ref        *GitRef
+	Submodules string
+	SkipLFS    bool
+	Verify     *RepositoryVerify
+}
+
+// SubmodulesRecursive mirrors the submodules of a repository and their own submodules.
+const SubmodulesRecursive = "recursive"
+
+// RepositoryVerify defines the signers allowed to sign the packaged refs of a git repository.
+type RepositoryVerify struct {
+	Keys       []string
+	Identities []SigstoreIdentity
+}
+
+// SigstoreIdentity is a Sigstore keyless signing identity.
+type SigstoreIdentity struct {
+	Subject string
+	Issuer  string
+}
+
+// Artifact defines a package from a language ecosystem.
+type Artifact struct {
+	Ecosystem string
+	Name      string
+	Version   string
+	URL       string
 }
 
 // File is the superset of file fields across API versions.
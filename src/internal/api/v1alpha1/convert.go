@@ -5,6 +5,7 @@
 package v1alpha1
 
 import (
+	"slices"
 	"strings"
 
 	"github.com/zarf-dev/zarf/src/api/v1alpha1"
@@ -43,6 +44,7 @@ func ConvertToGeneric(pkg v1alpha1.ZarfPackage) types.Package {
 			Version:                    pkg.Build.Version,
 			Migrations:                 pkg.Build.Migrations,
 			RegistryOverrides:          pkg.Build.RegistryOverrides,
+			ImageMirrors:               pkg.Build.ImageMirrors,
 			Differential:               pkg.Build.Differential,
 			DifferentialPackageVersion: pkg.Build.DifferentialPackageVersion,
 			Flavor:                     pkg.Build.Flavor,
@@ -60,6 +62,15 @@ func ConvertToGeneric(pkg v1alpha1.ZarfPackage) types.Package {
 		Constants:     constantsToGeneric(pkg.Constants),
 	}
 
+	for _, ref := range pkg.Build.VerifiedGitRefs {
+		g.Build.VerifiedGitRefs = append(g.Build.VerifiedGitRefs, types.VerifiedGitRef{
+			Repo:   ref.Repo,
+			Ref:    ref.Ref,
+			Hash:   ref.Hash,
+			Signer: ref.Signer,
+		})
+	}
+
 	for _, vr := range pkg.Build.VersionRequirements {
 		g.Build.VersionRequirements = append(g.Build.VersionRequirements, types.VersionRequirement{
 			Version: vr.Version,
@@ -85,8 +96,10 @@ func componentToGeneric(c v1alpha1.ZarfComponent) types.Component {
 		DataInjections:    dataInjectionsToGeneric(c.DataInjections),
 		HealthChecks:      healthChecksToGeneric(c.HealthChecks),
 		DeprecatedScripts: scriptsToGeneric(c.DeprecatedScripts),
-		Repositories:      reposToGeneric(c.Repos),
+		Repositories:      reposToGeneric(c.Repos, c.RecursiveSubmodules, c.SkipLFSRepos, c.VerifyRepos),
+		Artifacts:         artifactsToGeneric(c.Artifacts),
 		StateAccess:       stateAccessToGeneric(c.StateAccess),
+		MirrorCharts:      c.MirrorCharts,
 		Target: types.ComponentTarget{
 			OS:           c.Only.LocalOS,
 			Architecture: c.Only.Cluster.Architecture,
@@ -420,6 +433,7 @@ func buildFromGeneric(b types.BuildData) v1alpha1.ZarfBuildData {
 		Version:                    b.Version,
 		Migrations:                 b.Migrations,
 		RegistryOverrides:          b.RegistryOverrides,
+		ImageMirrors:               b.ImageMirrors,
 		Differential:               b.Differential,
 		DifferentialPackageVersion: b.DifferentialPackageVersion,
 		DifferentialMissing:        b.DifferentialMissing,
@@ -431,6 +445,15 @@ func buildFromGeneric(b types.BuildData) v1alpha1.ZarfBuildData {
 	// Preserve the apiVersion the package was originally read from across the conversion.
 	out.SetOriginalAPIVersion(b.OriginalAPIVersion)
 
+	for _, ref := range b.VerifiedGitRefs {
+		out.VerifiedGitRefs = append(out.VerifiedGitRefs, v1alpha1.ZarfVerifiedGitRef{
+			Repo:   ref.Repo,
+			Ref:    ref.Ref,
+			Hash:   ref.Hash,
+			Signer: ref.Signer,
+		})
+	}
+
 	for _, vr := range b.VersionRequirements {
 		out.VersionRequirements = append(out.VersionRequirements, v1alpha1.VersionRequirement{
 			Version: vr.Version,
@@ -465,16 +488,21 @@ func scriptsFromGeneric(s types.DeprecatedComponentScripts) v1alpha1.DeprecatedZ
 
 func componentFromGeneric(c types.Component) v1alpha1.ZarfComponent {
 	ac := v1alpha1.ZarfComponent{
-		Name:              c.Name,
-		Description:       c.Description,
-		Default:           c.Default,
-		Required:          requiredFromGeneric(c.Optional, c.Required),
-		DeprecatedGroup:   c.Group,
-		DataInjections:    dataInjectionsFromGeneric(c.DataInjections),
-		HealthChecks:      healthChecksFromGeneric(c.HealthChecks),
-		DeprecatedScripts: scriptsFromGeneric(c.DeprecatedScripts),
-		Repos:             reposFromGeneric(c.Repositories),
-		StateAccess:       stateAccessFromGeneric(c.StateAccess),
+		Name:                c.Name,
+		Description:         c.Description,
+		Default:             c.Default,
+		Required:            requiredFromGeneric(c.Optional, c.Required),
+		DeprecatedGroup:     c.Group,
+		DataInjections:      dataInjectionsFromGeneric(c.DataInjections),
+		HealthChecks:        healthChecksFromGeneric(c.HealthChecks),
+		DeprecatedScripts:   scriptsFromGeneric(c.DeprecatedScripts),
+		Repos:               reposFromGeneric(c.Repositories),
+		RecursiveSubmodules: recursiveSubmodulesFromGeneric(c.Repositories),
+		SkipLFSRepos:        skipLFSReposFromGeneric(c.Repositories),
+		VerifyRepos:         verifyReposFromGeneric(c.Repositories),
+		Artifacts:           artifactsFromGeneric(c.Artifacts),
+		StateAccess:         stateAccessFromGeneric(c.StateAccess),
+		MirrorCharts:        c.MirrorCharts,
 		Only: v1alpha1.ZarfComponentOnlyTarget{
 			LocalOS: c.Target.OS,
 			Cluster: v1alpha1.ZarfComponentOnlyCluster{
@@ -928,10 +956,25 @@ func healthChecksFromGeneric(in []types.NamespacedObjectKindReference) []v1alpha
 	return out
 }
 
-func reposToGeneric(repos []string) []types.Repository {
+func reposToGeneric(repos, recursiveSubmodules, skipLFSRepos []string, verifyRepos []v1alpha1.ZarfRepoVerify) []types.Repository {
 	var out []types.Repository
 	for _, url := range repos {
-		out = append(out, types.Repository{URL: url})
+		r := types.Repository{URL: url, SkipLFS: slices.Contains(skipLFSRepos, url)}
+		if slices.Contains(recursiveSubmodules, url) {
+			r.Submodules = types.SubmodulesRecursive
+		}
+		if i := slices.IndexFunc(verifyRepos, func(v v1alpha1.ZarfRepoVerify) bool { return v.Repo == url }); i >= 0 {
+			r.Verify = repoVerifyToGeneric(verifyRepos[i])
+		}
+		out = append(out, r)
+	}
+	return out
+}
+
+func repoVerifyToGeneric(v v1alpha1.ZarfRepoVerify) *types.RepositoryVerify {
+	out := &types.RepositoryVerify{Keys: v.Keys}
+	for _, id := range v.Identities {
+		out.Identities = append(out.Identities, types.SigstoreIdentity{Subject: id.Subject, Issuer: id.Issuer})
 	}
 	return out
 }
@@ -939,22 +982,61 @@ func reposToGeneric(repos []string) []types.Repository {
 func reposFromGeneric(repos []types.Repository) []string {
 	var out []string
 	for _, r := range repos {
-		url := r.URL
-		if r.Ref != nil {
-			if refStr := flattenGitRef(r.Ref); refStr != "" {
-				// Strip any existing @ref from the URL before appending, matching the
-				// split/join that transform.GitURLSplitRef + git.Clone perform at runtime.
-				if urlNoRef, _, err := transform.GitURLSplitRef(url); err == nil {
-					url = urlNoRef
-				}
-				url += "@" + refStr
-			}
+		out = append(out, repoFromGeneric(r))
+	}
+	return out
+}
+
+func recursiveSubmodulesFromGeneric(repos []types.Repository) []string {
+	var out []string
+	for _, r := range repos {
+		if r.Submodules == types.SubmodulesRecursive {
+			out = append(out, repoFromGeneric(r))
+		}
+	}
+	return out
+}
+
+func skipLFSReposFromGeneric(repos []types.Repository) []string {
+	var out []string
+	for _, r := range repos {
+		if r.SkipLFS {
+			out = append(out, repoFromGeneric(r))
 		}
-		out = append(out, url)
 	}
 	return out
 }
 
+func verifyReposFromGeneric(repos []types.Repository) []v1alpha1.ZarfRepoVerify {
+	var out []v1alpha1.ZarfRepoVerify
+	for _, r := range repos {
+		if r.Verify == nil {
+			continue
+		}
+		v := v1alpha1.ZarfRepoVerify{Repo: repoFromGeneric(r), Keys: r.Verify.Keys}
+		for _, id := range r.Verify.Identities {
+			v.Identities = append(v.Identities, v1alpha1.ZarfSigstoreIdentity{Subject: id.Subject, Issuer: id.Issuer})
+		}
+		out = append(out, v)
+	}
+	return out
+}
+
+func repoFromGeneric(r types.Repository) string {
+	url := r.URL
+	if r.Ref != nil {
+		if refStr := flattenGitRef(r.Ref); refStr != "" {
+			// Strip any existing @ref from the URL before appending, matching the
+			// split/join that transform.GitURLSplitRef + git.Clone perform at runtime.
+			if urlNoRef, _, err := transform.GitURLSplitRef(url); err == nil {
+				url = urlNoRef
+			}
+			url += "@" + refStr
+		}
+	}
+	return url
+}
+
 // flattenGitRef returns a ref string that, when passed through git.ParseRef at runtime,
 // produces the same plumbing.ReferenceName as the structured ref intended
 func flattenGitRef(ref *types.GitRef) string {
@@ -972,6 +1054,32 @@ func flattenGitRef(ref *types.GitRef) string {
 	return ""
 }
 
+func artifactsToGeneric(in []v1alpha1.ZarfArtifact) []types.Artifact {
+	var out []types.Artifact
+	for _, a := range in {
+		out = append(out, types.Artifact{
+			Ecosystem: string(a.Ecosystem),
+			Name:      a.Name,
+			Version:   a.Version,
+			URL:       a.URL,
+		})
+	}
+	return out
+}
+
+func artifactsFromGeneric(in []types.Artifact) []v1alpha1.ZarfArtifact {
+	var out []v1alpha1.ZarfArtifact
+	for _, a := range in {
+		out = append(out, v1alpha1.ZarfArtifact{
+			Ecosystem: v1alpha1.ArtifactEcosystem(a.Ecosystem),
+			Name:      a.Name,
+			Version:   a.Version,
+			URL:       a.URL,
+		})
+	}
+	return out
+}
+
 func stateAccessToGeneric(in []v1alpha1.StateAccessKey) []string {
 	var out []string
 	for _, s := range in {
@@ -6,6 +6,7 @@ package v1alpha1
 import (
 	"math/rand"
 	"reflect"
+	"slices"
 	"testing"
 
 	"github.com/stretchr/testify/require"
@@ -51,6 +52,8 @@ func TestConvertGenericRoundTripLossless(t *testing.T) {
 			Version:                    "v0.30.0",
 			Migrations:                 []string{"scripts-to-actions", "pluralize-set-variable"},
 			RegistryOverrides:          map[string]string{"reg": "override"},
+			ImageMirrors:               map[string]string{"reg/img:1.0.0": "mirror/img:1.0.0"},
+			VerifiedGitRefs:            []v1alpha1.ZarfVerifiedGitRef{{Repo: "https://github.com/example/repo", Ref: "refs/tags/v1.0.0", Hash: "0123456789abcdef", Signer: "gpg:ABCDEF"}},
 			Differential:               true,
 			DifferentialPackageVersion: "1.2.2",
 			DifferentialMissing:        []string{"comp-x"},
@@ -71,13 +74,24 @@ func TestConvertGenericRoundTripLossless(t *testing.T) {
 					Cluster: v1alpha1.ZarfComponentOnlyCluster{Architecture: "arm64", Distros: []string{"k3s"}},
 					Flavor:  "prod",
 				},
-				Import: v1alpha1.ZarfComponentImport{Name: "imp", Path: "path", URL: "oci://example.com/pkg"},
-				Repos:  []string{"https://github.com/example/repo"},
+				Import:              v1alpha1.ZarfComponentImport{Name: "imp", Path: "path", URL: "oci://example.com/pkg"},
+				Repos:               []string{"https://github.com/example/repo"},
+				RecursiveSubmodules: []string{"https://github.com/example/repo"},
+				SkipLFSRepos:        []string{"https://github.com/example/repo"},
+				VerifyRepos: []v1alpha1.ZarfRepoVerify{{
+					Repo:       "https://github.com/example/repo",
+					Keys:       []string{"maintainer.asc"},
+					Identities: []v1alpha1.ZarfSigstoreIdentity{{Subject: "dev@example.com", Issuer: "https://github.com/login/oauth"}},
+				}},
+				Artifacts: []v1alpha1.ZarfArtifact{
+					{Ecosystem: v1alpha1.ArtifactEcosystemNpm, Name: "@types/node", Version: "20.11.5"},
+				},
 				Images: []string{"nginx:latest"},
 				ImageArchives: []v1alpha1.ImageArchive{
 					{Path: "images.tar", Images: []string{"busybox:1.36"}},
 				},
-				StateAccess: []v1alpha1.StateAccessKey{v1alpha1.StateAccessRegistryCredentials},
+				StateAccess:  []v1alpha1.StateAccessKey{v1alpha1.StateAccessRegistryCredentials},
+				MirrorCharts: true,
 				Charts: []v1alpha1.ZarfChart{
 					{
 						Name:                 "chart",
@@ -163,6 +177,26 @@ func TestConvertGenericRoundTripFuzz(t *testing.T) {
 		pkg.APIVersion = v1alpha1.APIVersion
 		pkg.Kind = v1alpha1.ZarfPackageConfig
 		pkg.Build.SetOriginalAPIVersion(v1alpha1.APIVersion)
+		// recursiveSubmodules, skipLFSRepos and verifyRepos are carried on the repos they name, so they must match repos.
+		for j := range pkg.Components {
+			pkg.Components[j].RecursiveSubmodules = nil
+			if len(pkg.Components[j].Repos) > 0 && rng.Intn(2) == 0 {
+				pkg.Components[j].RecursiveSubmodules = slices.Clone(pkg.Components[j].Repos)
+			}
+			pkg.Components[j].SkipLFSRepos = nil
+			if len(pkg.Components[j].Repos) > 0 && rng.Intn(2) == 0 {
+				pkg.Components[j].SkipLFSRepos = slices.Clone(pkg.Components[j].Repos)
+			}
+			verifyRepos := pkg.Components[j].VerifyRepos
+			pkg.Components[j].VerifyRepos = nil
+			for k, repo := range pkg.Components[j].Repos {
+				if k < len(verifyRepos) {
+					verify := verifyRepos[k]
+					verify.Repo = repo
+					pkg.Components[j].VerifyRepos = append(pkg.Components[j].VerifyRepos, verify)
+				}
+			}
+		}
 
 		roundTripped := ConvertFromGeneric(ConvertToGeneric(pkg))
 		require.Equalf(t, pkg, roundTripped, "round-trip diverged on iteration %d", i)
@@ -7,6 +7,7 @@ package v1alpha1
 import (
 	"errors"
 	"fmt"
+	"slices"
 	"strings"
 
 	"github.com/zarf-dev/zarf/src/api/v1alpha1"
@@ -25,6 +26,7 @@ const (
 	PkgValidateErrConstant                = "invalid package constant: %w"
 	PkgValidateErrYOLONoOCI               = "OCI images not allowed in YOLO"
 	PkgValidateErrYOLONoGit               = "git repos not allowed in YOLO"
+	PkgValidateErrYOLONoArtifacts         = "artifacts not allowed in YOLO"
 	PkgValidateErrYOLONoArch              = "cluster architecture not allowed in YOLO"
 	PkgValidateErrYOLONoDistro            = "cluster distros not allowed in YOLO"
 	PkgValidateErrComponentNameNotUnique  = "component name %q is not unique"
@@ -49,6 +51,17 @@ const (
 	PkgValidateErrVariable                = "invalid package variable: %w"
 	PkgValidateErrNoComponents            = "package does not contain any compatible components"
 	PkgValidateErrActionTemplateOnCreate  = "templating is not supported in onCreate actions"
+	PkgValidateErrRecursiveSubmodules     = "recursiveSubmodules repo %q in component %q is not listed in repos"
+	PkgValidateErrSkipLFSRepo             = "skipLFSRepos repo %q in component %q is not listed in repos"
+	PkgValidateErrVerifyRepo              = "verifyRepos repo %q in component %q is not listed in repos"
+	PkgValidateErrVerifyRepoNotUnique     = "verifyRepos repo %q in component %q is listed more than once"
+	PkgValidateErrVerifyRepoNoSigners     = "verifyRepos repo %q in component %q must have at least one key or identity"
+	PkgValidateErrVerifyRepoIdentity      = "verifyRepos repo %q in component %q has an identity without a subject and issuer"
+	PkgValidateErrArtifact                = "invalid artifact definition: %w"
+	PkgValidateErrArtifactEcosystem       = "artifact %q has unsupported ecosystem %q"
+	PkgValidateErrArtifactVersion         = "artifact %q must include a version"
+	PkgValidateErrArtifactURL             = "%s artifact %q must include a url"
+	PkgValidateErrArtifactMavenName       = "maven artifact %q must be in the form groupId:artifactId"
 )
 
 // ValidatePackage runs all validation checks on the package.
@@ -76,6 +89,9 @@ func ValidatePackage(pkg v1alpha1.ZarfPackage) error {
 			if len(component.Repos) > 0 {
 				err = errors.Join(err, errors.New(PkgValidateErrYOLONoGit))
 			}
+			if len(component.Artifacts) > 0 {
+				err = errors.Join(err, errors.New(PkgValidateErrYOLONoArtifacts))
+			}
 			if component.Only.Cluster.Architecture != "" {
 				err = errors.Join(err, errors.New(PkgValidateErrYOLONoArch))
 			}
@@ -120,6 +136,40 @@ func ValidatePackage(pkg v1alpha1.ZarfPackage) error {
 				err = errors.Join(err, fmt.Errorf(PkgValidateErrManifest, manifestErr))
 			}
 		}
+		for _, repo := range component.RecursiveSubmodules {
+			if !slices.Contains(component.Repos, repo) {
+				err = errors.Join(err, fmt.Errorf(PkgValidateErrRecursiveSubmodules, repo, component.Name))
+			}
+		}
+		for _, repo := range component.SkipLFSRepos {
+			if !slices.Contains(component.Repos, repo) {
+				err = errors.Join(err, fmt.Errorf(PkgValidateErrSkipLFSRepo, repo, component.Name))
+			}
+		}
+		for _, artifact := range component.Artifacts {
+			if artifactErr := validateArtifact(artifact); artifactErr != nil {
+				err = errors.Join(err, fmt.Errorf(PkgValidateErrArtifact, artifactErr))
+			}
+		}
+		verifyRepos := map[string]bool{}
+		for _, verify := range component.VerifyRepos {
+			if !slices.Contains(component.Repos, verify.Repo) {
+				err = errors.Join(err, fmt.Errorf(PkgValidateErrVerifyRepo, verify.Repo, component.Name))
+			}
+			if verifyRepos[verify.Repo] {
+				err = errors.Join(err, fmt.Errorf(PkgValidateErrVerifyRepoNotUnique, verify.Repo, component.Name))
+			}
+			verifyRepos[verify.Repo] = true
+			if len(verify.Keys) == 0 && len(verify.Identities) == 0 {
+				err = errors.Join(err, fmt.Errorf(PkgValidateErrVerifyRepoNoSigners, verify.Repo, component.Name))
+			}
+			for _, id := range verify.Identities {
+				if id.Subject == "" || id.Issuer == "" {
+					err = errors.Join(err, fmt.Errorf(PkgValidateErrVerifyRepoIdentity, verify.Repo, component.Name))
+					break
+				}
+			}
+		}
 		if actionsErr := validateActions(component.Actions); actionsErr != nil {
 			err = errors.Join(err, fmt.Errorf("%q: %w", component.Name, actionsErr))
 		}
@@ -272,6 +322,31 @@ func validateReleaseName(chartName, releaseName string) error {
 }
 
 // validateChart runs all validation checks on a chart.
+func validateArtifact(artifact v1alpha1.ZarfArtifact) error {
+	var err error
+
+	switch artifact.Ecosystem {
+	case v1alpha1.ArtifactEcosystemNpm, v1alpha1.ArtifactEcosystemPyPI:
+	case v1alpha1.ArtifactEcosystemMaven:
+		groupID, artifactID, ok := strings.Cut(artifact.Name, ":")
+		if !ok || groupID == "" || artifactID == "" || strings.Contains(artifactID, ":") {
+			err = errors.Join(err, fmt.Errorf(PkgValidateErrArtifactMavenName, artifact.Name))
+		}
+	case v1alpha1.ArtifactEcosystemHelm, v1alpha1.ArtifactEcosystemGeneric:
+		if artifact.URL == "" {
+			err = errors.Join(err, fmt.Errorf(PkgValidateErrArtifactURL, artifact.Ecosystem, artifact.Name))
+		}
+	default:
+		err = errors.Join(err, fmt.Errorf(PkgValidateErrArtifactEcosystem, artifact.Name, artifact.Ecosystem))
+	}
+
+	if artifact.Version == "" {
+		err = errors.Join(err, fmt.Errorf(PkgValidateErrArtifactVersion, artifact.Name))
+	}
+
+	return err
+}
+
 func validateChart(chart v1alpha1.ZarfChart) error {
 	var err error
 
@@ -88,6 +88,33 @@ func TestZarfPackageValidate(t *testing.T) {
 					{
 						Name: "duplicate",
 					},
+					{
+						Name:                "submodules",
+						Repos:               []string{"https://github.com/example/repo.git@v1.0.0"},
+						RecursiveSubmodules: []string{"https://github.com/example/repo.git"},
+						SkipLFSRepos:        []string{"https://github.com/example/other.git"},
+					},
+					{
+						Name: "artifacts",
+						Artifacts: []v1alpha1.ZarfArtifact{
+							{Ecosystem: v1alpha1.ArtifactEcosystemNpm, Name: "left-pad", Version: "1.3.0"},
+							{Ecosystem: "cargo", Name: "left-pad", Version: "1.3.0"},
+							{Ecosystem: v1alpha1.ArtifactEcosystemMaven, Name: "commons-io", Version: "2.16.1"},
+							{Ecosystem: v1alpha1.ArtifactEcosystemGeneric, Name: "tool"},
+						},
+					},
+					{
+						Name:  "verify",
+						Repos: []string{"https://github.com/example/repo.git@v1.0.0"},
+						VerifyRepos: []v1alpha1.ZarfRepoVerify{
+							{Repo: "https://github.com/example/other.git", Keys: []string{"key.asc"}},
+							{Repo: "https://github.com/example/repo.git@v1.0.0"},
+							{
+								Repo:       "https://github.com/example/repo.git@v1.0.0",
+								Identities: []v1alpha1.ZarfSigstoreIdentity{{Subject: "dev@example.com"}},
+							},
+						},
+					},
 				},
 				Constants: []v1alpha1.Constant{
 					{
@@ -106,6 +133,16 @@ func TestZarfPackageValidate(t *testing.T) {
 				fmt.Sprintf(PkgValidateErrComponentNameNotUnique, "duplicate"),
 				fmt.Sprintf(PkgValidateErrGroupOneComponent, "a-group", "required-in-group"),
 				fmt.Sprintf(PkgValidateErrGroupMultipleDefaults, "multi-default", "multi-default", "multi-default-2"),
+				fmt.Sprintf(PkgValidateErrRecursiveSubmodules, "https://github.com/example/repo.git", "submodules"),
+				fmt.Sprintf(PkgValidateErrSkipLFSRepo, "https://github.com/example/other.git", "submodules"),
+				fmt.Sprintf(PkgValidateErrVerifyRepo, "https://github.com/example/other.git", "verify"),
+				fmt.Sprintf(PkgValidateErrVerifyRepoNoSigners, "https://github.com/example/repo.git@v1.0.0", "verify"),
+				fmt.Sprintf(PkgValidateErrVerifyRepoNotUnique, "https://github.com/example/repo.git@v1.0.0", "verify"),
+				fmt.Sprintf(PkgValidateErrVerifyRepoIdentity, "https://github.com/example/repo.git@v1.0.0", "verify"),
+				fmt.Errorf(PkgValidateErrArtifact, fmt.Errorf(PkgValidateErrArtifactEcosystem, "left-pad", "cargo")).Error(),
+				fmt.Errorf(PkgValidateErrArtifact, fmt.Errorf(PkgValidateErrArtifactMavenName, "commons-io")).Error(),
+				fmt.Errorf(PkgValidateErrArtifact, fmt.Errorf(PkgValidateErrArtifactURL, "generic", "tool")).Error(),
+				fmt.Sprintf(PkgValidateErrArtifactVersion, "tool"),
 			},
 		},
 		{
@@ -121,6 +158,9 @@ func TestZarfPackageValidate(t *testing.T) {
 						Name:   "yolo",
 						Images: []string{"an-image"},
 						Repos:  []string{"a-repo"},
+						Artifacts: []v1alpha1.ZarfArtifact{
+							{Ecosystem: v1alpha1.ArtifactEcosystemNpm, Name: "left-pad", Version: "1.3.0"},
+						},
 						Only: v1alpha1.ZarfComponentOnlyTarget{
 							Cluster: v1alpha1.ZarfComponentOnlyCluster{
 								Architecture: "not-empty",
@@ -134,6 +174,7 @@ func TestZarfPackageValidate(t *testing.T) {
 				PkgValidateErrInitNoYOLO,
 				PkgValidateErrYOLONoOCI,
 				PkgValidateErrYOLONoGit,
+				PkgValidateErrYOLONoArtifacts,
 				PkgValidateErrYOLONoArch,
 				PkgValidateErrYOLONoDistro,
 			},
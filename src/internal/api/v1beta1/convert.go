@@ -42,6 +42,7 @@ func ConvertToGeneric(pkg v1beta1.Package) types.Package {
 			Version:                    pkg.Build.Version,
 			Migrations:                 pkg.Build.Migrations,
 			RegistryOverrides:          pkg.Build.RegistryOverrides,
+			ImageMirrors:               pkg.Build.ImageMirrors,
 			Differential:               pkg.Build.Differential,
 			DifferentialPackageVersion: pkg.Build.DifferentialPackageVersion,
 			Flavor:                     pkg.Build.Flavor,
@@ -57,6 +58,15 @@ func ConvertToGeneric(pkg v1beta1.Package) types.Package {
 		Documentation: pkg.Documentation,
 	}
 
+	for _, ref := range pkg.Build.VerifiedGitRefs {
+		g.Build.VerifiedGitRefs = append(g.Build.VerifiedGitRefs, types.VerifiedGitRef{
+			Repo:   ref.Repo,
+			Ref:    ref.Ref,
+			Hash:   ref.Hash,
+			Signer: ref.Signer,
+		})
+	}
+
 	for _, vr := range pkg.Build.VersionRequirements {
 		g.Build.VersionRequirements = append(g.Build.VersionRequirements, types.VersionRequirement{
 			Version: vr.Version,
@@ -78,7 +88,9 @@ func componentToGeneric(c v1beta1.Component) types.Component {
 		Optional:     c.Optional,
 		Service:      string(c.Service),
 		Repositories: repositoriesToGeneric(c.Repositories),
+		Artifacts:    artifactsToGeneric(c.Artifacts),
 		StateAccess:  stateAccessToGeneric(c.StateAccess),
+		MirrorCharts: c.MirrorCharts,
 		Target: types.ComponentTarget{
 			OS:           c.Target.OS,
 			Architecture: c.Selector.Architecture,
@@ -390,6 +402,7 @@ func buildFromGeneric(b types.BuildData, m types.PackageMetadata) v1beta1.BuildD
 		Version:                    b.Version,
 		Migrations:                 b.Migrations,
 		RegistryOverrides:          b.RegistryOverrides,
+		ImageMirrors:               b.ImageMirrors,
 		Differential:               b.Differential,
 		DifferentialPackageVersion: b.DifferentialPackageVersion,
 		Flavor:                     b.Flavor,
@@ -408,6 +421,15 @@ func buildFromGeneric(b types.BuildData, m types.PackageMetadata) v1beta1.BuildD
 		out.AggregateChecksum = m.AggregateChecksum
 	}
 
+	for _, ref := range b.VerifiedGitRefs {
+		out.VerifiedGitRefs = append(out.VerifiedGitRefs, v1beta1.VerifiedGitRef{
+			Repo:   ref.Repo,
+			Ref:    ref.Ref,
+			Hash:   ref.Hash,
+			Signer: ref.Signer,
+		})
+	}
+
 	for _, vr := range b.VersionRequirements {
 		out.VersionRequirements = append(out.VersionRequirements, v1beta1.VersionRequirement{
 			Version: vr.Version,
@@ -425,7 +447,9 @@ func componentFromGeneric(c types.Component, isInit, migrateFromV1alpha1 bool) v
 		Optional:    optionalFromGeneric(c.Optional, c.Required),
 		ComponentSpec: v1beta1.ComponentSpec{
 			Repositories: repositoriesFromGeneric(c.Repositories),
+			Artifacts:    artifactsFromGeneric(c.Artifacts),
 			StateAccess:  stateAccessFromGeneric(c.StateAccess),
+			MirrorCharts: c.MirrorCharts,
 			Target: v1beta1.ComponentTarget{
 				OS: c.Target.OS,
 			},
@@ -810,7 +834,13 @@ func isGitURL(url string) bool {
 func repositoriesToGeneric(in []v1beta1.Repository) []types.Repository {
 	var out []types.Repository
 	for _, r := range in {
-		gr := types.Repository{URL: r.URL}
+		gr := types.Repository{URL: r.URL, Submodules: r.Submodules, SkipLFS: r.SkipLFS}
+		if r.Verify != nil {
+			gr.Verify = &types.RepositoryVerify{Keys: r.Verify.Keys}
+			for _, id := range r.Verify.Identities {
+				gr.Verify.Identities = append(gr.Verify.Identities, types.SigstoreIdentity{Subject: id.Subject, Issuer: id.Issuer})
+			}
+		}
 		if r.Ref != nil {
 			gr.Ref = &types.GitRef{
 				Tag:    r.Ref.Tag,
@@ -826,7 +856,13 @@ func repositoriesToGeneric(in []v1beta1.Repository) []types.Repository {
 func repositoriesFromGeneric(in []types.Repository) []v1beta1.Repository {
 	var out []v1beta1.Repository
 	for _, r := range in {
-		br := v1beta1.Repository{URL: r.URL}
+		br := v1beta1.Repository{URL: r.URL, Submodules: r.Submodules, SkipLFS: r.SkipLFS}
+		if r.Verify != nil {
+			br.Verify = &v1beta1.RepositoryVerify{Keys: r.Verify.Keys}
+			for _, id := range r.Verify.Identities {
+				br.Verify.Identities = append(br.Verify.Identities, v1beta1.SigstoreIdentity{Subject: id.Subject, Issuer: id.Issuer})
+			}
+		}
 		if r.Ref != nil {
 			br.Ref = &v1beta1.GitRef{
 				Tag:    r.Ref.Tag,
@@ -846,6 +882,32 @@ func repositoriesFromGeneric(in []types.Repository) []v1beta1.Repository {
 	return out
 }
 
+func artifactsToGeneric(in []v1beta1.Artifact) []types.Artifact {
+	var out []types.Artifact
+	for _, a := range in {
+		out = append(out, types.Artifact{
+			Ecosystem: string(a.Ecosystem),
+			Name:      a.Name,
+			Version:   a.Version,
+			URL:       a.URL,
+		})
+	}
+	return out
+}
+
+func artifactsFromGeneric(in []types.Artifact) []v1beta1.Artifact {
+	var out []v1beta1.Artifact
+	for _, a := range in {
+		out = append(out, v1beta1.Artifact{
+			Ecosystem: v1beta1.ArtifactEcosystem(a.Ecosystem),
+			Name:      a.Name,
+			Version:   a.Version,
+			URL:       a.URL,
+		})
+	}
+	return out
+}
+
 func stateAccessToGeneric(in []v1beta1.StateAccessKey) []string {
 	var out []string
 	for _, s := range in {
@@ -43,6 +43,8 @@ func TestConvertGenericRoundTripLossless(t *testing.T) {
 			Version:                    "v0.30.0",
 			Migrations:                 []string{"scripts-to-actions", "pluralize-set-variable"},
 			RegistryOverrides:          map[string]string{"reg": "override"},
+			ImageMirrors:               map[string]string{"reg/img:1.0.0": "mirror/img:1.0.0"},
+			VerifiedGitRefs:            []v1beta1.VerifiedGitRef{{Repo: "https://github.com/example/repo", Ref: "refs/tags/v1.0.0", Hash: "0123456789abcdef", Signer: "gpg:ABCDEF"}},
 			Differential:               true,
 			DifferentialPackageVersion: "1.2.2",
 			Flavor:                     "prod",
@@ -68,9 +70,21 @@ func TestConvertGenericRoundTripLossless(t *testing.T) {
 						Architecture: "arm64",
 						Flavor:       "prod",
 					},
-					Service:      v1beta1.ServiceRegistry,
-					Repositories: []v1beta1.Repository{{URL: "https://github.com/example/repo"}},
+					Service: v1beta1.ServiceRegistry,
+					Repositories: []v1beta1.Repository{{
+						URL:        "https://github.com/example/repo",
+						Submodules: "recursive",
+						SkipLFS:    true,
+						Verify: &v1beta1.RepositoryVerify{
+							Keys:       []string{"maintainer.asc"},
+							Identities: []v1beta1.SigstoreIdentity{{Subject: "dev@example.com", Issuer: "https://github.com/login/oauth"}},
+						},
+					}},
+					Artifacts: []v1beta1.Artifact{
+						{Ecosystem: v1beta1.ArtifactEcosystemNpm, Name: "@types/node", Version: "20.11.5"},
+					},
 					StateAccess:  []v1beta1.StateAccessKey{v1beta1.StateAccessRegistryCredentials},
+					MirrorCharts: true,
 					Images: []v1beta1.Image{
 						{Name: "nginx:latest", Source: "registry"},
 					},
@@ -0,0 +1,698 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2021-Present The Zarf Authors
+
+// Package artifact contains functions for mirroring packages from language ecosystems to the artifact server.
+package artifact
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha1" //nolint:gosec // Older Maven and npm releases only publish SHA-1 checksums.
+	"crypto/sha256"
+	"crypto/sha512"
+	"crypto/tls"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"hash"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"net/url"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"github.com/Masterminds/semver/v3"
+	repov1 "helm.sh/helm/v4/pkg/repo/v1"
+
+	"github.com/zarf-dev/zarf/src/api/v1alpha1"
+	"github.com/zarf-dev/zarf/src/config"
+	"github.com/zarf-dev/zarf/src/pkg/logger"
+)
+
+// Default registries used when an artifact does not set a url.
+const (
+	DefaultNpmRegistry   = "https://registry.npmjs.org"
+	DefaultPyPIRegistry  = "https://pypi.org"
+	DefaultMavenRegistry = "https://repo1.maven.org/maven2"
+)
+
+// npmMetadataFile holds the registry metadata of the packaged npm version, which is needed to publish it again.
+const npmMetadataFile = "metadata.json"
+
+// NewHTTPClient returns the HTTP client used to download and publish artifacts.
+func NewHTTPClient(insecureSkipTLSVerify bool) (*http.Client, error) {
+	transport, ok := http.DefaultTransport.(*http.Transport)
+	if !ok {
+		return nil, errors.New("could not get default transport")
+	}
+	transport = transport.Clone()
+	transport.TLSClientConfig = &tls.Config{InsecureSkipVerify: insecureSkipTLSVerify} //nolint:gosec // Controlled by --insecure-skip-tls-verify.
+	return &http.Client{Transport: transport}, nil
+}
+
+// Pull downloads the files of an artifact into dst. Every file is checked against the checksums published for it
+// and pulling fails when there are none.
+func Pull(ctx context.Context, client *http.Client, a v1alpha1.ZarfArtifact, dst string) error {
+	logger.From(ctx).Info("pulling artifact", "ecosystem", a.Ecosystem, "name", a.Name, "version", a.Version)
+	if err := os.MkdirAll(dst, 0o755); err != nil {
+		return err
+	}
+	f := fetcher{client: client}
+	switch a.Ecosystem {
+	case v1alpha1.ArtifactEcosystemNpm:
+		return f.pullNpm(ctx, a, dst)
+	case v1alpha1.ArtifactEcosystemPyPI:
+		return f.pullPyPI(ctx, a, dst)
+	case v1alpha1.ArtifactEcosystemMaven:
+		return f.pullMaven(ctx, a, dst)
+	case v1alpha1.ArtifactEcosystemHelm:
+		return f.pullHelm(ctx, a, dst)
+	case v1alpha1.ArtifactEcosystemGeneric:
+		return f.pullGeneric(ctx, a, dst)
+	default:
+		return fmt.Errorf("unsupported artifact ecosystem %q", a.Ecosystem)
+	}
+}
+
+// Push publishes the files of an artifact in src to the package registry of the artifact server at address.
+// Artifacts that already exist on the server are left untouched.
+func Push(ctx context.Context, client *http.Client, a v1alpha1.ZarfArtifact, src, address, username, token string) error {
+	logger.From(ctx).Info("pushing artifact", "ecosystem", a.Ecosystem, "name", a.Name, "version", a.Version, "server", address)
+	p := publisher{
+		client:   client,
+		address:  strings.TrimSuffix(address, "/"),
+		username: username,
+		token:    token,
+	}
+	switch a.Ecosystem {
+	case v1alpha1.ArtifactEcosystemNpm:
+		return p.pushNpm(ctx, a, src)
+	case v1alpha1.ArtifactEcosystemPyPI:
+		return p.pushPyPI(ctx, a, src)
+	case v1alpha1.ArtifactEcosystemMaven:
+		return p.pushMaven(ctx, a, src)
+	case v1alpha1.ArtifactEcosystemHelm:
+		return p.pushHelm(ctx, src)
+	case v1alpha1.ArtifactEcosystemGeneric:
+		return p.pushGeneric(ctx, a, src)
+	default:
+		return fmt.Errorf("unsupported artifact ecosystem %q", a.Ecosystem)
+	}
+}
+
+func registryURL(a v1alpha1.ZarfArtifact, defaultURL string) string {
+	if a.URL == "" {
+		return defaultURL
+	}
+	return strings.TrimSuffix(a.URL, "/")
+}
+
+// digestAlgorithms are the checksum algorithms downloads can be verified with.
+var digestAlgorithms = map[string]func() hash.Hash{
+	"sha1":   sha1.New, //nolint:gosec // Older Maven and npm releases only publish SHA-1 checksums.
+	"sha256": sha256.New,
+	"sha384": sha512.New384,
+	"sha512": sha512.New,
+}
+
+// digest is a checksum published for a file.
+type digest struct {
+	algorithm string
+	// value is the hex encoded sum.
+	value string
+}
+
+// hexDigests returns the digest with the given hex encoded value, or none when the value is empty.
+func hexDigests(algorithm, value string) []digest {
+	if value == "" {
+		return nil
+	}
+	return []digest{{algorithm: algorithm, value: value}}
+}
+
+type npmVersion struct {
+	Dist struct {
+		Tarball   string `json:"tarball"`
+		Integrity string `json:"integrity"`
+		Shasum    string `json:"shasum"`
+	} `json:"dist"`
+}
+
+func (f fetcher) pullNpm(ctx context.Context, a v1alpha1.ZarfArtifact, dst string) error {
+	metadataURL := fmt.Sprintf("%s/%s/%s", registryURL(a, DefaultNpmRegistry), a.Name, url.PathEscape(a.Version))
+	var metadata json.RawMessage
+	if err := f.getJSON(ctx, metadataURL, &metadata); err != nil {
+		return fmt.Errorf("unable to get the npm metadata of %s@%s: %w", a.Name, a.Version, err)
+	}
+	if err := os.WriteFile(filepath.Join(dst, npmMetadataFile), metadata, 0o644); err != nil {
+		return err
+	}
+	var version npmVersion
+	if err := json.Unmarshal(metadata, &version); err != nil {
+		return fmt.Errorf("unable to parse the npm metadata of %s@%s: %w", a.Name, a.Version, err)
+	}
+	if version.Dist.Tarball == "" {
+		return fmt.Errorf("npm package %s@%s has no tarball", a.Name, a.Version)
+	}
+	digests, err := npmDigests(version.Dist.Integrity, version.Dist.Shasum)
+	if err != nil {
+		return err
+	}
+	return f.download(ctx, version.Dist.Tarball, filepath.Join(dst, path.Base(version.Dist.Tarball)), digests)
+}
+
+// npmDigests returns the digests of an npm tarball from its subresource integrity string and legacy shasum.
+func npmDigests(integrity, shasum string) ([]digest, error) {
+	digests := hexDigests("sha1", shasum)
+	for _, entry := range strings.Fields(integrity) {
+		algorithm, value, ok := strings.Cut(entry, "-")
+		if _, supported := digestAlgorithms[algorithm]; !ok || !supported {
+			continue
+		}
+		// Options may follow the digest
+		value, _, _ = strings.Cut(value, "?")
+		sum, err := base64.StdEncoding.DecodeString(value)
+		if err != nil {
+			return nil, fmt.Errorf("invalid npm integrity %q: %w", entry, err)
+		}
+		digests = append(digests, digest{algorithm: algorithm, value: hex.EncodeToString(sum)})
+	}
+	return digests, nil
+}
+
+type pypiRelease struct {
+	URLs []struct {
+		Filename string `json:"filename"`
+		URL      string `json:"url"`
+		Digests  struct {
+			SHA256 string `json:"sha256"`
+		} `json:"digests"`
+	} `json:"urls"`
+}
+
+func (f fetcher) pullPyPI(ctx context.Context, a v1alpha1.ZarfArtifact, dst string) error {
+	releaseURL := fmt.Sprintf("%s/pypi/%s/%s/json", registryURL(a, DefaultPyPIRegistry), url.PathEscape(a.Name), url.PathEscape(a.Version))
+	var release pypiRelease
+	if err := f.getJSON(ctx, releaseURL, &release); err != nil {
+		return fmt.Errorf("unable to get the PyPI release of %s==%s: %w", a.Name, a.Version, err)
+	}
+	if len(release.URLs) == 0 {
+		return fmt.Errorf("PyPI release %s==%s has no files", a.Name, a.Version)
+	}
+	for _, file := range release.URLs {
+		if err := f.download(ctx, file.URL, filepath.Join(dst, filepath.Base(file.Filename)), hexDigests("sha256", file.Digests.SHA256)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// mavenPath returns the repository path of a Maven groupId:artifactId at a version.
+func mavenPath(a v1alpha1.ZarfArtifact) (string, string, error) {
+	groupID, artifactID, ok := strings.Cut(a.Name, ":")
+	if !ok || groupID == "" || artifactID == "" {
+		return "", "", fmt.Errorf("maven artifact %q must be in the form groupId:artifactId", a.Name)
+	}
+	return path.Join(strings.ReplaceAll(groupID, ".", "/"), artifactID, a.Version), artifactID, nil
+}
+
+func (f fetcher) pullMaven(ctx context.Context, a v1alpha1.ZarfArtifact, dst string) error {
+	dir, artifactID, err := mavenPath(a)
+	if err != nil {
+		return err
+	}
+	base := fmt.Sprintf("%s/%s/%s-%s", registryURL(a, DefaultMavenRegistry), dir, artifactID, a.Version)
+	pom := fmt.Sprintf("%s-%s.pom", artifactID, a.Version)
+	if err := f.downloadMaven(ctx, base+".pom", filepath.Join(dst, pom)); err != nil {
+		return err
+	}
+	// Parent and BOM artifacts only publish a pom
+	jar := fmt.Sprintf("%s-%s.jar", artifactID, a.Version)
+	found, err := f.exists(ctx, base+".jar")
+	if err != nil {
+		return err
+	}
+	if !found {
+		return nil
+	}
+	return f.downloadMaven(ctx, base+".jar", filepath.Join(dst, jar))
+}
+
+// downloadMaven downloads a file from a Maven repository, checking it against every checksum file published next to it.
+func (f fetcher) downloadMaven(ctx context.Context, src, dst string) error {
+	digests := []digest{}
+	for _, algorithm := range []string{"sha512", "sha256", "sha1"} {
+		d, err := f.checksumFile(ctx, src+"."+algorithm, algorithm)
+		if err != nil {
+			return err
+		}
+		digests = append(digests, d...)
+	}
+	return f.download(ctx, src, dst, digests)
+}
+
+// checksumFile returns the digest in the checksum file at src, or none when there is no such file. Checksum files
+// hold the hex encoded sum, optionally followed by the file name.
+func (f fetcher) checksumFile(ctx context.Context, src, algorithm string) ([]digest, error) {
+	b, err := f.getOptional(ctx, src)
+	if err != nil || b == nil {
+		return nil, err
+	}
+	fields := strings.Fields(string(b))
+	if len(fields) == 0 {
+		return nil, fmt.Errorf("checksum file %s is empty", src)
+	}
+	if _, err := hex.DecodeString(fields[0]); err != nil || len(fields[0]) != digestAlgorithms[algorithm]().Size()*2 {
+		return nil, fmt.Errorf("checksum file %s does not hold a %s checksum", src, algorithm)
+	}
+	return hexDigests(algorithm, fields[0]), nil
+}
+
+func (f fetcher) pullHelm(ctx context.Context, a v1alpha1.ZarfArtifact, dst string) (err error) {
+	repoURL := strings.TrimSuffix(a.URL, "/")
+	indexFile, err := os.CreateTemp(config.CommonOptions.TempDirectory, "index-*.yaml")
+	if err != nil {
+		return err
+	}
+	indexPath := indexFile.Name()
+	defer func() {
+		err = errors.Join(err, os.Remove(indexPath))
+	}()
+	saveErr := f.save(ctx, repoURL+"/index.yaml", indexFile)
+	if err := errors.Join(saveErr, indexFile.Close()); err != nil {
+		return fmt.Errorf("unable to get the index of chart repository %s: %w", repoURL, err)
+	}
+	index, err := repov1.LoadIndexFile(indexPath)
+	if err != nil {
+		return err
+	}
+	chartVersion, err := index.Get(a.Name, a.Version)
+	if err != nil {
+		return fmt.Errorf("unable to find chart %s version %s in %s: %w", a.Name, a.Version, repoURL, err)
+	}
+	if len(chartVersion.URLs) == 0 {
+		return fmt.Errorf("chart %s version %s has no urls", a.Name, a.Version)
+	}
+	chartURL, err := repov1.ResolveReferenceURL(repoURL, chartVersion.URLs[0])
+	if err != nil {
+		return err
+	}
+	return f.download(ctx, chartURL, filepath.Join(dst, fmt.Sprintf("%s-%s.tgz", a.Name, a.Version)), hexDigests("sha256", chartVersion.Digest))
+}
+
+func (f fetcher) pullGeneric(ctx context.Context, a v1alpha1.ZarfArtifact, dst string) error {
+	src, checksum := splitChecksum(a.URL)
+	name, err := genericFilename(src)
+	if err != nil {
+		return err
+	}
+	digests := hexDigests("sha256", checksum)
+	if len(digests) == 0 {
+		// Fall back to the checksum file many download sites publish next to the file
+		digests, err = f.checksumFile(ctx, src+".sha256", "sha256")
+		if err != nil {
+			return err
+		}
+		if len(digests) == 0 {
+			return fmt.Errorf("no checksum is published for %s, add an @sha256 checksum suffix to the url", src)
+		}
+	}
+	return f.download(ctx, src, filepath.Join(dst, name), digests)
+}
+
+// splitChecksum splits the @ checksum suffix off a url.
+func splitChecksum(src string) (string, string) {
+	idx := strings.LastIndex(src, "@")
+	if idx < 0 || idx < strings.LastIndex(src, "/") {
+		return src, ""
+	}
+	return src[:idx], src[idx+1:]
+}
+
+// genericFilename returns the name of the file a generic artifact url points to.
+func genericFilename(src string) (string, error) {
+	u, err := url.Parse(src)
+	if err != nil {
+		return "", err
+	}
+	name := path.Base(u.Path)
+	if name == "/" || name == "." {
+		return "", fmt.Errorf("unable to determine the file name of %s", src)
+	}
+	return name, nil
+}
+
+// fetcher downloads artifacts from their upstream registries.
+type fetcher struct {
+	client *http.Client
+}
+
+func (f fetcher) get(ctx context.Context, src string) (_ *http.Response, err error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, src, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("accept", "application/json")
+	resp, err := f.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, errors.Join(fmt.Errorf("unexpected status %s for %s", resp.Status, src), resp.Body.Close())
+	}
+	return resp, nil
+}
+
+// getOptional returns the content at src, or nil when it does not exist.
+func (f fetcher) getOptional(ctx context.Context, src string) (_ []byte, err error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, src, nil)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := f.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		err = errors.Join(err, resp.Body.Close())
+	}()
+	switch {
+	case resp.StatusCode == http.StatusNotFound:
+		return nil, nil
+	case resp.StatusCode == http.StatusOK:
+		return io.ReadAll(resp.Body)
+	default:
+		return nil, fmt.Errorf("unexpected status %s for %s", resp.Status, src)
+	}
+}
+
+// save writes the content at src to w.
+func (f fetcher) save(ctx context.Context, src string, w io.Writer) (err error) {
+	resp, err := f.get(ctx, src)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		err = errors.Join(err, resp.Body.Close())
+	}()
+	_, err = io.Copy(w, resp.Body)
+	return err
+}
+
+// download writes the file at src to dst and checks it against every one of digests, of which there must be at least one.
+func (f fetcher) download(ctx context.Context, src, dst string, digests []digest) (err error) {
+	if len(digests) == 0 {
+		return fmt.Errorf("no checksum is published for %s", src)
+	}
+	writers := []io.Writer{}
+	hashes := []hash.Hash{}
+	for _, d := range digests {
+		h := digestAlgorithms[d.algorithm]()
+		hashes = append(hashes, h)
+		writers = append(writers, h)
+	}
+	file, err := os.Create(dst)
+	if err != nil {
+		return err
+	}
+	saveErr := f.save(ctx, src, io.MultiWriter(append(writers, file)...))
+	if err := errors.Join(saveErr, file.Close()); err != nil {
+		return err
+	}
+	for i, d := range digests {
+		if received := hex.EncodeToString(hashes[i].Sum(nil)); !strings.EqualFold(received, d.value) {
+			return fmt.Errorf("%s checksum mismatch for %s: expected %s, got %s", d.algorithm, src, d.value, received)
+		}
+	}
+	return nil
+}
+
+func (f fetcher) getJSON(ctx context.Context, src string, v any) (err error) {
+	resp, err := f.get(ctx, src)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		err = errors.Join(err, resp.Body.Close())
+	}()
+	return json.NewDecoder(resp.Body).Decode(v)
+}
+
+func (f fetcher) exists(ctx context.Context, src string) (_ bool, err error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodHead, src, nil)
+	if err != nil {
+		return false, err
+	}
+	resp, err := f.client.Do(req)
+	if err != nil {
+		return false, err
+	}
+	defer func() {
+		err = errors.Join(err, resp.Body.Close())
+	}()
+	switch {
+	case resp.StatusCode == http.StatusNotFound:
+		return false, nil
+	case resp.StatusCode >= 200 && resp.StatusCode < 300:
+		return true, nil
+	default:
+		return false, fmt.Errorf("unexpected status %s for %s", resp.Status, src)
+	}
+}
+
+type publisher struct {
+	client   *http.Client
+	address  string
+	username string
+	token    string
+}
+
+// do sends a request to the package registry. A conflict means the package version is already published.
+func (p publisher) do(ctx context.Context, method, target, contentType string, body io.Reader, size int64) (err error) {
+	req, err := http.NewRequestWithContext(ctx, method, target, body)
+	if err != nil {
+		return err
+	}
+	req.ContentLength = size
+	req.SetBasicAuth(p.username, p.token)
+	req.Header.Set("content-type", contentType)
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		err = errors.Join(err, resp.Body.Close())
+	}()
+	if resp.StatusCode == http.StatusConflict {
+		logger.From(ctx).Debug("artifact already exists on the server", "url", target)
+		return nil
+	}
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		b, err := io.ReadAll(resp.Body)
+		if err != nil {
+			return err
+		}
+		return fmt.Errorf("unexpected status %s for %s %s: %s", resp.Status, method, target, strings.TrimSpace(string(b)))
+	}
+	return nil
+}
+
+func (p publisher) sendFile(ctx context.Context, method, target, file string) (err error) {
+	f, err := os.Open(file)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		err = errors.Join(err, f.Close())
+	}()
+	info, err := f.Stat()
+	if err != nil {
+		return err
+	}
+	// The transport closes the request body, leave closing the file to the defer
+	return p.do(ctx, method, target, "application/octet-stream", io.NopCloser(f), info.Size())
+}
+
+// files returns the artifact files in src, skipping any metadata stored next to them.
+func files(src string) ([]string, error) {
+	entries, err := os.ReadDir(src)
+	if err != nil {
+		return nil, err
+	}
+	names := []string{}
+	for _, entry := range entries {
+		if entry.IsDir() || entry.Name() == npmMetadataFile {
+			continue
+		}
+		names = append(names, entry.Name())
+	}
+	if len(names) == 0 {
+		return nil, fmt.Errorf("no artifact files found in %s", src)
+	}
+	return names, nil
+}
+
+func (p publisher) pushNpm(ctx context.Context, a v1alpha1.ZarfArtifact, src string) error {
+	names, err := files(src)
+	if err != nil {
+		return err
+	}
+	metadata, err := os.ReadFile(filepath.Join(src, npmMetadataFile))
+	if err != nil {
+		return err
+	}
+	tarball, err := os.ReadFile(filepath.Join(src, names[0]))
+	if err != nil {
+		return err
+	}
+	distTags, err := p.npmDistTags(ctx, a)
+	if err != nil {
+		return err
+	}
+	body := map[string]any{
+		"_id":       a.Name,
+		"name":      a.Name,
+		"dist-tags": distTags,
+		"versions": map[string]json.RawMessage{
+			a.Version: metadata,
+		},
+		"_attachments": map[string]any{
+			names[0]: map[string]any{
+				"content_type": "application/octet-stream",
+				"data":         base64.StdEncoding.EncodeToString(tarball),
+				"length":       len(tarball),
+			},
+		},
+	}
+	b, err := json.Marshal(body)
+	if err != nil {
+		return err
+	}
+	return p.do(ctx, http.MethodPut, fmt.Sprintf("%s/npm/%s", p.address, a.Name), "application/json", bytes.NewReader(b), int64(len(b)))
+}
+
+// npmDistTags returns the dist-tags to publish an npm version with. The latest tag is only moved to versions newer
+// than the one it points to on the server, so publishing an older version does not change what installs by default.
+func (p publisher) npmDistTags(ctx context.Context, a v1alpha1.ZarfArtifact) (_ map[string]string, err error) {
+	target := fmt.Sprintf("%s/npm/%s", p.address, a.Name)
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, target, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.SetBasicAuth(p.username, p.token)
+	req.Header.Set("accept", "application/json")
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		err = errors.Join(err, resp.Body.Close())
+	}()
+	if resp.StatusCode == http.StatusNotFound {
+		return map[string]string{"latest": a.Version}, nil
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unexpected status %s for GET %s", resp.Status, target)
+	}
+	var pkg struct {
+		DistTags map[string]string `json:"dist-tags"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&pkg); err != nil {
+		return nil, fmt.Errorf("unable to parse the npm metadata of %s on the server: %w", a.Name, err)
+	}
+	latest, ok := pkg.DistTags["latest"]
+	if !ok {
+		return map[string]string{"latest": a.Version}, nil
+	}
+	current, currentErr := semver.NewVersion(latest)
+	version, versionErr := semver.NewVersion(a.Version)
+	if currentErr == nil && versionErr == nil && version.GreaterThan(current) {
+		return map[string]string{"latest": a.Version}, nil
+	}
+	return map[string]string{}, nil
+}
+
+func (p publisher) pushPyPI(ctx context.Context, a v1alpha1.ZarfArtifact, src string) error {
+	names, err := files(src)
+	if err != nil {
+		return err
+	}
+	for _, name := range names {
+		content, err := os.ReadFile(filepath.Join(src, name))
+		if err != nil {
+			return err
+		}
+		sum := sha256.Sum256(content)
+		var body bytes.Buffer
+		w := multipart.NewWriter(&body)
+		fields := [][2]string{
+			{":action", "file_upload"},
+			{"name", a.Name},
+			{"version", a.Version},
+			{"sha256_digest", hex.EncodeToString(sum[:])},
+		}
+		for _, field := range fields {
+			if err := w.WriteField(field[0], field[1]); err != nil {
+				return err
+			}
+		}
+		part, err := w.CreateFormFile("content", name)
+		if err != nil {
+			return err
+		}
+		if _, err := part.Write(content); err != nil {
+			return err
+		}
+		if err := w.Close(); err != nil {
+			return err
+		}
+		if err := p.do(ctx, http.MethodPost, p.address+"/pypi", w.FormDataContentType(), &body, int64(body.Len())); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (p publisher) pushMaven(ctx context.Context, a v1alpha1.ZarfArtifact, src string) error {
+	dir, _, err := mavenPath(a)
+	if err != nil {
+		return err
+	}
+	names, err := files(src)
+	if err != nil {
+		return err
+	}
+	for _, name := range names {
+		if err := p.sendFile(ctx, http.MethodPut, fmt.Sprintf("%s/maven/%s/%s", p.address, dir, name), filepath.Join(src, name)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (p publisher) pushHelm(ctx context.Context, src string) error {
+	names, err := files(src)
+	if err != nil {
+		return err
+	}
+	return p.sendFile(ctx, http.MethodPost, p.address+"/helm/api/charts", filepath.Join(src, names[0]))
+}
+
+func (p publisher) pushGeneric(ctx context.Context, a v1alpha1.ZarfArtifact, src string) error {
+	names, err := files(src)
+	if err != nil {
+		return err
+	}
+	for _, name := range names {
+		target := fmt.Sprintf("%s/generic/%s/%s/%s", p.address, url.PathEscape(a.Name), url.PathEscape(a.Version), url.PathEscape(name))
+		if err := p.sendFile(ctx, http.MethodPut, target, filepath.Join(src, name)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,398 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2021-Present The Zarf Authors
+
+package artifact
+
+import (
+	"crypto/sha1" //nolint:gosec // Legacy checksums are still verified.
+	"crypto/sha256"
+	"crypto/sha512"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/zarf-dev/zarf/src/api/v1alpha1"
+	"github.com/zarf-dev/zarf/src/test/testutil"
+)
+
+func sha256Hex(b []byte) string {
+	sum := sha256.Sum256(b)
+	return hex.EncodeToString(sum[:])
+}
+
+func newUpstream(t *testing.T) *httptest.Server {
+	t.Helper()
+	tarball := []byte("npm tarball")
+	wheel := []byte("python wheel")
+	chart := []byte("helm chart")
+
+	mux := http.NewServeMux()
+	srv := httptest.NewTLSServer(mux)
+	t.Cleanup(srv.Close)
+
+	mux.HandleFunc("GET /npm/@types/node/20.11.5", func(w http.ResponseWriter, _ *http.Request) {
+		sum := sha512.Sum512(tarball)
+		shasum := sha1.Sum(tarball) //nolint:gosec // Legacy checksums are still verified.
+		fmt.Fprintf(w, `{"name":"@types/node","version":"20.11.5","dist":{"tarball":"%s/npm/@types/node/-/node-20.11.5.tgz","integrity":"sha512-%s","shasum":"%s"}}`,
+			srv.URL, base64.StdEncoding.EncodeToString(sum[:]), hex.EncodeToString(shasum[:]))
+	})
+	mux.HandleFunc("GET /npm/@types/node/-/node-20.11.5.tgz", func(w http.ResponseWriter, _ *http.Request) {
+		w.Write(tarball) //nolint:errcheck
+	})
+	mux.HandleFunc("GET /pypi/pypi/requests/2.31.0/json", func(w http.ResponseWriter, _ *http.Request) {
+		fmt.Fprintf(w, `{"urls":[{"filename":"requests-2.31.0-py3-none-any.whl","url":"%s/files/requests-2.31.0-py3-none-any.whl","digests":{"sha256":"%s"}}]}`,
+			srv.URL, sha256Hex(wheel))
+	})
+	mux.HandleFunc("GET /files/requests-2.31.0-py3-none-any.whl", func(w http.ResponseWriter, _ *http.Request) {
+		w.Write(wheel) //nolint:errcheck
+	})
+	mux.HandleFunc("GET /maven/org/example/lib/1.0.0/lib-1.0.0.pom", func(w http.ResponseWriter, _ *http.Request) {
+		w.Write([]byte("<project/>")) //nolint:errcheck
+	})
+	mux.HandleFunc("GET /maven/org/example/lib/1.0.0/lib-1.0.0.pom.sha1", func(w http.ResponseWriter, _ *http.Request) {
+		sum := sha1.Sum([]byte("<project/>"))       //nolint:gosec // Legacy checksums are still verified.
+		w.Write([]byte(hex.EncodeToString(sum[:]))) //nolint:errcheck
+	})
+	mux.HandleFunc("/maven/org/example/lib/1.0.0/lib-1.0.0.jar", func(w http.ResponseWriter, _ *http.Request) {
+		w.Write([]byte("jar")) //nolint:errcheck
+	})
+	mux.HandleFunc("GET /maven/org/example/lib/1.0.0/lib-1.0.0.jar.sha256", func(w http.ResponseWriter, _ *http.Request) {
+		fmt.Fprintf(w, "%s  lib-1.0.0.jar\n", sha256Hex([]byte("jar")))
+	})
+	mux.HandleFunc("GET /charts/index.yaml", func(w http.ResponseWriter, _ *http.Request) {
+		fmt.Fprintf(w, "apiVersion: v1\nentries:\n  podinfo:\n  - name: podinfo\n    version: 6.4.0\n    digest: %s\n    urls:\n    - podinfo-6.4.0.tgz\n", sha256Hex(chart))
+	})
+	mux.HandleFunc("GET /charts/podinfo-6.4.0.tgz", func(w http.ResponseWriter, _ *http.Request) {
+		w.Write(chart) //nolint:errcheck
+	})
+	mux.HandleFunc("GET /files/tool.tar.gz", func(w http.ResponseWriter, _ *http.Request) {
+		w.Write([]byte("tool")) //nolint:errcheck
+	})
+	mux.HandleFunc("GET /files/tool.tar.gz.sha256", func(w http.ResponseWriter, _ *http.Request) {
+		w.Write([]byte(sha256Hex([]byte("tool")))) //nolint:errcheck
+	})
+	return srv
+}
+
+type published struct {
+	method      string
+	path        string
+	contentType string
+	body        []byte
+}
+
+func newPackageRegistry(t *testing.T) (*httptest.Server, func() []published) {
+	t.Helper()
+	var mu sync.Mutex
+	requests := []published{}
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		username, token, ok := r.BasicAuth()
+		if !ok || username != "zarf-git-user" || token != "token" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		if r.Method == http.MethodGet {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		b, err := io.ReadAll(r.Body)
+		if err != nil {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		mu.Lock()
+		defer mu.Unlock()
+		for _, req := range requests {
+			if req.method == r.Method && req.path == r.URL.Path && r.URL.Path != "/api/packages/zarf-git-user/pypi" {
+				w.WriteHeader(http.StatusConflict)
+				return
+			}
+		}
+		requests = append(requests, published{method: r.Method, path: r.URL.Path, contentType: r.Header.Get("content-type"), body: b})
+		w.WriteHeader(http.StatusCreated)
+	}))
+	t.Cleanup(srv.Close)
+	return srv, func() []published {
+		mu.Lock()
+		defer mu.Unlock()
+		return requests
+	}
+}
+
+func TestPullAndPush(t *testing.T) {
+	t.Parallel()
+
+	upstream := newUpstream(t)
+	registry, requests := newPackageRegistry(t)
+	address := registry.URL + "/api/packages/zarf-git-user"
+	client, err := NewHTTPClient(true)
+	require.NoError(t, err)
+
+	tests := []struct {
+		name          string
+		artifact      v1alpha1.ZarfArtifact
+		expectedFiles []string
+		expectedPaths []string
+	}{
+		{
+			name: "npm",
+			artifact: v1alpha1.ZarfArtifact{
+				Ecosystem: v1alpha1.ArtifactEcosystemNpm,
+				Name:      "@types/node",
+				Version:   "20.11.5",
+				URL:       upstream.URL + "/npm",
+			},
+			expectedFiles: []string{"metadata.json", "node-20.11.5.tgz"},
+			expectedPaths: []string{"PUT /api/packages/zarf-git-user/npm/@types/node"},
+		},
+		{
+			name: "pypi",
+			artifact: v1alpha1.ZarfArtifact{
+				Ecosystem: v1alpha1.ArtifactEcosystemPyPI,
+				Name:      "requests",
+				Version:   "2.31.0",
+				URL:       upstream.URL + "/pypi",
+			},
+			expectedFiles: []string{"requests-2.31.0-py3-none-any.whl"},
+			expectedPaths: []string{"POST /api/packages/zarf-git-user/pypi"},
+		},
+		{
+			name: "maven",
+			artifact: v1alpha1.ZarfArtifact{
+				Ecosystem: v1alpha1.ArtifactEcosystemMaven,
+				Name:      "org.example:lib",
+				Version:   "1.0.0",
+				URL:       upstream.URL + "/maven",
+			},
+			expectedFiles: []string{"lib-1.0.0.jar", "lib-1.0.0.pom"},
+			expectedPaths: []string{
+				"PUT /api/packages/zarf-git-user/maven/org/example/lib/1.0.0/lib-1.0.0.jar",
+				"PUT /api/packages/zarf-git-user/maven/org/example/lib/1.0.0/lib-1.0.0.pom",
+			},
+		},
+		{
+			name: "helm",
+			artifact: v1alpha1.ZarfArtifact{
+				Ecosystem: v1alpha1.ArtifactEcosystemHelm,
+				Name:      "podinfo",
+				Version:   "6.4.0",
+				URL:       upstream.URL + "/charts",
+			},
+			expectedFiles: []string{"podinfo-6.4.0.tgz"},
+			expectedPaths: []string{"POST /api/packages/zarf-git-user/helm/api/charts"},
+		},
+		{
+			name: "generic",
+			artifact: v1alpha1.ZarfArtifact{
+				Ecosystem: v1alpha1.ArtifactEcosystemGeneric,
+				Name:      "tool",
+				Version:   "1.2.3",
+				URL:       fmt.Sprintf("%s/files/tool.tar.gz@%s", upstream.URL, sha256Hex([]byte("tool"))),
+			},
+			expectedFiles: []string{"tool.tar.gz"},
+			expectedPaths: []string{"PUT /api/packages/zarf-git-user/generic/tool/1.2.3/tool.tar.gz"},
+		},
+		{
+			name: "generic with a checksum file",
+			artifact: v1alpha1.ZarfArtifact{
+				Ecosystem: v1alpha1.ArtifactEcosystemGeneric,
+				Name:      "tool-checksum-file",
+				Version:   "1.2.3",
+				URL:       upstream.URL + "/files/tool.tar.gz",
+			},
+			expectedFiles: []string{"tool.tar.gz"},
+			expectedPaths: []string{"PUT /api/packages/zarf-git-user/generic/tool-checksum-file/1.2.3/tool.tar.gz"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			ctx := testutil.TestContext(t)
+			dir := t.TempDir()
+
+			err := Pull(ctx, client, tt.artifact, dir)
+			require.NoError(t, err)
+			entries, err := os.ReadDir(dir)
+			require.NoError(t, err)
+			names := []string{}
+			for _, entry := range entries {
+				names = append(names, entry.Name())
+			}
+			require.Equal(t, tt.expectedFiles, names)
+
+			err = Push(ctx, client, tt.artifact, dir, address, "zarf-git-user", "token")
+			require.NoError(t, err)
+			// Publishing again is a no-op
+			err = Push(ctx, client, tt.artifact, dir, address, "zarf-git-user", "token")
+			require.NoError(t, err)
+
+			for _, expected := range tt.expectedPaths {
+				found := false
+				for _, req := range requests() {
+					if req.method+" "+req.path == expected {
+						found = true
+					}
+				}
+				require.True(t, found, "expected request %s", expected)
+			}
+		})
+	}
+
+	err = Push(testutil.TestContext(t), client, tests[0].artifact, t.TempDir(), address, "zarf-git-user", "token")
+	require.ErrorContains(t, err, "no artifact files found")
+
+	// The upstream certificate is only accepted when TLS verification is skipped
+	err = Pull(testutil.TestContext(t), http.DefaultClient, tests[0].artifact, t.TempDir())
+	require.ErrorContains(t, err, "certificate")
+}
+
+func TestPushNpmBody(t *testing.T) {
+	t.Parallel()
+	ctx := testutil.TestContext(t)
+
+	registry, requests := newPackageRegistry(t)
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, npmMetadataFile), []byte(`{"name":"left-pad","version":"1.3.0"}`), 0o644))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "left-pad-1.3.0.tgz"), []byte("tarball"), 0o644))
+
+	a := v1alpha1.ZarfArtifact{Ecosystem: v1alpha1.ArtifactEcosystemNpm, Name: "left-pad", Version: "1.3.0"}
+	err := Push(ctx, http.DefaultClient, a, dir, registry.URL+"/api/packages/zarf-git-user", "zarf-git-user", "token")
+	require.NoError(t, err)
+
+	reqs := requests()
+	require.Len(t, reqs, 1)
+	require.Equal(t, "application/json", reqs[0].contentType)
+	body := struct {
+		Name        string                     `json:"name"`
+		DistTags    map[string]string          `json:"dist-tags"`
+		Versions    map[string]json.RawMessage `json:"versions"`
+		Attachments map[string]struct {
+			Data   string `json:"data"`
+			Length int    `json:"length"`
+		} `json:"_attachments"`
+	}{}
+	require.NoError(t, json.Unmarshal(reqs[0].body, &body))
+	require.Equal(t, "left-pad", body.Name)
+	require.Equal(t, map[string]string{"latest": "1.3.0"}, body.DistTags)
+	require.JSONEq(t, `{"name":"left-pad","version":"1.3.0"}`, string(body.Versions["1.3.0"]))
+	require.Equal(t, base64.StdEncoding.EncodeToString([]byte("tarball")), body.Attachments["left-pad-1.3.0.tgz"].Data)
+	require.Equal(t, 7, body.Attachments["left-pad-1.3.0.tgz"].Length)
+
+	err = Push(ctx, http.DefaultClient, a, dir, registry.URL+"/api/packages/zarf-git-user", "zarf-git-user", "wrong")
+	require.ErrorContains(t, err, "401 Unauthorized")
+}
+
+func TestPushNpmDistTags(t *testing.T) {
+	t.Parallel()
+	ctx := testutil.TestContext(t)
+
+	var mu sync.Mutex
+	distTags := map[string]string{}
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodGet {
+			fmt.Fprint(w, `{"name":"left-pad","dist-tags":{"latest":"1.3.0"}}`)
+			return
+		}
+		body := struct {
+			DistTags map[string]string `json:"dist-tags"`
+		}{}
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		mu.Lock()
+		defer mu.Unlock()
+		distTags = body.DistTags
+		w.WriteHeader(http.StatusCreated)
+	}))
+	t.Cleanup(srv.Close)
+	pushed := func(version string) map[string]string {
+		dir := t.TempDir()
+		require.NoError(t, os.WriteFile(filepath.Join(dir, npmMetadataFile), []byte(`{"name":"left-pad"}`), 0o644))
+		require.NoError(t, os.WriteFile(filepath.Join(dir, "left-pad.tgz"), []byte("tarball"), 0o644))
+		a := v1alpha1.ZarfArtifact{Ecosystem: v1alpha1.ArtifactEcosystemNpm, Name: "left-pad", Version: version}
+		require.NoError(t, Push(ctx, http.DefaultClient, a, dir, srv.URL, "zarf-git-user", "token"))
+		mu.Lock()
+		defer mu.Unlock()
+		return distTags
+	}
+
+	// Publishing an older version leaves latest on the server as is
+	require.Empty(t, pushed("1.2.0"))
+	require.Equal(t, map[string]string{"latest": "1.4.0"}, pushed("1.4.0"))
+}
+
+func TestPullIntegrityMismatch(t *testing.T) {
+	t.Parallel()
+	ctx := testutil.TestContext(t)
+
+	mux := http.NewServeMux()
+	srv := httptest.NewServer(mux)
+	t.Cleanup(srv.Close)
+	mux.HandleFunc("GET /left-pad/1.3.0", func(w http.ResponseWriter, _ *http.Request) {
+		fmt.Fprintf(w, `{"dist":{"tarball":"%s/left-pad/-/left-pad-1.3.0.tgz","integrity":"sha512-bm90IHRoZSBzdW0="}}`, srv.URL)
+	})
+	mux.HandleFunc("GET /left-pad/-/left-pad-1.3.0.tgz", func(w http.ResponseWriter, _ *http.Request) {
+		w.Write([]byte("tarball")) //nolint:errcheck
+	})
+
+	a := v1alpha1.ZarfArtifact{Ecosystem: v1alpha1.ArtifactEcosystemNpm, Name: "left-pad", Version: "1.3.0", URL: srv.URL}
+	err := Pull(ctx, http.DefaultClient, a, t.TempDir())
+	require.ErrorContains(t, err, "sha512 checksum mismatch")
+}
+
+func TestPullWithoutChecksum(t *testing.T) {
+	t.Parallel()
+	ctx := testutil.TestContext(t)
+
+	mux := http.NewServeMux()
+	srv := httptest.NewServer(mux)
+	t.Cleanup(srv.Close)
+	mux.HandleFunc("GET /npm/left-pad/1.3.0", func(w http.ResponseWriter, _ *http.Request) {
+		fmt.Fprintf(w, `{"dist":{"tarball":"%s/files/left-pad-1.3.0.tgz","integrity":"md5-bm90IHRoZSBzdW0="}}`, srv.URL)
+	})
+	for _, file := range []string{"/files/left-pad-1.3.0.tgz", "/maven/org/example/lib/1.0.0/lib-1.0.0.pom", "/tool.tar.gz"} {
+		mux.HandleFunc("GET "+file, func(w http.ResponseWriter, _ *http.Request) {
+			w.Write([]byte("file")) //nolint:errcheck
+		})
+	}
+
+	tests := []struct {
+		name        string
+		artifact    v1alpha1.ZarfArtifact
+		expectedErr string
+	}{
+		{
+			name:        "npm without a supported integrity",
+			artifact:    v1alpha1.ZarfArtifact{Ecosystem: v1alpha1.ArtifactEcosystemNpm, Name: "left-pad", Version: "1.3.0", URL: srv.URL + "/npm"},
+			expectedErr: "no checksum is published for " + srv.URL + "/files/left-pad-1.3.0.tgz",
+		},
+		{
+			name:        "maven without checksum files",
+			artifact:    v1alpha1.ZarfArtifact{Ecosystem: v1alpha1.ArtifactEcosystemMaven, Name: "org.example:lib", Version: "1.0.0", URL: srv.URL + "/maven"},
+			expectedErr: "no checksum is published for " + srv.URL + "/maven/org/example/lib/1.0.0/lib-1.0.0.pom",
+		},
+		{
+			name:        "generic without a checksum",
+			artifact:    v1alpha1.ZarfArtifact{Ecosystem: v1alpha1.ArtifactEcosystemGeneric, Name: "tool", Version: "1.2.3", URL: srv.URL + "/tool.tar.gz"},
+			expectedErr: "add an @sha256 checksum suffix to the url",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			err := Pull(ctx, http.DefaultClient, tt.artifact, t.TempDir())
+			require.ErrorContains(t, err, tt.expectedErr)
+		})
+	}
+}
@@ -0,0 +1,487 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2021-Present The Zarf Authors
+
+package git
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"crypto/tls"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/filemode"
+	"github.com/go-git/go-git/v5/plumbing/object"
+
+	"github.com/zarf-dev/zarf/src/pkg/logger"
+	"github.com/zarf-dev/zarf/src/pkg/transform"
+	"github.com/zarf-dev/zarf/src/pkg/utils"
+)
+
+const (
+	lfsPointerVersion = "version https://git-lfs.github.com/spec/v1"
+	// lfsPointerMaxSize is the size above which git-lfs no longer considers a blob to be a pointer.
+	lfsPointerMaxSize = 1024
+	lfsBatchSize      = 100
+	lfsMediaType      = "application/vnd.git-lfs+json"
+)
+
+var lfsOIDRegex = regexp.MustCompile(`^[0-9a-f]{64}$`)
+
+// lfsObject identifies a Git LFS object by the SHA-256 of its content.
+type lfsObject struct {
+	OID  string `json:"oid"`
+	Size int64  `json:"size"`
+}
+
+type lfsBatchRequest struct {
+	Operation string      `json:"operation"`
+	Transfers []string    `json:"transfers"`
+	Objects   []lfsObject `json:"objects"`
+}
+
+type lfsBatchResponse struct {
+	Objects []lfsBatchObject `json:"objects"`
+}
+
+type lfsBatchObject struct {
+	lfsObject
+	Actions map[string]lfsAction `json:"actions,omitempty"`
+	Error   *lfsObjectError      `json:"error,omitempty"`
+}
+
+type lfsAction struct {
+	Href   string            `json:"href"`
+	Header map[string]string `json:"header,omitempty"`
+}
+
+type lfsObjectError struct {
+	Code    int    `json:"code"`
+	Message string `json:"message"`
+}
+
+// lfsClient talks to the Git LFS batch API of a single repository.
+type lfsClient struct {
+	client   *http.Client
+	endpoint string
+	username string
+	password string
+	// serverAddress is the address the Git server builds its action URLs from, set when it is reached through a tunnel.
+	serverAddress string
+}
+
+// NewLFSHTTPClient returns the HTTP client used to transfer Git LFS objects.
+func NewLFSHTTPClient(insecureSkipTLSVerify bool) (*http.Client, error) {
+	transport, ok := http.DefaultTransport.(*http.Transport)
+	if !ok {
+		return nil, errors.New("could not get default transport")
+	}
+	transport = transport.Clone()
+	transport.TLSClientConfig = &tls.Config{InsecureSkipVerify: insecureSkipTLSVerify} //nolint:gosec // Controlled by --insecure-skip-tls-verify.
+	return &http.Client{Transport: transport}, nil
+}
+
+// lfsEndpoint returns the Git LFS server URL of a repository following the git-lfs defaults.
+func lfsEndpoint(repoURL string) string {
+	repoURL = strings.TrimSuffix(repoURL, "/")
+	if !strings.HasSuffix(repoURL, ".git") {
+		repoURL += ".git"
+	}
+	return repoURL + "/info/lfs"
+}
+
+// parseLFSPointer returns the object referenced by a Git LFS pointer file.
+func parseLFSPointer(b []byte) (lfsObject, bool) {
+	if len(b) > lfsPointerMaxSize {
+		return lfsObject{}, false
+	}
+	scanner := bufio.NewScanner(bytes.NewReader(b))
+	if !scanner.Scan() || scanner.Text() != lfsPointerVersion {
+		return lfsObject{}, false
+	}
+	obj := lfsObject{Size: -1}
+	for scanner.Scan() {
+		key, value, ok := strings.Cut(scanner.Text(), " ")
+		if !ok {
+			return lfsObject{}, false
+		}
+		switch key {
+		case "oid":
+			oid, ok := strings.CutPrefix(value, "sha256:")
+			if !ok || !lfsOIDRegex.MatchString(oid) {
+				return lfsObject{}, false
+			}
+			obj.OID = oid
+		case "size":
+			size, err := strconv.ParseInt(value, 10, 64)
+			if err != nil || size < 0 {
+				return lfsObject{}, false
+			}
+			obj.Size = size
+		}
+	}
+	if obj.OID == "" || obj.Size < 0 {
+		return lfsObject{}, false
+	}
+	return obj, true
+}
+
+// FetchLFS downloads the Git LFS objects referenced by the branches and tags of the repository.
+func (r *Repository) FetchLFS(ctx context.Context, httpClient *http.Client, address string) error {
+	l := logger.From(ctx)
+	gitURLNoRef, _, err := transform.GitURLSplitRef(address)
+	if err != nil {
+		return err
+	}
+
+	pointers, err := r.lfsPointers()
+	if err != nil {
+		return err
+	}
+	missing := []lfsObject{}
+	for _, obj := range pointers {
+		if _, err := os.Stat(r.lfsObjectPath(obj.OID)); err == nil {
+			continue
+		}
+		missing = append(missing, obj)
+	}
+	if len(missing) == 0 {
+		return nil
+	}
+
+	client := lfsClient{client: httpClient, endpoint: lfsEndpoint(gitURLNoRef)}
+	gitCred, err := utils.FindAuthForHost(gitURLNoRef)
+	if err != nil {
+		return err
+	}
+	if gitCred != nil {
+		client.username = gitCred.Auth.Username
+		client.password = gitCred.Auth.Password
+	}
+
+	l.Info("fetching Git LFS objects", "address", address, "count", len(missing))
+	for batch := range slices.Chunk(missing, lfsBatchSize) {
+		resp, err := client.batch(ctx, "download", batch)
+		if err != nil {
+			return err
+		}
+		for _, obj := range resp.Objects {
+			if obj.Error != nil {
+				return fmt.Errorf("unable to download Git LFS object %s: %s", obj.OID, obj.Error.Message)
+			}
+			action, ok := obj.Actions["download"]
+			if !ok {
+				return fmt.Errorf("the Git LFS server did not return a download for object %s", obj.OID)
+			}
+			if err := client.download(ctx, action, obj.lfsObject, r.lfsObjectPath(obj.OID)); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// pushLFS uploads the Git LFS objects stored with the repository to the target repository. Actions that the
+// server returns for serverAddress are sent to the host of targetURL instead.
+func (r *Repository) pushLFS(ctx context.Context, httpClient *http.Client, targetURL, serverAddress, username, password string) error {
+	l := logger.From(ctx)
+	objects, err := r.localLFSObjects()
+	if err != nil {
+		return err
+	}
+	if len(objects) == 0 {
+		return nil
+	}
+
+	client := lfsClient{
+		client:        httpClient,
+		endpoint:      lfsEndpoint(targetURL),
+		username:      username,
+		password:      password,
+		serverAddress: serverAddress,
+	}
+	l.Info("pushing Git LFS objects", "count", len(objects))
+	for batch := range slices.Chunk(objects, lfsBatchSize) {
+		resp, err := client.batch(ctx, "upload", batch)
+		if err != nil {
+			return err
+		}
+		for _, obj := range resp.Objects {
+			if obj.Error != nil {
+				return fmt.Errorf("unable to upload Git LFS object %s: %s", obj.OID, obj.Error.Message)
+			}
+			// Objects without an upload action are already stored on the server
+			action, ok := obj.Actions["upload"]
+			if !ok {
+				continue
+			}
+			if err := client.upload(ctx, action, r.lfsObjectPath(obj.OID), obj.Size); err != nil {
+				return err
+			}
+			if verify, ok := obj.Actions["verify"]; ok {
+				if err := client.verify(ctx, verify, obj.lfsObject); err != nil {
+					return err
+				}
+			}
+		}
+	}
+	return nil
+}
+
+func (r *Repository) lfsObjectPath(oid string) string {
+	return filepath.Join(r.path, ".git", "lfs", "objects", oid[0:2], oid[2:4], oid)
+}
+
+// lfsPointers returns the Git LFS objects referenced at the tip of every branch and tag.
+func (r *Repository) lfsPointers() ([]lfsObject, error) {
+	repo, err := git.PlainOpen(r.path)
+	if err != nil {
+		return nil, fmt.Errorf("not a valid git repo or unable to open: %w", err)
+	}
+	refs, err := packagedRefs(repo)
+	if err != nil {
+		return nil, err
+	}
+	commits := map[plumbing.Hash]*object.Commit{}
+	for _, commit := range refs {
+		commits[commit.Hash] = commit
+	}
+
+	seen := map[string]bool{}
+	pointers := []lfsObject{}
+	for _, commit := range commits {
+		tree, err := commit.Tree()
+		if err != nil {
+			return nil, err
+		}
+		err = tree.Files().ForEach(func(f *object.File) error {
+			if f.Size > lfsPointerMaxSize || (f.Mode != filemode.Regular && f.Mode != filemode.Executable) {
+				return nil
+			}
+			contents, err := f.Contents()
+			if err != nil {
+				return err
+			}
+			obj, ok := parseLFSPointer([]byte(contents))
+			if !ok || seen[obj.OID] {
+				return nil
+			}
+			seen[obj.OID] = true
+			pointers = append(pointers, obj)
+			return nil
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+	slices.SortFunc(pointers, func(a, b lfsObject) int {
+		return strings.Compare(a.OID, b.OID)
+	})
+	return pointers, nil
+}
+
+// localLFSObjects returns the Git LFS objects stored with the repository.
+func (r *Repository) localLFSObjects() ([]lfsObject, error) {
+	objects := []lfsObject{}
+	root := filepath.Join(r.path, ".git", "lfs", "objects")
+	err := filepath.WalkDir(root, func(path string, d fs.DirEntry, err error) error {
+		if errors.Is(err, fs.ErrNotExist) && path == root {
+			return filepath.SkipDir
+		}
+		if err != nil {
+			return err
+		}
+		if d.IsDir() || !lfsOIDRegex.MatchString(d.Name()) {
+			return nil
+		}
+		info, err := d.Info()
+		if err != nil {
+			return err
+		}
+		objects = append(objects, lfsObject{OID: d.Name(), Size: info.Size()})
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return objects, nil
+}
+
+func (c lfsClient) batch(ctx context.Context, operation string, objects []lfsObject) (lfsBatchResponse, error) {
+	b, err := json.Marshal(lfsBatchRequest{
+		Operation: operation,
+		Transfers: []string{"basic"},
+		Objects:   objects,
+	})
+	if err != nil {
+		return lfsBatchResponse{}, err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.endpoint+"/objects/batch", bytes.NewReader(b))
+	if err != nil {
+		return lfsBatchResponse{}, err
+	}
+	req.Header.Set("Accept", lfsMediaType)
+	req.Header.Set("Content-Type", lfsMediaType)
+	if c.username != "" || c.password != "" {
+		req.SetBasicAuth(c.username, c.password)
+	}
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return lfsBatchResponse{}, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+		return lfsBatchResponse{}, fmt.Errorf("the %s batch request to the Git LFS server %s failed with status %d: %s", operation, c.endpoint, resp.StatusCode, strings.TrimSpace(string(body)))
+	}
+	var batchResp lfsBatchResponse
+	if err := json.NewDecoder(resp.Body).Decode(&batchResp); err != nil {
+		return lfsBatchResponse{}, fmt.Errorf("unable to decode the Git LFS batch response: %w", err)
+	}
+	return batchResp, nil
+}
+
+func (c lfsClient) download(ctx context.Context, action lfsAction, obj lfsObject, dst string) (err error) {
+	resp, err := c.do(ctx, http.MethodGet, action, nil, -1)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if err := os.MkdirAll(filepath.Dir(dst), 0o755); err != nil {
+		return err
+	}
+	f, err := os.CreateTemp(filepath.Dir(dst), obj.OID+".*.tmp")
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err != nil {
+			err = errors.Join(err, os.Remove(f.Name()))
+		}
+	}()
+
+	h := sha256.New()
+	n, err := io.Copy(io.MultiWriter(f, h), resp.Body)
+	if err != nil {
+		return errors.Join(fmt.Errorf("unable to download Git LFS object %s: %w", obj.OID, err), f.Close())
+	}
+	if err := f.Close(); err != nil {
+		return err
+	}
+	if n != obj.Size || hex.EncodeToString(h.Sum(nil)) != obj.OID {
+		return fmt.Errorf("downloaded Git LFS object %s does not match its pointer", obj.OID)
+	}
+	return os.Rename(f.Name(), dst)
+}
+
+func (c lfsClient) upload(ctx context.Context, action lfsAction, src string, size int64) error {
+	f, err := os.Open(src)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	resp, err := c.do(ctx, http.MethodPut, action, f, size)
+	if err != nil {
+		return err
+	}
+	return resp.Body.Close()
+}
+
+func (c lfsClient) verify(ctx context.Context, action lfsAction, obj lfsObject) error {
+	b, err := json.Marshal(obj)
+	if err != nil {
+		return err
+	}
+	resp, err := c.do(ctx, http.MethodPost, action, bytes.NewReader(b), int64(len(b)))
+	if err != nil {
+		return err
+	}
+	return resp.Body.Close()
+}
+
+// do runs a transfer action, authenticating with the Git server when the action is served by it without its own credentials.
+func (c lfsClient) do(ctx context.Context, method string, action lfsAction, body io.Reader, size int64) (*http.Response, error) {
+	href, err := c.actionURL(action.Href)
+	if err != nil {
+		return nil, err
+	}
+	req, err := http.NewRequestWithContext(ctx, method, href, body)
+	if err != nil {
+		return nil, err
+	}
+	if size >= 0 {
+		req.ContentLength = size
+	}
+	switch method {
+	case http.MethodPut:
+		req.Header.Set("Content-Type", "application/octet-stream")
+	case http.MethodPost:
+		req.Header.Set("Accept", lfsMediaType)
+		req.Header.Set("Content-Type", lfsMediaType)
+	}
+	for k, v := range action.Header {
+		req.Header.Set(k, v)
+	}
+	if req.Header.Get("Authorization") == "" && (c.username != "" || c.password != "") && sameHost(c.endpoint, href) {
+		req.SetBasicAuth(c.username, c.password)
+	}
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+		resp.Body.Close()
+		return nil, fmt.Errorf("the Git LFS transfer %s %s failed with status %d: %s", method, href, resp.StatusCode, strings.TrimSpace(string(body)))
+	}
+	return resp, nil
+}
+
+// actionURL returns the URL an action is sent to. The Git server builds action URLs from the address it knows itself
+// by, e.g. Gitea's ROOT_URL, which cannot be resolved when the server is reached through a tunnel, so these are moved
+// onto the scheme and host of the endpoint.
+func (c lfsClient) actionURL(href string) (string, error) {
+	if c.serverAddress == "" || sameHost(c.endpoint, href) || !sameHost(c.serverAddress, href) {
+		return href, nil
+	}
+	endpointURL, err := url.Parse(c.endpoint)
+	if err != nil {
+		return "", err
+	}
+	hrefURL, err := url.Parse(href)
+	if err != nil {
+		return "", err
+	}
+	hrefURL.Scheme = endpointURL.Scheme
+	hrefURL.Host = endpointURL.Host
+	return hrefURL.String(), nil
+}
+
+func sameHost(a, b string) bool {
+	aURL, err := url.Parse(a)
+	if err != nil {
+		return false
+	}
+	bURL, err := url.Parse(b)
+	if err != nil {
+		return false
+	}
+	return aURL.Host == bURL.Host
+}
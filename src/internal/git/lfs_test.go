@@ -0,0 +1,269 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2021-Present The Zarf Authors
+
+package git
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing/object"
+	"github.com/stretchr/testify/require"
+
+	"github.com/zarf-dev/zarf/src/test/testutil"
+)
+
+// lfsServer is a minimal Git LFS server storing objects per repository.
+type lfsServer struct {
+	mu      sync.Mutex
+	objects map[string]map[string][]byte
+	srv     *httptest.Server
+	// rootURL is the address the server builds action URLs from, defaulting to the URL it is served on.
+	rootURL string
+}
+
+func newLFSServer(t *testing.T) *lfsServer {
+	t.Helper()
+	s := &lfsServer{objects: map[string]map[string][]byte{}}
+	s.srv = httptest.NewTLSServer(http.HandlerFunc(s.ServeHTTP))
+	t.Cleanup(s.srv.Close)
+	return s
+}
+
+func (s *lfsServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	repo, rest, ok := strings.Cut(strings.TrimPrefix(r.URL.Path, "/"), ".git/info/lfs/objects/")
+	if !ok {
+		http.NotFound(w, r)
+		return
+	}
+	if s.objects[repo] == nil {
+		s.objects[repo] = map[string][]byte{}
+	}
+	store := s.objects[repo]
+	switch {
+	case rest == "batch" && r.Method == http.MethodPost:
+		var req lfsBatchRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		rootURL := s.srv.URL
+		if s.rootURL != "" {
+			rootURL = s.rootURL
+		}
+		resp := lfsBatchResponse{}
+		for _, obj := range req.Objects {
+			href := fmt.Sprintf("%s/%s.git/info/lfs/objects/%s", rootURL, repo, obj.OID)
+			batchObj := lfsBatchObject{lfsObject: obj}
+			_, exists := store[obj.OID]
+			switch {
+			case req.Operation == "download" && !exists:
+				batchObj.Error = &lfsObjectError{Code: http.StatusNotFound, Message: "object does not exist"}
+			case req.Operation == "download":
+				batchObj.Actions = map[string]lfsAction{"download": {Href: href}}
+			case !exists:
+				batchObj.Actions = map[string]lfsAction{"upload": {Href: href}}
+			}
+			resp.Objects = append(resp.Objects, batchObj)
+		}
+		w.Header().Set("Content-Type", lfsMediaType)
+		//nolint:errcheck
+		json.NewEncoder(w).Encode(resp)
+	case r.Method == http.MethodGet:
+		b, ok := store[rest]
+		if !ok {
+			http.NotFound(w, r)
+			return
+		}
+		//nolint:errcheck
+		w.Write(b)
+	case r.Method == http.MethodPut:
+		if username, _, ok := r.BasicAuth(); !ok || username != "push-user" {
+			http.Error(w, "unauthorized", http.StatusUnauthorized)
+			return
+		}
+		b, err := io.ReadAll(r.Body)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		store[rest] = b
+	default:
+		http.NotFound(w, r)
+	}
+}
+
+func lfsPointer(content []byte) (string, string) {
+	sum := sha256.Sum256(content)
+	oid := hex.EncodeToString(sum[:])
+	return oid, fmt.Sprintf("%s\noid sha256:%s\nsize %d\n", lfsPointerVersion, oid, len(content))
+}
+
+func TestLFS(t *testing.T) {
+	t.Parallel()
+	ctx := testutil.TestContext(t)
+
+	server := newLFSServer(t)
+	content := []byte("model weights")
+	oid, pointer := lfsPointer(content)
+	server.objects["upstream"] = map[string][]byte{oid: content}
+
+	repoPath := t.TempDir()
+	initRepo, err := git.PlainInit(repoPath, false)
+	require.NoError(t, err)
+	require.NoError(t, os.WriteFile(filepath.Join(repoPath, "model.bin"), []byte(pointer), 0o644))
+	require.NoError(t, os.WriteFile(filepath.Join(repoPath, "README.md"), []byte("# Model\n"), 0o644))
+	w, err := initRepo.Worktree()
+	require.NoError(t, err)
+	_, err = w.Add(".")
+	require.NoError(t, err)
+	_, err = w.Commit("Initial commit", &git.CommitOptions{
+		Author: &object.Signature{Email: "example@example.com"},
+	})
+	require.NoError(t, err)
+
+	r := &Repository{path: repoPath}
+	// The default client does not trust the certificate of the server
+	err = r.FetchLFS(ctx, http.DefaultClient, fmt.Sprintf("%s/upstream.git@main", server.srv.URL))
+	require.ErrorContains(t, err, "certificate")
+	insecureClient, err := NewLFSHTTPClient(true)
+	require.NoError(t, err)
+	err = r.FetchLFS(ctx, insecureClient, fmt.Sprintf("%s/upstream.git@main", server.srv.URL))
+	require.NoError(t, err)
+	b, err := os.ReadFile(r.lfsObjectPath(oid))
+	require.NoError(t, err)
+	require.Equal(t, content, b)
+
+	err = r.pushLFS(ctx, server.srv.Client(), fmt.Sprintf("%s/push-user/upstream.git", server.srv.URL), "", "push-user", "password")
+	require.NoError(t, err)
+	require.Equal(t, content, server.objects["push-user/upstream"][oid])
+
+	// Objects already on the server are not uploaded again
+	server.objects["push-user/upstream"][oid] = []byte("unchanged")
+	err = r.pushLFS(ctx, server.srv.Client(), fmt.Sprintf("%s/push-user/upstream.git", server.srv.URL), "", "push-user", "password")
+	require.NoError(t, err)
+	require.Equal(t, []byte("unchanged"), server.objects["push-user/upstream"][oid])
+
+	// Actions built from the in-cluster address of the server are sent through the tunnel with credentials
+	server.mu.Lock()
+	server.rootURL = "http://zarf-gitea-http.zarf.svc.cluster.local:3000"
+	server.mu.Unlock()
+	err = r.pushLFS(ctx, server.srv.Client(), fmt.Sprintf("%s/push-user/tunneled.git", server.srv.URL), server.rootURL, "push-user", "password")
+	require.NoError(t, err)
+	require.Equal(t, content, server.objects["push-user/tunneled"][oid])
+	server.mu.Lock()
+	server.rootURL = ""
+	server.mu.Unlock()
+
+	// Pointers to objects the server does not have fail the fetch
+	require.NoError(t, os.RemoveAll(filepath.Join(repoPath, ".git", "lfs")))
+	err = r.FetchLFS(ctx, server.srv.Client(), fmt.Sprintf("%s/other.git", server.srv.URL))
+	require.ErrorContains(t, err, "object does not exist")
+}
+
+func TestParseLFSPointer(t *testing.T) {
+	t.Parallel()
+
+	oid, pointer := lfsPointer([]byte("hello"))
+	tests := []struct {
+		name     string
+		contents string
+		expected lfsObject
+		ok       bool
+	}{
+		{
+			name:     "pointer",
+			contents: pointer,
+			expected: lfsObject{OID: oid, Size: 5},
+			ok:       true,
+		},
+		{
+			name:     "pointer with extension",
+			contents: fmt.Sprintf("%s\next-0-foo sha256:%s\noid sha256:%s\nsize 5\n", lfsPointerVersion, oid, oid),
+			expected: lfsObject{OID: oid, Size: 5},
+			ok:       true,
+		},
+		{
+			name:     "regular file",
+			contents: "hello world\n",
+		},
+		{
+			name:     "missing size",
+			contents: fmt.Sprintf("%s\noid sha256:%s\n", lfsPointerVersion, oid),
+		},
+		{
+			name:     "invalid oid",
+			contents: fmt.Sprintf("%s\noid sha256:abc\nsize 5\n", lfsPointerVersion),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			obj, ok := parseLFSPointer([]byte(tt.contents))
+			require.Equal(t, tt.ok, ok)
+			require.Equal(t, tt.expected, obj)
+		})
+	}
+}
+
+func TestLFSActionURL(t *testing.T) {
+	t.Parallel()
+
+	c := lfsClient{
+		endpoint:      "http://127.0.0.1:41234/push-user/repo.git/info/lfs",
+		serverAddress: "http://zarf-gitea-http.zarf.svc.cluster.local:3000",
+	}
+	tests := []struct {
+		name     string
+		client   lfsClient
+		href     string
+		expected string
+	}{
+		{
+			name:     "server address is moved onto the tunnel",
+			client:   c,
+			href:     "http://zarf-gitea-http.zarf.svc.cluster.local:3000/push-user/repo.git/info/lfs/objects/abc",
+			expected: "http://127.0.0.1:41234/push-user/repo.git/info/lfs/objects/abc",
+		},
+		{
+			name:     "endpoint is kept",
+			client:   c,
+			href:     "http://127.0.0.1:41234/push-user/repo.git/info/lfs/objects/abc",
+			expected: "http://127.0.0.1:41234/push-user/repo.git/info/lfs/objects/abc",
+		},
+		{
+			name:     "other hosts are kept",
+			client:   c,
+			href:     "https://objects.example.com/abc?signature=xyz",
+			expected: "https://objects.example.com/abc?signature=xyz",
+		},
+		{
+			name:     "no server address",
+			client:   lfsClient{endpoint: c.endpoint},
+			href:     "http://zarf-gitea-http.zarf.svc.cluster.local:3000/push-user/repo.git/info/lfs/objects/abc",
+			expected: "http://zarf-gitea-http.zarf.svc.cluster.local:3000/push-user/repo.git/info/lfs/objects/abc",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			href, err := tt.client.actionURL(tt.href)
+			require.NoError(t, err)
+			require.Equal(t, tt.expected, href)
+		})
+	}
+}
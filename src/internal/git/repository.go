@@ -7,8 +7,11 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	nethttp "net/http"
+	"net/url"
 	"os"
 	"path/filepath"
+	"slices"
 	"strings"
 
 	"github.com/go-git/go-git/v5"
@@ -18,6 +21,7 @@ import (
 	"github.com/go-git/go-git/v5/plumbing/transport"
 	"github.com/go-git/go-git/v5/plumbing/transport/http"
 
+	"github.com/zarf-dev/zarf/src/pkg/images"
 	"github.com/zarf-dev/zarf/src/pkg/logger"
 	"github.com/zarf-dev/zarf/src/pkg/transform"
 	"github.com/zarf-dev/zarf/src/pkg/utils"
@@ -113,6 +117,15 @@ func Clone(ctx context.Context, rootPath, address string, shallow bool) (*Reposi
 		return nil
 	}
 
+	// A clone holds a slot of its host for its whole duration, go-git does not let the request transport be set per clone
+	if u, err := url.Parse(gitURLNoRef); err == nil && u.Host != "" {
+		release, err := images.SchedulerFrom(ctx).Acquire(ctx, u.Host)
+		if err != nil {
+			return nil, err
+		}
+		defer release()
+	}
+
 	l.Info("cloning Git repository", "address", address)
 
 	repo, err := git.PlainCloneContext(ctx, r.path, false, cloneOpts)
@@ -162,37 +175,66 @@ func (r *Repository) Path() string {
 	return r.path
 }
 
-// Push pushes the repository to the remote git server.
-func (r *Repository) Push(ctx context.Context, address, username, password string) error {
+// PushOptions are optional parameters to Push.
+type PushOptions struct {
+	// NoForce refuses to update refs that are not a fast-forward of the remote instead of force pushing them.
+	NoForce bool
+	// Owner is the user or organization the repository is pushed under, defaulting to the pushing user.
+	Owner string
+	// LFSClient is the HTTP client the Git LFS objects of the repository are uploaded with, defaulting to http.DefaultClient.
+	LFSClient *nethttp.Client
+	// ServerAddress is the address the git server knows itself by when the push reaches it through a tunnel.
+	// Git LFS actions the server returns for this address are sent through the tunnel instead.
+	ServerAddress string
+}
+
+// PushReport lists the refs of a push by how they changed on the remote.
+type PushReport struct {
+	Created   []string
+	Updated   []string
+	Unchanged []string
+	// Forced are the updated refs that were not a fast-forward of the remote.
+	Forced []string
+}
+
+// ErrNonFastForward is returned by Push when NoForce is set and a ref is not a fast-forward of the remote.
+var ErrNonFastForward = errors.New("refusing to update refs that are not a fast-forward of the remote")
+
+// Push pushes the branches and tags that differ from the remote git server.
+func (r *Repository) Push(ctx context.Context, address, username, password string, opts PushOptions) (PushReport, error) {
 	l := logger.From(ctx)
 	repo, err := git.PlainOpen(r.path)
 	if err != nil {
-		return fmt.Errorf("not a valid git repo or unable to open: %w", err)
+		return PushReport{}, fmt.Errorf("not a valid git repo or unable to open: %w", err)
 	}
 
 	// Configure new remote
 	remote, err := repo.Remote(onlineRemoteName)
 	if err != nil {
-		return fmt.Errorf("unable to find the git remote: %w", err)
+		return PushReport{}, fmt.Errorf("unable to find the git remote: %w", err)
 	}
 	if len(remote.Config().URLs) == 0 {
-		return fmt.Errorf("repository has zero remotes configured")
+		return PushReport{}, fmt.Errorf("repository has zero remotes configured")
+	}
+	owner := opts.Owner
+	if owner == "" {
+		owner = username
 	}
-	targetURL, err := transform.GitURL(address, remote.Config().URLs[0], username)
+	targetURL, err := transform.GitURL(address, remote.Config().URLs[0], owner)
 	if err != nil {
-		return fmt.Errorf("unable to transform the git url: %w", err)
+		return PushReport{}, fmt.Errorf("unable to transform the git url: %w", err)
 	}
 	// Remove any preexisting offlineRemotes (happens when a retry is triggered)
 	err = repo.DeleteRemote(offlineRemoteName)
 	if err != nil && !errors.Is(err, git.ErrRemoteNotFound) {
-		return err
+		return PushReport{}, err
 	}
-	_, err = repo.CreateRemote(&config.RemoteConfig{
+	offlineRemote, err := repo.CreateRemote(&config.RemoteConfig{
 		Name: offlineRemoteName,
 		URLs: []string{targetURL.String()},
 	})
 	if err != nil {
-		return fmt.Errorf("failed to create offline remote: %w", err)
+		return PushReport{}, fmt.Errorf("failed to create offline remote: %w", err)
 	}
 
 	// Push to new remote
@@ -201,49 +243,195 @@ func (r *Repository) Push(ctx context.Context, address, username, password strin
 		Password: password,
 	}
 
-	// Fetch remote offline refs in case of old update or if multiple refs are specified in one package
-	// Attempt the fetch, if it fails, log a warning and continue trying to push (might as well try..)
-	fetchOptions := &git.FetchOptions{
-		RemoteName: offlineRemoteName,
-		Auth:       &gitCred,
-		RefSpecs: []config.RefSpec{
-			"refs/heads/*:refs/heads/*",
-			"refs/tags/*:refs/tags/*",
-		},
-	}
-	err = repo.FetchContext(ctx, fetchOptions)
-	if errors.Is(err, transport.ErrRepositoryNotFound) {
-		l.Debug("repo not yet available offline, skipping fetch")
-	} else if errors.Is(err, git.ErrForceNeeded) {
-		l.Debug("repo fetch requires force, skipping fetch")
-	} else if errors.Is(err, git.NoErrAlreadyUpToDate) {
-		l.Debug("repo already up-to-date, skipping fetch")
+	// Compare the refs advertised by the remote with the packaged refs to only push what changed
+	advertised, err := offlineRemote.ListContext(ctx, &git.ListOptions{Auth: &gitCred})
+	if errors.Is(err, transport.ErrRepositoryNotFound) || errors.Is(err, transport.ErrEmptyRemoteRepository) {
+		l.Debug("repo not yet available offline, pushing all refs")
 	} else if err != nil {
-		return fmt.Errorf("unable to fetch the git repo prior to push: %w", err)
+		return PushReport{}, fmt.Errorf("unable to list the refs of the git repo prior to push: %w", err)
+	}
+	remoteRefs := map[plumbing.ReferenceName]plumbing.Hash{}
+	for _, ref := range advertised {
+		if ref.Type() == plumbing.HashReference {
+			remoteRefs[ref.Name()] = ref.Hash()
+		}
+	}
+	if opts.NoForce {
+		// Commits made on the server are fetched so branches the server moved past can be told apart from diverged
+		// ones, refs whose commits stay missing are not a fast-forward
+		err = fetchRemoteCommits(ctx, repo, remoteRefs, &gitCred)
+		if err != nil {
+			l.Warn("unable to fetch the commits of the remote refs", "remote", targetURL.String(), "error", err)
+		}
+	}
+	report, refSpecs, err := planPush(repo, remoteRefs, opts.NoForce)
+	if err != nil {
+		return PushReport{}, err
+	}
+	if opts.NoForce && len(report.Forced) > 0 {
+		return report, fmt.Errorf("%w: %s", ErrNonFastForward, strings.Join(report.Forced, ", "))
+	}
+	for _, name := range report.Forced {
+		l.Warn("force pushing ref that is not a fast-forward of the remote", "ref", name, "remote", targetURL.String())
 	}
 
-	// Push all heads and tags to the offline remote
-	err = repo.PushContext(ctx, &git.PushOptions{
-		RemoteName: offlineRemoteName,
-		Auth:       &gitCred,
-		// TODO: (@JEFFMCCOY) add the parsing for the `+` force prefix (see https://github.com/zarf-dev/zarf/issues/1410)
-		//Force: isForce,
-		// If a provided refspec doesn't push anything, it is just ignored
-		RefSpecs: []config.RefSpec{
-			"refs/heads/*:refs/heads/*",
-			"refs/tags/*:refs/tags/*",
-		},
-	})
-	if errors.Is(err, git.NoErrAlreadyUpToDate) {
+	if len(refSpecs) == 0 {
 		l.Debug("repo already up-to-date")
-	} else if errors.Is(err, plumbing.ErrObjectNotFound) {
-		return fmt.Errorf("unable to push repo due to likely shallow clone: %w", err)
-	} else if err != nil {
-		return fmt.Errorf("unable to push repo to the gitops service: %w", err)
+	} else {
+		err = repo.PushContext(ctx, &git.PushOptions{
+			RemoteName: offlineRemoteName,
+			Auth:       &gitCred,
+			RefSpecs:   refSpecs,
+		})
+		if errors.Is(err, git.NoErrAlreadyUpToDate) {
+			l.Debug("repo already up-to-date")
+		} else if errors.Is(err, plumbing.ErrObjectNotFound) {
+			return report, fmt.Errorf("unable to push repo due to likely shallow clone: %w", err)
+		} else if err != nil {
+			return report, fmt.Errorf("unable to push repo to the gitops service: %w", err)
+		}
+	}
+	// Gitea only accepts LFS objects for repositories that exist, so these are pushed after the refs
+	lfsClient := opts.LFSClient
+	if lfsClient == nil {
+		lfsClient = nethttp.DefaultClient
+	}
+	err = r.pushLFS(ctx, lfsClient, targetURL.String(), opts.ServerAddress, username, password)
+	if err != nil {
+		return report, fmt.Errorf("unable to push Git LFS objects to the gitops service: %w", err)
 	}
 
+	return report, nil
+}
+
+// fetchRemoteCommits fetches the remote branches whose commits are missing from the local repository, such as commits
+// made on the git server, into remote-tracking refs so that the branches can be compared with the local history.
+func fetchRemoteCommits(ctx context.Context, repo *git.Repository, remoteRefs map[plumbing.ReferenceName]plumbing.Hash, auth transport.AuthMethod) error {
+	refSpecs := []config.RefSpec{}
+	for name, hash := range remoteRefs {
+		if !name.IsBranch() {
+			continue
+		}
+		if _, err := repo.Reference(name, false); err != nil {
+			continue
+		}
+		if _, err := repo.CommitObject(hash); err == nil {
+			continue
+		}
+		refSpecs = append(refSpecs, config.RefSpec(fmt.Sprintf("+%s:refs/remotes/%s/%s", name, offlineRemoteName, name.Short())))
+	}
+	if len(refSpecs) == 0 {
+		return nil
+	}
+	slices.Sort(refSpecs)
+	err := repo.FetchContext(ctx, &git.FetchOptions{
+		RemoteName: offlineRemoteName,
+		RefSpecs:   refSpecs,
+		Auth:       auth,
+		Tags:       git.NoTags,
+	})
+	if err != nil && !errors.Is(err, git.NoErrAlreadyUpToDate) {
+		return err
+	}
 	return nil
 }
+
+// planPush compares the local branches and tags with the refs on the remote and returns the refspecs that push the
+// refs that differ, force pushing the refs that are not a fast-forward of the remote. With noForce, branches that the
+// remote has already moved past are left unchanged instead of being reset to the local commit.
+func planPush(repo *git.Repository, remoteRefs map[plumbing.ReferenceName]plumbing.Hash, noForce bool) (PushReport, []config.RefSpec, error) {
+	refs, err := repo.References()
+	if err != nil {
+		return PushReport{}, nil, err
+	}
+	localRefs := []*plumbing.Reference{}
+	err = refs.ForEach(func(ref *plumbing.Reference) error {
+		if ref.Type() == plumbing.HashReference && (ref.Name().IsBranch() || ref.Name().IsTag()) {
+			localRefs = append(localRefs, ref)
+		}
+		return nil
+	})
+	if err != nil {
+		return PushReport{}, nil, err
+	}
+	slices.SortFunc(localRefs, func(a, b *plumbing.Reference) int {
+		return strings.Compare(a.Name().String(), b.Name().String())
+	})
+
+	report := PushReport{}
+	refSpecs := []config.RefSpec{}
+	for _, ref := range localRefs {
+		name := ref.Name().String()
+		remoteHash, ok := remoteRefs[ref.Name()]
+		switch {
+		case !ok:
+			report.Created = append(report.Created, name)
+			refSpecs = append(refSpecs, config.RefSpec(fmt.Sprintf("%s:%s", name, name)))
+		case remoteHash == ref.Hash():
+			report.Unchanged = append(report.Unchanged, name)
+		case ref.Name().IsBranch() && isAncestor(repo, remoteHash, ref.Hash()):
+			report.Updated = append(report.Updated, name)
+			refSpecs = append(refSpecs, config.RefSpec(fmt.Sprintf("%s:%s", name, name)))
+		case noForce && ref.Name().IsBranch() && isAncestor(repo, ref.Hash(), remoteHash):
+			// The remote already contains the local commit along with commits made on the git server
+			report.Unchanged = append(report.Unchanged, name)
+		default:
+			// Moving a tag always requires a force push
+			report.Updated = append(report.Updated, name)
+			report.Forced = append(report.Forced, name)
+			refSpecs = append(refSpecs, config.RefSpec(fmt.Sprintf("+%s:%s", name, name)))
+		}
+	}
+	return report, refSpecs, nil
+}
+
+// isAncestor returns true if the ancestor commit is part of the history of the descendant commit. Commits that are
+// missing from the local repository could not be fetched, so neither commit is treated as an ancestor of the other.
+func isAncestor(repo *git.Repository, ancestorHash, descendantHash plumbing.Hash) bool {
+	ancestor, err := repo.CommitObject(ancestorHash)
+	if err != nil {
+		return false
+	}
+	descendant, err := repo.CommitObject(descendantHash)
+	if err != nil {
+		return false
+	}
+	ok, err := ancestor.IsAncestor(descendant)
+	return err == nil && ok
+}
+
+// packagedRefs returns the commit at the tip of every branch and tag in the repository.
+func packagedRefs(repo *git.Repository) (map[plumbing.ReferenceName]*object.Commit, error) {
+	refs, err := repo.References()
+	if err != nil {
+		return nil, err
+	}
+	commits := map[plumbing.ReferenceName]*object.Commit{}
+	err = refs.ForEach(func(ref *plumbing.Reference) error {
+		if ref.Type() != plumbing.HashReference || !(ref.Name().IsBranch() || ref.Name().IsTag()) {
+			return nil
+		}
+		obj, err := repo.Object(plumbing.AnyObject, ref.Hash())
+		if err != nil {
+			return fmt.Errorf("unable to resolve %s: %w", ref.Name(), err)
+		}
+		if tag, ok := obj.(*object.Tag); ok {
+			obj, err = tag.Object()
+			if err != nil {
+				return fmt.Errorf("unable to resolve %s: %w", ref.Name(), err)
+			}
+		}
+		if commit, ok := obj.(*object.Commit); ok {
+			commits[ref.Name()] = commit
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return commits, nil
+}
+
 func (r *Repository) checkoutRefAsBranch(ref string, branch plumbing.ReferenceName) error {
 	repo, err := git.PlainOpen(r.path)
 	if err != nil {
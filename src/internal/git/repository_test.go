@@ -16,6 +16,7 @@ import (
 	"github.com/go-git/go-git/v5"
 	"github.com/go-git/go-git/v5/config"
 	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/filemode"
 	"github.com/go-git/go-git/v5/plumbing/object"
 	"github.com/go-git/go-git/v5/storage/memory"
 	"github.com/stretchr/testify/require"
@@ -95,3 +96,119 @@ func TestRepository(t *testing.T) {
 	require.NoError(t, err)
 	require.Equal(t, filepath.Join(rootPath, expectedPath), repo.Path())
 }
+
+func TestPush(t *testing.T) {
+	t.Parallel()
+	ctx := testutil.TestContext(t)
+
+	gitDir := t.TempDir()
+	gitSrv := gitkit.New(gitkit.Config{
+		Dir:        gitDir,
+		AutoCreate: true,
+	})
+	err := gitSrv.Setup()
+	require.NoError(t, err)
+	srv := httptest.NewServer(http.HandlerFunc(gitSrv.ServeHTTP))
+	t.Cleanup(func() {
+		srv.Close()
+	})
+
+	upstreamPath := t.TempDir()
+	upstream, err := git.PlainInit(upstreamPath, false)
+	require.NoError(t, err)
+	initialCommit := commitTree(t, upstream, []object.TreeEntry{
+		{Name: "README.md", Mode: filemode.Regular, Hash: storeBlob(t, upstream, "# Hello\n")},
+	})
+	_, err = upstream.CreateTag("v1.0.0", initialCommit, nil)
+	require.NoError(t, err)
+
+	r, err := Clone(ctx, t.TempDir(), fmt.Sprintf("file://%s", upstreamPath), false)
+	require.NoError(t, err)
+	repo, err := git.PlainOpen(r.Path())
+	require.NoError(t, err)
+	setMaster := func(parent plumbing.Hash, message string) {
+		t.Helper()
+		initial, err := repo.CommitObject(initialCommit)
+		require.NoError(t, err)
+		commitHash, err := storeObject(repo, &object.Commit{
+			Author:       initial.Author,
+			Committer:    initial.Committer,
+			Message:      message,
+			TreeHash:     initial.TreeHash,
+			ParentHashes: []plumbing.Hash{parent},
+		})
+		require.NoError(t, err)
+		require.NoError(t, repo.Storer.SetReference(plumbing.NewHashReference(plumbing.Master, commitHash)))
+	}
+
+	report, err := r.Push(ctx, srv.URL, "push-user", "password", PushOptions{})
+	require.NoError(t, err)
+	require.Equal(t, []string{"refs/heads/master", "refs/tags/v1.0.0"}, report.Created)
+	require.Empty(t, report.Updated)
+
+	// Nothing is pushed when the server already has the refs
+	report, err = r.Push(ctx, srv.URL, "push-user", "password", PushOptions{})
+	require.NoError(t, err)
+	require.Empty(t, report.Created)
+	require.Empty(t, report.Updated)
+	require.Equal(t, []string{"refs/heads/master", "refs/tags/v1.0.0"}, report.Unchanged)
+
+	// Fast-forwards are pushed without force
+	setMaster(initialCommit, "Second commit")
+	report, err = r.Push(ctx, srv.URL, "push-user", "password", PushOptions{})
+	require.NoError(t, err)
+	require.Equal(t, []string{"refs/heads/master"}, report.Updated)
+	require.Empty(t, report.Forced)
+	require.Equal(t, []string{"refs/tags/v1.0.0"}, report.Unchanged)
+
+	// Diverged refs are force pushed unless NoForce is set
+	setMaster(initialCommit, "Diverged commit")
+	report, err = r.Push(ctx, srv.URL, "push-user", "password", PushOptions{NoForce: true})
+	require.ErrorIs(t, err, ErrNonFastForward)
+	require.Equal(t, []string{"refs/heads/master"}, report.Forced)
+	report, err = r.Push(ctx, srv.URL, "push-user", "password", PushOptions{})
+	require.NoError(t, err)
+	require.Equal(t, []string{"refs/heads/master"}, report.Forced)
+	report, err = r.Push(ctx, srv.URL, "push-user", "password", PushOptions{})
+	require.NoError(t, err)
+	require.Equal(t, []string{"refs/heads/master", "refs/tags/v1.0.0"}, report.Unchanged)
+
+	// With NoForce commits made on the server are fetched, so a remote that moved past the local branch is left unchanged
+	offlineRemote, err := repo.Remote(offlineRemoteName)
+	require.NoError(t, err)
+	serverClonePath := t.TempDir()
+	serverClone, err := git.PlainClone(serverClonePath, false, &git.CloneOptions{URL: offlineRemote.Config().URLs[0]})
+	require.NoError(t, err)
+	require.NoError(t, os.WriteFile(filepath.Join(serverClonePath, "CHANGELOG.md"), []byte("# Changes\n"), 0o644))
+	w, err := serverClone.Worktree()
+	require.NoError(t, err)
+	_, err = w.Add("CHANGELOG.md")
+	require.NoError(t, err)
+	_, err = w.Commit("Server commit", &git.CommitOptions{Author: &object.Signature{Email: "example@example.com"}})
+	require.NoError(t, err)
+	require.NoError(t, serverClone.Push(&git.PushOptions{}))
+	report, err = r.Push(ctx, srv.URL, "push-user", "password", PushOptions{NoForce: true})
+	require.NoError(t, err)
+	require.Empty(t, report.Updated)
+	require.Equal(t, []string{"refs/heads/master", "refs/tags/v1.0.0"}, report.Unchanged)
+
+	// Without NoForce the branch is reset to the local commit
+	report, err = r.Push(ctx, srv.URL, "push-user", "password", PushOptions{})
+	require.NoError(t, err)
+	require.Equal(t, []string{"refs/heads/master"}, report.Forced)
+	require.Equal(t, []string{"refs/tags/v1.0.0"}, report.Unchanged)
+
+	// Local commits that do not build on the commits made on the server would overwrite them
+	setMaster(initialCommit, "Another diverged commit")
+	report, err = r.Push(ctx, srv.URL, "push-user", "password", PushOptions{NoForce: true})
+	require.ErrorIs(t, err, ErrNonFastForward)
+	require.Equal(t, []string{"refs/heads/master"}, report.Forced)
+
+	// Repos can be pushed under an organization instead of the push user
+	report, err = r.Push(ctx, srv.URL, "push-user", "password", PushOptions{Owner: "team-a"})
+	require.NoError(t, err)
+	require.Equal(t, []string{"refs/heads/master", "refs/tags/v1.0.0"}, report.Created)
+	orgRepos, err := filepath.Glob(filepath.Join(gitDir, "team-a", "*"))
+	require.NoError(t, err)
+	require.Len(t, orgRepos, 1)
+}
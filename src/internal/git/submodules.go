@@ -0,0 +1,324 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2021-Present The Zarf Authors
+
+package git
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"os"
+	"path"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/config"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/filemode"
+	"github.com/go-git/go-git/v5/plumbing/object"
+
+	"github.com/zarf-dev/zarf/src/pkg/logger"
+	"github.com/zarf-dev/zarf/src/pkg/transform"
+)
+
+const (
+	gitmodulesFile = ".gitmodules"
+	// submoduleRefPrefix prefixes the branches and tags whose .gitmodules point at the Zarf git server.
+	submoduleRefPrefix = "zarf-submodules-"
+)
+
+// CloneSubmodules clones the submodules pinned at the tip of every branch and tag into rootPath as their own
+// repositories, recursing into their submodules. It returns the addresses of the cloned submodules.
+func (r *Repository) CloneSubmodules(ctx context.Context, rootPath, address string) ([]string, error) {
+	l := logger.From(ctx)
+	return r.walkSubmodules(address, func(subAddress string) (*Repository, error) {
+		// Submodules shared with another repo in the component are only cloned once
+		repoFolder, err := transform.GitURLtoFolderName(subAddress)
+		if err != nil {
+			return nil, err
+		}
+		if _, err := os.Stat(filepath.Join(rootPath, repoFolder)); err == nil {
+			return Open(rootPath, subAddress)
+		}
+		l.Info("cloning Git submodule", "repo", address, "submodule", subAddress)
+		return Clone(ctx, rootPath, subAddress, false)
+	})
+}
+
+// PackagedSubmodules returns the addresses of the submodules previously cloned into rootPath by CloneSubmodules.
+func (r *Repository) PackagedSubmodules(rootPath, address string) ([]string, error) {
+	return r.walkSubmodules(address, func(subAddress string) (*Repository, error) {
+		return Open(rootPath, subAddress)
+	})
+}
+
+// RewriteSubmodules creates a zarf-submodules-<name> branch or tag for every branch and tag with a .gitmodules file.
+// The commit on top of the original changes the submodule URLs to their mirrors on the target git server so that
+// recursive clones work in the air gap, while the original refs keep their upstream commits.
+func (r *Repository) RewriteSubmodules(address, targetBaseURL, owner string) error {
+	repo, err := git.PlainOpen(r.path)
+	if err != nil {
+		return fmt.Errorf("not a valid git repo or unable to open: %w", err)
+	}
+	refs, err := submoduleRefs(repo, address)
+	if err != nil {
+		return err
+	}
+	for _, name := range sortedRefNames(refs) {
+		commit := refs[name]
+		tree, err := commit.Tree()
+		if err != nil {
+			return err
+		}
+		modules, err := readGitmodules(tree)
+		if err != nil {
+			return fmt.Errorf("unable to read %s on %s: %w", gitmodulesFile, name.Short(), err)
+		}
+		if modules == nil {
+			continue
+		}
+		for _, sub := range modules.Submodules {
+			subURL, err := resolveSubmoduleURL(address, sub.URL)
+			if err != nil {
+				return err
+			}
+			targetURL, err := transform.GitURL(targetBaseURL, subURL, owner)
+			if err != nil {
+				return fmt.Errorf("unable to transform the submodule url %s: %w", subURL, err)
+			}
+			sub.URL = targetURL.String()
+		}
+		b, err := modules.Marshal()
+		if err != nil {
+			return err
+		}
+
+		blob := repo.Storer.NewEncodedObject()
+		blob.SetType(plumbing.BlobObject)
+		w, err := blob.Writer()
+		if err != nil {
+			return err
+		}
+		if _, err := w.Write(b); err != nil {
+			return errors.Join(err, w.Close())
+		}
+		if err := w.Close(); err != nil {
+			return err
+		}
+		blobHash, err := repo.Storer.SetEncodedObject(blob)
+		if err != nil {
+			return err
+		}
+
+		entries := slices.Clone(tree.Entries)
+		for i := range entries {
+			if entries[i].Name == gitmodulesFile {
+				entries[i].Hash = blobHash
+			}
+		}
+		treeHash, err := storeObject(repo, &object.Tree{Entries: entries})
+		if err != nil {
+			return err
+		}
+
+		// Reuse the original commit time so that the rewritten commit is the same on every deploy
+		sig := object.Signature{Name: "Zarf", Email: "zarf@localhost", When: commit.Committer.When}
+		commitHash, err := storeObject(repo, &object.Commit{
+			Author:       sig,
+			Committer:    sig,
+			Message:      fmt.Sprintf("Point submodules of %s at the Zarf git server\n", name.Short()),
+			TreeHash:     treeHash,
+			ParentHashes: []plumbing.Hash{commit.Hash},
+		})
+		if err != nil {
+			return err
+		}
+
+		rewrittenName := plumbing.NewBranchReferenceName(submoduleRefPrefix + name.Short())
+		if name.IsTag() {
+			rewrittenName = plumbing.NewTagReferenceName(submoduleRefPrefix + name.Short())
+		}
+		if err := repo.Storer.SetReference(plumbing.NewHashReference(rewrittenName, commitHash)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// submodule is a submodule pinned by one of the packaged refs.
+type submodule struct {
+	url    string
+	commit plumbing.Hash
+}
+
+func (s submodule) address() string {
+	return fmt.Sprintf("%s@%s", s.url, s.commit)
+}
+
+// walkSubmodules opens every submodule pinned by the repository and, recursively, by its submodules.
+func (r *Repository) walkSubmodules(address string, open func(subAddress string) (*Repository, error)) ([]string, error) {
+	type queued struct {
+		repo    *Repository
+		address string
+	}
+	queue := []queued{{repo: r, address: address}}
+	seen := map[string]bool{}
+	addresses := []string{}
+	for len(queue) > 0 {
+		current := queue[0]
+		queue = queue[1:]
+		submodules, err := current.repo.submodules(current.address)
+		if err != nil {
+			return nil, err
+		}
+		for _, sub := range submodules {
+			subAddress := sub.address()
+			if seen[subAddress] {
+				continue
+			}
+			seen[subAddress] = true
+			subRepo, err := open(subAddress)
+			if err != nil {
+				return nil, fmt.Errorf("unable to open submodule %s of %s: %w", subAddress, current.address, err)
+			}
+			addresses = append(addresses, subAddress)
+			queue = append(queue, queued{repo: subRepo, address: subAddress})
+		}
+	}
+	return addresses, nil
+}
+
+// submodules returns the submodules pinned at the tip of every branch and tag of the repository.
+func (r *Repository) submodules(address string) ([]submodule, error) {
+	repo, err := git.PlainOpen(r.path)
+	if err != nil {
+		return nil, fmt.Errorf("not a valid git repo or unable to open: %w", err)
+	}
+	refs, err := submoduleRefs(repo, address)
+	if err != nil {
+		return nil, err
+	}
+	submodules := []submodule{}
+	for _, name := range sortedRefNames(refs) {
+		tree, err := refs[name].Tree()
+		if err != nil {
+			return nil, err
+		}
+		modules, err := readGitmodules(tree)
+		if err != nil {
+			return nil, fmt.Errorf("unable to read %s on %s: %w", gitmodulesFile, name.Short(), err)
+		}
+		if modules == nil {
+			continue
+		}
+		names := make([]string, 0, len(modules.Submodules))
+		for n := range modules.Submodules {
+			names = append(names, n)
+		}
+		slices.Sort(names)
+		for _, subName := range names {
+			sub := modules.Submodules[subName]
+			entry, err := tree.FindEntry(sub.Path)
+			if errors.Is(err, object.ErrEntryNotFound) || errors.Is(err, object.ErrDirectoryNotFound) {
+				continue
+			}
+			if err != nil {
+				return nil, err
+			}
+			if entry.Mode != filemode.Submodule {
+				continue
+			}
+			subURL, err := resolveSubmoduleURL(address, sub.URL)
+			if err != nil {
+				return nil, err
+			}
+			s := submodule{url: subURL, commit: entry.Hash}
+			if !slices.Contains(submodules, s) {
+				submodules = append(submodules, s)
+			}
+		}
+	}
+	return submodules, nil
+}
+
+// submoduleRefs returns the branches and tags whose submodules are mirrored. Submodules are cloned with all of their
+// refs, but only the commit pinned by the superproject is followed.
+func submoduleRefs(repo *git.Repository, address string) (map[plumbing.ReferenceName]*object.Commit, error) {
+	refs, err := packagedRefs(repo)
+	if err != nil {
+		return nil, err
+	}
+	_, refPlain, err := transform.GitURLSplitRef(address)
+	if err != nil {
+		return nil, err
+	}
+	for name, commit := range refs {
+		if strings.HasPrefix(name.Short(), submoduleRefPrefix) {
+			delete(refs, name)
+			continue
+		}
+		if plumbing.IsHash(refPlain) && commit.Hash.String() != refPlain {
+			delete(refs, name)
+		}
+	}
+	return refs, nil
+}
+
+// readGitmodules returns the submodules configured in the tree, or nil if it has no .gitmodules file.
+func readGitmodules(tree *object.Tree) (*config.Modules, error) {
+	f, err := tree.File(gitmodulesFile)
+	if errors.Is(err, object.ErrFileNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	contents, err := f.Contents()
+	if err != nil {
+		return nil, err
+	}
+	modules := config.NewModules()
+	if err := modules.Unmarshal([]byte(contents)); err != nil {
+		return nil, err
+	}
+	return modules, nil
+}
+
+// resolveSubmoduleURL resolves submodule URLs relative to the superproject the same way git does.
+func resolveSubmoduleURL(address, subURL string) (string, error) {
+	if !strings.HasPrefix(subURL, "./") && !strings.HasPrefix(subURL, "../") {
+		return subURL, nil
+	}
+	gitURLNoRef, _, err := transform.GitURLSplitRef(address)
+	if err != nil {
+		return "", err
+	}
+	u, err := url.Parse(gitURLNoRef)
+	if err != nil {
+		return "", fmt.Errorf("unable to resolve the submodule url %s relative to %s: %w", subURL, gitURLNoRef, err)
+	}
+	u.Path = path.Join(u.Path, subURL)
+	return u.String(), nil
+}
+
+func sortedRefNames(refs map[plumbing.ReferenceName]*object.Commit) []plumbing.ReferenceName {
+	names := make([]plumbing.ReferenceName, 0, len(refs))
+	for name := range refs {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+	return names
+}
+
+func storeObject(repo *git.Repository, obj interface {
+	Encode(plumbing.EncodedObject) error
+}) (plumbing.Hash, error) {
+	encoded := repo.Storer.NewEncodedObject()
+	if err := obj.Encode(encoded); err != nil {
+		return plumbing.ZeroHash, err
+	}
+	return repo.Storer.SetEncodedObject(encoded)
+}
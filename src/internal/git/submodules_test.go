@@ -0,0 +1,140 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2021-Present The Zarf Authors
+
+package git
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/filemode"
+	"github.com/go-git/go-git/v5/plumbing/object"
+	"github.com/stretchr/testify/require"
+
+	"github.com/zarf-dev/zarf/src/pkg/transform"
+	"github.com/zarf-dev/zarf/src/test/testutil"
+)
+
+func commitTree(t *testing.T, repo *git.Repository, entries []object.TreeEntry) plumbing.Hash {
+	t.Helper()
+	treeHash, err := storeObject(repo, &object.Tree{Entries: entries})
+	require.NoError(t, err)
+	sig := object.Signature{Email: "example@example.com", When: time.Unix(1700000000, 0)}
+	commitHash, err := storeObject(repo, &object.Commit{Author: sig, Committer: sig, Message: "Initial commit", TreeHash: treeHash})
+	require.NoError(t, err)
+	require.NoError(t, repo.Storer.SetReference(plumbing.NewHashReference(plumbing.Master, commitHash)))
+	return commitHash
+}
+
+func storeBlob(t *testing.T, repo *git.Repository, contents string) plumbing.Hash {
+	t.Helper()
+	blob := repo.Storer.NewEncodedObject()
+	blob.SetType(plumbing.BlobObject)
+	w, err := blob.Writer()
+	require.NoError(t, err)
+	_, err = w.Write([]byte(contents))
+	require.NoError(t, err)
+	require.NoError(t, w.Close())
+	hash, err := repo.Storer.SetEncodedObject(blob)
+	require.NoError(t, err)
+	return hash
+}
+
+func TestSubmodules(t *testing.T) {
+	t.Parallel()
+	ctx := testutil.TestContext(t)
+
+	upstream := t.TempDir()
+	subRepo, err := git.PlainInit(filepath.Join(upstream, "sub"), false)
+	require.NoError(t, err)
+	subCommit := commitTree(t, subRepo, []object.TreeEntry{
+		{Name: "lib.txt", Mode: filemode.Regular, Hash: storeBlob(t, subRepo, "library\n")},
+	})
+
+	parentRepo, err := git.PlainInit(filepath.Join(upstream, "parent"), false)
+	require.NoError(t, err)
+	gitmodules := "[submodule \"lib\"]\n\tpath = lib\n\turl = ../sub\n"
+	parentCommit := commitTree(t, parentRepo, []object.TreeEntry{
+		{Name: ".gitmodules", Mode: filemode.Regular, Hash: storeBlob(t, parentRepo, gitmodules)},
+		{Name: "README.md", Mode: filemode.Regular, Hash: storeBlob(t, parentRepo, "# Parent\n")},
+		{Name: "lib", Mode: filemode.Submodule, Hash: subCommit},
+	})
+
+	rootPath := t.TempDir()
+	parentAddress := fmt.Sprintf("file://%s", filepath.Join(upstream, "parent"))
+	subAddress := fmt.Sprintf("file://%s@%s", filepath.Join(upstream, "sub"), subCommit)
+
+	r, err := Clone(ctx, rootPath, parentAddress, false)
+	require.NoError(t, err)
+	addresses, err := r.CloneSubmodules(ctx, rootPath, parentAddress)
+	require.NoError(t, err)
+	require.Equal(t, []string{subAddress}, addresses)
+	subPath, err := transform.GitURLtoFolderName(subAddress)
+	require.NoError(t, err)
+	_, err = os.Stat(filepath.Join(rootPath, subPath, "lib.txt"))
+	require.NoError(t, err)
+
+	addresses, err = r.PackagedSubmodules(rootPath, parentAddress)
+	require.NoError(t, err)
+	require.Equal(t, []string{subAddress}, addresses)
+
+	err = r.RewriteSubmodules(parentAddress, "http://zarf-gitea-http.zarf.svc.cluster.local:3000", "zarf-git-user")
+	require.NoError(t, err)
+	repo, err := git.PlainOpen(r.Path())
+	require.NoError(t, err)
+	ref, err := repo.Reference(plumbing.NewBranchReferenceName("zarf-submodules-master"), true)
+	require.NoError(t, err)
+	rewritten, err := repo.CommitObject(ref.Hash())
+	require.NoError(t, err)
+	require.Equal(t, []plumbing.Hash{parentCommit}, rewritten.ParentHashes)
+	tree, err := rewritten.Tree()
+	require.NoError(t, err)
+	entry, err := tree.FindEntry("lib")
+	require.NoError(t, err)
+	require.Equal(t, subCommit, entry.Hash)
+	modules, err := readGitmodules(tree)
+	require.NoError(t, err)
+	expectedURL, err := transform.GitURL("http://zarf-gitea-http.zarf.svc.cluster.local:3000", fmt.Sprintf("file://%s", filepath.Join(upstream, "sub")), "zarf-git-user")
+	require.NoError(t, err)
+	require.Equal(t, "lib", modules.Submodules["lib"].Path)
+	require.Equal(t, expectedURL.String(), modules.Submodules["lib"].URL)
+
+	// The upstream branch is left untouched
+	master, err := repo.Reference(plumbing.Master, true)
+	require.NoError(t, err)
+	require.Equal(t, parentCommit, master.Hash())
+
+	// Rewriting again produces the same commit
+	err = r.RewriteSubmodules(parentAddress, "http://zarf-gitea-http.zarf.svc.cluster.local:3000", "zarf-git-user")
+	require.NoError(t, err)
+	again, err := repo.Reference(plumbing.NewBranchReferenceName("zarf-submodules-master"), true)
+	require.NoError(t, err)
+	require.Equal(t, ref.Hash(), again.Hash())
+}
+
+func TestResolveSubmoduleURL(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		subURL   string
+		expected string
+	}{
+		{subURL: "https://github.com/example/lib.git", expected: "https://github.com/example/lib.git"},
+		{subURL: "../lib.git", expected: "https://github.com/example/lib.git"},
+		{subURL: "../../other/lib.git", expected: "https://github.com/other/lib.git"},
+		{subURL: "./lib.git", expected: "https://github.com/example/parent.git/lib.git"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.subURL, func(t *testing.T) {
+			t.Parallel()
+			resolved, err := resolveSubmoduleURL("https://github.com/example/parent.git@v1.0.0", tt.subURL)
+			require.NoError(t, err)
+			require.Equal(t, tt.expected, resolved)
+		})
+	}
+}
@@ -0,0 +1,389 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2021-Present The Zarf Authors
+
+package git
+
+import (
+	"bytes"
+	"crypto"
+	"crypto/x509"
+	"encoding/asn1"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"io"
+	"maps"
+	"slices"
+	"strings"
+
+	"github.com/ProtonMail/go-crypto/openpgp"
+	"github.com/digitorus/pkcs7"
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/object"
+	rekorv1 "github.com/sigstore/protobuf-specs/gen/pb-go/rekor/v1"
+	"github.com/sigstore/sigstore-go/pkg/fulcio/certificate"
+	"github.com/sigstore/sigstore-go/pkg/root"
+	"github.com/sigstore/sigstore-go/pkg/tlog"
+	"golang.org/x/crypto/ssh"
+	"google.golang.org/protobuf/proto"
+)
+
+const (
+	pgpSignatureHeader     = "-----BEGIN PGP SIGNATURE-----"
+	sshSignatureHeader     = "-----BEGIN SSH SIGNATURE-----"
+	gitsignSignatureHeader = "-----BEGIN SIGNED MESSAGE-----"
+	pgpPublicKeyHeader     = "-----BEGIN PGP PUBLIC KEY BLOCK-----"
+
+	// sshSigMagic and sshSigNamespace are defined by the SSHSIG protocol and the namespace git signs with.
+	sshSigMagic     = "SSHSIG"
+	sshSigNamespace = "git"
+)
+
+// oidRekorTransparencyLogEntry is the unsigned attribute gitsign stores the Rekor log entry of a signature in
+// when signing with GITSIGN_REKOR_MODE=offline.
+var oidRekorTransparencyLogEntry = asn1.ObjectIdentifier{1, 3, 6, 1, 4, 1, 57264, 3, 1}
+
+// SigstoreIdentity is a Sigstore keyless signing identity.
+type SigstoreIdentity struct {
+	Subject string
+	Issuer  string
+}
+
+// VerifiedRef is a packaged ref whose signature was verified.
+type VerifiedRef struct {
+	// Ref is the full name of the branch or tag.
+	Ref string
+	// Hash is the hash of the signed commit or annotated tag.
+	Hash string
+	// Signer describes the key or identity that produced the signature.
+	Signer string
+}
+
+// Verifier checks git commit and tag signatures against a set of allowed signers.
+type Verifier struct {
+	keyring    openpgp.EntityList
+	sshKeys    []ssh.PublicKey
+	identities []SigstoreIdentity
+	cas        []root.CertificateAuthority
+	rekorLogs  map[string]*root.TransparencyLog
+}
+
+// NewVerifier creates a verifier allowing the given keys and gitsign identities. Each key is the content of an
+// armored GPG public key block or an SSH authorized_keys file. Gitsign certificates must chain to one of the
+// Fulcio certificate authorities of trusted and be logged in one of its Rekor logs.
+func NewVerifier(keys [][]byte, identities []SigstoreIdentity, trusted root.TrustedMaterial) (*Verifier, error) {
+	v := &Verifier{
+		identities: identities,
+	}
+	if trusted != nil {
+		v.cas = trusted.FulcioCertificateAuthorities()
+		v.rekorLogs = trusted.RekorLogs()
+	}
+	for _, key := range keys {
+		if bytes.Contains(key, []byte(pgpPublicKeyHeader)) {
+			keyring, err := openpgp.ReadArmoredKeyRing(bytes.NewReader(key))
+			if err != nil {
+				return nil, fmt.Errorf("unable to read GPG public key: %w", err)
+			}
+			v.keyring = append(v.keyring, keyring...)
+			continue
+		}
+		for _, line := range strings.Split(string(key), "\n") {
+			line = strings.TrimSpace(line)
+			if line == "" || strings.HasPrefix(line, "#") {
+				continue
+			}
+			pub, _, _, _, err := ssh.ParseAuthorizedKey([]byte(line))
+			if err != nil {
+				return nil, fmt.Errorf("unable to read SSH public key: %w", err)
+			}
+			v.sshKeys = append(v.sshKeys, pub)
+		}
+	}
+	if len(v.keyring) == 0 && len(v.sshKeys) == 0 && len(v.identities) == 0 {
+		return nil, errors.New("no signing keys or identities to verify against")
+	}
+	return v, nil
+}
+
+// Verify checks that the tip of every packaged branch and tag is signed by an allowed signer. Annotated tags
+// are accepted when either the tag or the commit it points to is signed. The zarf-ref branch Clone creates for
+// a tag is covered by the tag itself.
+func (r *Repository) Verify(v *Verifier) ([]VerifiedRef, error) {
+	repo, err := git.PlainOpen(r.path)
+	if err != nil {
+		return nil, fmt.Errorf("not a valid git repo or unable to open: %w", err)
+	}
+	refs, err := repo.References()
+	if err != nil {
+		return nil, err
+	}
+	packaged := map[plumbing.ReferenceName]plumbing.Hash{}
+	err = refs.ForEach(func(ref *plumbing.Reference) error {
+		if ref.Type() == plumbing.HashReference && (ref.Name().IsBranch() || ref.Name().IsTag()) {
+			packaged[ref.Name()] = ref.Hash()
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	verified := []VerifiedRef{}
+	for _, name := range slices.Sorted(maps.Keys(packaged)) {
+		if tag, ok := strings.CutPrefix(name.Short(), "zarf-ref-"); ok && name.IsBranch() {
+			if _, ok := packaged[plumbing.NewTagReferenceName(tag)]; ok {
+				continue
+			}
+		}
+		ref, err := verifyRef(repo, packaged[name], v)
+		if err != nil {
+			return nil, fmt.Errorf("%s is not signed by an allowed signer: %w", name, err)
+		}
+		ref.Ref = name.String()
+		verified = append(verified, ref)
+	}
+	return verified, nil
+}
+
+func verifyRef(repo *git.Repository, hash plumbing.Hash, v *Verifier) (VerifiedRef, error) {
+	obj, err := repo.Object(plumbing.AnyObject, hash)
+	if err != nil {
+		return VerifiedRef{}, err
+	}
+	var tagErr error
+	if tag, ok := obj.(*object.Tag); ok {
+		signer, err := v.verifyObject(tag.PGPSignature, tag.EncodeWithoutSignature)
+		if err == nil {
+			return VerifiedRef{Hash: tag.Hash.String(), Signer: signer}, nil
+		}
+		tagErr = fmt.Errorf("tag %s: %w", tag.Hash, err)
+		obj, err = tag.Object()
+		if err != nil {
+			return VerifiedRef{}, err
+		}
+	}
+	commit, ok := obj.(*object.Commit)
+	if !ok {
+		return VerifiedRef{}, errors.Join(tagErr, fmt.Errorf("%s does not point to a commit", hash))
+	}
+	signer, err := v.verifyObject(commit.PGPSignature, commit.EncodeWithoutSignature)
+	if err != nil {
+		return VerifiedRef{}, errors.Join(tagErr, fmt.Errorf("commit %s: %w", commit.Hash, err))
+	}
+	return VerifiedRef{Hash: commit.Hash.String(), Signer: signer}, nil
+}
+
+// verifyObject checks the signature of an object whose unsigned payload is produced by encode.
+func (v *Verifier) verifyObject(signature string, encode func(plumbing.EncodedObject) error) (string, error) {
+	if signature == "" {
+		return "", errors.New("no signature")
+	}
+	encoded := &plumbing.MemoryObject{}
+	if err := encode(encoded); err != nil {
+		return "", err
+	}
+	rd, err := encoded.Reader()
+	if err != nil {
+		return "", err
+	}
+	payload, err := io.ReadAll(rd)
+	if err != nil {
+		return "", err
+	}
+
+	switch {
+	case strings.HasPrefix(signature, pgpSignatureHeader):
+		return v.verifyPGP(signature, payload)
+	case strings.HasPrefix(signature, sshSignatureHeader):
+		return v.verifySSH(signature, payload)
+	case strings.HasPrefix(signature, gitsignSignatureHeader):
+		return v.verifyGitsign(signature, payload)
+	default:
+		return "", errors.New("unsupported signature format")
+	}
+}
+
+func (v *Verifier) verifyPGP(signature string, payload []byte) (string, error) {
+	if len(v.keyring) == 0 {
+		return "", errors.New("GPG signature but no GPG keys are allowed")
+	}
+	entity, err := openpgp.CheckArmoredDetachedSignature(v.keyring, bytes.NewReader(payload), strings.NewReader(signature), nil)
+	if err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("gpg:%X", entity.PrimaryKey.Fingerprint), nil
+}
+
+// verifySSH checks an SSHSIG signature as produced by ssh-keygen -Y sign.
+// https://github.com/openssh/openssh-portable/blob/master/PROTOCOL.sshsig
+func (v *Verifier) verifySSH(signature string, payload []byte) (string, error) {
+	block, err := decodeSignatureBlock(signature, "SSH SIGNATURE")
+	if err != nil {
+		return "", err
+	}
+	sigData, ok := bytes.CutPrefix(block, []byte(sshSigMagic))
+	if !ok {
+		return "", errors.New("invalid SSH signature")
+	}
+	var sig struct {
+		Version       uint32
+		PublicKey     []byte
+		Namespace     string
+		Reserved      string
+		HashAlgorithm string
+		Signature     []byte
+	}
+	if err := ssh.Unmarshal(sigData, &sig); err != nil {
+		return "", fmt.Errorf("invalid SSH signature: %w", err)
+	}
+	if sig.Version != 1 {
+		return "", fmt.Errorf("unsupported SSH signature version %d", sig.Version)
+	}
+	if sig.Namespace != sshSigNamespace {
+		return "", fmt.Errorf("SSH signature has namespace %q, expected %q", sig.Namespace, sshSigNamespace)
+	}
+	pub, err := ssh.ParsePublicKey(sig.PublicKey)
+	if err != nil {
+		return "", err
+	}
+	allowed := false
+	for _, key := range v.sshKeys {
+		if bytes.Equal(key.Marshal(), pub.Marshal()) {
+			allowed = true
+			break
+		}
+	}
+	if !allowed {
+		return "", fmt.Errorf("SSH key %s is not allowed", ssh.FingerprintSHA256(pub))
+	}
+
+	var hash crypto.Hash
+	switch sig.HashAlgorithm {
+	case "sha256":
+		hash = crypto.SHA256
+	case "sha512":
+		hash = crypto.SHA512
+	default:
+		return "", fmt.Errorf("unsupported SSH signature hash algorithm %q", sig.HashAlgorithm)
+	}
+	h := hash.New()
+	h.Write(payload)
+	signed := append([]byte(sshSigMagic), ssh.Marshal(struct {
+		Namespace     string
+		Reserved      string
+		HashAlgorithm string
+		Hash          []byte
+	}{sig.Namespace, sig.Reserved, sig.HashAlgorithm, h.Sum(nil)})...)
+
+	sshSig := &ssh.Signature{}
+	if err := ssh.Unmarshal(sig.Signature, sshSig); err != nil {
+		return "", fmt.Errorf("invalid SSH signature: %w", err)
+	}
+	if err := pub.Verify(signed, sshSig); err != nil {
+		return "", err
+	}
+	return "ssh:" + ssh.FingerprintSHA256(pub), nil
+}
+
+// verifyGitsign checks a gitsign signature, a detached CMS signature by a short-lived Fulcio certificate.
+// The signature must carry its Rekor log entry, whose signed entry timestamp is verified against the trusted
+// Rekor logs, and the certificate is verified at the time the entry was integrated into the log.
+func (v *Verifier) verifyGitsign(signature string, payload []byte) (string, error) {
+	if len(v.identities) == 0 {
+		return "", errors.New("gitsign signature but no identities are allowed")
+	}
+	block, err := decodeSignatureBlock(signature, "SIGNED MESSAGE")
+	if err != nil {
+		return "", err
+	}
+	p7, err := pkcs7.Parse(block)
+	if err != nil {
+		return "", fmt.Errorf("invalid gitsign signature: %w", err)
+	}
+	p7.Content = payload
+	if err := p7.Verify(); err != nil {
+		return "", err
+	}
+	cert := p7.GetOnlySigner()
+	if cert == nil {
+		return "", errors.New("gitsign signature must have exactly one signer")
+	}
+	entry, err := gitsignLogEntry(p7)
+	if err != nil {
+		return "", err
+	}
+	if !entry.HasInclusionPromise() {
+		return "", errors.New("gitsign Rekor log entry has no signed entry timestamp")
+	}
+	if err := tlog.VerifySET(entry, v.rekorLogs); err != nil {
+		return "", fmt.Errorf("gitsign Rekor log entry is not signed by a trusted log: %w", err)
+	}
+	loggedCert, ok := entry.PublicKey().(*x509.Certificate)
+	if !ok || !loggedCert.Equal(cert) || !bytes.Equal(entry.Signature(), p7.Signers[0].EncryptedDigest) {
+		return "", errors.New("gitsign Rekor log entry does not match the signature")
+	}
+	// The certificate is only valid for a few minutes, so it is checked at the time the log recorded the
+	// signature rather than the signing time claimed by the signer.
+	signingTime := entry.IntegratedTime()
+	var chainErr error
+	trusted := false
+	for _, ca := range v.cas {
+		if _, err := ca.Verify(cert, signingTime); err != nil {
+			chainErr = errors.Join(chainErr, err)
+			continue
+		}
+		trusted = true
+		break
+	}
+	if !trusted {
+		return "", fmt.Errorf("gitsign certificate is not issued by a trusted certificate authority: %w", chainErr)
+	}
+
+	summary, err := certificate.SummarizeCertificate(cert)
+	if err != nil {
+		return "", err
+	}
+	for _, id := range v.identities {
+		if id.Subject == summary.SubjectAlternativeName && id.Issuer == summary.Issuer {
+			return fmt.Sprintf("sigstore:%s (%s)", id.Subject, id.Issuer), nil
+		}
+	}
+	return "", fmt.Errorf("gitsign identity %s (%s) is not allowed", summary.SubjectAlternativeName, summary.Issuer)
+}
+
+// gitsignLogEntry returns the Rekor log entry embedded in the unsigned attributes of a gitsign signature.
+func gitsignLogEntry(p7 *pkcs7.PKCS7) (*tlog.Entry, error) {
+	for _, attr := range p7.Signers[0].UnauthenticatedAttributes {
+		if !attr.Type.Equal(oidRekorTransparencyLogEntry) {
+			continue
+		}
+		var b []byte
+		if _, err := asn1.Unmarshal(attr.Value.Bytes, &b); err != nil {
+			return nil, fmt.Errorf("invalid gitsign Rekor log entry: %w", err)
+		}
+		tle := &rekorv1.TransparencyLogEntry{}
+		if err := proto.Unmarshal(b, tle); err != nil {
+			return nil, fmt.Errorf("invalid gitsign Rekor log entry: %w", err)
+		}
+		entry, err := tlog.ParseTransparencyLogEntry(tle)
+		if err != nil {
+			return nil, fmt.Errorf("invalid gitsign Rekor log entry: %w", err)
+		}
+		return entry, nil
+	}
+	return nil, errors.New("gitsign signature has no Rekor log entry, sign with GITSIGN_REKOR_MODE=offline")
+}
+
+// decodeSignatureBlock returns the content of the single PEM block of the given type in signature.
+func decodeSignatureBlock(signature, blockType string) ([]byte, error) {
+	block, rest := pem.Decode([]byte(signature))
+	if block == nil || block.Type != blockType {
+		return nil, fmt.Errorf("invalid %s block", strings.ToLower(blockType))
+	}
+	if len(bytes.TrimSpace(rest)) > 0 {
+		return nil, errors.New("multiple signatures are not supported")
+	}
+	return block.Bytes, nil
+}
@@ -0,0 +1,393 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2021-Present The Zarf Authors
+
+package git
+
+import (
+	"bytes"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/sha512"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/asn1"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"encoding/pem"
+	"fmt"
+	"io"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/ProtonMail/go-crypto/openpgp"
+	"github.com/ProtonMail/go-crypto/openpgp/armor"
+	"github.com/cyberphone/json-canonicalization/go/src/webpki.org/jsoncanonicalizer"
+	"github.com/digitorus/pkcs7"
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/object"
+	protocommon "github.com/sigstore/protobuf-specs/gen/pb-go/common/v1"
+	rekorv1 "github.com/sigstore/protobuf-specs/gen/pb-go/rekor/v1"
+	"github.com/sigstore/sigstore-go/pkg/fulcio/certificate"
+	"github.com/sigstore/sigstore-go/pkg/root"
+	"github.com/sigstore/sigstore-go/pkg/tlog"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/crypto/ssh"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/zarf-dev/zarf/src/test/testutil"
+)
+
+// signedObject stores a commit or tag signed with the signature returned by sign for its unsigned payload.
+func signedObject(t *testing.T, repo *git.Repository, obj interface {
+	EncodeWithoutSignature(plumbing.EncodedObject) error
+}, sign func(payload []byte) string) plumbing.Hash {
+	t.Helper()
+	encoded := &plumbing.MemoryObject{}
+	require.NoError(t, obj.EncodeWithoutSignature(encoded))
+	rd, err := encoded.Reader()
+	require.NoError(t, err)
+	payload, err := io.ReadAll(rd)
+	require.NoError(t, err)
+	switch o := obj.(type) {
+	case *object.Commit:
+		o.PGPSignature = sign(payload)
+		hash, err := storeObject(repo, o)
+		require.NoError(t, err)
+		return hash
+	case *object.Tag:
+		o.PGPSignature = sign(payload)
+		hash, err := storeObject(repo, o)
+		require.NoError(t, err)
+		return hash
+	}
+	t.Fatalf("unsupported object %T", obj)
+	return plumbing.ZeroHash
+}
+
+func gpgKey(t *testing.T) (func([]byte) string, []byte, string) {
+	t.Helper()
+	entity, err := openpgp.NewEntity("Zarf", "", "dev@example.com", nil)
+	require.NoError(t, err)
+	pub := &bytes.Buffer{}
+	w, err := armor.Encode(pub, openpgp.PublicKeyType, nil)
+	require.NoError(t, err)
+	require.NoError(t, entity.Serialize(w))
+	require.NoError(t, w.Close())
+	sign := func(payload []byte) string {
+		sig := &bytes.Buffer{}
+		require.NoError(t, openpgp.ArmoredDetachSign(sig, entity, bytes.NewReader(payload), nil))
+		return sig.String()
+	}
+	return sign, pub.Bytes(), fmt.Sprintf("gpg:%X", entity.PrimaryKey.Fingerprint)
+}
+
+func sshKey(t *testing.T) (func([]byte) string, []byte, string) {
+	t.Helper()
+	_, priv, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+	signer, err := ssh.NewSignerFromKey(priv)
+	require.NoError(t, err)
+	sign := func(payload []byte) string {
+		h := sha512.Sum512(payload)
+		signed := append([]byte(sshSigMagic), ssh.Marshal(struct {
+			Namespace     string
+			Reserved      string
+			HashAlgorithm string
+			Hash          []byte
+		}{sshSigNamespace, "", "sha512", h[:]})...)
+		sig, err := signer.Sign(rand.Reader, signed)
+		require.NoError(t, err)
+		blob := append([]byte(sshSigMagic), ssh.Marshal(struct {
+			Version       uint32
+			PublicKey     []byte
+			Namespace     string
+			Reserved      string
+			HashAlgorithm string
+			Signature     []byte
+		}{1, signer.PublicKey().Marshal(), sshSigNamespace, "", "sha512", ssh.Marshal(sig)})...)
+		return string(pem.EncodeToMemory(&pem.Block{Type: "SSH SIGNATURE", Bytes: blob}))
+	}
+	authorizedKeys := append([]byte("# maintainers\n"), ssh.MarshalAuthorizedKey(signer.PublicKey())...)
+	return sign, authorizedKeys, "ssh:" + ssh.FingerprintSHA256(signer.PublicKey())
+}
+
+// fakeRekor signs the entry timestamps of Rekor log entries embedded in gitsign signatures.
+type fakeRekor struct {
+	key   *ecdsa.PrivateKey
+	logID []byte
+}
+
+func newFakeRekor(t *testing.T) *fakeRekor {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	der, err := x509.MarshalPKIXPublicKey(key.Public())
+	require.NoError(t, err)
+	logID := sha256.Sum256(der)
+	return &fakeRekor{key: key, logID: logID[:]}
+}
+
+// trustedMaterial returns a trusted root with the given CA and Rekor log.
+func trustedMaterial(t *testing.T, ca root.CertificateAuthority, rekor *fakeRekor) root.TrustedMaterial {
+	t.Helper()
+	rekorLogs := map[string]*root.TransparencyLog{
+		hex.EncodeToString(rekor.logID): {
+			ID:                  rekor.logID,
+			ValidityPeriodStart: time.Now().Add(-time.Hour),
+			HashFunc:            crypto.SHA256,
+			PublicKey:           rekor.key.Public(),
+			SignatureHashFunc:   crypto.SHA256,
+		},
+	}
+	trusted, err := root.NewTrustedRoot(root.TrustedRootMediaType01, []root.CertificateAuthority{ca}, nil, nil, rekorLogs)
+	require.NoError(t, err)
+	return trusted
+}
+
+// logEntry returns the serialized hashedrekord log entry of the only signer of the CMS signature der, the way
+// gitsign embeds it when signing offline.
+func (r *fakeRekor) logEntry(t *testing.T, der []byte) []byte {
+	t.Helper()
+	p7, err := pkcs7.Parse(der)
+	require.NoError(t, err)
+	signer := p7.Signers[0]
+	// The signature is over the DER encoded SET of signed attributes
+	type attribute struct {
+		Type  asn1.ObjectIdentifier
+		Value asn1.RawValue `asn1:"set"`
+	}
+	attrs := []attribute{}
+	for _, attr := range signer.AuthenticatedAttributes {
+		attrs = append(attrs, attribute{Type: attr.Type, Value: attr.Value})
+	}
+	encoded, err := asn1.Marshal(struct {
+		A []attribute `asn1:"set"`
+	}{A: attrs})
+	require.NoError(t, err)
+	var set asn1.RawValue
+	_, err = asn1.Unmarshal(encoded, &set)
+	require.NoError(t, err)
+	digest := sha256.Sum256(set.Bytes)
+
+	certPEM := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: p7.GetOnlySigner().Raw})
+	body, err := json.Marshal(map[string]any{
+		"apiVersion": "0.0.1",
+		"kind":       "hashedrekord",
+		"spec": map[string]any{
+			"data": map[string]any{"hash": map[string]any{"algorithm": "sha256", "value": hex.EncodeToString(digest[:])}},
+			"signature": map[string]any{
+				"content":   base64.StdEncoding.EncodeToString(signer.EncryptedDigest),
+				"publicKey": map[string]any{"content": base64.StdEncoding.EncodeToString(certPEM)},
+			},
+		},
+	})
+	require.NoError(t, err)
+
+	integratedTime := time.Now().Unix()
+	payload, err := json.Marshal(tlog.RekorPayload{
+		Body:           base64.StdEncoding.EncodeToString(body),
+		IntegratedTime: integratedTime,
+		LogIndex:       1,
+		LogID:          hex.EncodeToString(r.logID),
+	})
+	require.NoError(t, err)
+	canonicalized, err := jsoncanonicalizer.Transform(payload)
+	require.NoError(t, err)
+	hash := sha256.Sum256(canonicalized)
+	signedEntryTimestamp, err := ecdsa.SignASN1(rand.Reader, r.key, hash[:])
+	require.NoError(t, err)
+
+	tle, err := proto.Marshal(&rekorv1.TransparencyLogEntry{
+		LogIndex:          1,
+		LogId:             &protocommon.LogId{KeyId: r.logID},
+		KindVersion:       &rekorv1.KindVersion{Kind: "hashedrekord", Version: "0.0.1"},
+		IntegratedTime:    integratedTime,
+		InclusionPromise:  &rekorv1.InclusionPromise{SignedEntryTimestamp: signedEntryTimestamp},
+		CanonicalizedBody: body,
+	})
+	require.NoError(t, err)
+	return tle
+}
+
+// gitsignKey returns a signer using a Fulcio style certificate for the identity issued by the returned CA.
+// Signatures embed a log entry from rekor unless it is nil.
+func gitsignKey(t *testing.T, subject, issuer string, rekor *fakeRekor) (func([]byte) string, root.CertificateAuthority) {
+	t.Helper()
+	now := time.Now()
+	caKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	caTemplate := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "sigstore"},
+		NotBefore:             now.Add(-time.Hour),
+		NotAfter:              now.Add(time.Hour),
+		IsCA:                  true,
+		BasicConstraintsValid: true,
+		KeyUsage:              x509.KeyUsageCertSign,
+	}
+	caDER, err := x509.CreateCertificate(rand.Reader, caTemplate, caTemplate, caKey.Public(), caKey)
+	require.NoError(t, err)
+	caCert, err := x509.ParseCertificate(caDER)
+	require.NoError(t, err)
+
+	leafKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	issuerExt, err := asn1.MarshalWithParams(issuer, "utf8")
+	require.NoError(t, err)
+	leafTemplate := &x509.Certificate{
+		SerialNumber:    big.NewInt(2),
+		NotBefore:       now.Add(-time.Minute),
+		NotAfter:        now.Add(10 * time.Minute),
+		EmailAddresses:  []string{subject},
+		KeyUsage:        x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:     []x509.ExtKeyUsage{x509.ExtKeyUsageCodeSigning},
+		ExtraExtensions: []pkix.Extension{{Id: certificate.OIDIssuerV2, Value: issuerExt}},
+	}
+	leafDER, err := x509.CreateCertificate(rand.Reader, leafTemplate, caCert, leafKey.Public(), caKey)
+	require.NoError(t, err)
+	leafCert, err := x509.ParseCertificate(leafDER)
+	require.NoError(t, err)
+
+	sign := func(payload []byte) string {
+		sd, err := pkcs7.NewSignedData(payload)
+		require.NoError(t, err)
+		sd.SetDigestAlgorithm(pkcs7.OIDDigestAlgorithmSHA256)
+		require.NoError(t, sd.AddSigner(leafCert, leafKey, pkcs7.SignerInfoConfig{}))
+		sd.Detach()
+		der, err := sd.Finish()
+		require.NoError(t, err)
+		if rekor != nil {
+			tle := rekor.logEntry(t, der)
+			attr := pkcs7.Attribute{Type: asn1.ObjectIdentifier{1, 3, 6, 1, 4, 1, 57264, 3, 1}, Value: tle}
+			require.NoError(t, sd.GetSignedData().SignerInfos[0].SetUnauthenticatedAttributes([]pkcs7.Attribute{attr}))
+			der, err = sd.Finish()
+			require.NoError(t, err)
+		}
+		return string(pem.EncodeToMemory(&pem.Block{Type: "SIGNED MESSAGE", Bytes: der}))
+	}
+	return sign, &root.FulcioCertificateAuthority{Root: caCert}
+}
+
+func TestVerify(t *testing.T) {
+	t.Parallel()
+
+	gpgSign, gpgPub, gpgSigner := gpgKey(t)
+	sshSign, sshPub, sshSigner := sshKey(t)
+	otherSign, _, _ := sshKey(t)
+	rekor := newFakeRekor(t)
+	gitsignSign, ca := gitsignKey(t, "dev@example.com", "https://github.com/login/oauth", rekor)
+
+	path := t.TempDir()
+	repo, err := git.PlainInit(path, false)
+	require.NoError(t, err)
+	commit, err := repo.CommitObject(commitTree(t, repo, nil))
+	require.NoError(t, err)
+	newCommit := func(message string) *object.Commit {
+		return &object.Commit{Author: commit.Author, Committer: commit.Committer, Message: message, TreeHash: commit.TreeHash}
+	}
+	newTag := func(name string, target plumbing.Hash) *object.Tag {
+		return &object.Tag{Name: name, Tagger: commit.Author, Message: name + "\n", TargetType: plumbing.CommitObject, Target: target}
+	}
+	setRef := func(name plumbing.ReferenceName, hash plumbing.Hash) {
+		require.NoError(t, repo.Storer.SetReference(plumbing.NewHashReference(name, hash)))
+	}
+
+	unsigned, err := storeObject(repo, newCommit("unsigned"))
+	require.NoError(t, err)
+	setRef(plumbing.Master, signedObject(t, repo, newCommit("gpg"), gpgSign))
+	setRef(plumbing.NewBranchReferenceName("gitsign"), signedObject(t, repo, newCommit("gitsign"), gitsignSign))
+	// Annotated tag signed over an unsigned commit, alongside the branch Clone creates for it
+	sshTag := signedObject(t, repo, newTag("v1.0.0", unsigned), sshSign)
+	setRef(plumbing.NewTagReferenceName("v1.0.0"), sshTag)
+	setRef(plumbing.NewBranchReferenceName("zarf-ref-v1.0.0"), unsigned)
+	// Lightweight tag on a signed commit
+	sshCommit := signedObject(t, repo, newCommit("ssh"), sshSign)
+	setRef(plumbing.NewTagReferenceName("v2.0.0"), sshCommit)
+
+	r := &Repository{path: path}
+	identities := []SigstoreIdentity{{Subject: "dev@example.com", Issuer: "https://github.com/login/oauth"}}
+	v, err := NewVerifier([][]byte{gpgPub, sshPub}, identities, trustedMaterial(t, ca, rekor))
+	require.NoError(t, err)
+	verified, err := r.Verify(v)
+	require.NoError(t, err)
+	master, err := repo.Reference(plumbing.Master, false)
+	require.NoError(t, err)
+	gitsign, err := repo.Reference(plumbing.NewBranchReferenceName("gitsign"), false)
+	require.NoError(t, err)
+	expected := []VerifiedRef{
+		{Ref: "refs/heads/gitsign", Hash: gitsign.Hash().String(), Signer: "sigstore:dev@example.com (https://github.com/login/oauth)"},
+		{Ref: "refs/heads/master", Hash: master.Hash().String(), Signer: gpgSigner},
+		{Ref: "refs/tags/v1.0.0", Hash: sshTag.String(), Signer: sshSigner},
+		{Ref: "refs/tags/v2.0.0", Hash: sshCommit.String(), Signer: sshSigner},
+	}
+	require.Equal(t, expected, verified)
+
+	// Identities that do not match the certificate are rejected
+	v, err = NewVerifier([][]byte{gpgPub, sshPub}, []SigstoreIdentity{{Subject: "dev@example.com", Issuer: "https://accounts.google.com"}}, trustedMaterial(t, ca, rekor))
+	require.NoError(t, err)
+	_, err = r.Verify(v)
+	require.ErrorContains(t, err, "refs/heads/gitsign is not signed by an allowed signer")
+	require.ErrorContains(t, err, "gitsign identity dev@example.com (https://github.com/login/oauth) is not allowed")
+
+	// Certificates from an untrusted CA are rejected
+	_, otherCA := gitsignKey(t, "dev@example.com", "https://github.com/login/oauth", rekor)
+	v, err = NewVerifier([][]byte{gpgPub, sshPub}, identities, trustedMaterial(t, otherCA, rekor))
+	require.NoError(t, err)
+	_, err = r.Verify(v)
+	require.ErrorContains(t, err, "gitsign certificate is not issued by a trusted certificate authority")
+
+	// Log entries signed by an untrusted Rekor log are rejected
+	v, err = NewVerifier([][]byte{gpgPub, sshPub}, identities, trustedMaterial(t, ca, newFakeRekor(t)))
+	require.NoError(t, err)
+	_, err = r.Verify(v)
+	require.ErrorContains(t, err, "gitsign Rekor log entry is not signed by a trusted log")
+
+	// Signatures without a Rekor log entry are rejected
+	unloggedSign, unloggedCA := gitsignKey(t, "dev@example.com", "https://github.com/login/oauth", nil)
+	setRef(plumbing.NewBranchReferenceName("gitsign"), signedObject(t, repo, newCommit("unlogged"), unloggedSign))
+	v, err = NewVerifier([][]byte{gpgPub, sshPub}, identities, trustedMaterial(t, unloggedCA, rekor))
+	require.NoError(t, err)
+	_, err = r.Verify(v)
+	require.ErrorContains(t, err, "gitsign signature has no Rekor log entry")
+	setRef(plumbing.NewBranchReferenceName("gitsign"), gitsign.Hash())
+
+	// Missing keys fail verification of the refs they signed
+	v, err = NewVerifier([][]byte{sshPub}, identities, trustedMaterial(t, ca, rekor))
+	require.NoError(t, err)
+	_, err = r.Verify(v)
+	require.ErrorContains(t, err, "refs/heads/master is not signed by an allowed signer")
+
+	// A tag retargeted to a commit signed by an unknown key is rejected
+	setRef(plumbing.NewTagReferenceName("v2.0.0"), signedObject(t, repo, newCommit("retargeted"), otherSign))
+	v, err = NewVerifier([][]byte{gpgPub, sshPub}, identities, trustedMaterial(t, ca, rekor))
+	require.NoError(t, err)
+	_, err = r.Verify(v)
+	require.ErrorContains(t, err, "refs/tags/v2.0.0 is not signed by an allowed signer")
+	require.ErrorContains(t, err, "is not allowed")
+
+	// Unsigned refs are rejected
+	setRef(plumbing.NewTagReferenceName("v2.0.0"), sshCommit)
+	setRef(plumbing.NewBranchReferenceName("unsigned"), unsigned)
+	_, err = r.Verify(v)
+	require.ErrorContains(t, err, "refs/heads/unsigned is not signed by an allowed signer")
+	require.ErrorContains(t, err, "no signature")
+
+	// Cloning a tag only verifies the tag
+	cloned, err := Clone(testutil.TestContext(t), t.TempDir(), fmt.Sprintf("file://%s@v1.0.0", path), false)
+	require.NoError(t, err)
+	verified, err = cloned.Verify(v)
+	require.NoError(t, err)
+	require.Equal(t, []VerifiedRef{{Ref: "refs/tags/v1.0.0", Hash: sshTag.String(), Signer: sshSigner}}, verified)
+
+	_, err = NewVerifier(nil, nil, nil)
+	require.EqualError(t, err, "no signing keys or identities to verify against")
+	_, err = NewVerifier([][]byte{[]byte("not a key")}, nil, nil)
+	require.ErrorContains(t, err, "unable to read SSH public key")
+}
@@ -16,7 +16,13 @@ import (
 	"time"
 )
 
-const artifactTokenName = "zarf-artifact-registry-token"
+const (
+	artifactTokenName = "zarf-artifact-registry-token"
+	// readOnlyTeamName is the team of an organization that gives the pull user read access to all of its repositories
+	readOnlyTeamName = "zarf-read-only"
+	// organizationDescription marks the organizations that Zarf created so that their repositories can be pruned
+	organizationDescription = "Managed by Zarf"
+)
 
 // Client is a client that communicates with the Gitea API.
 type Client struct {
@@ -205,3 +211,258 @@ func (g *Client) AddReadOnlyUserToRepository(ctx context.Context, repo, username
 	}
 	return nil
 }
+
+// CreateRepository creates a private repository owned by the client user if it does not already exist.
+func (g *Client) CreateRepository(ctx context.Context, repo string) error {
+	createRepoData := map[string]interface{}{
+		"name":    repo,
+		"private": true,
+	}
+	body, err := json.Marshal(createRepoData)
+	if err != nil {
+		return err
+	}
+	_, statusCode, err := g.DoRequest(ctx, http.MethodPost, "/api/v1/user/repos", body)
+	if err != nil {
+		return err
+	}
+	if statusCode == http.StatusConflict {
+		return nil
+	}
+	if statusCode < 200 || statusCode >= 300 {
+		return fmt.Errorf("failed to create repository %q: unexpected status code %d", repo, statusCode)
+	}
+	return nil
+}
+
+// CreateOrganization creates a private organization if it does not already exist and gives the read only user
+// pull access to all of its repositories.
+func (g *Client) CreateOrganization(ctx context.Context, org, readOnlyUser string) error {
+	_, statusCode, err := g.DoRequest(ctx, http.MethodGet, fmt.Sprintf("/api/v1/orgs/%s", url.PathEscape(org)), nil)
+	if err != nil {
+		return err
+	}
+	if statusCode == http.StatusNotFound {
+		createOrgData := map[string]interface{}{
+			"username":    org,
+			"description": organizationDescription,
+			"visibility":  "private",
+		}
+		body, err := json.Marshal(createOrgData)
+		if err != nil {
+			return err
+		}
+		_, statusCode, err = g.DoRequest(ctx, http.MethodPost, "/api/v1/orgs", body)
+		if err != nil {
+			return err
+		}
+		if statusCode < 200 || statusCode >= 300 {
+			return fmt.Errorf("failed to create organization %q: unexpected status code %d", org, statusCode)
+		}
+	} else if statusCode < 200 || statusCode >= 300 {
+		return fmt.Errorf("failed to get organization %q: unexpected status code %d", org, statusCode)
+	}
+
+	if readOnlyUser == "" || readOnlyUser == g.username {
+		return nil
+	}
+	teamID, err := g.readOnlyTeam(ctx, org)
+	if err != nil {
+		return err
+	}
+	_, statusCode, err = g.DoRequest(ctx, http.MethodPut, fmt.Sprintf("/api/v1/teams/%d/members/%s", teamID, url.PathEscape(readOnlyUser)), nil)
+	if err != nil {
+		return err
+	}
+	if statusCode < 200 || statusCode >= 300 {
+		return fmt.Errorf("failed to add user %q to the read only team of organization %q: unexpected status code %d", readOnlyUser, org, statusCode)
+	}
+	return nil
+}
+
+// readOnlyTeam returns the ID of the read only team of an organization, creating the team if it does not exist.
+func (g *Client) readOnlyTeam(ctx context.Context, org string) (int64, error) {
+	b, statusCode, err := g.DoRequest(ctx, http.MethodGet, fmt.Sprintf("/api/v1/orgs/%s/teams", url.PathEscape(org)), nil)
+	if err != nil {
+		return 0, err
+	}
+	if statusCode < 200 || statusCode >= 300 {
+		return 0, fmt.Errorf("failed to list the teams of organization %q: unexpected status code %d", org, statusCode)
+	}
+	teams := []struct {
+		ID   int64  `json:"id"`
+		Name string `json:"name"`
+	}{}
+	err = json.Unmarshal(b, &teams)
+	if err != nil {
+		return 0, err
+	}
+	for _, team := range teams {
+		if team.Name == readOnlyTeamName {
+			return team.ID, nil
+		}
+	}
+
+	createTeamData := map[string]interface{}{
+		"name":                      readOnlyTeamName,
+		"permission":                "read",
+		"includes_all_repositories": true,
+		"can_create_org_repo":       false,
+		"units":                     []string{"repo.code"},
+	}
+	body, err := json.Marshal(createTeamData)
+	if err != nil {
+		return 0, err
+	}
+	b, statusCode, err = g.DoRequest(ctx, http.MethodPost, fmt.Sprintf("/api/v1/orgs/%s/teams", url.PathEscape(org)), body)
+	if err != nil {
+		return 0, err
+	}
+	if statusCode < 200 || statusCode >= 300 {
+		return 0, fmt.Errorf("failed to create the read only team of organization %q: unexpected status code %d", org, statusCode)
+	}
+	team := struct {
+		ID int64 `json:"id"`
+	}{}
+	err = json.Unmarshal(b, &team)
+	if err != nil {
+		return 0, err
+	}
+	return team.ID, nil
+}
+
+// CreateOrgRepository creates a private repository owned by an organization if it does not already exist.
+func (g *Client) CreateOrgRepository(ctx context.Context, org, repo string) error {
+	createRepoData := map[string]interface{}{
+		"name":    repo,
+		"private": true,
+	}
+	body, err := json.Marshal(createRepoData)
+	if err != nil {
+		return err
+	}
+	_, statusCode, err := g.DoRequest(ctx, http.MethodPost, fmt.Sprintf("/api/v1/orgs/%s/repos", url.PathEscape(org)), body)
+	if err != nil {
+		return err
+	}
+	if statusCode == http.StatusConflict {
+		return nil
+	}
+	if statusCode < 200 || statusCode >= 300 {
+		return fmt.Errorf("failed to create repository %q in organization %q: unexpected status code %d", repo, org, statusCode)
+	}
+	return nil
+}
+
+// Repository is a repository on the Gitea server.
+type Repository struct {
+	Owner string
+	Name  string
+	Size  int64
+	// UpdatedAt changes on every push and on every edit of the repository settings
+	UpdatedAt time.Time
+}
+
+// ListRepositories returns the repositories owned by the client user and by the organizations that Zarf created.
+// Repositories of other users and organizations are never returned, even when the client user can access them.
+func (g *Client) ListRepositories(ctx context.Context) ([]Repository, error) {
+	repos, err := g.listRepositories(ctx, fmt.Sprintf("/api/v1/users/%s/repos", url.PathEscape(g.username)))
+	if err != nil {
+		return nil, err
+	}
+	orgs, err := g.listZarfOrganizations(ctx)
+	if err != nil {
+		return nil, err
+	}
+	for _, org := range orgs {
+		orgRepos, err := g.listRepositories(ctx, fmt.Sprintf("/api/v1/orgs/%s/repos", url.PathEscape(org)))
+		if err != nil {
+			return nil, err
+		}
+		repos = append(repos, orgRepos...)
+	}
+	return repos, nil
+}
+
+// listZarfOrganizations returns the organizations of the client user that Zarf created.
+func (g *Client) listZarfOrganizations(ctx context.Context) ([]string, error) {
+	orgs := []string{}
+	for page := 1; ; page++ {
+		b, statusCode, err := g.DoRequest(ctx, http.MethodGet, fmt.Sprintf("/api/v1/user/orgs?limit=50&page=%d", page), nil)
+		if err != nil {
+			return nil, err
+		}
+		if statusCode < 200 || statusCode >= 300 {
+			return nil, fmt.Errorf("failed to list organizations: unexpected status code %d", statusCode)
+		}
+		orgResponse := []struct {
+			Username    string `json:"username"`
+			Description string `json:"description"`
+		}{}
+		err = json.Unmarshal(b, &orgResponse)
+		if err != nil {
+			return nil, err
+		}
+		if len(orgResponse) == 0 {
+			return orgs, nil
+		}
+		for _, org := range orgResponse {
+			if org.Description == organizationDescription {
+				orgs = append(orgs, org.Username)
+			}
+		}
+	}
+}
+
+// listRepositories returns every page of repositories of a user or organization listing endpoint.
+func (g *Client) listRepositories(ctx context.Context, apiPath string) ([]Repository, error) {
+	repos := []Repository{}
+	for page := 1; ; page++ {
+		b, statusCode, err := g.DoRequest(ctx, http.MethodGet, fmt.Sprintf("%s?limit=50&page=%d", apiPath, page), nil)
+		if err != nil {
+			return nil, err
+		}
+		if statusCode < 200 || statusCode >= 300 {
+			return nil, fmt.Errorf("failed to list repositories: unexpected status code %d", statusCode)
+		}
+		repoResponse := []struct {
+			Name  string `json:"name"`
+			Owner struct {
+				Login string `json:"login"`
+			} `json:"owner"`
+			// Size is reported in KiB
+			Size      int64     `json:"size"`
+			UpdatedAt time.Time `json:"updated_at"`
+		}{}
+		err = json.Unmarshal(b, &repoResponse)
+		if err != nil {
+			return nil, err
+		}
+		if len(repoResponse) == 0 {
+			return repos, nil
+		}
+		for _, repo := range repoResponse {
+			repos = append(repos, Repository{
+				Owner:     repo.Owner.Login,
+				Name:      repo.Name,
+				Size:      repo.Size * 1024,
+				UpdatedAt: repo.UpdatedAt,
+			})
+		}
+	}
+}
+
+// DeleteRepository deletes a repository if it exists.
+func (g *Client) DeleteRepository(ctx context.Context, owner, repo string) error {
+	_, statusCode, err := g.DoRequest(ctx, http.MethodDelete, fmt.Sprintf("/api/v1/repos/%s/%s", url.PathEscape(owner), url.PathEscape(repo)), nil)
+	if err != nil {
+		return err
+	}
+	if statusCode == http.StatusNotFound {
+		return nil
+	}
+	if statusCode < 200 || statusCode >= 300 {
+		return fmt.Errorf("failed to delete repository %q of %q: unexpected status code %d", repo, owner, statusCode)
+	}
+	return nil
+}
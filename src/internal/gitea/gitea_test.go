@@ -5,9 +5,12 @@ package gitea
 
 import (
 	"context"
+	"encoding/json"
+	"fmt"
 	"net/http"
 	"net/http/httptest"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/require"
 )
@@ -56,3 +59,243 @@ func TestUpdateGitUser(t *testing.T) {
 		})
 	}
 }
+
+func TestCreateRepository(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		status  int
+		wantErr bool
+	}{
+		{name: "created", status: http.StatusCreated, wantErr: false},
+		{name: "already exists", status: http.StatusConflict, wantErr: false},
+		{name: "unauthorized", status: http.StatusUnauthorized, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if r.Method != http.MethodPost || r.URL.Path != "/api/v1/user/repos" {
+					w.WriteHeader(http.StatusNotFound)
+					return
+				}
+				w.WriteHeader(tt.status)
+			}))
+			defer srv.Close()
+
+			c, err := NewClient(srv.URL, "zarf-git-user", "password")
+			require.NoError(t, err)
+
+			err = c.CreateRepository(context.Background(), "podinfo-1646971829")
+			if tt.wantErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+		})
+	}
+}
+
+func TestCreateOrganization(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name            string
+		orgExists       bool
+		teamExists      bool
+		readOnlyUser    string
+		expectedRequest []string
+	}{
+		{
+			name:         "new organization",
+			readOnlyUser: "zarf-git-read-user",
+			expectedRequest: []string{
+				"GET /api/v1/orgs/podinfo",
+				"POST /api/v1/orgs",
+				"GET /api/v1/orgs/podinfo/teams",
+				"POST /api/v1/orgs/podinfo/teams",
+				"PUT /api/v1/teams/7/members/zarf-git-read-user",
+			},
+		},
+		{
+			name:         "existing organization",
+			orgExists:    true,
+			teamExists:   true,
+			readOnlyUser: "zarf-git-read-user",
+			expectedRequest: []string{
+				"GET /api/v1/orgs/podinfo",
+				"GET /api/v1/orgs/podinfo/teams",
+				"PUT /api/v1/teams/7/members/zarf-git-read-user",
+			},
+		},
+		{
+			name:         "push user reads",
+			readOnlyUser: "zarf-git-user",
+			expectedRequest: []string{
+				"GET /api/v1/orgs/podinfo",
+				"POST /api/v1/orgs",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			requests := []string{}
+			createOrgData := map[string]interface{}{}
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				requests = append(requests, r.Method+" "+r.URL.Path)
+				switch r.Method + " " + r.URL.Path {
+				case "GET /api/v1/orgs/podinfo":
+					if !tt.orgExists {
+						w.WriteHeader(http.StatusNotFound)
+					}
+				case "POST /api/v1/orgs":
+					json.NewDecoder(r.Body).Decode(&createOrgData) //nolint:errcheck
+					w.WriteHeader(http.StatusCreated)
+				case "GET /api/v1/orgs/podinfo/teams":
+					if tt.teamExists {
+						w.Write([]byte(`[{"id":1,"name":"Owners"},{"id":7,"name":"zarf-read-only"}]`)) //nolint:errcheck
+						return
+					}
+					w.Write([]byte(`[{"id":1,"name":"Owners"}]`)) //nolint:errcheck
+				case "POST /api/v1/orgs/podinfo/teams":
+					w.WriteHeader(http.StatusCreated)
+					w.Write([]byte(`{"id":7,"name":"zarf-read-only"}`)) //nolint:errcheck
+				case "PUT /api/v1/teams/7/members/zarf-git-read-user":
+					w.WriteHeader(http.StatusNoContent)
+				default:
+					w.WriteHeader(http.StatusNotFound)
+				}
+			}))
+			defer srv.Close()
+
+			c, err := NewClient(srv.URL, "zarf-git-user", "password")
+			require.NoError(t, err)
+
+			err = c.CreateOrganization(context.Background(), "podinfo", tt.readOnlyUser)
+			require.NoError(t, err)
+			require.Equal(t, tt.expectedRequest, requests)
+			if !tt.orgExists {
+				// Organizations are marked so that their repositories are included when pruning
+				require.Equal(t, "Managed by Zarf", createOrgData["description"])
+			}
+		})
+	}
+}
+
+func TestCreateOrgRepository(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		status  int
+		wantErr bool
+	}{
+		{name: "created", status: http.StatusCreated, wantErr: false},
+		{name: "already exists", status: http.StatusConflict, wantErr: false},
+		{name: "missing organization", status: http.StatusNotFound, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if r.Method != http.MethodPost || r.URL.Path != "/api/v1/orgs/podinfo/repos" {
+					w.WriteHeader(http.StatusBadRequest)
+					return
+				}
+				w.WriteHeader(tt.status)
+			}))
+			defer srv.Close()
+
+			c, err := NewClient(srv.URL, "zarf-git-user", "password")
+			require.NoError(t, err)
+
+			err = c.CreateOrgRepository(context.Background(), "podinfo", "podinfo-1646971829")
+			if tt.wantErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+		})
+	}
+}
+
+func TestListRepositories(t *testing.T) {
+	t.Parallel()
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		if r.URL.Query().Get("page") != "1" {
+			fmt.Fprint(w, `[]`)
+			return
+		}
+		switch r.URL.Path {
+		case "/api/v1/users/zarf-git-user/repos":
+			fmt.Fprint(w, `[{"name":"podinfo-1646971829","owner":{"login":"zarf-git-user"},"size":2,"updated_at":"2024-01-02T03:04:05Z"}]`)
+		case "/api/v1/user/orgs":
+			fmt.Fprint(w, `[{"username":"podinfo","description":"Managed by Zarf"},{"username":"team-a","description":""}]`)
+		case "/api/v1/orgs/podinfo/repos":
+			fmt.Fprint(w, `[{"name":"podinfo-1646971829","owner":{"login":"podinfo"},"size":1,"updated_at":"2024-02-02T03:04:05Z"}]`)
+		default:
+			// Repositories of organizations that Zarf did not create are never listed
+			w.WriteHeader(http.StatusBadRequest)
+		}
+	}))
+	defer srv.Close()
+
+	c, err := NewClient(srv.URL, "zarf-git-user", "password")
+	require.NoError(t, err)
+
+	repos, err := c.ListRepositories(context.Background())
+	require.NoError(t, err)
+	expected := []Repository{
+		{Owner: "zarf-git-user", Name: "podinfo-1646971829", Size: 2048, UpdatedAt: time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)},
+		{Owner: "podinfo", Name: "podinfo-1646971829", Size: 1024, UpdatedAt: time.Date(2024, 2, 2, 3, 4, 5, 0, time.UTC)},
+	}
+	require.Equal(t, expected, repos)
+}
+
+func TestDeleteRepository(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		status  int
+		wantErr bool
+	}{
+		{name: "deleted", status: http.StatusNoContent, wantErr: false},
+		{name: "already deleted", status: http.StatusNotFound, wantErr: false},
+		{name: "forbidden", status: http.StatusForbidden, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if r.Method != http.MethodDelete || r.URL.Path != "/api/v1/repos/zarf-git-user/podinfo-1646971829" {
+					w.WriteHeader(http.StatusBadRequest)
+					return
+				}
+				w.WriteHeader(tt.status)
+			}))
+			defer srv.Close()
+
+			c, err := NewClient(srv.URL, "zarf-git-user", "password")
+			require.NoError(t, err)
+
+			err = c.DeleteRepository(context.Background(), "zarf-git-user", "podinfo-1646971829")
+			if tt.wantErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+		})
+	}
+}
@@ -0,0 +1,280 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2021-Present The Zarf Authors
+
+// Package gitlab contains GitLab client specific functionality.
+package gitlab
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"time"
+)
+
+const (
+	artifactTokenName = "zarf-artifact-registry-token"
+	// reporterAccessLevel allows members to pull the repository without pushing to it.
+	reporterAccessLevel = 20
+	// artifactTokenLifetime stays below the maximum personal access token lifetime that GitLab allows by default.
+	artifactTokenLifetime = 364 * 24 * time.Hour
+)
+
+// Client is a client that communicates with the GitLab v4 API.
+type Client struct {
+	httpClient *http.Client
+	endpoint   *url.URL
+	username   string
+	token      string
+}
+
+// NewClient creates and returns a new GitLab client authenticated with a personal access token.
+func NewClient(endpoint, username, token string) (*Client, error) {
+	u, err := url.Parse(endpoint)
+	if err != nil {
+		return nil, err
+	}
+	transport, ok := http.DefaultTransport.(*http.Transport)
+	if !ok {
+		return nil, errors.New("could not get default transport")
+	}
+	transport = transport.Clone()
+	transport.MaxIdleConnsPerHost = transport.MaxIdleConns
+	httpClient := &http.Client{
+		Timeout:   10 * time.Second,
+		Transport: transport,
+	}
+	client := &Client{
+		httpClient: httpClient,
+		endpoint:   u,
+		username:   username,
+		token:      token,
+	}
+	return client, nil
+}
+
+// DoRequest performs a request to the GitLab API at the given path.
+func (g *Client) DoRequest(ctx context.Context, method string, path string, body []byte) (_ []byte, _ int, err error) {
+	u, err := g.endpoint.Parse(path)
+	if err != nil {
+		return nil, 0, err
+	}
+	req, err := http.NewRequestWithContext(ctx, method, u.String(), bytes.NewBuffer(body))
+	if err != nil {
+		return nil, 0, err
+	}
+	req.Header.Add("PRIVATE-TOKEN", g.token)
+	req.Header.Add("accept", "application/json")
+	req.Header.Add("content-type", "application/json")
+	resp, err := g.httpClient.Do(req)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer func() {
+		errClose := resp.Body.Close()
+		err = errors.Join(err, errClose)
+	}()
+
+	b, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, 0, err
+	}
+	return b, resp.StatusCode, nil
+}
+
+// CreateRepository creates a private project in the namespace of the client user if it does not already exist.
+func (g *Client) CreateRepository(ctx context.Context, repo string) error {
+	projectPath := url.PathEscape(fmt.Sprintf("%s/%s", g.username, repo))
+	_, statusCode, err := g.DoRequest(ctx, http.MethodGet, fmt.Sprintf("/api/v4/projects/%s", projectPath), nil)
+	if err != nil {
+		return err
+	}
+	if statusCode == http.StatusOK {
+		return nil
+	}
+	if statusCode != http.StatusNotFound {
+		return fmt.Errorf("failed to get project %q: unexpected status code %d", repo, statusCode)
+	}
+
+	b, statusCode, err := g.DoRequest(ctx, http.MethodGet, fmt.Sprintf("/api/v4/namespaces/%s", url.PathEscape(g.username)), nil)
+	if err != nil {
+		return err
+	}
+	if statusCode != http.StatusOK {
+		return fmt.Errorf("failed to get namespace %q: unexpected status code %d", g.username, statusCode)
+	}
+	namespace := struct {
+		ID int `json:"id"`
+	}{}
+	err = json.Unmarshal(b, &namespace)
+	if err != nil {
+		return err
+	}
+
+	createProjectData := map[string]interface{}{
+		"name":         repo,
+		"path":         repo,
+		"namespace_id": namespace.ID,
+		"visibility":   "private",
+	}
+	body, err := json.Marshal(createProjectData)
+	if err != nil {
+		return err
+	}
+	_, statusCode, err = g.DoRequest(ctx, http.MethodPost, "/api/v4/projects", body)
+	if err != nil {
+		return err
+	}
+	if statusCode != http.StatusCreated {
+		return fmt.Errorf("failed to create project %q: unexpected status code %d", repo, statusCode)
+	}
+	return nil
+}
+
+// AddReadOnlyUserToRepository adds a user as a reporter to a project.
+func (g *Client) AddReadOnlyUserToRepository(ctx context.Context, repo, username string) error {
+	// The owner of the project already has access
+	if username == g.username {
+		return nil
+	}
+	userID, err := g.userID(ctx, username)
+	if err != nil {
+		return err
+	}
+	addMemberData := map[string]interface{}{
+		"user_id":      userID,
+		"access_level": reporterAccessLevel,
+	}
+	body, err := json.Marshal(addMemberData)
+	if err != nil {
+		return err
+	}
+	projectPath := url.PathEscape(fmt.Sprintf("%s/%s", g.username, repo))
+	_, statusCode, err := g.DoRequest(ctx, http.MethodPost, fmt.Sprintf("/api/v4/projects/%s/members", projectPath), body)
+	if err != nil {
+		return err
+	}
+	// Conflict is returned when the user is already a member
+	if statusCode != http.StatusCreated && statusCode != http.StatusConflict {
+		return fmt.Errorf("failed to add user %q to project %q: unexpected status code %d", username, repo, statusCode)
+	}
+	return nil
+}
+
+// UpdateGitUser updates the password of a GitLab user. This requires the client user to be an administrator.
+// The client user authenticates with a personal access token that is rotated in GitLab, so its password is left unchanged.
+func (g *Client) UpdateGitUser(ctx context.Context, username string, password string) error {
+	if username == g.username {
+		return nil
+	}
+	userID, err := g.userID(ctx, username)
+	if err != nil {
+		return err
+	}
+	updateUserData := map[string]interface{}{
+		"password": password,
+	}
+	body, err := json.Marshal(updateUserData)
+	if err != nil {
+		return err
+	}
+	_, statusCode, err := g.DoRequest(ctx, http.MethodPut, fmt.Sprintf("/api/v4/users/%d", userID), body)
+	if err != nil {
+		return err
+	}
+	if statusCode < 200 || statusCode >= 300 {
+		return fmt.Errorf("failed to update git user %q: unexpected status code %d", username, statusCode)
+	}
+	return nil
+}
+
+// CreatePackageRegistryToken creates or replaces an existing package registry token for the client user.
+// This requires the client user to be an administrator.
+func (g *Client) CreatePackageRegistryToken(ctx context.Context) (string, error) {
+	userID, err := g.userID(ctx, g.username)
+	if err != nil {
+		return "", err
+	}
+
+	// Revoke the token if it already exists.
+	b, statusCode, err := g.DoRequest(ctx, http.MethodGet,
+		fmt.Sprintf("/api/v4/personal_access_tokens?user_id=%d&state=active&search=%s", userID, artifactTokenName), nil)
+	if err != nil {
+		return "", err
+	}
+	if statusCode != http.StatusOK {
+		return "", fmt.Errorf("failed to list tokens of user %q: unexpected status code %d", g.username, statusCode)
+	}
+	var tokens []struct {
+		ID   int    `json:"id"`
+		Name string `json:"name"`
+	}
+	err = json.Unmarshal(b, &tokens)
+	if err != nil {
+		return "", err
+	}
+	for _, token := range tokens {
+		if token.Name != artifactTokenName {
+			continue
+		}
+		_, statusCode, err := g.DoRequest(ctx, http.MethodDelete, fmt.Sprintf("/api/v4/personal_access_tokens/%d", token.ID), nil)
+		if err != nil {
+			return "", err
+		}
+		if statusCode != http.StatusNoContent && statusCode != http.StatusNotFound {
+			return "", fmt.Errorf("failed to revoke token %d: unexpected status code %d", token.ID, statusCode)
+		}
+	}
+
+	// Create the new token.
+	createTokenData := map[string]interface{}{
+		"name":       artifactTokenName,
+		"scopes":     []string{"api"},
+		"expires_at": time.Now().Add(artifactTokenLifetime).Format(time.DateOnly),
+	}
+	body, err := json.Marshal(createTokenData)
+	if err != nil {
+		return "", err
+	}
+	b, statusCode, err = g.DoRequest(ctx, http.MethodPost, fmt.Sprintf("/api/v4/users/%d/personal_access_tokens", userID), body)
+	if err != nil {
+		return "", err
+	}
+	if statusCode != http.StatusCreated {
+		return "", fmt.Errorf("failed to create a token for user %q: unexpected status code %d", g.username, statusCode)
+	}
+	createTokenResponse := struct {
+		Token string `json:"token"`
+	}{}
+	err = json.Unmarshal(b, &createTokenResponse)
+	if err != nil {
+		return "", err
+	}
+	return createTokenResponse.Token, nil
+}
+
+// userID looks up the ID of a user by username.
+func (g *Client) userID(ctx context.Context, username string) (int, error) {
+	b, statusCode, err := g.DoRequest(ctx, http.MethodGet, fmt.Sprintf("/api/v4/users?username=%s", url.QueryEscape(username)), nil)
+	if err != nil {
+		return 0, err
+	}
+	if statusCode != http.StatusOK {
+		return 0, fmt.Errorf("failed to get user %q: unexpected status code %d", username, statusCode)
+	}
+	var users []struct {
+		ID int `json:"id"`
+	}
+	err = json.Unmarshal(b, &users)
+	if err != nil {
+		return 0, err
+	}
+	if len(users) == 0 {
+		return 0, fmt.Errorf("user %q does not exist", username)
+	}
+	return users[0].ID, nil
+}
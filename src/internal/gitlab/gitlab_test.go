@@ -0,0 +1,152 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2021-Present The Zarf Authors
+
+package gitlab
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// fakeGitLab serves the subset of the GitLab v4 API used by the client.
+type fakeGitLab struct {
+	mu       sync.Mutex
+	projects map[string][]int
+	tokens   map[int]string
+	nextID   int
+	password string
+}
+
+func (f *fakeGitLab) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if r.Header.Get("PRIVATE-TOKEN") != "token" {
+		w.WriteHeader(http.StatusUnauthorized)
+		return
+	}
+	users := map[string]int{"zarf-git-user": 1, "zarf-git-read-user": 2}
+	switch {
+	case r.Method == http.MethodGet && r.URL.Path == "/api/v4/users":
+		id, ok := users[r.URL.Query().Get("username")]
+		if !ok {
+			//nolint:errcheck
+			w.Write([]byte("[]"))
+			return
+		}
+		//nolint:errcheck
+		json.NewEncoder(w).Encode([]map[string]int{{"id": id}})
+	case r.Method == http.MethodGet && r.URL.RawPath == "/api/v4/projects/zarf-git-user%2Fpodinfo-1646971829":
+		if _, ok := f.projects["podinfo-1646971829"]; !ok {
+			w.WriteHeader(http.StatusNotFound)
+		}
+	case r.Method == http.MethodGet && r.URL.Path == "/api/v4/namespaces/zarf-git-user":
+		//nolint:errcheck
+		w.Write([]byte(`{"id": 7}`))
+	case r.Method == http.MethodPost && r.URL.Path == "/api/v4/projects":
+		var req struct {
+			Path        string `json:"path"`
+			NamespaceID int    `json:"namespace_id"`
+			Visibility  string `json:"visibility"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil || req.NamespaceID != 7 || req.Visibility != "private" {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		f.projects[req.Path] = []int{}
+		w.WriteHeader(http.StatusCreated)
+	case r.Method == http.MethodPost && r.URL.RawPath == "/api/v4/projects/zarf-git-user%2Fpodinfo-1646971829/members":
+		var req struct {
+			UserID      int `json:"user_id"`
+			AccessLevel int `json:"access_level"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil || req.AccessLevel != reporterAccessLevel {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		for _, member := range f.projects["podinfo-1646971829"] {
+			if member == req.UserID {
+				w.WriteHeader(http.StatusConflict)
+				return
+			}
+		}
+		f.projects["podinfo-1646971829"] = append(f.projects["podinfo-1646971829"], req.UserID)
+		w.WriteHeader(http.StatusCreated)
+	case r.Method == http.MethodPut && r.URL.Path == "/api/v4/users/2":
+		var req struct {
+			Password string `json:"password"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		f.password = req.Password
+	case r.Method == http.MethodGet && r.URL.Path == "/api/v4/personal_access_tokens":
+		tokens := []map[string]interface{}{}
+		for id, name := range f.tokens {
+			tokens = append(tokens, map[string]interface{}{"id": id, "name": name})
+		}
+		//nolint:errcheck
+		json.NewEncoder(w).Encode(tokens)
+	case r.Method == http.MethodDelete && r.URL.Path == "/api/v4/personal_access_tokens/1":
+		delete(f.tokens, 1)
+		w.WriteHeader(http.StatusNoContent)
+	case r.Method == http.MethodPost && r.URL.Path == "/api/v4/users/1/personal_access_tokens":
+		f.nextID++
+		f.tokens[f.nextID] = artifactTokenName
+		w.WriteHeader(http.StatusCreated)
+		//nolint:errcheck
+		w.Write([]byte(`{"token": "glpat-new"}`))
+	default:
+		w.WriteHeader(http.StatusNotFound)
+	}
+}
+
+func TestClient(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+
+	f := &fakeGitLab{projects: map[string][]int{}, tokens: map[int]string{}}
+	srv := httptest.NewServer(f)
+	defer srv.Close()
+
+	c, err := NewClient(srv.URL, "zarf-git-user", "token")
+	require.NoError(t, err)
+
+	// Creating the project twice does not fail
+	require.NoError(t, c.CreateRepository(ctx, "podinfo-1646971829"))
+	require.NoError(t, c.CreateRepository(ctx, "podinfo-1646971829"))
+	require.Contains(t, f.projects, "podinfo-1646971829")
+
+	// Adding the read only user twice does not fail and the owner is skipped
+	require.NoError(t, c.AddReadOnlyUserToRepository(ctx, "podinfo-1646971829", "zarf-git-read-user"))
+	require.NoError(t, c.AddReadOnlyUserToRepository(ctx, "podinfo-1646971829", "zarf-git-read-user"))
+	require.NoError(t, c.AddReadOnlyUserToRepository(ctx, "podinfo-1646971829", "zarf-git-user"))
+	require.Equal(t, []int{2}, f.projects["podinfo-1646971829"])
+	err = c.AddReadOnlyUserToRepository(ctx, "podinfo-1646971829", "missing")
+	require.ErrorContains(t, err, `user "missing" does not exist`)
+
+	require.NoError(t, c.UpdateGitUser(ctx, "zarf-git-read-user", "new-password"))
+	require.Equal(t, "new-password", f.password)
+	require.NoError(t, c.UpdateGitUser(ctx, "zarf-git-user", "glpat-rotated"))
+	require.Equal(t, "new-password", f.password)
+
+	// Creating the token replaces the existing one
+	token, err := c.CreatePackageRegistryToken(ctx)
+	require.NoError(t, err)
+	require.Equal(t, "glpat-new", token)
+	_, err = c.CreatePackageRegistryToken(ctx)
+	require.NoError(t, err)
+	require.Equal(t, map[int]string{2: artifactTokenName}, f.tokens)
+
+	// Requests with the wrong token fail
+	c, err = NewClient(srv.URL, "zarf-git-user", "wrong")
+	require.NoError(t, err)
+	require.Error(t, c.CreateRepository(ctx, "podinfo-1646971829"))
+}
@@ -0,0 +1,72 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2021-Present The Zarf Authors
+
+// Package gitprovider selects the API Zarf uses to manage repositories and users on the git server.
+package gitprovider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/zarf-dev/zarf/src/internal/gitea"
+	"github.com/zarf-dev/zarf/src/internal/gitlab"
+	"github.com/zarf-dev/zarf/src/pkg/state"
+)
+
+// Provider manages repositories and users on a git server.
+type Provider interface {
+	// CreateRepository creates a repository owned by the push user if it does not already exist.
+	CreateRepository(ctx context.Context, repo string) error
+	// AddReadOnlyUserToRepository gives a user pull access to a repository owned by the push user.
+	AddReadOnlyUserToRepository(ctx context.Context, repo, username string) error
+	// UpdateGitUser sets the password of a user.
+	UpdateGitUser(ctx context.Context, username, password string) error
+	// CreatePackageRegistryToken creates or replaces the package registry token of the push user.
+	CreatePackageRegistryToken(ctx context.Context) (string, error)
+}
+
+// OrganizationProvider is implemented by providers that can group repositories into organizations.
+type OrganizationProvider interface {
+	// CreateOrganization creates an organization if it does not already exist and gives a user pull access to all of its repositories.
+	CreateOrganization(ctx context.Context, org, readOnlyUser string) error
+	// CreateOrgRepository creates a repository owned by an organization if it does not already exist.
+	CreateOrgRepository(ctx context.Context, org, repo string) error
+}
+
+// New returns the provider configured for the git server, reaching its API at endpoint.
+func New(gitServer state.GitServerInfo, endpoint string) (Provider, error) {
+	switch provider := gitServer.EffectiveProvider(); provider {
+	case state.GitProviderGitea:
+		return gitea.NewClient(endpoint, gitServer.PushUsername, gitServer.PushPassword)
+	case state.GitProviderGitLab:
+		return gitlab.NewClient(endpoint, gitServer.PushUsername, gitServer.PushPassword)
+	case state.GitProviderPrecreated:
+		return precreated{}, nil
+	default:
+		return nil, fmt.Errorf("unsupported git provider %q", provider)
+	}
+}
+
+// precreated is used for git servers where every repository is created ahead of time with the required access.
+type precreated struct{}
+
+// CreateRepository does nothing as the repository must already exist.
+func (precreated) CreateRepository(context.Context, string) error {
+	return nil
+}
+
+// AddReadOnlyUserToRepository does nothing as the pull user must already have access.
+func (precreated) AddReadOnlyUserToRepository(context.Context, string, string) error {
+	return nil
+}
+
+// UpdateGitUser is not supported as users are managed outside of Zarf.
+func (precreated) UpdateGitUser(context.Context, string, string) error {
+	return errors.New("updating git users is not supported for pre-created repositories")
+}
+
+// CreatePackageRegistryToken is not supported as tokens are managed outside of Zarf.
+func (precreated) CreatePackageRegistryToken(context.Context) (string, error) {
+	return "", errors.New("creating package registry tokens is not supported for pre-created repositories")
+}
@@ -0,0 +1,64 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2021-Present The Zarf Authors
+
+package gitprovider
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/zarf-dev/zarf/src/internal/gitea"
+	"github.com/zarf-dev/zarf/src/internal/gitlab"
+	"github.com/zarf-dev/zarf/src/pkg/state"
+)
+
+func TestNew(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name      string
+		gitServer state.GitServerInfo
+		expected  Provider
+	}{
+		{
+			name:      "internal defaults to gitea",
+			gitServer: state.GitServerInfo{Address: state.ZarfInClusterGitServiceURL},
+			expected:  &gitea.Client{},
+		},
+		{
+			name:      "external defaults to precreated",
+			gitServer: state.GitServerInfo{Address: "https://git.example.com"},
+			expected:  precreated{},
+		},
+		{
+			name:      "gitlab",
+			gitServer: state.GitServerInfo{Address: "https://gitlab.example.com", Provider: state.GitProviderGitLab},
+			expected:  &gitlab.Client{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			provider, err := New(tt.gitServer, "https://git.example.com")
+			require.NoError(t, err)
+			require.IsType(t, tt.expected, provider)
+		})
+	}
+
+	_, err := New(state.GitServerInfo{Provider: "bitbucket"}, "https://git.example.com")
+	require.EqualError(t, err, `unsupported git provider "bitbucket"`)
+}
+
+func TestPrecreated(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+
+	p := precreated{}
+	require.NoError(t, p.CreateRepository(ctx, "podinfo-1646971829"))
+	require.NoError(t, p.AddReadOnlyUserToRepository(ctx, "podinfo-1646971829", "reader"))
+	require.Error(t, p.UpdateGitUser(ctx, "reader", "password"))
+	_, err := p.CreatePackageRegistryToken(ctx)
+	require.Error(t, err)
+}
@@ -0,0 +1,110 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2021-Present The Zarf Authors
+
+package helm
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"os"
+	"path"
+	"strings"
+
+	"github.com/defenseunicorns/pkg/helpers/v2"
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+	chartv2 "helm.sh/helm/v4/pkg/chart/v2"
+	"helm.sh/helm/v4/pkg/chart/v2/loader"
+	"helm.sh/helm/v4/pkg/registry"
+	"oras.land/oras-go/v2"
+	"oras.land/oras-go/v2/content"
+
+	"github.com/zarf-dev/zarf/src/api/v1alpha1"
+	"github.com/zarf-dev/zarf/src/pkg/transform"
+)
+
+// ChartOCIReference returns the reference a packaged chart is mirrored under in a registry.
+// OCI charts keep their original repository path and published charts are placed under their repository host and path,
+// which is where the Flux OCIRepository and Argo CD Application hooks point mutated chart references.
+// Charts from git or local paths have no registry of origin and are referenced by chart name alone.
+func ChartOCIReference(chart v1alpha1.ZarfChart, metadata *chartv2.Metadata) (string, error) {
+	var name string
+	switch {
+	case registry.IsOCI(chart.URL):
+		name = strings.TrimPrefix(chart.URL, helpers.OCIURLPrefix)
+	case chart.URL != "" && !isGitChartURL(chart.URL):
+		u, err := url.Parse(chart.URL)
+		if err != nil {
+			return "", fmt.Errorf("unable to parse chart url %q: %w", chart.URL, err)
+		}
+		repoName := chart.RepoName
+		if repoName == "" {
+			repoName = chart.Name
+		}
+		name = path.Join(u.Host, u.Path, repoName)
+	default:
+		name = metadata.Name
+	}
+	ref, err := transform.ParseImageRef(fmt.Sprintf("%s:%s", name, metadata.Version))
+	if err != nil {
+		return "", fmt.Errorf("unable to create a reference for chart %q: %w", chart.Name, err)
+	}
+	return ref.Reference, nil
+}
+
+// StoreChartArtifact packs the chart archive at archivePath as a Helm OCI artifact in dst, tagged with the chart's
+// reference from ChartOCIReference. The reference is returned.
+func StoreChartArtifact(ctx context.Context, dst oras.Target, chart v1alpha1.ZarfChart, archivePath string) (string, error) {
+	b, err := os.ReadFile(archivePath)
+	if err != nil {
+		return "", fmt.Errorf("unable to read chart archive: %w", err)
+	}
+	loadedChart, err := loader.LoadArchive(bytes.NewReader(b))
+	if err != nil {
+		return "", fmt.Errorf("unable to load helm chart archive: %w", err)
+	}
+	ref, err := ChartOCIReference(chart, loadedChart.Metadata)
+	if err != nil {
+		return "", err
+	}
+
+	configBytes, err := json.Marshal(loadedChart.Metadata)
+	if err != nil {
+		return "", err
+	}
+	configDesc := content.NewDescriptorFromBytes(registry.ConfigMediaType, configBytes)
+	if err := dst.Push(ctx, configDesc, bytes.NewReader(configBytes)); err != nil {
+		return "", fmt.Errorf("unable to store chart config: %w", err)
+	}
+	layerDesc := content.NewDescriptorFromBytes(registry.ChartLayerMediaType, b)
+	if err := dst.Push(ctx, layerDesc, bytes.NewReader(b)); err != nil {
+		return "", fmt.Errorf("unable to store chart archive: %w", err)
+	}
+
+	root, err := oras.PackManifest(ctx, dst, oras.PackManifestVersion1_1, "", oras.PackManifestOptions{
+		Layers:           []ocispec.Descriptor{layerDesc},
+		ConfigDescriptor: &configDesc,
+		ManifestAnnotations: map[string]string{
+			ocispec.AnnotationTitle:   loadedChart.Metadata.Name,
+			ocispec.AnnotationVersion: loadedChart.Metadata.Version,
+		},
+	})
+	if err != nil {
+		return "", fmt.Errorf("unable to pack chart manifest: %w", err)
+	}
+	if err := dst.Tag(ctx, root, ref); err != nil {
+		return "", err
+	}
+	return ref, nil
+}
+
+// isGitChartURL reports whether a chart URL points at a git repository rather than a chart repository.
+func isGitChartURL(chartURL string) bool {
+	u, _, err := transform.GitURLSplitRef(chartURL)
+	if err != nil {
+		return false
+	}
+	return strings.HasSuffix(u, ".git")
+}
@@ -0,0 +1,99 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2021-Present The Zarf Authors
+
+package helm
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	chartv2 "helm.sh/helm/v4/pkg/chart/v2"
+	chartutil "helm.sh/helm/v4/pkg/chart/v2/util"
+	"helm.sh/helm/v4/pkg/registry"
+	"oras.land/oras-go/v2"
+	"oras.land/oras-go/v2/content/memory"
+	"oras.land/oras-go/v2/registry/remote"
+
+	"github.com/zarf-dev/zarf/src/api/v1alpha1"
+	"github.com/zarf-dev/zarf/src/test/testutil"
+)
+
+func TestChartOCIReference(t *testing.T) {
+	t.Parallel()
+	metadata := &chartv2.Metadata{Name: "podinfo", Version: "6.4.0"}
+	tests := []struct {
+		name     string
+		chart    v1alpha1.ZarfChart
+		expected string
+	}{
+		{
+			name:     "oci chart",
+			chart:    v1alpha1.ZarfChart{Name: "podinfo", URL: "oci://ghcr.io/stefanprodan/charts/podinfo"},
+			expected: "ghcr.io/stefanprodan/charts/podinfo:6.4.0",
+		},
+		{
+			name:     "helm repository chart",
+			chart:    v1alpha1.ZarfChart{Name: "podinfo", URL: "https://stefanprodan.github.io/podinfo"},
+			expected: "stefanprodan.github.io/podinfo/podinfo:6.4.0",
+		},
+		{
+			name:     "helm repository chart with repo name",
+			chart:    v1alpha1.ZarfChart{Name: "my-podinfo", URL: "https://stefanprodan.github.io/podinfo", RepoName: "podinfo"},
+			expected: "stefanprodan.github.io/podinfo/podinfo:6.4.0",
+		},
+		{
+			name:     "git chart",
+			chart:    v1alpha1.ZarfChart{Name: "my-podinfo", URL: "https://github.com/stefanprodan/podinfo.git@6.4.0", GitPath: "charts/podinfo"},
+			expected: "docker.io/library/podinfo:6.4.0",
+		},
+		{
+			name:     "local chart",
+			chart:    v1alpha1.ZarfChart{Name: "my-podinfo", LocalPath: "chart"},
+			expected: "docker.io/library/podinfo:6.4.0",
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			ref, err := ChartOCIReference(tc.chart, metadata)
+			require.NoError(t, err)
+			require.Equal(t, tc.expected, ref)
+		})
+	}
+}
+
+func TestStoreChartArtifact(t *testing.T) {
+	t.Parallel()
+	ctx := testutil.TestContext(t)
+
+	ch := &chartv2.Chart{Metadata: &chartv2.Metadata{
+		APIVersion: chartv2.APIVersionV1,
+		Name:       "simple-chart",
+		Version:    "1.0.0",
+	}}
+	tgzPath, err := chartutil.Save(ch, t.TempDir())
+	require.NoError(t, err)
+
+	store := memory.New()
+	chart := v1alpha1.ZarfChart{Name: "simple-chart", Version: "1.0.0", URL: "oci://ghcr.io/zarf-dev/charts/simple-chart"}
+	ref, err := StoreChartArtifact(ctx, store, chart, tgzPath)
+	require.NoError(t, err)
+	require.Equal(t, "ghcr.io/zarf-dev/charts/simple-chart:1.0.0", ref)
+
+	// The artifact must be consumable by Helm once it is in a registry.
+	regAddr := testutil.SetupInMemoryRegistryDynamic(ctx, t)
+	dstRef := fmt.Sprintf("%s/zarf-dev/charts/simple-chart:1.0.0", regAddr)
+	repo, err := remote.NewRepository(dstRef)
+	require.NoError(t, err)
+	repo.PlainHTTP = true
+	_, err = oras.Copy(ctx, store, ref, repo, "1.0.0", oras.DefaultCopyOptions)
+	require.NoError(t, err)
+
+	regClient, err := registry.NewClient(registry.ClientOptPlainHTTP())
+	require.NoError(t, err)
+	result, err := regClient.Pull(dstRef)
+	require.NoError(t, err)
+	require.Equal(t, "simple-chart", result.Chart.Meta.Name)
+	require.Equal(t, "1.0.0", result.Chart.Meta.Version)
+}
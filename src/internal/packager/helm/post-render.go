@@ -8,12 +8,14 @@ import (
 	"bytes"
 	"context"
 	"fmt"
+	"maps"
 	"slices"
 
 	"github.com/zarf-dev/zarf/src/pkg/state"
 
 	"github.com/zarf-dev/zarf/src/api/v1alpha1"
 	"github.com/zarf-dev/zarf/src/config"
+	"github.com/zarf-dev/zarf/src/internal/agent/hooks"
 	"github.com/zarf-dev/zarf/src/pkg/cluster"
 	"github.com/zarf-dev/zarf/src/pkg/logger"
 	"github.com/zarf-dev/zarf/src/pkg/variables"
@@ -38,14 +40,18 @@ type renderer struct {
 	state           *state.State
 	actionConfig    *action.Configuration
 	variableConfig  *variables.VariableConfig
+	// agentMutatedKinds are the kinds the agent in the cluster mutates, including those of its mutation rules.
+	agentMutatedKinds map[schema.GroupKind][][]string
 
 	connectStrings    state.ConnectStrings
 	namespaces        map[string]*corev1.Namespace
 	pkgName           string
 	namespaceOverride string
+	registryTarget    string
+	gitOrganization   string
 }
 
-func newRenderer(ctx context.Context, chart v1alpha1.ZarfChart, takeOwnership bool, c *cluster.Cluster, connectedDeploy bool, s *state.State, actionConfig *action.Configuration, variableConfig *variables.VariableConfig, pkgName string, namespaceOverride string) (*renderer, error) {
+func newRenderer(ctx context.Context, chart v1alpha1.ZarfChart, takeOwnership bool, c *cluster.Cluster, connectedDeploy bool, s *state.State, actionConfig *action.Configuration, variableConfig *variables.VariableConfig, pkgName string, namespaceOverride string, registryTarget string, gitOrganization string) (*renderer, error) {
 	if actionConfig == nil {
 		return nil, fmt.Errorf("action configuration required to run post renderer")
 	}
@@ -68,6 +74,8 @@ func newRenderer(ctx context.Context, chart v1alpha1.ZarfChart, takeOwnership bo
 		namespaces:        map[string]*corev1.Namespace{},
 		pkgName:           pkgName,
 		namespaceOverride: namespaceOverride,
+		registryTarget:    registryTarget,
+		gitOrganization:   gitOrganization,
 	}
 
 	namespace, err := rend.cluster.Clientset.CoreV1().Namespaces().Get(ctx, rend.chart.Namespace, metav1.GetOptions{})
@@ -82,9 +90,34 @@ func newRenderer(ctx context.Context, chart v1alpha1.ZarfChart, takeOwnership bo
 		rend.namespaces[rend.chart.Namespace] = namespace
 	}
 
+	if rend.shouldAddAgentIgnoreLabels() {
+		rules, err := agentMutationRules(ctx, rend.cluster)
+		if err != nil {
+			return nil, err
+		}
+		rend.agentMutatedKinds = mutatedKinds(rules)
+	}
+
 	return rend, nil
 }
 
+// agentMutationRules reads the mutation rules the agent was initialized with.
+func agentMutationRules(ctx context.Context, c *cluster.Cluster) ([]hooks.MutationRule, error) {
+	cm, err := c.Clientset.CoreV1().ConfigMaps(state.ZarfNamespaceName).Get(ctx, hooks.MutationRulesConfigMap, metav1.GetOptions{})
+	// Agents deployed before mutation rules were added have no ConfigMap
+	if kerrors.IsNotFound(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("unable to get the agent mutation rules: %w", err)
+	}
+	rules, err := hooks.ParseMutationRules([]byte(cm.Data[hooks.MutationRulesKey]))
+	if err != nil {
+		return nil, fmt.Errorf("unable to parse the agent mutation rules: %w", err)
+	}
+	return rules, nil
+}
+
 // Run satisfies the Helm post-renderer interface. It templates the Zarf variables, finds connect strings, adopts namespaces, and applies Zarf state secrets
 func (r *renderer) Run(renderedManifests *bytes.Buffer) (*bytes.Buffer, error) {
 	// This is very low cost and consistent for how we replace elsewhere, also good for debugging
@@ -118,13 +151,20 @@ func (r *renderer) adoptAndUpdateNamespaces(ctx context.Context) error {
 	}
 	for name, namespace := range r.namespaces {
 		// Check to see if this namespace already exists
-		var existingNamespace bool
-		for _, serverNamespace := range namespaceList.Items {
-			if serverNamespace.Name == name {
-				existingNamespace = true
+		var serverNamespace *corev1.Namespace
+		for i := range namespaceList.Items {
+			if namespaceList.Items[i].Name == name {
+				serverNamespace = &namespaceList.Items[i]
 				break
 			}
 		}
+		existingNamespace := serverNamespace != nil
+		if r.registryTarget != "" {
+			if namespace.Annotations == nil {
+				namespace.Annotations = map[string]string{}
+			}
+			namespace.Annotations[cluster.RegistryTargetAnnotation] = r.registryTarget
+		}
 		// If the namespace doesn't exist then create it. If it does exist and is already managed by Zarf then update the labels with
 		// the new package and namespace override labels.
 		if !existingNamespace {
@@ -147,8 +187,20 @@ func (r *renderer) adoptAndUpdateNamespaces(ctx context.Context) error {
 			}
 		}
 
-		if r.state.RegistryInfo.IsConfigured() {
-			validRegistrySecret, err := c.GenerateRegistryPullCreds(ctx, name, config.ZarfImagePullSecretName, r.state.RegistryInfo)
+		// Existing namespaces that were not adopted keep their own registry target selection.
+		registryNamespace := namespace
+		if existingNamespace && !r.takeOwnership {
+			registryNamespace = serverNamespace
+			if r.registryTarget != "" && cluster.RegistryTargetForNamespace(r.state, serverNamespace) != r.registryTarget {
+				l.Warn("existing namespace was not adopted and does not select the registry target, images may not be routed to it", "name", name, "registryTarget", r.registryTarget)
+			}
+		}
+		registryInfo, err := cluster.RegistryInfoForNamespace(r.state, registryNamespace)
+		if err != nil {
+			return err
+		}
+		if registryInfo.IsConfigured() {
+			validRegistrySecret, err := c.GenerateRegistryPullCreds(ctx, name, config.ZarfImagePullSecretName, registryInfo)
 			if err != nil {
 				return err
 			}
@@ -156,7 +208,13 @@ func (r *renderer) adoptAndUpdateNamespaces(ctx context.Context) error {
 			if err != nil {
 				return fmt.Errorf("problem applying registry secret for the %s namespace: %w", name, err)
 			}
-			if r.state.RegistryInfo.ShouldUseMTLS() {
+			// ServiceAccounts that already exist, such as default, are not seen by the agent so add the secret to them directly
+			if r.state.AgentImagePullSecretMode == state.ImagePullSecretModeServiceAccount {
+				if err := c.AddZarfImagePullSecretToServiceAccounts(ctx, name); err != nil {
+					return err
+				}
+			}
+			if registryInfo.ShouldUseMTLS() {
 				clientPKI, err := c.GetRegistryClientMTLSCert(ctx)
 				if err != nil {
 					return fmt.Errorf("failed to get registry client certs: %w", err)
@@ -198,7 +256,7 @@ func (r *renderer) editHelmResources(ctx context.Context, resources []releaseuti
 			}
 			// In connected or YOLO mode, add agent ignore labels so the webhook doesn't mutate resources
 			if r.shouldAddAgentIgnoreLabels() {
-				if err := addAgentIgnoreLabels(obj); err != nil {
+				if err := addAgentIgnoreLabels(obj, r.agentMutatedKinds); err != nil {
 					return err
 				}
 			}
@@ -286,24 +344,40 @@ func (r *renderer) addLabelsToNestedPath(obj *unstructured.Unstructured, path []
 // label paths where the ignore label should be applied.
 // These come from the webhook configuration in packages/zarf-agent/chart/templates/webhook.yaml.
 var agentMutatedKinds = map[schema.GroupKind][][]string{
-	{Group: "", Kind: "Pod"}:                                    {{"metadata", "labels"}},
-	{Group: "apps", Kind: "Deployment"}:                         {{"spec", "template", "metadata", "labels"}},
-	{Group: "apps", Kind: "StatefulSet"}:                        {{"spec", "template", "metadata", "labels"}},
-	{Group: "apps", Kind: "DaemonSet"}:                          {{"spec", "template", "metadata", "labels"}},
-	{Group: "apps", Kind: "ReplicaSet"}:                         {{"spec", "template", "metadata", "labels"}},
-	{Group: "batch", Kind: "Job"}:                               {{"spec", "template", "metadata", "labels"}},
-	{Group: "batch", Kind: "CronJob"}:                           {{"spec", "jobTemplate", "spec", "template", "metadata", "labels"}},
-	{Group: "source.toolkit.fluxcd.io", Kind: "GitRepository"}:  {{"metadata", "labels"}},
-	{Group: "source.toolkit.fluxcd.io", Kind: "OCIRepository"}:  {{"metadata", "labels"}},
-	{Group: "source.toolkit.fluxcd.io", Kind: "HelmRepository"}: {{"metadata", "labels"}},
-	{Group: "argoproj.io", Kind: "Application"}:                 {{"metadata", "labels"}},
-	{Group: "argoproj.io", Kind: "ApplicationSet"}:              {{"metadata", "labels"}},
-	{Group: "argoproj.io", Kind: "AppProject"}:                  {{"metadata", "labels"}},
-	{Group: "", Kind: "Secret"}:                                 {{"metadata", "labels"}},
+	{Group: "", Kind: "Pod"}:                                      {{"metadata", "labels"}},
+	{Group: "apps", Kind: "Deployment"}:                           {{"spec", "template", "metadata", "labels"}},
+	{Group: "apps", Kind: "StatefulSet"}:                          {{"spec", "template", "metadata", "labels"}},
+	{Group: "apps", Kind: "DaemonSet"}:                            {{"spec", "template", "metadata", "labels"}},
+	{Group: "apps", Kind: "ReplicaSet"}:                           {{"spec", "template", "metadata", "labels"}},
+	{Group: "batch", Kind: "Job"}:                                 {{"spec", "template", "metadata", "labels"}},
+	{Group: "batch", Kind: "CronJob"}:                             {{"spec", "jobTemplate", "spec", "template", "metadata", "labels"}},
+	{Group: "source.toolkit.fluxcd.io", Kind: "GitRepository"}:    {{"metadata", "labels"}},
+	{Group: "source.toolkit.fluxcd.io", Kind: "OCIRepository"}:    {{"metadata", "labels"}},
+	{Group: "source.toolkit.fluxcd.io", Kind: "HelmRepository"}:   {{"metadata", "labels"}},
+	{Group: "kustomize.toolkit.fluxcd.io", Kind: "Kustomization"}: {{"metadata", "labels"}},
+	{Group: "helm.toolkit.fluxcd.io", Kind: "HelmRelease"}:        {{"metadata", "labels"}},
+	{Group: "argoproj.io", Kind: "Application"}:                   {{"metadata", "labels"}},
+	{Group: "argoproj.io", Kind: "ApplicationSet"}:                {{"metadata", "labels"}},
+	{Group: "argoproj.io", Kind: "AppProject"}:                    {{"metadata", "labels"}},
+	{Group: "", Kind: "Secret"}:                                   {{"metadata", "labels"}},
+	{Group: "", Kind: "ServiceAccount"}:                           {{"metadata", "labels"}},
+}
+
+// mutatedKinds adds the kinds targeted by mutation rules to agentMutatedKinds. The generic hook mutates fields of the
+// resource itself, so the ignore label goes on its metadata.
+func mutatedKinds(rules []hooks.MutationRule) map[schema.GroupKind][][]string {
+	kinds := maps.Clone(agentMutatedKinds)
+	for _, rule := range rules {
+		gk := schema.GroupKind{Group: rule.Group, Kind: rule.Kind}
+		if _, ok := kinds[gk]; !ok {
+			kinds[gk] = [][]string{{"metadata", "labels"}}
+		}
+	}
+	return kinds
 }
 
-func addAgentIgnoreLabels(obj *unstructured.Unstructured) error {
-	labelPaths, ok := agentMutatedKinds[obj.GroupVersionKind().GroupKind()]
+func addAgentIgnoreLabels(obj *unstructured.Unstructured, kinds map[schema.GroupKind][][]string) error {
+	labelPaths, ok := kinds[obj.GroupVersionKind().GroupKind()]
 	if !ok {
 		return nil
 	}
@@ -338,6 +412,9 @@ func (r *renderer) setPackageLabels(labels map[string]string) map[string]string
 		if r.namespaceOverride != "" {
 			labels[cluster.NamespaceOverrideLabel] = r.namespaceOverride
 		}
+		if r.gitOrganization != "" {
+			labels[cluster.GitOrganizationLabel] = r.gitOrganization
+		}
 	}
 	return labels
 }
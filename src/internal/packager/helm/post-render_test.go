@@ -4,18 +4,27 @@
 package helm
 
 import (
-	"os"
 	"path/filepath"
-	"regexp"
 	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/require"
+	"github.com/zarf-dev/zarf/src/config"
+	"github.com/zarf-dev/zarf/src/internal/agent/hooks"
+	"github.com/zarf-dev/zarf/src/pkg/cluster"
 	"github.com/zarf-dev/zarf/src/pkg/pki"
 	"github.com/zarf-dev/zarf/src/pkg/state"
+	"github.com/zarf-dev/zarf/src/test/testutil"
+	"helm.sh/helm/v4/pkg/chart/common"
+	chartutil "helm.sh/helm/v4/pkg/chart/common/util"
+	"helm.sh/helm/v4/pkg/chart/v2/loader"
+	"helm.sh/helm/v4/pkg/engine"
 	admissionregistrationv1 "k8s.io/api/admissionregistration/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/kubernetes/fake"
 	"sigs.k8s.io/yaml"
 )
 
@@ -206,6 +215,43 @@ func TestRendererShouldAddAgentIgnoreLabels(t *testing.T) {
 	}
 }
 
+func TestAdoptAndUpdateNamespacesRegistryTarget(t *testing.T) {
+	t.Parallel()
+	ctx := testutil.TestContext(t)
+
+	c := &cluster.Cluster{Clientset: fake.NewClientset()}
+	existing := &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "existing"}}
+	_, err := c.Clientset.CoreV1().Namespaces().Create(ctx, existing, metav1.CreateOptions{})
+	require.NoError(t, err)
+
+	r := &renderer{
+		cluster: c,
+		state: &state.State{
+			RegistryInfo:    state.RegistryInfo{Address: "127.0.0.1:31999"},
+			RegistryTargets: []state.RegistryTarget{{Name: "tenant-a", Address: "registry.example.com", Prefix: "tenant-a"}},
+		},
+		namespaces: map[string]*corev1.Namespace{
+			"created":  cluster.NewZarfManagedNamespace("created"),
+			"existing": existing.DeepCopy(),
+		},
+		registryTarget: "tenant-a",
+	}
+	require.NoError(t, r.adoptAndUpdateNamespaces(ctx))
+
+	// New namespaces are annotated with the target and pull from it.
+	created, err := c.Clientset.CoreV1().Namespaces().Get(ctx, "created", metav1.GetOptions{})
+	require.NoError(t, err)
+	require.Equal(t, "tenant-a", created.Annotations[cluster.RegistryTargetAnnotation])
+	secret, err := c.Clientset.CoreV1().Secrets("created").Get(ctx, config.ZarfImagePullSecretName, metav1.GetOptions{})
+	require.NoError(t, err)
+	require.Contains(t, string(secret.Data[".dockerconfigjson"]), "registry.example.com/tenant-a")
+
+	// Existing namespaces that are not adopted keep their own selection.
+	secret, err = c.Clientset.CoreV1().Secrets("existing").Get(ctx, config.ZarfImagePullSecretName, metav1.GetOptions{})
+	require.NoError(t, err)
+	require.Contains(t, string(secret.Data[".dockerconfigjson"]), "127.0.0.1:31999")
+}
+
 func TestAddAgentIgnoreLabels(t *testing.T) {
 	t.Parallel()
 
@@ -414,7 +460,7 @@ func TestAddAgentIgnoreLabels(t *testing.T) {
 			// Capture pre-existing top-level labels for preservation check
 			preLabels := tt.obj.GetLabels()
 
-			err := addAgentIgnoreLabels(tt.obj)
+			err := addAgentIgnoreLabels(tt.obj, agentMutatedKinds)
 			require.NoError(t, err)
 
 			// Verify existing top-level labels are preserved
@@ -443,14 +489,36 @@ func TestAddAgentIgnoreLabels(t *testing.T) {
 func TestAgentMutatedKindsMatchesWebhook(t *testing.T) {
 	t.Parallel()
 
-	webhookPath := filepath.Join("..", "..", "..", "..", "packages", "zarf-agent", "chart", "templates", "webhook.yaml")
-	data, err := os.ReadFile(webhookPath)
+	chartPath := filepath.Join("..", "..", "..", "..", "packages", "zarf-agent", "chart")
+	agentChart, err := loader.Load(chartPath)
 	require.NoError(t, err)
 
-	// Strip Helm template directives so the manifest can be parsed as plain YAML.
-	// then replace remaining inline expressions with a placeholder.
-	cleaned := regexp.MustCompile(`(?m)^\s*{{[^}]*}}\s*\n`).ReplaceAllString(string(data), "")
-	cleaned = regexp.MustCompile(`{{[^}]*}}`).ReplaceAllString(cleaned, "placeholder")
+	// Render the webhook with a mutation rule so that the rules generated from it are checked too.
+	rulesYAML := `
+- group: tekton.dev
+  version: v1
+  kind: Task
+  resource: tasks
+  fields:
+    - path: spec.steps[*].image
+      transform: image
+`
+	rules, err := hooks.ParseMutationRules([]byte(rulesYAML))
+	require.NoError(t, err)
+	var rulesValue []any
+	require.NoError(t, yaml.Unmarshal([]byte(rulesYAML), &rulesValue))
+	// Enable every optional webhook so that all of their rules are checked.
+	values := map[string]any{
+		"mutationRules":       rulesValue,
+		"imagePullSecretMode": "serviceaccount",
+	}
+	renderValues, err := chartutil.ToRenderValues(agentChart, values,
+		common.ReleaseOptions{Name: "zarf-agent", Namespace: "zarf"}, common.DefaultCapabilities)
+	require.NoError(t, err)
+	rendered, err := engine.Render(agentChart, renderValues)
+	require.NoError(t, err)
+	data, ok := rendered[agentChart.Name()+"/templates/webhook.yaml"]
+	require.True(t, ok, "expected the agent chart to render webhook.yaml")
 
 	// Only parse the rules — decoding the full MutatingWebhookConfiguration would
 	// fail on the templated caBundle placeholder which is not valid base64.
@@ -459,20 +527,26 @@ func TestAgentMutatedKindsMatchesWebhook(t *testing.T) {
 			Rules []admissionregistrationv1.RuleWithOperations `json:"rules"`
 		} `json:"webhooks"`
 	}
-	require.NoError(t, yaml.Unmarshal([]byte(cleaned), &cfg))
+	require.NoError(t, yaml.Unmarshal([]byte(data), &cfg))
 	require.NotEmpty(t, cfg.Webhooks, "expected webhook configuration to contain webhooks")
+	kinds := mutatedKinds(rules)
 
 	// Maps plural resource names from the webhook rules to their Kind.
 	// Update this when adding a new resource to webhook.yaml.
 	resourceToKind := map[string]string{
 		"pods":             "Pod",
 		"secrets":          "Secret",
+		"serviceaccounts":  "ServiceAccount",
 		"gitrepositories":  "GitRepository",
 		"ocirepositories":  "OCIRepository",
 		"helmrepositories": "HelmRepository",
+		"kustomizations":   "Kustomization",
+		"helmreleases":     "HelmRelease",
 		"applications":     "Application",
 		"applicationsets":  "ApplicationSet",
 		"appprojects":      "AppProject",
+		// From the mutation rule above
+		"tasks": "Task",
 	}
 
 	webhookGroupKinds := map[schema.GroupKind]struct{}{}
@@ -492,10 +566,12 @@ func TestAgentMutatedKindsMatchesWebhook(t *testing.T) {
 		}
 	}
 
-	// Every webhook-targeted GroupKind must be present in agentMutatedKinds so
+	require.Contains(t, webhookGroupKinds, schema.GroupKind{Group: "tekton.dev", Kind: "Task"})
+
+	// Every webhook-targeted GroupKind must be present in the mutated kinds so
 	// that addAgentIgnoreLabels can annotate it with the ignore label.
 	for gk := range webhookGroupKinds {
-		_, ok := agentMutatedKinds[gk]
+		_, ok := kinds[gk]
 		require.Truef(t, ok, "webhook targets %v but it is missing from agentMutatedKinds", gk)
 	}
 
@@ -510,7 +586,7 @@ func TestAgentMutatedKindsMatchesWebhook(t *testing.T) {
 		{Group: "batch", Kind: "Job"}:        {},
 		{Group: "batch", Kind: "CronJob"}:    {},
 	}
-	for gk := range agentMutatedKinds {
+	for gk := range kinds {
 		if _, ok := podControllers[gk]; ok {
 			continue
 		}
@@ -5,11 +5,14 @@
 package helm
 
 import (
+	"bytes"
 	"context"
 	"errors"
 	"fmt"
 	"io"
 	"log/slog"
+	"net/http"
+	"net/url"
 	"os"
 	"path/filepath"
 	"strings"
@@ -36,6 +39,7 @@ import (
 	"github.com/zarf-dev/zarf/src/config"
 	"github.com/zarf-dev/zarf/src/config/lang"
 	"github.com/zarf-dev/zarf/src/internal/git"
+	"github.com/zarf-dev/zarf/src/pkg/images"
 	"github.com/zarf-dev/zarf/src/pkg/ocischeme"
 	"github.com/zarf-dev/zarf/src/pkg/transform"
 	"github.com/zarf-dev/zarf/src/pkg/utils"
@@ -263,7 +267,7 @@ func DownloadPublishedChart(ctx context.Context, chart v1alpha1.ZarfChart, paths
 		chartURL, err = repov1.FindChartInRepoURL(
 			chart.URL,
 			chartName,
-			getter.All(pull.Settings),
+			scheduledGetters(ctx, getter.All(pull.Settings)),
 			repov1.WithChartVersion(chart.Version),
 			repov1.WithUsernamePassword(username, password),
 			repov1.WithClientTLS(pull.CertFile, pull.KeyFile, pull.CaFile),
@@ -281,11 +285,7 @@ func DownloadPublishedChart(ctx context.Context, chart v1alpha1.ZarfChart, paths
 		if err != nil {
 			return err
 		}
-		clientOpts := []registry.ClientOption{registry.ClientOptEnableCache(true)}
-		if plainHTTP {
-			clientOpts = append(clientOpts, registry.ClientOptPlainHTTP())
-		}
-		regClient, err = registry.NewClient(clientOpts...)
+		regClient, err = newRegistryClient(ctx, plainHTTP)
 		if err != nil {
 			return fmt.Errorf("unable to create the new registry client: %w", err)
 		}
@@ -300,7 +300,7 @@ func DownloadPublishedChart(ctx context.Context, chart v1alpha1.ZarfChart, paths
 		ContentCache:   contentCache,
 		// TODO: Further research this with regular/OCI charts
 		Verify:  downloader.VerifyNever,
-		Getters: getter.All(pull.Settings),
+		Getters: scheduledGetters(ctx, getter.All(pull.Settings)),
 		Options: []getter.Option{
 			// plainHTTP is negotiated only in the OCI branch above and stays false for
 			// a traditional repo; Helm's http/https getter (unlike its OCI getter)
@@ -360,6 +360,65 @@ func DownloadPublishedChart(ctx context.Context, chart v1alpha1.ZarfChart, paths
 	return nil
 }
 
+// newRegistryClient returns a Helm registry client whose requests are scheduled with the other fetches of
+// the package being assembled, see images.WithScheduler.
+func newRegistryClient(ctx context.Context, plainHTTP bool) (*registry.Client, error) {
+	httpClient := &http.Client{
+		Transport: images.SchedulerFrom(ctx).Transport(ctx, registry.NewTransport(false)),
+	}
+	clientOpts := []registry.ClientOption{
+		registry.ClientOptEnableCache(true),
+		registry.ClientOptHTTPClient(httpClient),
+	}
+	if plainHTTP {
+		clientOpts = append(clientOpts, registry.ClientOptPlainHTTP())
+	}
+	return registry.NewClient(clientOpts...)
+}
+
+// scheduledGetters wraps the http and https getters of providers so that every chart and index download
+// waits for a slot of its host. Helm does not expose the responses of its getters, so unlike image pulls
+// these downloads only honor backoffs triggered by other requests to the same host.
+func scheduledGetters(ctx context.Context, providers getter.Providers) getter.Providers {
+	scheduler := images.SchedulerFrom(ctx)
+	scheduled := make(getter.Providers, 0, len(providers))
+	for _, p := range providers {
+		if p.Provides("http") || p.Provides("https") {
+			newGetter := p.New
+			p.New = func(options ...getter.Option) (getter.Getter, error) {
+				g, err := newGetter(options...)
+				if err != nil {
+					return nil, err
+				}
+				return &scheduledGetter{ctx: ctx, getter: g, scheduler: scheduler}, nil
+			}
+		}
+		scheduled = append(scheduled, p)
+	}
+	return scheduled
+}
+
+// scheduledGetter is a getter.Getter that holds a slot of the host while it downloads.
+type scheduledGetter struct {
+	ctx       context.Context
+	getter    getter.Getter
+	scheduler *images.Scheduler
+}
+
+// Get implements getter.Getter.
+func (g *scheduledGetter) Get(href string, options ...getter.Option) (*bytes.Buffer, error) {
+	u, err := url.Parse(href)
+	if err != nil {
+		return nil, err
+	}
+	release, err := g.scheduler.Acquire(g.ctx, u.Host)
+	if err != nil {
+		return nil, err
+	}
+	defer release()
+	return g.getter.Get(href, options...)
+}
+
 // DownloadChartFromGitToTemp downloads a chart from git into a temp directory
 func DownloadChartFromGitToTemp(ctx context.Context, url string) (string, error) {
 	path, err := utils.MakeTempDir(config.CommonOptions.TempDirectory)
@@ -418,11 +477,7 @@ func buildChartDependencies(ctx context.Context, chart v1alpha1.ZarfChart, cache
 	}
 
 	// Download and build the specified dependencies
-	clientOpts := []registry.ClientOption{registry.ClientOptEnableCache(true)}
-	if plainHTTP {
-		clientOpts = append(clientOpts, registry.ClientOptPlainHTTP())
-	}
-	regClient, err := registry.NewClient(clientOpts...)
+	regClient, err := newRegistryClient(ctx, plainHTTP)
 	if err != nil {
 		return fmt.Errorf("unable to create a new registry client: %w", err)
 	}
@@ -435,7 +490,7 @@ func buildChartDependencies(ctx context.Context, chart v1alpha1.ZarfChart, cache
 		Out:            &logger.LogWriter{Logger: l, Level: logger.Debug},
 		ContentCache:   contentCache,
 		ChartPath:      chart.LocalPath,
-		Getters:        getter.All(settings),
+		Getters:        scheduledGetters(ctx, getter.All(settings)),
 		RegistryClient: regClient,
 
 		RepositoryConfig: settings.RepositoryConfig,
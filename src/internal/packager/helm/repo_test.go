@@ -5,18 +5,23 @@
 package helm
 
 import (
+	"context"
 	"fmt"
 	"net/http"
 	"net/http/httptest"
+	"net/url"
 	"os"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/require"
 	chartv2 "helm.sh/helm/v4/pkg/chart/v2"
 	chartutil "helm.sh/helm/v4/pkg/chart/v2/util"
+	"helm.sh/helm/v4/pkg/getter"
 	"helm.sh/helm/v4/pkg/registry"
 
 	"github.com/zarf-dev/zarf/src/api/v1alpha1"
+	"github.com/zarf-dev/zarf/src/pkg/images"
 	"github.com/zarf-dev/zarf/src/pkg/packager/layout"
 	"github.com/zarf-dev/zarf/src/test/testutil"
 	"github.com/zarf-dev/zarf/src/types"
@@ -117,3 +122,34 @@ func TestDownloadPublishedChartFromOCI(t *testing.T) {
 	require.NoError(t, err)
 	require.FileExists(t, paths.Archive(chart.Name, chart.Version))
 }
+
+func TestScheduledGetters(t *testing.T) {
+	t.Parallel()
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.Write([]byte("index")) //nolint:errcheck
+	}))
+	t.Cleanup(srv.Close)
+	u, err := url.Parse(srv.URL)
+	require.NoError(t, err)
+
+	scheduler := images.NewScheduler(map[string]int{u.Host: 1})
+	ctx := images.WithScheduler(testutil.TestContext(t), scheduler)
+	release, err := scheduler.Acquire(ctx, u.Host)
+	require.NoError(t, err)
+
+	// The download waits while the only slot of the host is held by another fetch
+	timeoutCtx, cancel := context.WithTimeout(ctx, 50*time.Millisecond)
+	defer cancel()
+	g, err := scheduledGetters(timeoutCtx, getter.Getters()).ByScheme("http")
+	require.NoError(t, err)
+	_, err = g.Get(srv.URL)
+	require.ErrorIs(t, err, context.DeadlineExceeded)
+
+	release()
+	g, err = scheduledGetters(ctx, getter.Getters()).ByScheme("http")
+	require.NoError(t, err)
+	buf, err := g.Get(srv.URL)
+	require.NoError(t, err)
+	require.Equal(t, "index", buf.String())
+}
@@ -78,6 +78,7 @@ func GetZarfTemplates(ctx context.Context, componentName string, s *state.State)
 			builtinMap["AGENT_KEY"] = base64.StdEncoding.EncodeToString(agentTLS.Key)
 			builtinMap["AGENT_CA"] = base64.StdEncoding.EncodeToString(agentTLS.CA)
 			builtinMap["AGENT_MUTATION_POLICY"] = string(s.AgentMutationPolicy)
+			builtinMap["AGENT_IMAGE_PULL_SECRET_MODE"] = string(s.AgentImagePullSecretMode)
 
 		case "zarf-seed-registry", "zarf-registry":
 			builtinMap["SEED_REGISTRY"] = state.LocalhostRegistryAddress(s.IPFamily, s.InjectorInfo.Port)
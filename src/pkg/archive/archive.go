@@ -180,6 +180,8 @@ type DecompressOpts struct {
 	OverwriteExisting bool
 	// SkipValidation suppresses errors for missing Files entries.
 	SkipValidation bool
+	// SkipPrefixes leaves entries whose archive path starts with any of these prefixes unextracted.
+	SkipPrefixes []string
 	// Extractor allows the user to specify which extractor should be used for decompression.
 	// If this is not set it will be determined automatically from the file extension
 	Extractor archives.Extractor
@@ -280,6 +282,9 @@ func extract(ctx context.Context, input io.Reader, dst string, opts DecompressOp
 	default:
 		handler = defaultHandler(root)
 	}
+	if len(opts.SkipPrefixes) > 0 {
+		handler = skipHandler(handler, opts.SkipPrefixes)
+	}
 
 	if err := opts.Extractor.Extract(ctx, input, handler); err != nil {
 		return fmt.Errorf("extracting: %w", err)
@@ -372,6 +377,18 @@ func filterHandler(root *os.Root, wantSet, found map[string]bool) func(_ context
 	}
 }
 
+// skipHandler wraps next so that entries whose names start with any of the given prefixes are ignored.
+func skipHandler(next archives.FileHandler, prefixes []string) archives.FileHandler {
+	return func(ctx context.Context, f archives.FileInfo) error {
+		for _, prefix := range prefixes {
+			if strings.HasPrefix(f.NameInArchive, prefix) {
+				return nil
+			}
+		}
+		return next(ctx, f)
+	}
+}
+
 // writeEntry validates and dispatches an archive entry within root.
 // Directory and file operations use os.Root methods, which provide
 // kernel-enforced path traversal and symlink escape protection.
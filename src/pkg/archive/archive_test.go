@@ -8,6 +8,8 @@ import (
 	"archive/tar"
 	"bytes"
 	"context"
+	"io"
+	"io/fs"
 	"os"
 	"path/filepath"
 	"strings"
@@ -512,3 +514,56 @@ func TestDecompressOptions(t *testing.T) {
 		})
 	}
 }
+
+func TestIndexTar(t *testing.T) {
+	t.Parallel()
+	tarPath := filepath.Join(t.TempDir(), "archive.tar")
+	entries := map[string]string{
+		"a.txt":             "alpha",
+		"dir/b.txt":         "bravo bravo",
+		"./dir/sub/c.txt":   "charlie",
+		"dir/sub/empty.txt": "",
+	}
+	require.NoError(t, os.WriteFile(tarPath, tarBytes(t, entries), testFilePerm))
+
+	idx, err := IndexTar(tarPath)
+	require.NoError(t, err)
+	require.Equal(t, tarPath, idx.Path())
+	require.Len(t, idx.Entries("dir/"), 3)
+
+	for name, content := range entries {
+		e, ok := idx.Stat(name)
+		require.True(t, ok, name)
+		require.Equal(t, int64(len(content)), e.Size)
+		rc, err := idx.Open(name)
+		require.NoError(t, err)
+		b, err := io.ReadAll(rc)
+		require.NoError(t, err)
+		require.NoError(t, rc.Close())
+		require.Equal(t, content, string(b))
+	}
+
+	_, err = idx.Open("missing.txt")
+	require.ErrorIs(t, err, fs.ErrNotExist)
+
+	_, err = IndexTar(filepath.Join(t.TempDir(), "archive.tar.zst"))
+	require.ErrorContains(t, err, "is not an uncompressed tar archive")
+}
+
+func TestDecompressSkipPrefixes(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	tarPath := filepath.Join(t.TempDir(), "archive.tar")
+	require.NoError(t, os.WriteFile(tarPath, tarBytes(t, map[string]string{
+		"images/index.json":        "{}",
+		"images/blobs/sha256/abc":  "blob",
+		"components/component.tar": "component",
+	}), testFilePerm))
+
+	outDir := t.TempDir()
+	require.NoError(t, Decompress(ctx, tarPath, outDir, DecompressOpts{SkipPrefixes: []string{"images/blobs/"}}))
+	require.Equal(t, map[string]string{
+		"images/index.json":        "{}",
+		"components/component.tar": "component",
+	}, treeContents(t, outDir))
+}
@@ -0,0 +1,118 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2021-Present The Zarf Authors
+
+package archive
+
+import (
+	"archive/tar"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path"
+	"strings"
+)
+
+// TarEntry describes a regular file stored in an uncompressed tar archive.
+type TarEntry struct {
+	// Name is the cleaned, slash-separated name of the entry in the archive.
+	Name string
+	// Offset is the position in the archive where the entry's data starts.
+	Offset int64
+	// Size is the length of the entry's data.
+	Size int64
+}
+
+// TarIndex provides random access to the regular files of an uncompressed tar archive without extracting it.
+type TarIndex struct {
+	path    string
+	entries map[string]TarEntry
+}
+
+// IndexTar reads the headers of the uncompressed tar archive at tarPath and records where the data of each
+// regular file starts. File contents are seeked past, not read.
+func IndexTar(tarPath string) (_ *TarIndex, err error) {
+	if !strings.HasSuffix(tarPath, extensionTar) {
+		return nil, fmt.Errorf("%s is not an uncompressed tar archive", tarPath)
+	}
+	f, err := os.Open(tarPath)
+	if err != nil {
+		return nil, fmt.Errorf("opening %q: %w", tarPath, err)
+	}
+	defer func() { err = errors.Join(err, f.Close()) }()
+
+	tr := tar.NewReader(f)
+	entries := map[string]TarEntry{}
+	for {
+		hdr, err := tr.Next()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("reading %q: %w", tarPath, err)
+		}
+		if hdr.Typeflag != tar.TypeReg {
+			continue
+		}
+		name := path.Clean(hdr.Name)
+		if err := validateEntryName(name); err != nil {
+			return nil, err
+		}
+		// tar.Reader reads whole blocks without buffering ahead, so once Next returns the file is positioned
+		// at the start of this entry's data.
+		offset, err := f.Seek(0, io.SeekCurrent)
+		if err != nil {
+			return nil, fmt.Errorf("reading %q: %w", tarPath, err)
+		}
+		entries[name] = TarEntry{Name: name, Offset: offset, Size: hdr.Size}
+	}
+	return &TarIndex{path: tarPath, entries: entries}, nil
+}
+
+// Path returns the path of the indexed archive.
+func (t *TarIndex) Path() string {
+	return t.path
+}
+
+// Stat returns the entry stored under name.
+func (t *TarIndex) Stat(name string) (TarEntry, bool) {
+	e, ok := t.entries[path.Clean(name)]
+	return e, ok
+}
+
+// Entries returns every entry whose name starts with prefix.
+func (t *TarIndex) Entries(prefix string) []TarEntry {
+	var out []TarEntry
+	for name, e := range t.entries {
+		if strings.HasPrefix(name, prefix) {
+			out = append(out, e)
+		}
+	}
+	return out
+}
+
+// Open returns a reader over the data of the entry stored under name. Each call opens the archive separately so
+// readers may be used concurrently.
+func (t *TarIndex) Open(name string) (io.ReadCloser, error) {
+	e, ok := t.Stat(name)
+	if !ok {
+		return nil, fmt.Errorf("%s not found in %s: %w", name, t.path, fs.ErrNotExist)
+	}
+	f, err := os.Open(t.path)
+	if err != nil {
+		return nil, fmt.Errorf("opening %q: %w", t.path, err)
+	}
+	return &sectionReadCloser{SectionReader: io.NewSectionReader(f, e.Offset, e.Size), f: f}, nil
+}
+
+// sectionReadCloser reads a single entry from an open archive and closes the archive when done.
+type sectionReadCloser struct {
+	*io.SectionReader
+	f *os.File
+}
+
+// Close closes the underlying archive.
+func (s *sectionReadCloser) Close() error {
+	return s.f.Close()
+}
@@ -0,0 +1,311 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2021-Present The Zarf Authors
+
+package cluster
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"slices"
+	"strings"
+	"time"
+
+	"filippo.io/age"
+	"golang.org/x/crypto/chacha20poly1305"
+	"golang.org/x/crypto/scrypt"
+	corev1 "k8s.io/api/core/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	v1ac "k8s.io/client-go/applyconfigurations/core/v1"
+
+	"github.com/zarf-dev/zarf/src/config"
+	"github.com/zarf-dev/zarf/src/pkg/logger"
+	"github.com/zarf-dev/zarf/src/pkg/state"
+)
+
+const (
+	stateBackupVersion = "v1"
+	// agentTLSSecretName is the secret the Zarf agent serves its webhook certificate from.
+	agentTLSSecretName = "agent-hook-tls"
+
+	// scrypt parameters recommended for interactive logins, see https://pkg.go.dev/golang.org/x/crypto/scrypt.
+	stateBackupScryptN = 1 << 15
+	stateBackupScryptR = 8
+	stateBackupScryptP = 1
+
+	// ageHeader starts every file encrypted with age, see https://age-encryption.org/v1.
+	ageHeader = "age-encryption.org/v1\n"
+)
+
+// StateBackup holds the secrets in the Zarf namespace that are needed to restore Zarf on a rebuilt cluster.
+type StateBackup struct {
+	Version    string          `json:"version"`
+	ExportedAt time.Time       `json:"exportedAt"`
+	Secrets    []corev1.Secret `json:"secrets"`
+}
+
+// encryptedStateBackup is the file format of an exported StateBackup.
+type encryptedStateBackup struct {
+	Version    string `json:"version"`
+	KDF        string `json:"kdf"`
+	Salt       []byte `json:"salt"`
+	N          int    `json:"n"`
+	R          int    `json:"r"`
+	P          int    `json:"p"`
+	Nonce      []byte `json:"nonce"`
+	Ciphertext []byte `json:"ciphertext"`
+}
+
+// ExportState reads the Zarf state, the deployed package records, and the TLS and credential secrets of the Zarf
+// services from the cluster.
+func (c *Cluster) ExportState(ctx context.Context) (*StateBackup, error) {
+	secrets := c.Clientset.CoreV1().Secrets(state.ZarfNamespaceName)
+
+	stateSecret, err := secrets.Get(ctx, state.ZarfStateSecretName, metav1.GetOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("unable to get the Zarf state, has Zarf been initiated: %w", err)
+	}
+	backup := &StateBackup{
+		Version:    stateBackupVersion,
+		ExportedAt: time.Now().UTC(),
+		Secrets:    []corev1.Secret{backupSecret(*stateSecret)},
+	}
+
+	packageSecrets, err := secrets.List(ctx, metav1.ListOptions{LabelSelector: state.ZarfPackageInfoLabel})
+	if err != nil {
+		return nil, fmt.Errorf("unable to list the deployed packages: %w", err)
+	}
+	for _, secret := range packageSecrets.Items {
+		backup.Secrets = append(backup.Secrets, backupSecret(secret))
+	}
+
+	// Not every cluster runs every service, so these secrets may not exist
+	optional := []string{
+		state.RegistryServerTLSSecret,
+		state.RegistryClientTLSSecret,
+		agentTLSSecretName,
+		config.ZarfGitServerSecretName,
+		config.ZarfImagePullSecretName,
+	}
+	for _, name := range optional {
+		secret, err := secrets.Get(ctx, name, metav1.GetOptions{})
+		if kerrors.IsNotFound(err) {
+			continue
+		}
+		if err != nil {
+			return nil, fmt.Errorf("unable to get secret %s: %w", name, err)
+		}
+		backup.Secrets = append(backup.Secrets, backupSecret(*secret))
+	}
+	return backup, nil
+}
+
+// backupSecret keeps only the parts of a secret that are needed to recreate it in another cluster.
+func backupSecret(secret corev1.Secret) corev1.Secret {
+	return corev1.Secret{
+		TypeMeta: metav1.TypeMeta{APIVersion: "v1", Kind: "Secret"},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        secret.Name,
+			Namespace:   state.ZarfNamespaceName,
+			Labels:      secret.Labels,
+			Annotations: secret.Annotations,
+		},
+		Type: secret.Type,
+		Data: secret.Data,
+	}
+}
+
+// ImportStateOptions are the options for ImportState.
+type ImportStateOptions struct {
+	// Overwrite replaces the Zarf state and any other secrets in the backup that already exist in the cluster.
+	Overwrite bool
+}
+
+// ImportState restores the secrets of a StateBackup into the Zarf namespace.
+func (c *Cluster) ImportState(ctx context.Context, backup *StateBackup, opts ImportStateOptions) error {
+	l := logger.From(ctx)
+	if backup.Version != stateBackupVersion {
+		return fmt.Errorf("unsupported state backup version %q", backup.Version)
+	}
+	hasState := false
+	for _, secret := range backup.Secrets {
+		if secret.Name == state.ZarfStateSecretName {
+			hasState = true
+		}
+	}
+	if !hasState {
+		return errors.New("the state backup does not contain the Zarf state")
+	}
+
+	// Check every secret before applying any so that a refused import leaves the cluster untouched
+	if !opts.Overwrite {
+		existing := []string{}
+		for _, secret := range backup.Secrets {
+			_, err := c.Clientset.CoreV1().Secrets(state.ZarfNamespaceName).Get(ctx, secret.Name, metav1.GetOptions{})
+			if kerrors.IsNotFound(err) {
+				continue
+			}
+			if err != nil {
+				return fmt.Errorf("unable to check for an existing secret %s: %w", secret.Name, err)
+			}
+			existing = append(existing, secret.Name)
+		}
+		if slices.Contains(existing, state.ZarfStateSecretName) {
+			return errors.New("the cluster already has a Zarf state, use --overwrite to replace it")
+		}
+		if len(existing) > 0 {
+			return fmt.Errorf("the cluster already has the secrets %s, use --overwrite to replace them", strings.Join(existing, ", "))
+		}
+	}
+
+	zarfNamespace := NewZarfManagedApplyNamespace(state.ZarfNamespaceName)
+	_, err := c.Clientset.CoreV1().Namespaces().Apply(ctx, zarfNamespace, metav1.ApplyOptions{Force: true, FieldManager: FieldManagerName})
+	if err != nil {
+		return fmt.Errorf("unable to apply the Zarf namespace: %w", err)
+	}
+
+	for _, secret := range backup.Secrets {
+		secretApply := v1ac.Secret(secret.Name, state.ZarfNamespaceName).
+			WithLabels(secret.Labels).
+			WithAnnotations(secret.Annotations).
+			WithData(secret.Data)
+		if secret.Type != "" {
+			secretApply.WithType(secret.Type)
+		}
+		applied, err := c.Clientset.CoreV1().Secrets(state.ZarfNamespaceName).Apply(ctx, secretApply, metav1.ApplyOptions{Force: true, FieldManager: FieldManagerName})
+		if err != nil {
+			return fmt.Errorf("unable to restore secret %s: %w", secret.Name, err)
+		}
+		c.observeResourceVersion(applied.Name, applied.ResourceVersion)
+		l.Debug("restored secret", "name", secret.Name)
+	}
+	return nil
+}
+
+// EncryptStateBackup encrypts a StateBackup with a key derived from the passphrase.
+func EncryptStateBackup(backup *StateBackup, passphrase []byte) ([]byte, error) {
+	if len(passphrase) == 0 {
+		return nil, errors.New("a passphrase is required to encrypt the state backup")
+	}
+	plaintext, err := json.Marshal(backup)
+	if err != nil {
+		return nil, err
+	}
+	envelope := encryptedStateBackup{
+		Version: stateBackupVersion,
+		KDF:     "scrypt",
+		Salt:    make([]byte, 32),
+		N:       stateBackupScryptN,
+		R:       stateBackupScryptR,
+		P:       stateBackupScryptP,
+		Nonce:   make([]byte, chacha20poly1305.NonceSizeX),
+	}
+	if _, err := rand.Read(envelope.Salt); err != nil {
+		return nil, err
+	}
+	if _, err := rand.Read(envelope.Nonce); err != nil {
+		return nil, err
+	}
+	key, err := scrypt.Key(passphrase, envelope.Salt, envelope.N, envelope.R, envelope.P, chacha20poly1305.KeySize)
+	if err != nil {
+		return nil, err
+	}
+	aead, err := chacha20poly1305.NewX(key)
+	if err != nil {
+		return nil, err
+	}
+	envelope.Ciphertext = aead.Seal(nil, envelope.Nonce, plaintext, nil)
+	return json.Marshal(envelope)
+}
+
+// DecryptStateBackup decrypts a StateBackup that was encrypted with EncryptStateBackup.
+func DecryptStateBackup(data, passphrase []byte) (*StateBackup, error) {
+	if IsAgeStateBackup(data) {
+		return nil, errors.New("the state backup is encrypted to age recipients, use --identity to decrypt it")
+	}
+	envelope := encryptedStateBackup{}
+	if err := json.Unmarshal(data, &envelope); err != nil {
+		return nil, fmt.Errorf("unable to read the state backup: %w", err)
+	}
+	if envelope.Version != stateBackupVersion || envelope.KDF != "scrypt" {
+		return nil, fmt.Errorf("unsupported state backup version %q with key derivation %q", envelope.Version, envelope.KDF)
+	}
+	// Bound the key derivation cost so that a crafted file cannot exhaust memory or CPU
+	if envelope.N < 2 || envelope.N > stateBackupScryptN || envelope.R < 1 || envelope.R > stateBackupScryptR || envelope.P < 1 || envelope.P > stateBackupScryptP {
+		return nil, fmt.Errorf("the state backup has unsupported scrypt parameters N=%d r=%d p=%d", envelope.N, envelope.R, envelope.P)
+	}
+	if len(envelope.Nonce) != chacha20poly1305.NonceSizeX {
+		return nil, errors.New("the state backup has an invalid nonce")
+	}
+	key, err := scrypt.Key(passphrase, envelope.Salt, envelope.N, envelope.R, envelope.P, chacha20poly1305.KeySize)
+	if err != nil {
+		return nil, err
+	}
+	aead, err := chacha20poly1305.NewX(key)
+	if err != nil {
+		return nil, err
+	}
+	plaintext, err := aead.Open(nil, envelope.Nonce, envelope.Ciphertext, nil)
+	if err != nil {
+		return nil, errors.New("unable to decrypt the state backup, the passphrase is incorrect or the file is corrupt")
+	}
+	backup := &StateBackup{}
+	if err := json.Unmarshal(plaintext, backup); err != nil {
+		return nil, fmt.Errorf("unable to read the state backup: %w", err)
+	}
+	return backup, nil
+}
+
+// EncryptStateBackupToRecipients encrypts a StateBackup to age recipients. The result is a standard age file that
+// can also be decrypted with the age CLI.
+func EncryptStateBackupToRecipients(backup *StateBackup, recipients []age.Recipient) ([]byte, error) {
+	if len(recipients) == 0 {
+		return nil, errors.New("at least one recipient is required to encrypt the state backup")
+	}
+	plaintext, err := json.Marshal(backup)
+	if err != nil {
+		return nil, err
+	}
+	var buf bytes.Buffer
+	w, err := age.Encrypt(&buf, recipients...)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := w.Write(plaintext); err != nil {
+		return nil, err
+	}
+	if err := w.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// DecryptStateBackupWithIdentities decrypts a StateBackup that was encrypted with EncryptStateBackupToRecipients.
+func DecryptStateBackupWithIdentities(data []byte, identities []age.Identity) (*StateBackup, error) {
+	if !IsAgeStateBackup(data) {
+		return nil, errors.New("the state backup is encrypted with a passphrase, use --passphrase-file or the prompt to decrypt it")
+	}
+	r, err := age.Decrypt(bytes.NewReader(data), identities...)
+	if err != nil {
+		return nil, fmt.Errorf("unable to decrypt the state backup: %w", err)
+	}
+	plaintext, err := io.ReadAll(r)
+	if err != nil {
+		return nil, fmt.Errorf("unable to decrypt the state backup, the file is corrupt: %w", err)
+	}
+	backup := &StateBackup{}
+	if err := json.Unmarshal(plaintext, backup); err != nil {
+		return nil, fmt.Errorf("unable to read the state backup: %w", err)
+	}
+	return backup, nil
+}
+
+// IsAgeStateBackup returns true if the state backup was encrypted to age recipients instead of with a passphrase.
+func IsAgeStateBackup(data []byte) bool {
+	return bytes.HasPrefix(data, []byte(ageHeader))
+}
@@ -0,0 +1,176 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2021-Present The Zarf Authors
+
+package cluster
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"filippo.io/age"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+
+	"github.com/zarf-dev/zarf/src/pkg/state"
+)
+
+func TestStateBackupRoundTrip(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+
+	secret := func(name string, labels map[string]string, data string) *corev1.Secret {
+		return &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:            name,
+				Namespace:       state.ZarfNamespaceName,
+				Labels:          labels,
+				ResourceVersion: "7",
+			},
+			Data: map[string][]byte{"data": []byte(data)},
+		}
+	}
+	stateSecret := secret(state.ZarfStateSecretName, map[string]string{state.ZarfManagedByLabel: "zarf"}, "")
+	stateSecret.Data = map[string][]byte{state.ZarfStateDataKey: []byte(`{"distro":"k3s","gitServer":{"pushUsername":"zarf-git-user"}}`)}
+	packageSecret := secret("zarf-package-podinfo", map[string]string{state.ZarfPackageInfoLabel: "podinfo"}, "")
+	packageSecret.Data = map[string][]byte{"data": []byte(`{"name":"podinfo"}`)}
+	source := &Cluster{Clientset: fake.NewClientset(
+		stateSecret,
+		packageSecret,
+		secret(agentTLSSecretName, nil, "agent"),
+		secret(state.RegistryServerTLSSecret, nil, "server"),
+		secret("unrelated", nil, "unrelated"),
+	)}
+
+	backup, err := source.ExportState(ctx)
+	require.NoError(t, err)
+	names := []string{}
+	for _, s := range backup.Secrets {
+		require.Empty(t, s.ResourceVersion)
+		names = append(names, s.Name)
+	}
+	require.ElementsMatch(t, []string{state.ZarfStateSecretName, "zarf-package-podinfo", agentTLSSecretName, state.RegistryServerTLSSecret}, names)
+
+	data, err := EncryptStateBackup(backup, []byte("correct horse"))
+	require.NoError(t, err)
+	require.NotContains(t, string(data), "zarf-git-user")
+	_, err = DecryptStateBackup(data, []byte("wrong horse"))
+	require.Error(t, err)
+	decrypted, err := DecryptStateBackup(data, []byte("correct horse"))
+	require.NoError(t, err)
+
+	target := &Cluster{Clientset: fake.NewClientset()}
+	err = target.ImportState(ctx, decrypted, ImportStateOptions{})
+	require.NoError(t, err)
+	s, err := target.LoadState(ctx)
+	require.NoError(t, err)
+	require.Equal(t, "zarf-git-user", s.GitServer.PushUsername)
+	deployedPackages, err := target.GetDeployedZarfPackages(ctx)
+	require.NoError(t, err)
+	require.Len(t, deployedPackages, 1)
+	require.Equal(t, "podinfo", deployedPackages[0].Name)
+	agentSecret, err := target.Clientset.CoreV1().Secrets(state.ZarfNamespaceName).Get(ctx, agentTLSSecretName, metav1.GetOptions{})
+	require.NoError(t, err)
+	require.Equal(t, []byte("agent"), agentSecret.Data["data"])
+
+	// An initialized cluster is only replaced when asked to
+	err = target.ImportState(ctx, decrypted, ImportStateOptions{})
+	require.EqualError(t, err, "the cluster already has a Zarf state, use --overwrite to replace it")
+	err = target.ImportState(ctx, decrypted, ImportStateOptions{Overwrite: true})
+	require.NoError(t, err)
+
+	// Existing secrets other than the state are not silently replaced either
+	existing := secret(agentTLSSecretName, nil, "existing")
+	partial := &Cluster{Clientset: fake.NewClientset(existing)}
+	err = partial.ImportState(ctx, decrypted, ImportStateOptions{})
+	require.EqualError(t, err, "the cluster already has the secrets agent-hook-tls, use --overwrite to replace them")
+	_, err = partial.LoadState(ctx)
+	require.Error(t, err)
+	err = partial.ImportState(ctx, decrypted, ImportStateOptions{Overwrite: true})
+	require.NoError(t, err)
+	agentSecret, err = partial.Clientset.CoreV1().Secrets(state.ZarfNamespaceName).Get(ctx, agentTLSSecretName, metav1.GetOptions{})
+	require.NoError(t, err)
+	require.Equal(t, []byte("agent"), agentSecret.Data["data"])
+}
+
+func TestDecryptStateBackupScryptParameters(t *testing.T) {
+	t.Parallel()
+
+	data, err := EncryptStateBackup(&StateBackup{Version: stateBackupVersion}, []byte("correct horse"))
+	require.NoError(t, err)
+
+	tests := []struct {
+		name   string
+		modify func(*encryptedStateBackup)
+	}{
+		{
+			name:   "N too large",
+			modify: func(e *encryptedStateBackup) { e.N = 1 << 30 },
+		},
+		{
+			name:   "r too large",
+			modify: func(e *encryptedStateBackup) { e.R = 1 << 20 },
+		},
+		{
+			name:   "p too large",
+			modify: func(e *encryptedStateBackup) { e.P = 64 },
+		},
+		{
+			name:   "zero",
+			modify: func(e *encryptedStateBackup) { e.N = 0 },
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			envelope := encryptedStateBackup{}
+			require.NoError(t, json.Unmarshal(data, &envelope))
+			tt.modify(&envelope)
+			modified, err := json.Marshal(envelope)
+			require.NoError(t, err)
+			_, err = DecryptStateBackup(modified, []byte("correct horse"))
+			require.ErrorContains(t, err, "unsupported scrypt parameters")
+		})
+	}
+}
+
+func TestStateBackupAgeRecipients(t *testing.T) {
+	t.Parallel()
+
+	backup := &StateBackup{
+		Version: stateBackupVersion,
+		Secrets: []corev1.Secret{{ObjectMeta: metav1.ObjectMeta{Name: state.ZarfStateSecretName}, Data: map[string][]byte{"data": []byte("zarf-git-user")}}},
+	}
+	operator, err := age.GenerateX25519Identity()
+	require.NoError(t, err)
+	backupKey, err := age.GenerateX25519Identity()
+	require.NoError(t, err)
+	other, err := age.GenerateX25519Identity()
+	require.NoError(t, err)
+
+	data, err := EncryptStateBackupToRecipients(backup, []age.Recipient{operator.Recipient(), backupKey.Recipient()})
+	require.NoError(t, err)
+	require.True(t, IsAgeStateBackup(data))
+	require.NotContains(t, string(data), "zarf-git-user")
+
+	// Any of the recipients can decrypt the backup
+	for _, identity := range []*age.X25519Identity{operator, backupKey} {
+		decrypted, err := DecryptStateBackupWithIdentities(data, []age.Identity{identity})
+		require.NoError(t, err)
+		require.Equal(t, backup.Secrets, decrypted.Secrets)
+	}
+	_, err = DecryptStateBackupWithIdentities(data, []age.Identity{other})
+	require.ErrorContains(t, err, "unable to decrypt the state backup")
+	_, err = DecryptStateBackup(data, []byte("correct horse"))
+	require.EqualError(t, err, "the state backup is encrypted to age recipients, use --identity to decrypt it")
+
+	_, err = EncryptStateBackupToRecipients(backup, nil)
+	require.Error(t, err)
+	passphraseData, err := EncryptStateBackup(backup, []byte("correct horse"))
+	require.NoError(t, err)
+	require.False(t, IsAgeStateBackup(passphraseData))
+	_, err = DecryptStateBackupWithIdentities(passphraseData, []age.Identity{operator})
+	require.ErrorContains(t, err, "encrypted with a passphrase")
+}
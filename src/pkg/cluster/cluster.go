@@ -10,6 +10,7 @@ import (
 	"errors"
 	"fmt"
 	"slices"
+	"sync"
 	"time"
 
 	"github.com/avast/retry-go/v4"
@@ -40,12 +41,16 @@ const (
 	DefaultTimeout = 30 * time.Second
 	// AgentLabel is used to give instructions to the Zarf agent
 	AgentLabel = "zarf.dev/agent"
+	// RegistryTargetAnnotation selects the registry target that images in a namespace are routed to
+	RegistryTargetAnnotation = "zarf.dev/registry-target"
 	// FieldManagerName is the field manager used during server side apply
 	FieldManagerName = "zarf"
 	// PackageLabel is the label used to identify the owning of package.
 	PackageLabel string = "zarf.dev/package"
 	// NamespaceOverrideLabel is the label used to identify the namespace override.
 	NamespaceOverrideLabel string = "zarf.dev/namespace-override"
+	// GitOrganizationLabel is the label used to identify the git server organization the package's repos were pushed into.
+	GitOrganizationLabel string = "zarf.dev/git-organization"
 )
 
 // Cluster Zarf specific cluster management functions.
@@ -56,6 +61,10 @@ type Cluster struct {
 	RestConfig *rest.Config
 	// Watcher implements kstatus StatusWatcher
 	Watcher watcher.StatusWatcher
+	// resourceVersionsMu guards resourceVersions
+	resourceVersionsMu sync.Mutex
+	// resourceVersions are the last observed resourceVersions of the Zarf state and package secrets, keyed by name
+	resourceVersions map[string]string
 }
 
 // NewWithWait creates a new Cluster instance and waits for the given timeout for the cluster to be ready.
@@ -182,6 +191,8 @@ type InitStateOptions struct {
 	AgentTLS *pki.GeneratedPKI
 	// AgentMutationPolicy controls whether the agent mutates by default (default-mutate) or only on explicit label (default-ignore).
 	AgentMutationPolicy state.MutationPolicy
+	// AgentImagePullSecretMode controls how the agent gives pods access to the Zarf image pull secret.
+	AgentImagePullSecretMode state.ImagePullSecretMode
 	// InternalServices lists the state services that Zarf is deploying in this init run.
 	InternalServices state.ServiceSet
 }
@@ -366,6 +377,10 @@ func (c *Cluster) InitState(ctx context.Context, opts InitStateOptions) (*state.
 		s.AgentMutationPolicy = opts.AgentMutationPolicy
 	}
 
+	if opts.AgentImagePullSecretMode != "" {
+		s.AgentImagePullSecretMode = opts.AgentImagePullSecretMode
+	}
+
 	// Save the state back to K8s
 	if err := c.SaveState(ctx, s); err != nil {
 		return nil, fmt.Errorf("unable to save the Zarf state: %w", err)
@@ -517,6 +532,7 @@ func (c *Cluster) LoadState(ctx context.Context) (*state.State, error) {
 	if err != nil {
 		return nil, fmt.Errorf("%w: %w", stateErr, err)
 	}
+	c.observeResourceVersion(secret.Name, secret.ResourceVersion)
 
 	s := &state.State{}
 	err = json.Unmarshal(secret.Data[state.ZarfStateDataKey], &s)
@@ -554,11 +570,15 @@ func (c *Cluster) SaveState(ctx context.Context, s *state.State) error {
 		WithData(map[string][]byte{
 			state.ZarfStateDataKey: data,
 		})
+	if resourceVersion := c.observedResourceVersion(state.ZarfStateSecretName); resourceVersion != "" {
+		secret.WithResourceVersion(resourceVersion)
+	}
 
-	_, err = c.Clientset.CoreV1().Secrets(*secret.Namespace).Apply(ctx, secret, metav1.ApplyOptions{Force: true, FieldManager: FieldManagerName})
+	updatedSecret, err := c.Clientset.CoreV1().Secrets(*secret.Namespace).Apply(ctx, secret, metav1.ApplyOptions{Force: true, FieldManager: FieldManagerName})
 	if err != nil {
-		return fmt.Errorf("unable to apply the zarf state secret: %w", err)
+		return fmt.Errorf("unable to apply the zarf state secret: %w", conflictError(err, "the Zarf state was changed since it was loaded"))
 	}
+	c.observeResourceVersion(updatedSecret.Name, updatedSecret.ResourceVersion)
 	return nil
 }
 
@@ -0,0 +1,287 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2021-Present The Zarf Authors
+
+package cluster
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/user"
+	"sync"
+	"time"
+
+	coordinationv1 "k8s.io/api/coordination/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+
+	"github.com/zarf-dev/zarf/src/pkg/logger"
+	"github.com/zarf-dev/zarf/src/pkg/state"
+)
+
+const (
+	// DeployLockName is the name of the Lease in the Zarf namespace that is held while Zarf changes the cluster.
+	DeployLockName = "zarf-deploy-lock"
+	// DeployLockOperationAnnotation describes the operation that holds the deploy lock.
+	DeployLockOperationAnnotation = "zarf.dev/lock-operation"
+	// deployLockDuration is how long the deploy lock is held without being renewed before it is considered stale.
+	deployLockDuration = 60 * time.Second
+)
+
+// ErrConflict is returned when a Zarf resource was changed by another operation since it was read.
+var ErrConflict = errors.New("the resource was changed by another operation since it was read")
+
+// ErrDeployLockLost is returned when the deploy lock was taken over by another operation or expired while it was held.
+var ErrDeployLockLost = errors.New("the Zarf deploy lock was lost")
+
+// LockHeldError is returned when the deploy lock is held by another operation.
+type LockHeldError struct {
+	Holder      string
+	Operation   string
+	AcquireTime time.Time
+}
+
+func (e *LockHeldError) Error() string {
+	return fmt.Sprintf("the Zarf deploy lock is held by %s for %q since %s, wait for it to finish or use --override-lock if it is no longer running",
+		e.Holder, e.Operation, e.AcquireTime.Format(time.RFC3339))
+}
+
+// DeployLockOptions are the options for AcquireDeployLock.
+type DeployLockOptions struct {
+	// Operation describes what the lock is held for.
+	Operation string
+	// Override takes the lock even when it is held by another operation that has not gone stale.
+	Override bool
+}
+
+// AcquireDeployLock takes the cluster-wide deploy lock and keeps renewing it until the returned release function is
+// called. The lock is a Lease in the Zarf namespace, a lock that has not been renewed within its duration is stale
+// and is taken over. The returned context is derived from ctx and is cancelled with ErrDeployLockLost when the lock is
+// taken over by another operation or expires, the operation holding the lock should run with it. Release returns
+// ErrDeployLockLost when the lock was lost while it was held.
+func (c *Cluster) AcquireDeployLock(ctx context.Context, opts DeployLockOptions) (context.Context, func(context.Context) error, error) {
+	l := logger.From(ctx)
+	holder := lockHolderIdentity()
+
+	lease, err := c.takeDeployLock(ctx, holder, opts)
+	if err != nil {
+		return nil, nil, err
+	}
+	l.Debug("acquired the deploy lock", "holder", holder, "operation", opts.Operation)
+
+	lockCtx, cancelLock := context.WithCancelCause(ctx)
+	renewCtx, cancel := context.WithCancel(context.WithoutCancel(ctx))
+	var lostErr error
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		ticker := time.NewTicker(deployLockDuration / 3)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-renewCtx.Done():
+				return
+			case <-ticker.C:
+				// A renewal is not interrupted by release, which deletes the lock with the resourceVersion it returns
+				updateCtx, cancelUpdate := context.WithTimeout(context.WithoutCancel(renewCtx), deployLockDuration/3)
+				renewed, err := c.renewDeployLock(updateCtx, lease)
+				cancelUpdate()
+				if errors.Is(err, ErrDeployLockLost) {
+					l.Error("stopping the operation that held the deploy lock", "operation", opts.Operation, "error", err)
+					lostErr = err
+					cancelLock(err)
+					return
+				}
+				if err != nil {
+					l.Warn("unable to renew the deploy lock", "error", err)
+					continue
+				}
+				lease = renewed
+			}
+		}
+	}()
+
+	release := func(ctx context.Context) error {
+		cancel()
+		wg.Wait()
+		defer cancelLock(nil)
+		if lostErr != nil {
+			return lostErr
+		}
+		err := c.Clientset.CoordinationV1().Leases(state.ZarfNamespaceName).Delete(ctx, DeployLockName, metav1.DeleteOptions{
+			Preconditions: &metav1.Preconditions{ResourceVersion: &lease.ResourceVersion},
+		})
+		if kerrors.IsNotFound(err) || kerrors.IsConflict(err) {
+			return fmt.Errorf("%w, it was taken over by another operation", ErrDeployLockLost)
+		}
+		if err != nil {
+			return fmt.Errorf("unable to release the deploy lock: %w", err)
+		}
+		l.Debug("released the deploy lock", "holder", holder)
+		return nil
+	}
+	return lockCtx, release, nil
+}
+
+// renewDeployLock extends the deploy lock held as lease. It returns ErrDeployLockLost when the lock was taken over by
+// another operation or expired before it could be renewed.
+func (c *Cluster) renewDeployLock(ctx context.Context, lease *coordinationv1.Lease) (*coordinationv1.Lease, error) {
+	now := metav1.NewMicroTime(time.Now())
+	renewal := lease.DeepCopy()
+	renewal.Spec.RenewTime = &now
+	renewed, err := c.Clientset.CoordinationV1().Leases(state.ZarfNamespaceName).Update(ctx, renewal, metav1.UpdateOptions{})
+	if kerrors.IsNotFound(err) || kerrors.IsConflict(err) {
+		return nil, fmt.Errorf("%w, it was taken over by another operation: %w", ErrDeployLockLost, err)
+	}
+	if err != nil && deployLockIsStale(lease, now.Time) {
+		return nil, fmt.Errorf("%w, it expired before it could be renewed: %w", ErrDeployLockLost, err)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return renewed, nil
+}
+
+func (c *Cluster) takeDeployLock(ctx context.Context, holder string, opts DeployLockOptions) (*coordinationv1.Lease, error) {
+	leases := c.Clientset.CoordinationV1().Leases(state.ZarfNamespaceName)
+	now := metav1.NewMicroTime(time.Now())
+	duration := int32(deployLockDuration.Seconds())
+	spec := coordinationv1.LeaseSpec{
+		HolderIdentity:       &holder,
+		LeaseDurationSeconds: &duration,
+		AcquireTime:          &now,
+		RenewTime:            &now,
+	}
+
+	existing, err := leases.Get(ctx, DeployLockName, metav1.GetOptions{})
+	if kerrors.IsNotFound(err) {
+		lease := &coordinationv1.Lease{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:        DeployLockName,
+				Namespace:   state.ZarfNamespaceName,
+				Labels:      map[string]string{state.ZarfManagedByLabel: "zarf"},
+				Annotations: map[string]string{DeployLockOperationAnnotation: opts.Operation},
+			},
+			Spec: spec,
+		}
+		created, err := leases.Create(ctx, lease, metav1.CreateOptions{})
+		if kerrors.IsNotFound(err) {
+			// The Zarf namespace does not exist before the first init
+			zarfNamespace := NewZarfManagedApplyNamespace(state.ZarfNamespaceName)
+			_, err = c.Clientset.CoreV1().Namespaces().Apply(ctx, zarfNamespace, metav1.ApplyOptions{Force: true, FieldManager: FieldManagerName})
+			if err != nil {
+				return nil, fmt.Errorf("unable to apply the Zarf namespace: %w", err)
+			}
+			created, err = leases.Create(ctx, lease, metav1.CreateOptions{})
+		}
+		if kerrors.IsAlreadyExists(err) {
+			return nil, c.deployLockHeldError(ctx)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("unable to create the deploy lock: %w", err)
+		}
+		return created, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("unable to get the deploy lock: %w", err)
+	}
+
+	previousHolder := ptr.Deref(existing.Spec.HolderIdentity, "")
+	stale := deployLockIsStale(existing, now.Time)
+	if !stale && !opts.Override {
+		return nil, lockHeldError(existing)
+	}
+	if !stale {
+		logger.From(ctx).Warn("overriding the deploy lock", "holder", previousHolder, "operation", existing.Annotations[DeployLockOperationAnnotation])
+	} else if previousHolder != "" {
+		logger.From(ctx).Info("taking over a stale deploy lock", "holder", previousHolder, "operation", existing.Annotations[DeployLockOperationAnnotation])
+	}
+	if existing.Annotations == nil {
+		existing.Annotations = map[string]string{}
+	}
+	existing.Annotations[DeployLockOperationAnnotation] = opts.Operation
+	existing.Spec = spec
+	// The update carries the resourceVersion that was read, so only one operation can take over the lock
+	updated, err := leases.Update(ctx, existing, metav1.UpdateOptions{})
+	if kerrors.IsConflict(err) {
+		return nil, c.deployLockHeldError(ctx)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("unable to take over the deploy lock: %w", err)
+	}
+	return updated, nil
+}
+
+func (c *Cluster) deployLockHeldError(ctx context.Context) error {
+	lease, err := c.Clientset.CoordinationV1().Leases(state.ZarfNamespaceName).Get(ctx, DeployLockName, metav1.GetOptions{})
+	if err != nil {
+		return fmt.Errorf("the Zarf deploy lock was taken by another operation: %w", err)
+	}
+	return lockHeldError(lease)
+}
+
+func lockHeldError(lease *coordinationv1.Lease) error {
+	e := &LockHeldError{
+		Holder:    ptr.Deref(lease.Spec.HolderIdentity, ""),
+		Operation: lease.Annotations[DeployLockOperationAnnotation],
+	}
+	if lease.Spec.AcquireTime != nil {
+		e.AcquireTime = lease.Spec.AcquireTime.Time
+	}
+	return e
+}
+
+// deployLockIsStale returns true if the lock was released or its holder stopped renewing it.
+func deployLockIsStale(lease *coordinationv1.Lease, now time.Time) bool {
+	if ptr.Deref(lease.Spec.HolderIdentity, "") == "" || lease.Spec.RenewTime == nil || lease.Spec.LeaseDurationSeconds == nil {
+		return true
+	}
+	expiry := lease.Spec.RenewTime.Add(time.Duration(*lease.Spec.LeaseDurationSeconds) * time.Second)
+	return now.After(expiry)
+}
+
+// lockHolderIdentity identifies this process as user@host/pid.
+func lockHolderIdentity() string {
+	username := "unknown"
+	if u, err := user.Current(); err == nil {
+		username = u.Username
+	}
+	hostname, err := os.Hostname()
+	if err != nil {
+		hostname = "unknown"
+	}
+	return fmt.Sprintf("%s@%s/%d", username, hostname, os.Getpid())
+}
+
+// observeResourceVersion records the resourceVersion of a Zarf namespace secret that this client read or wrote.
+func (c *Cluster) observeResourceVersion(name, resourceVersion string) {
+	c.resourceVersionsMu.Lock()
+	defer c.resourceVersionsMu.Unlock()
+	if c.resourceVersions == nil {
+		c.resourceVersions = map[string]string{}
+	}
+	if resourceVersion == "" {
+		delete(c.resourceVersions, name)
+		return
+	}
+	c.resourceVersions[name] = resourceVersion
+}
+
+// observedResourceVersion returns the resourceVersion of a Zarf namespace secret that this client last read or
+// wrote, writes use it as a precondition so that they fail instead of overwriting changes made by another operation.
+func (c *Cluster) observedResourceVersion(name string) string {
+	c.resourceVersionsMu.Lock()
+	defer c.resourceVersionsMu.Unlock()
+	return c.resourceVersions[name]
+}
+
+// conflictError wraps an error returned by a write that failed its resourceVersion precondition.
+func conflictError(err error, resource string) error {
+	if kerrors.IsConflict(err) {
+		return fmt.Errorf("%w: %s, reload it and try again: %w", ErrConflict, resource, err)
+	}
+	return err
+}
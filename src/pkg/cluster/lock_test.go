@@ -0,0 +1,184 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2021-Present The Zarf Authors
+
+package cluster
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	coordinationv1 "k8s.io/api/coordination/v1"
+	corev1 "k8s.io/api/core/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/kubernetes/fake"
+	k8stesting "k8s.io/client-go/testing"
+	"k8s.io/utils/ptr"
+
+	"github.com/zarf-dev/zarf/src/pkg/state"
+)
+
+func TestAcquireDeployLock(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	c := &Cluster{Clientset: fake.NewClientset()}
+
+	lockCtx, release, err := c.AcquireDeployLock(ctx, DeployLockOptions{Operation: "zarf package deploy podinfo"})
+	require.NoError(t, err)
+	lease, err := c.Clientset.CoordinationV1().Leases(state.ZarfNamespaceName).Get(ctx, DeployLockName, metav1.GetOptions{})
+	require.NoError(t, err)
+	require.Equal(t, lockHolderIdentity(), *lease.Spec.HolderIdentity)
+	require.Equal(t, "zarf package deploy podinfo", lease.Annotations[DeployLockOperationAnnotation])
+
+	// A second operation cannot take a held lock
+	_, _, err = c.AcquireDeployLock(ctx, DeployLockOptions{Operation: "zarf tools update-creds git"})
+	var lockErr *LockHeldError
+	require.ErrorAs(t, err, &lockErr)
+	require.Equal(t, lockHolderIdentity(), lockErr.Holder)
+	require.Equal(t, "zarf package deploy podinfo", lockErr.Operation)
+
+	// Unless it overrides it
+	_, overrideRelease, err := c.AcquireDeployLock(ctx, DeployLockOptions{Operation: "zarf package remove podinfo", Override: true})
+	require.NoError(t, err)
+	lease, err = c.Clientset.CoordinationV1().Leases(state.ZarfNamespaceName).Get(ctx, DeployLockName, metav1.GetOptions{})
+	require.NoError(t, err)
+	require.Equal(t, "zarf package remove podinfo", lease.Annotations[DeployLockOperationAnnotation])
+
+	require.NoError(t, overrideRelease(ctx))
+	_, err = c.Clientset.CoordinationV1().Leases(state.ZarfNamespaceName).Get(ctx, DeployLockName, metav1.GetOptions{})
+	require.True(t, kerrors.IsNotFound(err))
+	// The operation whose lock was overridden learns that it lost the lock
+	require.ErrorIs(t, release(ctx), ErrDeployLockLost)
+	require.Error(t, lockCtx.Err())
+}
+
+func TestRenewDeployLock(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	errUnavailable := kerrors.NewServiceUnavailable("unavailable")
+
+	tests := []struct {
+		name        string
+		renewedAgo  time.Duration
+		updateErr   error
+		expectedErr error
+	}{
+		{
+			name:       "renewed",
+			renewedAgo: deployLockDuration / 3,
+		},
+		{
+			name:        "taken over by another operation",
+			renewedAgo:  deployLockDuration / 3,
+			updateErr:   kerrors.NewConflict(schema.GroupResource{Resource: "leases"}, DeployLockName, errors.New("object has been modified")),
+			expectedErr: ErrDeployLockLost,
+		},
+		{
+			name:        "transient error",
+			renewedAgo:  deployLockDuration / 3,
+			updateErr:   errUnavailable,
+			expectedErr: errUnavailable,
+		},
+		{
+			name:        "expired",
+			renewedAgo:  2 * deployLockDuration,
+			updateErr:   errUnavailable,
+			expectedErr: ErrDeployLockLost,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			renewTime := metav1.NewMicroTime(time.Now().Add(-tt.renewedAgo))
+			lease := &coordinationv1.Lease{
+				ObjectMeta: metav1.ObjectMeta{Name: DeployLockName, Namespace: state.ZarfNamespaceName},
+				Spec: coordinationv1.LeaseSpec{
+					HolderIdentity:       ptr.To(lockHolderIdentity()),
+					LeaseDurationSeconds: ptr.To(int32(deployLockDuration.Seconds())),
+					RenewTime:            &renewTime,
+				},
+			}
+			cs := fake.NewClientset(lease)
+			if tt.updateErr != nil {
+				cs.PrependReactor("update", "leases", func(_ k8stesting.Action) (bool, runtime.Object, error) {
+					return true, nil, tt.updateErr
+				})
+			}
+			c := &Cluster{Clientset: cs}
+
+			renewed, err := c.renewDeployLock(ctx, lease)
+			if tt.expectedErr != nil {
+				require.ErrorIs(t, err, tt.expectedErr)
+				return
+			}
+			require.NoError(t, err)
+			require.True(t, renewed.Spec.RenewTime.After(renewTime.Time))
+		})
+	}
+}
+
+func TestAcquireStaleDeployLock(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+
+	renewTime := metav1.NewMicroTime(time.Now().Add(-2 * deployLockDuration))
+	lease := &coordinationv1.Lease{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        DeployLockName,
+			Namespace:   state.ZarfNamespaceName,
+			Annotations: map[string]string{DeployLockOperationAnnotation: "zarf init"},
+		},
+		Spec: coordinationv1.LeaseSpec{
+			HolderIdentity:       ptr.To("ci@runner/1"),
+			LeaseDurationSeconds: ptr.To(int32(deployLockDuration.Seconds())),
+			AcquireTime:          &renewTime,
+			RenewTime:            &renewTime,
+		},
+	}
+	c := &Cluster{Clientset: fake.NewClientset(lease)}
+
+	_, release, err := c.AcquireDeployLock(ctx, DeployLockOptions{Operation: "zarf package deploy podinfo"})
+	require.NoError(t, err)
+	lease, err = c.Clientset.CoordinationV1().Leases(state.ZarfNamespaceName).Get(ctx, DeployLockName, metav1.GetOptions{})
+	require.NoError(t, err)
+	require.Equal(t, lockHolderIdentity(), *lease.Spec.HolderIdentity)
+	require.NoError(t, release(ctx))
+}
+
+func TestSaveStateResourceVersionPrecondition(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:            state.ZarfStateSecretName,
+			Namespace:       state.ZarfNamespaceName,
+			ResourceVersion: "5",
+		},
+		Data: map[string][]byte{state.ZarfStateDataKey: []byte(`{"distro":"k3s"}`)},
+	}
+	cs := fake.NewClientset(secret)
+	appliedResourceVersion := ""
+	cs.PrependReactor("patch", "secrets", func(action k8stesting.Action) (bool, runtime.Object, error) {
+		patch, ok := action.(k8stesting.PatchAction)
+		require.True(t, ok)
+		applied := &corev1.Secret{}
+		require.NoError(t, json.Unmarshal(patch.GetPatch(), applied))
+		appliedResourceVersion = applied.ResourceVersion
+		// Another operation changed the state after it was loaded
+		return true, nil, kerrors.NewConflict(schema.GroupResource{Resource: "secrets"}, state.ZarfStateSecretName, errors.New("object has been modified"))
+	})
+	c := &Cluster{Clientset: cs}
+
+	s, err := c.LoadState(ctx)
+	require.NoError(t, err)
+	err = c.SaveState(ctx, s)
+	require.ErrorIs(t, err, ErrConflict)
+	require.Equal(t, "5", appliedResourceVersion)
+}
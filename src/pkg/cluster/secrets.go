@@ -10,6 +10,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"net"
+	"slices"
 
 	corev1 "k8s.io/api/core/v1"
 	kerrors "k8s.io/apimachinery/pkg/api/errors"
@@ -117,6 +118,39 @@ func (c *Cluster) GenerateGitPullCreds(namespace, name string, gitServerInfo sta
 		})
 }
 
+// RegistryTargetForNamespace returns the name of the registry target selected by the namespace's registry target
+// annotation or, without one, by the state's namespace mapping. An empty name selects the default Zarf registry.
+func RegistryTargetForNamespace(s *state.State, namespace *corev1.Namespace) string {
+	if name := namespace.Annotations[RegistryTargetAnnotation]; name != "" {
+		return name
+	}
+	return s.NamespaceRegistryTargets[namespace.Name]
+}
+
+// RegistryInfoForNamespace returns the registry that images in the namespace are routed to.
+func RegistryInfoForNamespace(s *state.State, namespace *corev1.Namespace) (state.RegistryInfo, error) {
+	ri, err := s.RegistryInfoForTarget(RegistryTargetForNamespace(s, namespace))
+	if err != nil {
+		return state.RegistryInfo{}, fmt.Errorf("unable to route images for namespace %s: %w", namespace.Name, err)
+	}
+	return ri, nil
+}
+
+// GetRegistryInfoForNamespace looks up the namespace and returns the registry that images in it are routed to.
+// Cluster scoped requests and namespaces that do not exist yet only consider the state's namespace mapping.
+func (c *Cluster) GetRegistryInfoForNamespace(ctx context.Context, s *state.State, namespace string) (state.RegistryInfo, error) {
+	if namespace == "" {
+		return s.RegistryInfo, nil
+	}
+	ns, err := c.Clientset.CoreV1().Namespaces().Get(ctx, namespace, metav1.GetOptions{})
+	if kerrors.IsNotFound(err) {
+		ns = &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: namespace}}
+	} else if err != nil {
+		return state.RegistryInfo{}, err
+	}
+	return RegistryInfoForNamespace(s, ns)
+}
+
 // UpdateZarfManagedImageSecrets updates all Zarf-managed image secrets in all namespaces based on state
 func (c *Cluster) UpdateZarfManagedImageSecrets(ctx context.Context, s *state.State) error {
 	l := logger.From(ctx)
@@ -138,7 +172,12 @@ func (c *Cluster) UpdateZarfManagedImageSecrets(ctx context.Context, s *state.St
 		if currentRegistrySecret.Labels[state.ZarfManagedByLabel] != "zarf" && (namespace.Labels[AgentLabel] == "skip" || namespace.Labels[AgentLabel] == "ignore") {
 			continue
 		}
-		newRegistrySecret, err := c.GenerateRegistryPullCreds(ctx, namespace.Name, config.ZarfImagePullSecretName, s.RegistryInfo)
+		registryInfo, err := RegistryInfoForNamespace(s, &namespace)
+		if err != nil {
+			l.Warn("skipping the registry secret for a namespace with an unknown registry target", "name", namespace.Name, "error", err)
+			continue
+		}
+		newRegistrySecret, err := c.GenerateRegistryPullCreds(ctx, namespace.Name, config.ZarfImagePullSecretName, registryInfo)
 		if err != nil {
 			return err
 		}
@@ -147,11 +186,40 @@ func (c *Cluster) UpdateZarfManagedImageSecrets(ctx context.Context, s *state.St
 		if err != nil {
 			return err
 		}
+		if s.AgentImagePullSecretMode == state.ImagePullSecretModeServiceAccount {
+			if err := c.AddZarfImagePullSecretToServiceAccounts(ctx, namespace.Name); err != nil {
+				return err
+			}
+		}
 	}
 
 	return nil
 }
 
+// AddZarfImagePullSecretToServiceAccounts adds the Zarf image pull secret to every ServiceAccount in the namespace that does not reference it yet.
+func (c *Cluster) AddZarfImagePullSecretToServiceAccounts(ctx context.Context, namespace string) error {
+	l := logger.From(ctx)
+
+	serviceAccounts, err := c.Clientset.CoreV1().ServiceAccounts(namespace).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return err
+	}
+	for _, sa := range serviceAccounts.Items {
+		hasSecret := slices.ContainsFunc(sa.ImagePullSecrets, func(ref corev1.LocalObjectReference) bool {
+			return ref.Name == config.ZarfImagePullSecretName
+		})
+		if hasSecret {
+			continue
+		}
+		sa.ImagePullSecrets = append(sa.ImagePullSecrets, corev1.LocalObjectReference{Name: config.ZarfImagePullSecretName})
+		l.Debug("adding the Zarf image pull secret to the ServiceAccount", "name", sa.Name, "namespace", namespace)
+		if _, err := c.Clientset.CoreV1().ServiceAccounts(namespace).Update(ctx, &sa, metav1.UpdateOptions{}); err != nil {
+			return fmt.Errorf("unable to add the image pull secret to the ServiceAccount %s/%s: %w", namespace, sa.Name, err)
+		}
+	}
+	return nil
+}
+
 // UpdateZarfManagedGitSecrets updates all Zarf-managed git secrets in all namespaces based on state
 func (c *Cluster) UpdateZarfManagedGitSecrets(ctx context.Context, s *state.State) error {
 	l := logger.From(ctx)
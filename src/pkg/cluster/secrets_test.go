@@ -207,3 +207,106 @@ func TestUpdateZarfManagedSecrets(t *testing.T) {
 		})
 	}
 }
+
+func TestUpdateZarfManagedImageSecretsRegistryTargets(t *testing.T) {
+	ctx := testutil.TestContext(t)
+
+	c := &Cluster{Clientset: fake.NewClientset()}
+	s := &state.State{
+		RegistryInfo: state.RegistryInfo{Address: "127.0.0.1:31999", PullUsername: "pull-user", PullPassword: "pull-password"},
+		RegistryTargets: []state.RegistryTarget{
+			{Name: "tenant-a", Address: "registry.tenant-a.example.com", Prefix: "zarf", PushUsername: "push-a", PushPassword: "password-a"},
+			{Name: "tenant-b", Address: "registry.tenant-b.example.com", PullUsername: "pull-b", PullPassword: "password-b"},
+		},
+		NamespaceRegistryTargets: map[string]string{"mapped": "tenant-b"},
+	}
+	namespaces := []*corev1.Namespace{
+		{ObjectMeta: metav1.ObjectMeta{Name: "default-registry"}},
+		{ObjectMeta: metav1.ObjectMeta{Name: "mapped"}},
+		{ObjectMeta: metav1.ObjectMeta{Name: "annotated", Annotations: map[string]string{RegistryTargetAnnotation: "tenant-a"}}},
+		{ObjectMeta: metav1.ObjectMeta{Name: "unknown", Annotations: map[string]string{RegistryTargetAnnotation: "missing"}}},
+	}
+	for _, ns := range namespaces {
+		_, err := c.Clientset.CoreV1().Namespaces().Create(ctx, ns, metav1.CreateOptions{})
+		require.NoError(t, err)
+		secret := &corev1.Secret{ObjectMeta: metav1.ObjectMeta{Name: config.ZarfImagePullSecretName, Namespace: ns.Name}}
+		_, err = c.Clientset.CoreV1().Secrets(ns.Name).Create(ctx, secret, metav1.CreateOptions{})
+		require.NoError(t, err)
+	}
+
+	err := c.UpdateZarfManagedImageSecrets(ctx, s)
+	require.NoError(t, err)
+
+	expected := map[string]string{
+		"default-registry": `{"auths":{"127.0.0.1:31999":{"auth":"cHVsbC11c2VyOnB1bGwtcGFzc3dvcmQ="}}}`,
+		"mapped":           `{"auths":{"registry.tenant-b.example.com":{"auth":"cHVsbC1iOnBhc3N3b3JkLWI="}}}`,
+		"annotated":        `{"auths":{"registry.tenant-a.example.com/zarf":{"auth":"cHVzaC1hOnBhc3N3b3JkLWE="}}}`,
+		// Namespaces selecting a target that does not exist are left as is.
+		"unknown": "",
+	}
+	for namespace, dockerConfig := range expected {
+		secret, err := c.Clientset.CoreV1().Secrets(namespace).Get(ctx, config.ZarfImagePullSecretName, metav1.GetOptions{})
+		require.NoError(t, err)
+		require.Equal(t, dockerConfig, string(secret.Data[".dockerconfigjson"]), namespace)
+	}
+
+	ri, err := c.GetRegistryInfoForNamespace(ctx, s, "annotated")
+	require.NoError(t, err)
+	require.Equal(t, "registry.tenant-a.example.com/zarf", ri.Address)
+	ri, err = c.GetRegistryInfoForNamespace(ctx, s, "not-created-yet")
+	require.NoError(t, err)
+	require.Equal(t, s.RegistryInfo, ri)
+	_, err = c.GetRegistryInfoForNamespace(ctx, s, "unknown")
+	require.EqualError(t, err, `unable to route images for namespace unknown: registry target "missing" does not exist`)
+}
+
+func TestUpdateZarfManagedImageSecretsServiceAccounts(t *testing.T) {
+	ctx := testutil.TestContext(t)
+
+	c := &Cluster{Clientset: fake.NewClientset()}
+	s := &state.State{
+		RegistryInfo:             state.RegistryInfo{Address: "127.0.0.1:31999", PullUsername: "pull-user", PullPassword: "pull-password"},
+		AgentImagePullSecretMode: state.ImagePullSecretModeServiceAccount,
+	}
+	for _, name := range []string{"managed", "unmanaged"} {
+		_, err := c.Clientset.CoreV1().Namespaces().Create(ctx, &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: name}}, metav1.CreateOptions{})
+		require.NoError(t, err)
+		serviceAccounts := []*corev1.ServiceAccount{
+			{ObjectMeta: metav1.ObjectMeta{Name: "default", Namespace: name}},
+			{
+				ObjectMeta:       metav1.ObjectMeta{Name: "app", Namespace: name},
+				ImagePullSecrets: []corev1.LocalObjectReference{{Name: "other-registry"}},
+			},
+			{
+				ObjectMeta:       metav1.ObjectMeta{Name: "patched", Namespace: name},
+				ImagePullSecrets: []corev1.LocalObjectReference{{Name: config.ZarfImagePullSecretName}},
+			},
+		}
+		for _, sa := range serviceAccounts {
+			_, err := c.Clientset.CoreV1().ServiceAccounts(name).Create(ctx, sa, metav1.CreateOptions{})
+			require.NoError(t, err)
+		}
+	}
+	secret := &corev1.Secret{ObjectMeta: metav1.ObjectMeta{Name: config.ZarfImagePullSecretName, Namespace: "managed"}}
+	_, err := c.Clientset.CoreV1().Secrets("managed").Create(ctx, secret, metav1.CreateOptions{})
+	require.NoError(t, err)
+
+	err = c.UpdateZarfManagedImageSecrets(ctx, s)
+	require.NoError(t, err)
+
+	expected := map[string][]corev1.LocalObjectReference{
+		"default": {{Name: config.ZarfImagePullSecretName}},
+		"app":     {{Name: "other-registry"}, {Name: config.ZarfImagePullSecretName}},
+		"patched": {{Name: config.ZarfImagePullSecretName}},
+	}
+	for name, pullSecrets := range expected {
+		sa, err := c.Clientset.CoreV1().ServiceAccounts("managed").Get(ctx, name, metav1.GetOptions{})
+		require.NoError(t, err)
+		require.Equal(t, pullSecrets, sa.ImagePullSecrets, name)
+	}
+
+	// Namespaces without the Zarf image pull secret are not touched.
+	sa, err := c.Clientset.CoreV1().ServiceAccounts("unmanaged").Get(ctx, "default", metav1.GetOptions{})
+	require.NoError(t, err)
+	require.Empty(t, sa.ImagePullSecrets)
+}
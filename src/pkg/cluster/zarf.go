@@ -9,6 +9,7 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"net/url"
 	"strings"
 	"time"
 
@@ -19,7 +20,8 @@ import (
 
 	"github.com/zarf-dev/zarf/src/api/v1alpha1"
 	"github.com/zarf-dev/zarf/src/config"
-	"github.com/zarf-dev/zarf/src/internal/gitea"
+	"github.com/zarf-dev/zarf/src/internal/dns"
+	"github.com/zarf-dev/zarf/src/internal/gitprovider"
 	"github.com/zarf-dev/zarf/src/pkg/logger"
 	"github.com/zarf-dev/zarf/src/pkg/state"
 )
@@ -48,6 +50,7 @@ func (c *Cluster) GetDeployedZarfPackages(ctx context.Context) ([]state.Deployed
 			errs = append(errs, fmt.Errorf("unable to unmarshal the secret %s/%s", secret.Namespace, secret.Name))
 			continue
 		}
+		c.observeResourceVersion(secret.Name, secret.ResourceVersion)
 		deployedPackages = append(deployedPackages, deployedPackage)
 	}
 
@@ -74,6 +77,7 @@ func (c *Cluster) GetDeployedPackage(ctx context.Context, packageName string, op
 	if err != nil {
 		return nil, err
 	}
+	c.observeResourceVersion(secret.Name, secret.ResourceVersion)
 
 	err = json.Unmarshal(secret.Data["data"], deployedPackage)
 	if err != nil {
@@ -95,19 +99,28 @@ func (c *Cluster) UpdateDeployedPackage(ctx context.Context, depPkg state.Deploy
 		}).WithData(map[string][]byte{
 		"data": packageSecretData,
 	}).WithType(corev1.SecretTypeOpaque)
-	_, err = c.Clientset.CoreV1().Secrets(*packageSecret.Namespace).Apply(ctx, packageSecret, metav1.ApplyOptions{Force: true, FieldManager: FieldManagerName})
+	if resourceVersion := c.observedResourceVersion(*packageSecret.Name); resourceVersion != "" {
+		packageSecret.WithResourceVersion(resourceVersion)
+	}
+	updatedSecret, err := c.Clientset.CoreV1().Secrets(*packageSecret.Namespace).Apply(ctx, packageSecret, metav1.ApplyOptions{Force: true, FieldManager: FieldManagerName})
 	if err != nil {
-		return fmt.Errorf("unable to apply the deployed package secret: %w", err)
+		return fmt.Errorf("unable to apply the deployed package secret: %w", conflictError(err, "the deployed package was changed since it was loaded"))
 	}
+	c.observeResourceVersion(updatedSecret.Name, updatedSecret.ResourceVersion)
 	return nil
 }
 
 // DeleteDeployedPackage removes the metadata for the deployed package.
 func (c *Cluster) DeleteDeployedPackage(ctx context.Context, depPkg state.DeployedPackage) error {
-	err := c.Clientset.CoreV1().Secrets(state.ZarfNamespaceName).Delete(ctx, depPkg.GetSecretName(), metav1.DeleteOptions{})
+	deleteOpts := metav1.DeleteOptions{}
+	if resourceVersion := c.observedResourceVersion(depPkg.GetSecretName()); resourceVersion != "" {
+		deleteOpts.Preconditions = &metav1.Preconditions{ResourceVersion: &resourceVersion}
+	}
+	err := c.Clientset.CoreV1().Secrets(state.ZarfNamespaceName).Delete(ctx, depPkg.GetSecretName(), deleteOpts)
 	if err != nil {
-		return err
+		return conflictError(err, "the deployed package was changed since it was loaded")
 	}
+	c.observeResourceVersion(depPkg.GetSecretName(), "")
 	return nil
 }
 
@@ -193,10 +206,14 @@ func (c *Cluster) RecordPackageDeployment(ctx context.Context, pkg v1alpha1.Zarf
 		WithData(map[string][]byte{
 			"data": packageData,
 		})
+	if resourceVersion := c.observedResourceVersion(*deployedPackageSecret.Name); resourceVersion != "" {
+		deployedPackageSecret.WithResourceVersion(resourceVersion)
+	}
 	updatedSecret, err := c.Clientset.CoreV1().Secrets(*deployedPackageSecret.Namespace).Apply(ctx, deployedPackageSecret, metav1.ApplyOptions{Force: true, FieldManager: FieldManagerName})
 	if err != nil {
-		return nil, fmt.Errorf("failed to record package deployment in secret '%s': %w", *deployedPackageSecret.Name, err)
+		return nil, fmt.Errorf("failed to record package deployment in secret '%s': %w", *deployedPackageSecret.Name, conflictError(err, "the deployed package was changed since it was loaded"))
 	}
+	c.observeResourceVersion(updatedSecret.Name, updatedSecret.ResourceVersion)
 	if err := json.Unmarshal(updatedSecret.Data["data"], &deployedPackage); err != nil {
 		return nil, err
 	}
@@ -220,43 +237,47 @@ func (c *Cluster) GetInstalledChartsForComponent(ctx context.Context, packageNam
 	return installedCharts, nil
 }
 
-// UpdateInternalArtifactServerToken updates the the artifact server token on the internal gitea server and returns it
-func (c *Cluster) UpdateInternalArtifactServerToken(ctx context.Context, oldGitServer state.GitServerInfo) (string, error) {
-	tunnel, err := c.NewTunnel(state.ZarfNamespaceName, SvcResource, ZarfGitServerName, "", 0, ZarfGitServerPort)
-	if err != nil {
-		return "", err
-	}
-	_, err = tunnel.Connect(ctx)
-	if err != nil {
-		return "", err
-	}
-	defer tunnel.Close()
-	// tunnel is create with the default listenAddress - there will only be one endpoint until otherwise supported
-	tunnelURLs := tunnel.HTTPEndpoints()
-	if len(tunnelURLs) == 0 {
-		return "", errors.New("no tunnel endpoints found")
-	}
-	giteaClient, err := gitea.NewClient(tunnelURLs[0], oldGitServer.PushUsername, oldGitServer.PushPassword)
-	if err != nil {
-		return "", err
+// WithGitProvider calls fn with the provider of the git server and the endpoint its API is reachable at,
+// connecting through a tunnel when the git server is a service in the cluster.
+func (c *Cluster) WithGitProvider(ctx context.Context, gitServer state.GitServerInfo, fn func(endpoint string, provider gitprovider.Provider) error) error {
+	if !dns.IsServiceURL(gitServer.Address) {
+		provider, err := gitprovider.New(gitServer, gitServer.Address)
+		if err != nil {
+			return err
+		}
+		return fn(gitServer.Address, provider)
 	}
-	var newToken string
-	err = tunnel.Wrap(func() error {
-		newToken, err = giteaClient.CreatePackageRegistryToken(ctx)
+	return c.withServiceTunnel(ctx, gitServer.Address, func(endpoint string) error {
+		provider, err := gitprovider.New(gitServer, endpoint)
 		if err != nil {
 			return err
 		}
-		return nil
+		return fn(endpoint, provider)
 	})
+}
+
+// WithArtifactServer calls fn with the address of the artifact server, tunneling to it first when it runs in the cluster.
+func (c *Cluster) WithArtifactServer(ctx context.Context, artifactServer state.ArtifactServerInfo, fn func(address string) error) error {
+	if !dns.IsServiceURL(artifactServer.Address) {
+		return fn(artifactServer.Address)
+	}
+	u, err := url.Parse(artifactServer.Address)
 	if err != nil {
-		return "", err
+		return err
 	}
-	return newToken, nil
+	return c.withServiceTunnel(ctx, artifactServer.Address, func(endpoint string) error {
+		// The package registry lives under a path of the service which the tunnel endpoint has to keep
+		return fn(endpoint + u.Path)
+	})
 }
 
-// UpdateInternalGitServerSecret updates the internal gitea server secrets with the new git server info
-func (c *Cluster) UpdateInternalGitServerSecret(ctx context.Context, oldGitServer state.GitServerInfo, newGitServer state.GitServerInfo) error {
-	tunnel, err := c.NewTunnel(state.ZarfNamespaceName, SvcResource, ZarfGitServerName, "", 0, ZarfGitServerPort)
+// withServiceTunnel opens a tunnel to the in-cluster service of serviceURL and calls fn with its endpoint.
+func (c *Cluster) withServiceTunnel(ctx context.Context, serviceURL string, fn func(endpoint string) error) error {
+	namespace, name, port, err := dns.ParseServiceURL(serviceURL)
+	if err != nil {
+		return err
+	}
+	tunnel, err := c.NewTunnel(namespace, SvcResource, name, "", 0, port)
 	if err != nil {
 		return err
 	}
@@ -270,25 +291,50 @@ func (c *Cluster) UpdateInternalGitServerSecret(ctx context.Context, oldGitServe
 	if len(tunnelURLs) == 0 {
 		return errors.New("no tunnel endpoints found")
 	}
-	giteaClient, err := gitea.NewClient(tunnelURLs[0], oldGitServer.PushUsername, oldGitServer.PushPassword)
+	return tunnel.Wrap(func() error {
+		return fn(tunnelURLs[0])
+	})
+}
+
+// UpdateInternalArtifactServerToken creates a new package registry token through the provider of the git server and returns it
+func (c *Cluster) UpdateInternalArtifactServerToken(ctx context.Context, oldGitServer state.GitServerInfo) (string, error) {
+	var newToken string
+	err := c.WithGitProvider(ctx, managedGitServer(oldGitServer), func(_ string, provider gitprovider.Provider) error {
+		var err error
+		newToken, err = provider.CreatePackageRegistryToken(ctx)
+		if err != nil {
+			return err
+		}
+		return nil
+	})
 	if err != nil {
-		return err
+		return "", err
 	}
-	err = tunnel.Wrap(func() error {
-		err := giteaClient.UpdateGitUser(ctx, newGitServer.PullUsername, newGitServer.PullPassword)
+	return newToken, nil
+}
+
+// UpdateInternalGitServerSecret updates the credentials of the git server users through the provider of the git server
+func (c *Cluster) UpdateInternalGitServerSecret(ctx context.Context, oldGitServer state.GitServerInfo, newGitServer state.GitServerInfo) error {
+	return c.WithGitProvider(ctx, managedGitServer(oldGitServer), func(_ string, provider gitprovider.Provider) error {
+		err := provider.UpdateGitUser(ctx, newGitServer.PullUsername, newGitServer.PullPassword)
 		if err != nil {
 			return err
 		}
-		err = giteaClient.UpdateGitUser(ctx, newGitServer.PushUsername, newGitServer.PushPassword)
+		err = provider.UpdateGitUser(ctx, newGitServer.PushUsername, newGitServer.PushPassword)
 		if err != nil {
 			return err
 		}
 		return nil
 	})
-	if err != nil {
-		return err
+}
+
+// managedGitServer defaults the git server info to the internal Gitea server. Clusters initialized before the
+// git server was gated on services may have the internal server deployed without its address in the state.
+func managedGitServer(gitServer state.GitServerInfo) state.GitServerInfo {
+	if gitServer.Address == "" {
+		gitServer.Address = state.ZarfInClusterGitServiceURL
 	}
-	return nil
+	return gitServer
 }
 
 // InternalGitServerExists checks if the Zarf internal git server exists in the cluster.
@@ -7,6 +7,10 @@ package cluster
 import (
 	"context"
 	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
 	"testing"
 
 	"github.com/stretchr/testify/require"
@@ -162,3 +166,69 @@ func TestInternalGitServerExists(t *testing.T) {
 		})
 	}
 }
+
+func TestUpdateGitServerThroughGitLab(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+
+	var mu sync.Mutex
+	passwords := map[string]string{}
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+		if r.Header.Get("PRIVATE-TOKEN") != "glpat-old" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		switch {
+		case r.Method == http.MethodGet && r.URL.Path == "/api/v4/users":
+			ids := map[string]int{"push-user": 1, "pull-user": 2}
+			//nolint:errcheck
+			json.NewEncoder(w).Encode([]map[string]int{{"id": ids[r.URL.Query().Get("username")]}})
+		case r.Method == http.MethodPut && strings.HasPrefix(r.URL.Path, "/api/v4/users/"):
+			var req struct {
+				Password string `json:"password"`
+			}
+			if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+				w.WriteHeader(http.StatusBadRequest)
+				return
+			}
+			passwords[strings.TrimPrefix(r.URL.Path, "/api/v4/users/")] = req.Password
+		case r.Method == http.MethodGet && r.URL.Path == "/api/v4/personal_access_tokens":
+			//nolint:errcheck
+			w.Write([]byte("[]"))
+		case r.Method == http.MethodPost && r.URL.Path == "/api/v4/users/1/personal_access_tokens":
+			w.WriteHeader(http.StatusCreated)
+			//nolint:errcheck
+			w.Write([]byte(`{"token": "glpat-artifact"}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	t.Cleanup(srv.Close)
+
+	c := &Cluster{Clientset: fake.NewClientset()}
+	oldGitServer := state.GitServerInfo{
+		Address:      srv.URL,
+		Provider:     state.GitProviderGitLab,
+		PushUsername: "push-user",
+		PushPassword: "glpat-old",
+		PullUsername: "pull-user",
+		PullPassword: "old-password",
+	}
+	newGitServer := oldGitServer
+	newGitServer.PushPassword = "glpat-new"
+	newGitServer.PullPassword = "new-password"
+	require.True(t, newGitServer.ManagesUsers())
+
+	// Only the pull user's password changes as the push user's token is rotated in GitLab
+	err := c.UpdateInternalGitServerSecret(ctx, oldGitServer, newGitServer)
+	require.NoError(t, err)
+	require.Equal(t, map[string]string{"2": "new-password"}, passwords)
+
+	artifactServer := state.ArtifactServerInfo{Address: srv.URL + "/api/v4/projects/1/packages"}
+	require.True(t, oldGitServer.HostsArtifactServer(artifactServer))
+	token, err := c.UpdateInternalArtifactServerToken(ctx, oldGitServer)
+	require.NoError(t, err)
+	require.Equal(t, "glpat-artifact", token)
+}
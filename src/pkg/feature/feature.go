@@ -207,6 +207,7 @@ const (
 	RegistryProxy          Name = "registry-proxy"
 	Values                 Name = "values"
 	DockerDaemonDirectPull Name = "docker-daemon-direct-pull"
+	StreamPackageImages    Name = "stream-package-images"
 )
 
 func init() {
@@ -252,6 +253,14 @@ func init() {
 			Since:   "v0.80.0",
 			Stage:   GA,
 		},
+		{
+			Name: StreamPackageImages,
+			Description: "Pushes images straight from uncompressed (.tar) package tarballs during deploy and " +
+				"mirror-resources instead of extracting every image blob to a temporary directory first.",
+			Enabled: false,
+			Since:   "v0.81.0",
+			Stage:   Alpha,
+		},
 	}
 
 	err := setDefault(features)
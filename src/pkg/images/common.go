@@ -40,6 +40,17 @@ type RegistryOverride struct {
 	Override string
 }
 
+// RegistryMirror describes an ordered list of mirrors to try for a specific registry before falling back to it.
+type RegistryMirror struct {
+	// Source describes the upstream registry.
+	// May be of the form:
+	// - docker.io/library
+	// - docker.io
+	Source string
+	// Mirrors replace the source registry as a string prefix and are tried in order.
+	Mirrors []string
+}
+
 const (
 	// DockerMediaTypeManifest is the Legacy Docker manifest format, replaced by OCI manifest
 	DockerMediaTypeManifest = "application/vnd.docker.distribution.manifest.v2+json"
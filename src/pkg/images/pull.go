@@ -47,11 +47,16 @@ import (
 	"oras.land/oras-go/v2/registry/remote/auth"
 )
 
+// defaultMetadataConcurrency is the number of images whose metadata is fetched at once when no OCI concurrency is set.
+const defaultMetadataConcurrency = 10
+
 // PullOptions is the configuration for pulling images.
 type PullOptions struct {
-	OCIConcurrency        int
-	Arch                  string
-	RegistryOverrides     []RegistryOverride
+	OCIConcurrency    int
+	Arch              string
+	RegistryOverrides []RegistryOverride
+	// RegistryMirrors are tried in order for matching images before falling back to the upstream registry.
+	RegistryMirrors       []RegistryMirror
 	CacheDirectory        string
 	InsecureSkipTLSVerify bool
 	ResponseHeaderTimeout time.Duration
@@ -76,6 +81,8 @@ type imagePullInfo struct {
 type imageWithOverride struct {
 	overridden transform.Image
 	original   transform.Image
+	// mirrors are the registry mirror references to try, in order, before the overridden reference.
+	mirrors []transform.Image
 }
 
 // Pull pulls all images to the destination directory.
@@ -116,9 +123,14 @@ func Pull(ctx context.Context, imageList []transform.Image, destinationDirectory
 				break
 			}
 		}
+		mirrors, err := mirrorsForImage(img, opts.RegistryMirrors)
+		if err != nil {
+			return nil, err
+		}
 		imagesWithOverride = append(imagesWithOverride, imageWithOverride{
 			original:   img,
 			overridden: overriddenImage,
+			mirrors:    mirrors,
 		})
 	}
 
@@ -128,11 +140,16 @@ func Pull(ctx context.Context, imageList []transform.Image, destinationDirectory
 	uniqueHosts := map[string]struct{}{}
 	for _, v := range imagesWithOverride {
 		uniqueHosts[v.overridden.Host] = struct{}{}
+		for _, m := range v.mirrors {
+			uniqueHosts[m.Host] = struct{}{}
+		}
 	}
 	client, err := NewAuthClientFromDocker(ctx, opts.InsecureSkipTLSVerify, opts.ResponseHeaderTimeout, uniqueHosts)
 	if err != nil {
 		return nil, err
 	}
+	// Requests are scheduled with the other fetches of the caller, see WithScheduler
+	withRateLimits(ctx, client, SchedulerFrom(ctx))
 
 	platform := &ocispec.Platform{
 		Architecture: opts.Arch,
@@ -148,9 +165,20 @@ func Pull(ctx context.Context, imageList []transform.Image, destinationDirectory
 	// - Get all the manifests from images that will be pulled so they can be returned to the function
 	// - Mark any images that don't resolve so we can attempt to pull them from the daemon
 	eg, ectx := errgroup.WithContext(ctx)
-	eg.SetLimit(10)
+	eg.SetLimit(metadataConcurrency(opts.OCIConcurrency))
 	for _, image := range imagesWithOverride {
 		eg.Go(func() error {
+			var mirror string
+			if len(image.mirrors) > 0 {
+				mirrored, ok := resolveFromMirrors(ectx, client, image, opts)
+				if ok {
+					mirror = mirrored.Reference
+					image.overridden = mirrored
+				} else {
+					l.Warn("image not found on any registry mirror, falling back to upstream", "image", image.overridden.Reference)
+				}
+			}
+
 			repo := &orasRemote.Repository{}
 
 			ref, err := registry.ParseReference(image.overridden.Reference)
@@ -160,20 +188,14 @@ func Pull(ctx context.Context, imageList []transform.Image, destinationDirectory
 			repo.Reference = ref
 			repo.Client = client
 
-			// Only verify per-host when the flag is set, or when the host is
-			// localhost: a local dev/test registry is commonly plain HTTP even
-			// when --plain-http wasn't passed for that specific purpose.
-			var plainHTTP bool
-			if opts.PlainHTTP || dns.IsLocalOrPrivate(repo.Reference.Host()) {
-				plainHTTP, err = ocischeme.From(ctx).UsePlainHTTP(ctx, repo.Reference.Host(), ocischeme.ProbeOptions{InsecureSkipTLSVerify: opts.InsecureSkipTLSVerify})
-				if err != nil {
-					// It could be an image on the daemon instead of a registry.
-					l.Warn("unable to reach registry, attempting pull from docker daemon as fallback", "image", image.overridden.Reference, "err", err)
-					imageListLock.Lock()
-					defer imageListLock.Unlock()
-					dockerFallBackImages = append(dockerFallBackImages, image)
-					return nil
-				}
+			plainHTTP, err := negotiatePlainHTTP(ctx, repo.Reference.Host(), opts)
+			if err != nil {
+				// It could be an image on the daemon instead of a registry.
+				l.Warn("unable to reach registry, attempting pull from docker daemon as fallback", "image", image.overridden.Reference, "err", err)
+				imageListLock.Lock()
+				defer imageListLock.Unlock()
+				dockerFallBackImages = append(dockerFallBackImages, image)
+				return nil
 			}
 			repo.PlainHTTP = plainHTTP
 
@@ -228,7 +250,7 @@ func Pull(ctx context.Context, imageList []transform.Image, destinationDirectory
 				platforms:           platforms,
 				plainHTTP:           plainHTTP,
 			})
-			pulledImages = append(pulledImages, PulledImage{Image: image.original})
+			pulledImages = append(pulledImages, PulledImage{Image: image.original, Mirror: mirror})
 			l.Debug("pulled image", "name", image.overridden.Reference)
 			return nil
 		})
@@ -265,6 +287,66 @@ func Pull(ctx context.Context, imageList []transform.Image, destinationDirectory
 	return pulledImages, nil
 }
 
+// negotiatePlainHTTP reports whether host should be reached over plain HTTP.
+// Only verify per-host when the flag is set, or when the host is localhost:
+// a local dev/test registry is commonly plain HTTP even when --plain-http
+// wasn't passed for that specific purpose.
+func negotiatePlainHTTP(ctx context.Context, host string, opts PullOptions) (bool, error) {
+	if !opts.PlainHTTP && !dns.IsLocalOrPrivate(host) {
+		return false, nil
+	}
+	return ocischeme.From(ctx).UsePlainHTTP(ctx, host, ocischeme.ProbeOptions{InsecureSkipTLSVerify: opts.InsecureSkipTLSVerify})
+}
+
+// mirrorsForImage returns the mirror references for img from the first registry mirror whose source prefixes it.
+func mirrorsForImage(img transform.Image, registryMirrors []RegistryMirror) ([]transform.Image, error) {
+	for _, rm := range registryMirrors {
+		if !strings.HasPrefix(img.Reference, rm.Source) {
+			continue
+		}
+		mirrors := make([]transform.Image, 0, len(rm.Mirrors))
+		for _, m := range rm.Mirrors {
+			mirrored, err := transform.ParseImageRef(strings.Replace(img.Reference, rm.Source, m, 1))
+			if err != nil {
+				return nil, fmt.Errorf("failed to create mirror reference for image %s with mirror %s: %w", img.Reference, m, err)
+			}
+			mirrors = append(mirrors, mirrored)
+		}
+		return mirrors, nil
+	}
+	return nil, nil
+}
+
+// resolveFromMirrors returns the first mirror of image that resolves the reference.
+// Each mirror is authenticated with its own credentials from the Docker config through client.
+func resolveFromMirrors(ctx context.Context, client *auth.Client, image imageWithOverride, opts PullOptions) (transform.Image, bool) {
+	l := logger.From(ctx)
+	for _, mirror := range image.mirrors {
+		ref, err := registry.ParseReference(mirror.Reference)
+		if err != nil {
+			l.Warn("unable to parse registry mirror reference, trying next source", "image", image.original.Reference, "mirror", mirror.Reference, "err", err)
+			continue
+		}
+		plainHTTP, err := negotiatePlainHTTP(ctx, ref.Host(), opts)
+		if err != nil {
+			l.Warn("unable to reach registry mirror, trying next source", "image", image.original.Reference, "mirror", mirror.Reference, "err", err)
+			continue
+		}
+		repo := &orasRemote.Repository{
+			Reference: ref,
+			Client:    client,
+			PlainHTTP: plainHTTP,
+		}
+		if _, err := repo.Resolve(ctx, ref.Reference); err != nil {
+			l.Warn("unable to find image on registry mirror, trying next source", "image", image.original.Reference, "mirror", mirror.Reference, "err", err)
+			continue
+		}
+		l.Debug("resolved image from registry mirror", "image", image.original.Reference, "mirror", mirror.Reference)
+		return mirror, true
+	}
+	return transform.Image{}, false
+}
+
 func getDockerEndpointHost() (string, error) {
 	dockerCli, err := command.NewDockerCli(command.WithStandardStreams())
 	if err != nil {
@@ -287,6 +369,15 @@ func getDockerEndpointHost() (string, error) {
 	return endpoint.Host, nil
 }
 
+// metadataConcurrency is the number of images whose metadata is fetched at once. The images of every component are
+// pulled together, so the configured OCI concurrency bounds them alongside the per-host registry limits.
+func metadataConcurrency(ociConcurrency int) int {
+	if ociConcurrency <= 0 {
+		return defaultMetadataConcurrency
+	}
+	return ociConcurrency
+}
+
 func pullFromDockerDaemon(ctx context.Context, daemonImages []imageWithOverride, dst *oci.Store, arch string, concurrency int) (_ []PulledImage, err error) {
 	pulledImages := []PulledImage{}
 	dockerEndPointHost, err := getDockerEndpointHost()
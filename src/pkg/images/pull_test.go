@@ -192,10 +192,14 @@ func TestPull(t *testing.T) {
 	overrideUpstream := testutil.SetupInMemoryRegistryDynamic(ctx, t)
 	testutil.PushImage(ctx, t, overrideUpstream+"/library/podinfo", "6.4.0")
 
+	emptyMirror := testutil.SetupInMemoryRegistryDynamic(ctx, t)
+
 	testCases := []struct {
 		name              string
 		refs              []string
 		registryOverrides []RegistryOverride
+		registryMirrors   []RegistryMirror
+		expectedMirrors   map[string]string
 		expectErr         bool
 	}{
 		{
@@ -226,6 +230,34 @@ func TestPull(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "registry mirrors are tried in order",
+			refs: []string{
+				"fake.example/library/podinfo:6.4.0",
+			},
+			registryMirrors: []RegistryMirror{
+				{
+					Source:  "fake.example",
+					Mirrors: []string{emptyMirror, overrideUpstream},
+				},
+			},
+			expectedMirrors: map[string]string{
+				"fake.example/library/podinfo:6.4.0": overrideUpstream + "/library/podinfo:6.4.0",
+			},
+		},
+		{
+			name: "registry mirrors fall back to upstream",
+			refs: []string{
+				fmt.Sprintf("%s/fixtures/container:0.0.1", upstream),
+			},
+			registryMirrors: []RegistryMirror{
+				{
+					Source:  upstream,
+					Mirrors: []string{emptyMirror},
+				},
+			},
+			expectedMirrors: map[string]string{},
+		},
 	}
 
 	for _, tc := range testCases {
@@ -243,6 +275,7 @@ func TestPull(t *testing.T) {
 			opts := PullOptions{
 				CacheDirectory:    cacheDir,
 				RegistryOverrides: tc.registryOverrides,
+				RegistryMirrors:   tc.registryMirrors,
 				Arch:              "amd64",
 				PlainHTTP:         true,
 			}
@@ -254,6 +287,15 @@ func TestPull(t *testing.T) {
 			}
 			require.NoError(t, err)
 			require.Len(t, pulled, len(images))
+			if tc.expectedMirrors != nil {
+				actualMirrors := map[string]string{}
+				for _, p := range pulled {
+					if p.Mirror != "" {
+						actualMirrors[p.Image.Reference] = p.Mirror
+					}
+				}
+				require.Equal(t, tc.expectedMirrors, actualMirrors)
+			}
 
 			idx, err := getIndexFromOCILayout(filepath.Join(destDir))
 			require.NoError(t, err)
@@ -43,18 +43,31 @@ type PushOptions struct {
 
 // Push pushes images to a registry.
 func Push(ctx context.Context, imageList []transform.Image, sourceDirectory string, registryInfo state.RegistryInfo, cfg PushOptions) error {
-	start := time.Now()
-	if len(imageList) == 0 {
-		return fmt.Errorf("image list cannot be empty")
+	if err := validatePush(imageList, registryInfo, cfg); err != nil {
+		return err
 	}
 	if sourceDirectory == "" {
 		return fmt.Errorf("source directory cannot be empty")
 	}
-	if registryInfo.Address == "" {
-		return fmt.Errorf("registry address must be specified")
+	err := addRefNameAnnotationToImages(sourceDirectory)
+	if err != nil {
+		return err
 	}
-	if registryInfo.ShouldUseMTLS() && cfg.Cluster == nil {
-		return fmt.Errorf("registry uses Zarf-managed mTLS, but no cluster is available to obtain its client certificate")
+	src, err := oci.NewWithContext(ctx, sourceDirectory)
+	if err != nil {
+		return fmt.Errorf("failed to instantiate oci directory: %w", err)
+	}
+	return PushFromTarget(ctx, imageList, src, registryInfo, cfg)
+}
+
+// PushFromTarget pushes images read from src to a registry. Images are resolved in src by their reference.
+func PushFromTarget(ctx context.Context, imageList []transform.Image, src oras.ReadOnlyTarget, registryInfo state.RegistryInfo, cfg PushOptions) error {
+	start := time.Now()
+	if err := validatePush(imageList, registryInfo, cfg); err != nil {
+		return err
+	}
+	if src == nil {
+		return fmt.Errorf("source cannot be empty")
 	}
 	if cfg.Retries < 1 {
 		cfg.Retries = defaultRetries
@@ -69,16 +82,7 @@ func Push(ctx context.Context, imageList []transform.Image, sourceDirectory stri
 	}
 	l := logger.From(ctx)
 
-	err := addRefNameAnnotationToImages(sourceDirectory)
-	if err != nil {
-		return err
-	}
-
-	src, err := oci.NewWithContext(ctx, sourceDirectory)
-	if err != nil {
-		return fmt.Errorf("failed to instantiate oci directory: %w", err)
-	}
-
+	var err error
 	err = retry.Do(func() error {
 		// reset concurrency to user-provided value on each component retry
 		ociConcurrency := cfg.OCIConcurrency
@@ -230,6 +234,19 @@ func Push(ctx context.Context, imageList []transform.Image, sourceDirectory stri
 	return nil
 }
 
+func validatePush(imageList []transform.Image, registryInfo state.RegistryInfo, cfg PushOptions) error {
+	if len(imageList) == 0 {
+		return fmt.Errorf("image list cannot be empty")
+	}
+	if registryInfo.Address == "" {
+		return fmt.Errorf("registry address must be specified")
+	}
+	if registryInfo.ShouldUseMTLS() && cfg.Cluster == nil {
+		return fmt.Errorf("registry uses Zarf-managed mTLS, but no cluster is available to obtain its client certificate")
+	}
+	return nil
+}
+
 func addRefNameAnnotationToImages(ociLayoutDirectory string) error {
 	idx, err := getIndexFromOCILayout(ociLayoutDirectory)
 	if err != nil {
@@ -252,7 +269,7 @@ func addRefNameAnnotationToImages(ociLayoutDirectory string) error {
 	return nil
 }
 
-func copyImage(ctx context.Context, src *oci.Store, remote oras.Target, srcName string, dstName string, concurrency int) error {
+func copyImage(ctx context.Context, src oras.ReadOnlyTarget, remote oras.Target, srcName string, dstName string, concurrency int) error {
 	fetchOpts := oras.DefaultFetchBytesOptions
 	desc, b, err := oras.FetchBytes(ctx, src, srcName, fetchOpts)
 	if err != nil {
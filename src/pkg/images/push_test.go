@@ -7,9 +7,11 @@ package images
 import (
 	"context"
 	"fmt"
+	"path/filepath"
 	"testing"
 
 	"github.com/stretchr/testify/require"
+	"github.com/zarf-dev/zarf/src/pkg/archive"
 	"github.com/zarf-dev/zarf/src/pkg/state"
 	"github.com/zarf-dev/zarf/src/pkg/transform"
 	"github.com/zarf-dev/zarf/src/test/testutil"
@@ -112,6 +114,57 @@ func TestPush(t *testing.T) {
 	}
 }
 
+func TestPushFromTarStore(t *testing.T) {
+	t.Parallel()
+	testCases := []struct {
+		name            string
+		SourceDirectory string
+	}{
+		{
+			name:            "push from oras layout tarball",
+			SourceDirectory: "testdata/oras-oci-layout/images",
+		},
+		{
+			name:            "push from crane layout tarball",
+			SourceDirectory: "testdata/crane-oci-layout/images",
+		},
+	}
+	imageNames := []string{
+		"local-test:1.0.0",
+		"ghcr.io/zarf-dev/images/hello-world:latest",
+		"hello-world@sha256:03b62250a3cb1abd125271d393fc08bf0cc713391eda6b57c02d1ef85efcc25c",
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			ctx := testutil.TestContext(t)
+			tarPath := filepath.Join(t.TempDir(), "package.tar")
+			require.NoError(t, archive.Compress(ctx, []string{tc.SourceDirectory}, tarPath, archive.CompressOpts{}))
+			idx, err := archive.IndexTar(tarPath)
+			require.NoError(t, err)
+			src, err := NewTarStore(idx, "images")
+			require.NoError(t, err)
+
+			address := testutil.SetupInMemoryRegistryDynamic(ctx, t)
+			imageList := []transform.Image{}
+			for _, name := range imageNames {
+				ref, err := transform.ParseImageRef(name)
+				require.NoError(t, err)
+				imageList = append(imageList, ref)
+			}
+			err = PushFromTarget(ctx, imageList, src, state.RegistryInfo{Address: address}, PushOptions{PlainHTTP: true})
+			require.NoError(t, err)
+
+			for _, image := range imageNames {
+				ref, err := transform.ImageTransformHostWithoutChecksum(address, image)
+				require.NoError(t, err)
+				verifyImageExists(ctx, t, ref)
+			}
+		})
+	}
+}
+
 func TestPushRequiresClusterForZarfManagedMTLS(t *testing.T) {
 	t.Parallel()
 
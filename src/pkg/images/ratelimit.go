@@ -0,0 +1,259 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2021-Present The Zarf Authors
+
+// Package images provides functions for building and pushing images.
+package images
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/zarf-dev/zarf/src/pkg/logger"
+	"oras.land/oras-go/v2/registry/remote/auth"
+	"oras.land/oras-go/v2/registry/remote/retry"
+)
+
+const (
+	// rateLimitRemainingHeader is the header Docker Hub (and registries mimicking it) use to report the remaining pull quota.
+	rateLimitRemainingHeader = "ratelimit-remaining"
+	// rateLimitLimitHeader is the header Docker Hub (and registries mimicking it) use to report the total pull quota.
+	rateLimitLimitHeader = "ratelimit-limit"
+	// maxRetryAfter caps how long a single Retry-After response can pause requests to a registry host.
+	maxRetryAfter = 5 * time.Minute
+)
+
+// dockerHubHosts are the hosts image references may use for Docker Hub, mapped to the host requests are actually sent to.
+var dockerHubHosts = map[string]string{
+	"docker.io":       "registry-1.docker.io",
+	"index.docker.io": "registry-1.docker.io",
+}
+
+// Scheduler coordinates requests to remote hosts, limiting how many run concurrently and pausing a host
+// after it responds with 429 Too Many Requests. A single Scheduler is shared by every fetch of a package
+// assembly, so images, charts, repos and artifacts served from the same host draw from one budget.
+type Scheduler struct {
+	mu sync.Mutex
+	// limits is the maximum number of concurrent requests per host. Hosts without a limit are not bounded.
+	limits map[string]int
+	slots  map[string]chan struct{}
+	// backoffUntil holds the time before which no new requests should be sent to a host.
+	backoffUntil map[string]time.Time
+	// remaining holds the last quota reported by each host so changes are only logged once.
+	remaining map[string]string
+}
+
+// NewScheduler returns a Scheduler that allows at most limits[host] concurrent requests to each host.
+func NewScheduler(limits map[string]int) *Scheduler {
+	normalized := make(map[string]int, len(limits))
+	for host, limit := range limits {
+		normalized[normalizeRegistryHost(host)] = limit
+	}
+	return &Scheduler{
+		limits:       normalized,
+		slots:        map[string]chan struct{}{},
+		backoffUntil: map[string]time.Time{},
+		remaining:    map[string]string{},
+	}
+}
+
+func normalizeRegistryHost(host string) string {
+	if h, ok := dockerHubHosts[host]; ok {
+		return h
+	}
+	return host
+}
+
+// Acquire waits until a request may be sent to host and returns a function releasing the slot it holds.
+// Callers that cannot route their requests through Transport, such as git clones, use it to hold a slot
+// for the duration of the whole fetch.
+func (s *Scheduler) Acquire(ctx context.Context, host string) (func(), error) {
+	host = normalizeRegistryHost(host)
+	if err := s.waitForBackoff(ctx, host); err != nil {
+		return nil, err
+	}
+	slot := s.slot(host)
+	if slot == nil {
+		return func() {}, nil
+	}
+	select {
+	case slot <- struct{}{}:
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+	var once sync.Once
+	return func() {
+		once.Do(func() { <-slot })
+	}, nil
+}
+
+func (s *Scheduler) slot(host string) chan struct{} {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	limit, ok := s.limits[host]
+	if !ok || limit <= 0 {
+		return nil
+	}
+	if _, ok := s.slots[host]; !ok {
+		s.slots[host] = make(chan struct{}, limit)
+	}
+	return s.slots[host]
+}
+
+func (s *Scheduler) waitForBackoff(ctx context.Context, host string) error {
+	s.mu.Lock()
+	until := s.backoffUntil[host]
+	s.mu.Unlock()
+	wait := time.Until(until)
+	if wait <= 0 {
+		return nil
+	}
+	logger.From(ctx).Debug("waiting for registry rate limit to reset", "host", host, "wait", wait.Round(time.Second))
+	timer := time.NewTimer(wait)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// backoff pauses new requests to host for the given duration.
+func (s *Scheduler) backoff(host string, d time.Duration) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	until := time.Now().Add(d)
+	if until.After(s.backoffUntil[host]) {
+		s.backoffUntil[host] = until
+	}
+}
+
+// recordRemaining stores the quota reported by host and reports whether it changed since it was last seen.
+func (s *Scheduler) recordRemaining(host, remaining string) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.remaining[host] == remaining {
+		return false
+	}
+	s.remaining[host] = remaining
+	return true
+}
+
+// rateLimitTransport is an http.RoundTripper that schedules requests through a Scheduler, honoring
+// Retry-After on 429 responses and logging the remaining quota reported by the registry.
+type rateLimitTransport struct {
+	ctx       context.Context
+	base      http.RoundTripper
+	scheduler *Scheduler
+}
+
+// RoundTrip implements http.RoundTripper.
+func (t *rateLimitTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	l := logger.From(t.ctx)
+	host := req.URL.Host
+	release, err := t.scheduler.Acquire(req.Context(), host)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := t.base.RoundTrip(req)
+	if err != nil {
+		release()
+		return nil, err
+	}
+
+	if remaining := resp.Header.Get(rateLimitRemainingHeader); remaining != "" && t.scheduler.recordRemaining(host, remaining) {
+		l.Info("registry rate limit", "host", host, "remaining", quotaValue(remaining), "limit", quotaValue(resp.Header.Get(rateLimitLimitHeader)))
+	}
+	if resp.StatusCode == http.StatusTooManyRequests {
+		if wait, ok := parseRetryAfter(resp.Header.Get("Retry-After"), time.Now()); ok {
+			t.scheduler.backoff(host, wait)
+			l.Warn("rate limited by registry, backing off", "host", host, "retryAfter", wait.Round(time.Second))
+		} else {
+			l.Warn("rate limited by registry", "host", host)
+		}
+	}
+
+	// Hold the host slot until the body is consumed so large blob downloads count against the limit.
+	resp.Body = &releasingBody{ReadCloser: resp.Body, release: release}
+	return resp, nil
+}
+
+// releasingBody releases a host slot once the response body is closed.
+type releasingBody struct {
+	io.ReadCloser
+	release func()
+}
+
+// Close closes the body and releases the host slot.
+func (b *releasingBody) Close() error {
+	defer b.release()
+	return b.ReadCloser.Close()
+}
+
+// quotaValue strips the window from a Docker Hub style quota header, e.g. "76;w=21600" becomes "76".
+func quotaValue(header string) string {
+	v, _, _ := strings.Cut(header, ";")
+	return strings.TrimSpace(v)
+}
+
+// parseRetryAfter parses a Retry-After header given either in seconds or as an HTTP date.
+// The returned duration is capped at maxRetryAfter.
+func parseRetryAfter(header string, now time.Time) (time.Duration, bool) {
+	header = strings.TrimSpace(header)
+	if header == "" {
+		return 0, false
+	}
+	var wait time.Duration
+	if seconds, err := strconv.Atoi(header); err == nil {
+		wait = time.Duration(seconds) * time.Second
+	} else if at, err := http.ParseTime(header); err == nil {
+		wait = at.Sub(now)
+	} else {
+		return 0, false
+	}
+	if wait < 0 {
+		wait = 0
+	}
+	return min(wait, maxRetryAfter), true
+}
+
+// Transport returns an http.RoundTripper that sends requests through base once the scheduler allows them,
+// backing off hosts that respond with 429 Too Many Requests and logging the quota they report.
+func (s *Scheduler) Transport(ctx context.Context, base http.RoundTripper) http.RoundTripper {
+	if base == nil {
+		base = http.DefaultTransport
+	}
+	return &rateLimitTransport{ctx: ctx, base: base, scheduler: s}
+}
+
+// schedulerCtxKey provides a location to store a Scheduler in a context.
+type schedulerCtxKey struct{}
+
+// WithScheduler returns a copy of ctx carrying s, retrievable with SchedulerFrom.
+func WithScheduler(ctx context.Context, s *Scheduler) context.Context {
+	return context.WithValue(ctx, schedulerCtxKey{}, s)
+}
+
+// SchedulerFrom returns the Scheduler stored in ctx. When none is set it returns a Scheduler without
+// concurrency limits that still honors Retry-After.
+func SchedulerFrom(ctx context.Context) *Scheduler {
+	if s, ok := ctx.Value(schedulerCtxKey{}).(*Scheduler); ok && s != nil {
+		return s
+	}
+	return NewScheduler(nil)
+}
+
+// withRateLimits schedules every request made by client through the scheduler. The transport sits beneath
+// the retry transport so each retry is rescheduled as well.
+func withRateLimits(ctx context.Context, client *auth.Client, scheduler *Scheduler) {
+	if rt, ok := client.Client.Transport.(*retry.Transport); ok && rt.Base != nil {
+		rt.Base = scheduler.Transport(ctx, rt.Base)
+		return
+	}
+	client.Client.Transport = scheduler.Transport(ctx, client.Client.Transport)
+}
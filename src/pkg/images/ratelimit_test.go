@@ -0,0 +1,123 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2021-Present The Zarf Authors
+
+package images
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"github.com/zarf-dev/zarf/src/test/testutil"
+)
+
+func TestParseRetryAfter(t *testing.T) {
+	t.Parallel()
+	now := time.Date(2024, time.January, 1, 0, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name     string
+		header   string
+		expected time.Duration
+		ok       bool
+	}{
+		{name: "empty", header: "", ok: false},
+		{name: "seconds", header: "30", expected: 30 * time.Second, ok: true},
+		{name: "http date", header: now.Add(time.Minute).Format(http.TimeFormat), expected: time.Minute, ok: true},
+		{name: "date in the past", header: now.Add(-time.Minute).Format(http.TimeFormat), expected: 0, ok: true},
+		{name: "capped", header: "86400", expected: maxRetryAfter, ok: true},
+		{name: "invalid", header: "soon", ok: false},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			wait, ok := parseRetryAfter(tc.header, now)
+			require.Equal(t, tc.ok, ok)
+			require.Equal(t, tc.expected, wait)
+		})
+	}
+}
+
+func TestQuotaValue(t *testing.T) {
+	t.Parallel()
+	require.Equal(t, "76", quotaValue("76;w=21600"))
+	require.Equal(t, "100", quotaValue("100"))
+	require.Equal(t, "", quotaValue(""))
+}
+
+func TestRateLimitTransport(t *testing.T) {
+	t.Parallel()
+	ctx := testutil.TestContext(t)
+
+	var requests atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		if requests.Add(1) == 1 {
+			w.Header().Set("Retry-After", "1")
+			w.WriteHeader(http.StatusTooManyRequests)
+			return
+		}
+		w.Header().Set(rateLimitRemainingHeader, "99;w=21600")
+		w.WriteHeader(http.StatusOK)
+	}))
+	t.Cleanup(srv.Close)
+	u, err := url.Parse(srv.URL)
+	require.NoError(t, err)
+
+	scheduler := NewScheduler(map[string]int{u.Host: 1})
+	transport := scheduler.Transport(ctx, http.DefaultTransport)
+	client := &http.Client{Transport: transport}
+
+	resp, err := client.Get(srv.URL)
+	require.NoError(t, err)
+	require.Equal(t, http.StatusTooManyRequests, resp.StatusCode)
+	require.NoError(t, resp.Body.Close())
+
+	start := time.Now()
+	resp, err = client.Get(srv.URL)
+	require.NoError(t, err)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	require.GreaterOrEqual(t, time.Since(start), 500*time.Millisecond, "request should wait for the Retry-After backoff")
+
+	// The host slot is held until the body is closed.
+	acquireCtx, cancel := context.WithTimeout(ctx, 50*time.Millisecond)
+	defer cancel()
+	_, err = scheduler.Acquire(acquireCtx, u.Host)
+	require.Error(t, err)
+	_, err = io.Copy(io.Discard, resp.Body)
+	require.NoError(t, err)
+	require.NoError(t, resp.Body.Close())
+	release, err := scheduler.Acquire(ctx, u.Host)
+	require.NoError(t, err)
+	release()
+
+	require.Equal(t, "99;w=21600", scheduler.remaining[u.Host])
+}
+
+func TestSchedulerFrom(t *testing.T) {
+	t.Parallel()
+	ctx := testutil.TestContext(t)
+
+	scheduler := NewScheduler(map[string]int{"docker.io": 1})
+	require.Same(t, scheduler, SchedulerFrom(WithScheduler(ctx, scheduler)))
+	require.NotNil(t, SchedulerFrom(ctx))
+
+	// Docker Hub aliases share the slot of the host requests are sent to
+	release, err := scheduler.Acquire(ctx, "index.docker.io")
+	require.NoError(t, err)
+	acquireCtx, cancel := context.WithTimeout(ctx, 50*time.Millisecond)
+	defer cancel()
+	_, err = scheduler.Acquire(acquireCtx, "registry-1.docker.io")
+	require.ErrorIs(t, err, context.DeadlineExceeded)
+	release()
+}
+
+func TestMetadataConcurrency(t *testing.T) {
+	t.Parallel()
+	require.Equal(t, 3, metadataConcurrency(3))
+	require.Equal(t, defaultMetadataConcurrency, metadataConcurrency(0))
+}
@@ -0,0 +1,148 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2021-Present The Zarf Authors
+
+// Package images provides functions for building and pushing images.
+package images
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"path"
+
+	"github.com/opencontainers/go-digest"
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+	"oras.land/oras-go/v2"
+	"oras.land/oras-go/v2/content"
+	"oras.land/oras-go/v2/errdef"
+
+	"github.com/zarf-dev/zarf/src/pkg/archive"
+)
+
+// TarStore is a read-only OCI image layout stored inside an uncompressed tar archive.
+// Blobs are read directly from the archive as they are fetched, so the layout never has to be extracted to disk.
+type TarStore struct {
+	tar  *archive.TarIndex
+	root string
+	tags map[string]ocispec.Descriptor
+}
+
+var _ oras.ReadOnlyTarget = (*TarStore)(nil)
+
+// NewTarStore returns a read-only target over the OCI image layout found under root in the indexed archive.
+func NewTarStore(tar *archive.TarIndex, root string) (_ *TarStore, err error) {
+	s := &TarStore{
+		tar:  tar,
+		root: path.Clean(root),
+		tags: map[string]ocispec.Descriptor{},
+	}
+	rc, err := tar.Open(path.Join(s.root, ocispec.ImageIndexFile))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get index.json: %w", err)
+	}
+	defer func() { err = errors.Join(err, rc.Close()) }()
+	var idx ocispec.Index
+	if err := json.NewDecoder(rc).Decode(&idx); err != nil {
+		return nil, fmt.Errorf("unable to unmarshal index.json: %w", err)
+	}
+	for _, desc := range idx.Manifests {
+		// Crane sets ocispec.AnnotationBaseImageName instead of ocispec.AnnotationRefName
+		// so fall back to it to stay compatible with packages built with Crane.
+		ref := desc.Annotations[ocispec.AnnotationRefName]
+		if ref == "" {
+			ref = desc.Annotations[ocispec.AnnotationBaseImageName]
+		}
+		if ref != "" {
+			s.tags[ref] = desc
+		}
+	}
+	return s, nil
+}
+
+func (s *TarStore) blobPath(dgst digest.Digest) (string, error) {
+	if err := dgst.Validate(); err != nil {
+		return "", fmt.Errorf("%s: %s: %w", dgst, err, errdef.ErrInvalidDigest)
+	}
+	return path.Join(s.root, ocispec.ImageBlobsDir, dgst.Algorithm().String(), dgst.Encoded()), nil
+}
+
+// Fetch implements content.Fetcher. The returned reader verifies the blob against the descriptor as it is read.
+func (s *TarStore) Fetch(_ context.Context, target ocispec.Descriptor) (io.ReadCloser, error) {
+	p, err := s.blobPath(target.Digest)
+	if err != nil {
+		return nil, err
+	}
+	entry, ok := s.tar.Stat(p)
+	if !ok {
+		return nil, fmt.Errorf("%s: %s: %w", target.Digest, target.MediaType, errdef.ErrNotFound)
+	}
+	if entry.Size != target.Size {
+		return nil, fmt.Errorf("%s: %s: size mismatch: %w", target.Digest, target.MediaType, content.ErrMismatchedDigest)
+	}
+	rc, err := s.tar.Open(p)
+	if err != nil {
+		return nil, err
+	}
+	return NewVerifyingReadCloser(rc, target), nil
+}
+
+// Exists implements content.Storage.
+func (s *TarStore) Exists(_ context.Context, target ocispec.Descriptor) (bool, error) {
+	p, err := s.blobPath(target.Digest)
+	if err != nil {
+		return false, err
+	}
+	_, ok := s.tar.Stat(p)
+	return ok, nil
+}
+
+// Resolve implements content.Resolver. References are resolved by the image name annotations in index.json,
+// or by the digest of an image listed there.
+func (s *TarStore) Resolve(_ context.Context, reference string) (ocispec.Descriptor, error) {
+	if reference == "" {
+		return ocispec.Descriptor{}, errdef.ErrMissingReference
+	}
+	if desc, ok := s.tags[reference]; ok {
+		return desc, nil
+	}
+	dgst, err := digest.Parse(reference)
+	if err != nil {
+		return ocispec.Descriptor{}, fmt.Errorf("%s: %w", reference, errdef.ErrNotFound)
+	}
+	for _, desc := range s.tags {
+		if desc.Digest == dgst {
+			return desc, nil
+		}
+	}
+	return ocispec.Descriptor{}, fmt.Errorf("%s: %w", reference, errdef.ErrNotFound)
+}
+
+// NewVerifyingReadCloser returns a reader that verifies the size and digest of rc against desc once it is
+// fully read. Closing it closes rc.
+func NewVerifyingReadCloser(rc io.ReadCloser, desc ocispec.Descriptor) io.ReadCloser {
+	return &verifyingReadCloser{VerifyReader: content.NewVerifyReader(rc, desc), closer: rc}
+}
+
+// verifyingReadCloser verifies an archive entry as it is read and closes the entry when done.
+type verifyingReadCloser struct {
+	*content.VerifyReader
+	closer io.Closer
+}
+
+// Read reads the entry and verifies its size and digest once it is fully consumed.
+func (v *verifyingReadCloser) Read(p []byte) (int, error) {
+	n, err := v.VerifyReader.Read(p)
+	if errors.Is(err, io.EOF) {
+		if verr := v.Verify(); verr != nil {
+			return n, verr
+		}
+	}
+	return n, err
+}
+
+// Close closes the archive entry.
+func (v *verifyingReadCloser) Close() error {
+	return v.closer.Close()
+}
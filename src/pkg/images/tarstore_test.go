@@ -0,0 +1,87 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2021-Present The Zarf Authors
+
+package images
+
+import (
+	"archive/tar"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/opencontainers/go-digest"
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+	"github.com/stretchr/testify/require"
+	"github.com/zarf-dev/zarf/src/pkg/archive"
+	"github.com/zarf-dev/zarf/src/test/testutil"
+	"oras.land/oras-go/v2/content"
+	"oras.land/oras-go/v2/errdef"
+)
+
+func TestTarStore(t *testing.T) {
+	t.Parallel()
+	ctx := testutil.TestContext(t)
+
+	blob := []byte("layer contents")
+	tampered := []byte("layer c0ntents")
+	blobDesc := content.NewDescriptorFromBytes(ocispec.MediaTypeImageLayer, blob)
+	index := `{"schemaVersion":2,"manifests":[{"mediaType":"application/vnd.oci.image.manifest.v1+json","digest":"` +
+		blobDesc.Digest.String() + `","size":14,"annotations":{"org.opencontainers.image.base.name":"docker.io/library/test:1.0.0"}}]}`
+
+	tarPath := filepath.Join(t.TempDir(), "package.tar")
+	f, err := os.Create(tarPath)
+	require.NoError(t, err)
+	tw := tar.NewWriter(f)
+	entries := []struct {
+		name string
+		data []byte
+	}{
+		{"images/index.json", []byte(index)},
+		{"images/blobs/sha256/" + blobDesc.Digest.Encoded(), blob},
+		{"images/blobs/sha256/" + digest.FromBytes([]byte("other")).Encoded(), tampered},
+	}
+	for _, e := range entries {
+		require.NoError(t, tw.WriteHeader(&tar.Header{Name: e.name, Mode: 0o644, Size: int64(len(e.data)), Typeflag: tar.TypeReg}))
+		_, err := tw.Write(e.data)
+		require.NoError(t, err)
+	}
+	require.NoError(t, tw.Close())
+	require.NoError(t, f.Close())
+
+	idx, err := archive.IndexTar(tarPath)
+	require.NoError(t, err)
+	store, err := NewTarStore(idx, "images")
+	require.NoError(t, err)
+
+	desc, err := store.Resolve(ctx, "docker.io/library/test:1.0.0")
+	require.NoError(t, err)
+	require.Equal(t, blobDesc.Digest, desc.Digest)
+	desc, err = store.Resolve(ctx, blobDesc.Digest.String())
+	require.NoError(t, err)
+	require.Equal(t, blobDesc.Digest, desc.Digest)
+	_, err = store.Resolve(ctx, "docker.io/library/missing:1.0.0")
+	require.ErrorIs(t, err, errdef.ErrNotFound)
+
+	rc, err := store.Fetch(ctx, blobDesc)
+	require.NoError(t, err)
+	b, err := io.ReadAll(rc)
+	require.NoError(t, err)
+	require.NoError(t, rc.Close())
+	require.Equal(t, blob, b)
+
+	ok, err := store.Exists(ctx, blobDesc)
+	require.NoError(t, err)
+	require.True(t, ok)
+
+	// A blob whose contents do not match its name fails verification as it is read.
+	tamperedDesc := ocispec.Descriptor{MediaType: ocispec.MediaTypeImageLayer, Digest: digest.FromBytes([]byte("other")), Size: int64(len(tampered))}
+	rc, err = store.Fetch(ctx, tamperedDesc)
+	require.NoError(t, err)
+	_, err = io.ReadAll(rc)
+	require.ErrorIs(t, err, content.ErrMismatchedDigest)
+	require.NoError(t, rc.Close())
+
+	_, err = store.Fetch(ctx, content.NewDescriptorFromBytes(ocispec.MediaTypeImageLayer, []byte("missing")))
+	require.ErrorIs(t, err, errdef.ErrNotFound)
+}
@@ -26,6 +26,8 @@ import (
 // PulledImage describes an image that landed in the destination OCI layout.
 type PulledImage struct {
 	Image transform.Image
+	// Mirror is the reference the image was pulled from when it was served by a registry mirror.
+	Mirror string
 }
 
 const (
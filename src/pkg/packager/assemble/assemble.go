@@ -26,10 +26,13 @@ import (
 	"time"
 
 	"github.com/defenseunicorns/pkg/helpers/v2"
+	"github.com/sigstore/sigstore-go/pkg/root"
+
 	"github.com/zarf-dev/zarf/src/api"
 	"github.com/zarf-dev/zarf/src/api/v1alpha1"
 	"github.com/zarf-dev/zarf/src/config"
 	"github.com/zarf-dev/zarf/src/config/lang"
+	"github.com/zarf-dev/zarf/src/internal/artifact"
 	"github.com/zarf-dev/zarf/src/internal/git"
 	"github.com/zarf-dev/zarf/src/internal/packager/helm"
 	"github.com/zarf-dev/zarf/src/internal/packager/kustomize"
@@ -52,10 +55,15 @@ type AssembleOptions struct {
 	// Flavor causes the package to only include components with a matching `.components[x].only.flavor` or no flavor `.components[x].only.flavor` specified
 	Flavor string
 	// RegistryOverrides overrides the basepath of an OCI image with a path to a different registry
-	RegistryOverrides  []images.RegistryOverride
-	SigningKeyPath     string
-	SigningKeyPassword string
-	SkipSBOM           bool
+	RegistryOverrides []images.RegistryOverride
+	// RegistryMirrors are tried in order for matching images before falling back to the upstream registry
+	RegistryMirrors []images.RegistryMirror
+	// RegistryConcurrency limits the number of concurrent requests to each host when pulling images, charts,
+	// repos and artifacts. The limit is shared by every component of the package.
+	RegistryConcurrency map[string]int
+	SigningKeyPath      string
+	SigningKeyPassword  string
+	SkipSBOM            bool
 	// When DifferentialPackage is set the zarf package created only includes images and repos not in the differential package
 	DifferentialPackage v1alpha1.ZarfPackage
 	OCIConcurrency      int
@@ -102,15 +110,20 @@ func AssemblePackage(ctx context.Context, resolvedPackage load.ResolvedPackage,
 		definition.SetDifferentialBuild(opts.DifferentialPackage.Metadata.Version)
 	}
 
+	// Every fetch of this package draws from the same per-host budget
+	ctx = images.WithScheduler(ctx, images.NewScheduler(opts.RegistryConcurrency))
+
 	buildPath, err := utils.MakeTempDir(config.CommonOptions.TempDirectory)
 	if err != nil {
 		return nil, err
 	}
+	var verifiedGitRefs []api.VerifiedGitRef
 	for _, component := range pkg.Components {
-		err := assemblePackageComponent(ctx, component, packagePath, buildPath, opts.CachePath, opts.RemoteOptions)
+		verified, err := assemblePackageComponent(ctx, component, packagePath, buildPath, opts.CachePath, opts.RemoteOptions)
 		if err != nil {
 			return nil, err
 		}
+		verifiedGitRefs = append(verifiedGitRefs, verified...)
 	}
 
 	componentImages := []transform.Image{}
@@ -144,6 +157,7 @@ func AssemblePackage(ctx context.Context, resolvedPackage load.ResolvedPackage,
 			OCIConcurrency:        opts.OCIConcurrency,
 			Arch:                  pkg.Metadata.Architecture,
 			RegistryOverrides:     opts.RegistryOverrides,
+			RegistryMirrors:       opts.RegistryMirrors,
 			CacheDirectory:        filepath.Join(opts.CachePath, layout.ImagesDir),
 			InsecureSkipTLSVerify: opts.RemoteOptions.InsecureSkipTLSVerify,
 			PlainHTTP:             opts.RemoteOptions.PlainHTTP,
@@ -197,7 +211,7 @@ func AssemblePackage(ctx context.Context, resolvedPackage load.ResolvedPackage,
 	if err != nil {
 		return nil, err
 	}
-	if err = recordPackageMetadata(&definition, opts.Flavor, opts.RegistryOverrides, opts.WithBuildMachineInfo, buildPath, checksumSha); err != nil {
+	if err = recordPackageMetadata(&definition, opts.Flavor, opts.RegistryOverrides, pulledFromMirrors(manifests), verifiedGitRefs, opts.WithBuildMachineInfo, buildPath, checksumSha); err != nil {
 		return nil, err
 	}
 
@@ -279,7 +293,7 @@ func AssembleSkeleton(ctx context.Context, resolvedPackage load.ResolvedPackage,
 	// while moving package metadata updates to the generic definition.
 	definition = api.NewPackageDefinitionFromV1alpha1(pkg)
 
-	if err = recordPackageMetadata(&definition, opts.Flavor, nil, opts.WithBuildMachineInfo, buildPath, checksumSha); err != nil {
+	if err = recordPackageMetadata(&definition, opts.Flavor, nil, nil, nil, opts.WithBuildMachineInfo, buildPath, checksumSha); err != nil {
 		return nil, err
 	}
 
@@ -349,10 +363,10 @@ func validateImageArchivesNoDuplicates(components []v1alpha1.ZarfComponent) erro
 	return nil
 }
 
-func assemblePackageComponent(ctx context.Context, component v1alpha1.ZarfComponent, packagePath, buildPath, cachePath string, remoteOpts types.RemoteOptions) (err error) {
+func assemblePackageComponent(ctx context.Context, component v1alpha1.ZarfComponent, packagePath, buildPath, cachePath string, remoteOpts types.RemoteOptions) (verifiedGitRefs []api.VerifiedGitRef, err error) {
 	tmpBuildPath, err := utils.MakeTempDir(config.CommonOptions.TempDirectory)
 	if err != nil {
-		return err
+		return nil, err
 	}
 	defer func() {
 		err = errors.Join(err, os.RemoveAll(tmpBuildPath))
@@ -360,12 +374,12 @@ func assemblePackageComponent(ctx context.Context, component v1alpha1.ZarfCompon
 	compBuildPath := filepath.Join(tmpBuildPath, component.Name)
 	err = os.MkdirAll(compBuildPath, 0o700)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
 	onCreate := component.Actions.OnCreate
 	if err := actions.Run(ctx, packagePath, onCreate.Defaults, onCreate.Before, nil, nil, template.StateAccess{}); err != nil {
-		return fmt.Errorf("unable to run component before action: %w", err)
+		return nil, fmt.Errorf("unable to run component before action: %w", err)
 	}
 
 	// If any helm charts are defined, process them.
@@ -376,7 +390,7 @@ func assemblePackageComponent(ctx context.Context, component v1alpha1.ZarfCompon
 		}
 		err := PackageChart(ctx, chart, packagePath, paths, cachePath, remoteOpts)
 		if err != nil {
-			return err
+			return nil, err
 		}
 	}
 
@@ -390,11 +404,11 @@ func assemblePackageComponent(ctx context.Context, component v1alpha1.ZarfCompon
 				// get the compressedFileName from the source
 				compressedFileName, err := helpers.ExtractBasePathFromURL(file.Source)
 				if err != nil {
-					return fmt.Errorf(lang.ErrFileNameExtract, file.Source, err)
+					return nil, fmt.Errorf(lang.ErrFileNameExtract, file.Source, err)
 				}
 				tmpDir, err := utils.MakeTempDir(config.CommonOptions.TempDirectory)
 				if err != nil {
-					return err
+					return nil, err
 				}
 				defer func() {
 					err = errors.Join(err, os.RemoveAll(tmpDir))
@@ -403,18 +417,18 @@ func assemblePackageComponent(ctx context.Context, component v1alpha1.ZarfCompon
 
 				// If the file is an archive, download it to the componentPath.Temp
 				if err := utils.DownloadToFile(ctx, file.Source, compressedFile); err != nil {
-					return fmt.Errorf(lang.ErrDownloading, file.Source, err)
+					return nil, fmt.Errorf(lang.ErrDownloading, file.Source, err)
 				}
 				decompressOpts := archive.DecompressOpts{
 					Files: []string{file.ExtractPath},
 				}
 				err = archive.Decompress(ctx, compressedFile, destinationDir, decompressOpts)
 				if err != nil {
-					return fmt.Errorf(lang.ErrFileExtract, file.ExtractPath, compressedFileName, err)
+					return nil, fmt.Errorf(lang.ErrFileExtract, file.ExtractPath, compressedFileName, err)
 				}
 			} else {
 				if err := utils.DownloadToFile(ctx, file.Source, dst); err != nil {
-					return fmt.Errorf(lang.ErrDownloading, file.Source, err)
+					return nil, fmt.Errorf(lang.ErrDownloading, file.Source, err)
 				}
 			}
 		} else {
@@ -428,11 +442,11 @@ func assemblePackageComponent(ctx context.Context, component v1alpha1.ZarfCompon
 				}
 				err = archive.Decompress(ctx, src, destinationDir, decompressOpts)
 				if err != nil {
-					return fmt.Errorf(lang.ErrFileExtract, file.ExtractPath, src, err)
+					return nil, fmt.Errorf(lang.ErrFileExtract, file.ExtractPath, src, err)
 				}
 			} else {
 				if err := helpers.CreatePathAndCopy(src, dst); err != nil {
-					return fmt.Errorf("unable to copy file %s: %w", src, err)
+					return nil, fmt.Errorf("unable to copy file %s: %w", src, err)
 				}
 			}
 		}
@@ -442,7 +456,7 @@ func assemblePackageComponent(ctx context.Context, component v1alpha1.ZarfCompon
 			updatedExtractedFileOrDir := filepath.Join(destinationDir, file.ExtractPath)
 			if updatedExtractedFileOrDir != dst {
 				if err := os.Rename(updatedExtractedFileOrDir, dst); err != nil {
-					return fmt.Errorf(lang.ErrWritingFile, dst, err)
+					return nil, fmt.Errorf(lang.ErrWritingFile, dst, err)
 				}
 			}
 		}
@@ -450,19 +464,19 @@ func assemblePackageComponent(ctx context.Context, component v1alpha1.ZarfCompon
 		// Abort packaging on invalid shasum (if one is specified).
 		if file.Shasum != "" {
 			if err := helpers.SHAsMatch(dst, file.Shasum); err != nil {
-				return fmt.Errorf("sha mismatch for %s: %w", file.Source, err)
+				return nil, fmt.Errorf("sha mismatch for %s: %w", file.Source, err)
 			}
 		}
 
 		if file.Executable || helpers.IsDir(dst) {
 			err := os.Chmod(dst, helpers.ReadWriteExecuteUser)
 			if err != nil {
-				return err
+				return nil, err
 			}
 		} else {
 			err := os.Chmod(dst, helpers.ReadWriteUser)
 			if err != nil {
-				return err
+				return nil, err
 			}
 		}
 	}
@@ -473,7 +487,7 @@ func assemblePackageComponent(ctx context.Context, component v1alpha1.ZarfCompon
 
 		if helpers.IsURL(data.Source) {
 			if err := utils.DownloadToFile(ctx, data.Source, dst); err != nil {
-				return fmt.Errorf(lang.ErrDownloading, data.Source, err)
+				return nil, fmt.Errorf(lang.ErrDownloading, data.Source, err)
 			}
 		} else {
 			src := data.Source
@@ -481,7 +495,7 @@ func assemblePackageComponent(ctx context.Context, component v1alpha1.ZarfCompon
 				src = filepath.Join(packagePath, data.Source)
 			}
 			if err := helpers.CreatePathAndCopy(src, dst); err != nil {
-				return fmt.Errorf("unable to copy data injection %s: %w", data.Source, err)
+				return nil, fmt.Errorf("unable to copy data injection %s: %w", data.Source, err)
 			}
 		}
 	}
@@ -490,47 +504,134 @@ func assemblePackageComponent(ctx context.Context, component v1alpha1.ZarfCompon
 	if len(component.Manifests) > 0 {
 		err := os.MkdirAll(filepath.Join(compBuildPath, string(layout.ManifestsComponentDir)), 0o700)
 		if err != nil {
-			return err
+			return nil, err
 		}
 	}
 	for _, manifest := range component.Manifests {
 		err := PackageManifest(ctx, manifest, compBuildPath, packagePath)
 		if err != nil {
-			return err
+			return nil, err
 		}
 	}
 
 	// Load all specified git repos.
+	reposPath := filepath.Join(compBuildPath, string(layout.RepoComponentDir))
 	for _, url := range component.Repos {
 		// Pull all the references if there is no `@` in the string.
-		_, err := git.Clone(ctx, filepath.Join(compBuildPath, string(layout.RepoComponentDir)), url, false)
+		repo, err := git.Clone(ctx, reposPath, url, false)
+		if err != nil {
+			return nil, fmt.Errorf("unable to pull git repo %s: %w", url, err)
+		}
+		if i := slices.IndexFunc(component.VerifyRepos, func(v v1alpha1.ZarfRepoVerify) bool { return v.Repo == url }); i >= 0 {
+			verified, err := verifyRepo(repo, url, component.VerifyRepos[i], packagePath)
+			if err != nil {
+				return nil, err
+			}
+			verifiedGitRefs = append(verifiedGitRefs, verified...)
+		}
+		addresses := []string{url}
+		if slices.Contains(component.RecursiveSubmodules, url) {
+			submodules, err := repo.CloneSubmodules(ctx, reposPath, url)
+			if err != nil {
+				return nil, fmt.Errorf("unable to pull the submodules of git repo %s: %w", url, err)
+			}
+			addresses = append(addresses, submodules...)
+		}
+		if slices.Contains(component.SkipLFSRepos, url) {
+			continue
+		}
+		lfsClient, err := git.NewLFSHTTPClient(remoteOpts.InsecureSkipTLSVerify)
+		if err != nil {
+			return nil, err
+		}
+		lfsClient.Transport = images.SchedulerFrom(ctx).Transport(ctx, lfsClient.Transport)
+		for _, address := range addresses {
+			repo, err := git.Open(reposPath, address)
+			if err != nil {
+				return nil, err
+			}
+			if err := repo.FetchLFS(ctx, lfsClient, address); err != nil {
+				return nil, fmt.Errorf("unable to fetch Git LFS objects for git repo %s: %w", address, err)
+			}
+		}
+	}
+
+	if len(component.Artifacts) > 0 {
+		artifactClient, err := artifact.NewHTTPClient(remoteOpts.InsecureSkipTLSVerify)
 		if err != nil {
-			return fmt.Errorf("unable to pull git repo %s: %w", url, err)
+			return nil, err
+		}
+		artifactClient.Transport = images.SchedulerFrom(ctx).Transport(ctx, artifactClient.Transport)
+		for idx, a := range component.Artifacts {
+			dst := filepath.Join(compBuildPath, string(layout.ArtifactsComponentDir), strconv.Itoa(idx))
+			if err := artifact.Pull(ctx, artifactClient, a, dst); err != nil {
+				return nil, fmt.Errorf("unable to pull %s artifact %s: %w", a.Ecosystem, a.Name, err)
+			}
 		}
 	}
 
 	if err := actions.Run(ctx, packagePath, onCreate.Defaults, onCreate.After, nil, nil, template.StateAccess{}); err != nil {
-		return fmt.Errorf("unable to run component after action: %w", err)
+		return nil, fmt.Errorf("unable to run component after action: %w", err)
 	}
 
 	// Write the tar component.
 	entries, err := os.ReadDir(compBuildPath)
 	if err != nil {
-		return err
+		return nil, err
 	}
 	if len(entries) == 0 {
-		return nil
+		return verifiedGitRefs, nil
 	}
 	tarPath := filepath.Join(buildPath, "components", fmt.Sprintf("%s.tar", component.Name))
 	err = os.MkdirAll(filepath.Join(buildPath, "components"), 0o700)
 	if err != nil {
-		return err
+		return nil, err
 	}
 	err = createReproducibleTarballFromDir(compBuildPath, component.Name, tarPath, false)
 	if err != nil {
-		return err
+		return nil, err
 	}
-	return nil
+	return verifiedGitRefs, nil
+}
+
+// verifyRepo checks that every packaged ref of a cloned git repo is signed by a signer allowed in verify.
+func verifyRepo(repo *git.Repository, url string, verify v1alpha1.ZarfRepoVerify, packagePath string) ([]api.VerifiedGitRef, error) {
+	keys := [][]byte{}
+	for _, key := range verify.Keys {
+		if !filepath.IsAbs(key) {
+			key = filepath.Join(packagePath, key)
+		}
+		b, err := os.ReadFile(key)
+		if err != nil {
+			return nil, fmt.Errorf("unable to read signing key for git repo %s: %w", url, err)
+		}
+		keys = append(keys, b)
+	}
+	identities := []git.SigstoreIdentity{}
+	for _, id := range verify.Identities {
+		identities = append(identities, git.SigstoreIdentity{Subject: id.Subject, Issuer: id.Issuer})
+	}
+	var trusted root.TrustedMaterial
+	if len(identities) > 0 {
+		trustedRoot, err := signing.TrustedRoot()
+		if err != nil {
+			return nil, err
+		}
+		trusted = trustedRoot
+	}
+	verifier, err := git.NewVerifier(keys, identities, trusted)
+	if err != nil {
+		return nil, fmt.Errorf("unable to verify git repo %s: %w", url, err)
+	}
+	refs, err := repo.Verify(verifier)
+	if err != nil {
+		return nil, fmt.Errorf("unable to verify git repo %s: %w", url, err)
+	}
+	verified := []api.VerifiedGitRef{}
+	for _, ref := range refs {
+		verified = append(verified, api.VerifiedGitRef{Repo: url, Ref: ref.Ref, Hash: ref.Hash, Signer: ref.Signer})
+	}
+	return verified, nil
 }
 
 // PackageManifest takes a Zarf manifest definition and packs it into a package layout
@@ -811,7 +912,7 @@ func assembleSkeletonComponent(ctx context.Context, component v1alpha1.ZarfCompo
 	return nil
 }
 
-func recordPackageMetadata(definition *api.PackageDefinition, flavor string, registryOverrides []images.RegistryOverride, withBuildMachineInfo bool, buildPath, aggregateChecksum string) error {
+func recordPackageMetadata(definition *api.PackageDefinition, flavor string, registryOverrides []images.RegistryOverride, imageMirrors map[string]string, verifiedGitRefs []api.VerifiedGitRef, withBuildMachineInfo bool, buildPath, aggregateChecksum string) error {
 	pkg := definition.AsV1alpha1()
 	now := time.Now()
 	buildData := api.BuildData{
@@ -859,6 +960,8 @@ func recordPackageMetadata(definition *api.PackageDefinition, flavor string, reg
 	}
 
 	buildData.RegistryOverrides = overrides
+	buildData.ImageMirrors = imageMirrors
+	buildData.VerifiedGitRefs = verifiedGitRefs
 
 	// Set signed to false by default; this is updated if signing occurs.
 	signed := false
@@ -868,6 +971,21 @@ func recordPackageMetadata(definition *api.PackageDefinition, flavor string, reg
 	return nil
 }
 
+// pulledFromMirrors maps each image that was pulled from a registry mirror to the mirror reference it was pulled from.
+func pulledFromMirrors(pulled []images.PulledImage) map[string]string {
+	var mirrors map[string]string
+	for _, p := range pulled {
+		if p.Mirror == "" {
+			continue
+		}
+		if mirrors == nil {
+			mirrors = map[string]string{}
+		}
+		mirrors[p.Image.Reference] = p.Mirror
+	}
+	return mirrors
+}
+
 func collectVersionRequirements(pkg v1alpha1.ZarfPackage, hasIndex bool) []api.VersionRequirement {
 	var reqs []api.VersionRequirement
 	var hasImageArchives, hasTemplatedValuesFiles, hasVersionlessChart bool
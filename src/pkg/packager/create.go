@@ -26,6 +26,8 @@ import (
 type CreateOptions struct {
 	Flavor                  string
 	RegistryOverrides       []images.RegistryOverride
+	RegistryMirrors         []images.RegistryMirror
+	RegistryConcurrency     map[string]int
 	SigningKeyPath          string
 	SigningKeyPassword      string
 	SetVariables            map[string]string
@@ -98,6 +100,8 @@ func Create(ctx context.Context, packagePath string, output string, opts CreateO
 		DifferentialPackage:  differentialPkg,
 		Flavor:               opts.Flavor,
 		RegistryOverrides:    opts.RegistryOverrides,
+		RegistryMirrors:      opts.RegistryMirrors,
+		RegistryConcurrency:  opts.RegistryConcurrency,
 		SigningKeyPath:       opts.SigningKeyPath,
 		SigningKeyPassword:   opts.SigningKeyPassword,
 		CachePath:            opts.CachePath,
@@ -81,6 +81,22 @@ type DeployOptions struct {
 	AgentTLS *pki.GeneratedPKI
 	// AgentMutationPolicy controls whether the agent mutates by default (default-mutate) or only on explicit label (default-ignore).
 	AgentMutationPolicy state.MutationPolicy
+	// AgentImagePullSecretMode controls how the agent gives pods access to the Zarf image pull secret.
+	AgentImagePullSecretMode state.ImagePullSecretMode
+	// AgentCertExpiryWarning warns when the agent TLS certificate has less than this lifetime left. A warning is always
+	// given once less than 20% of the lifetime is left.
+	AgentCertExpiryWarning time.Duration
+	// RegistryTarget is the name of the registry target in state to push images to. The namespaces the package creates
+	// or adopts are annotated to route their images to it.
+	RegistryTarget string
+	// GitNoForce refuses to push git refs that are not a fast-forward of the git server instead of force pushing them
+	GitNoForce bool
+	// GitOrganization pushes the package's repos into this organization of the git server instead of the account of the push user
+	GitOrganization string
+	// GitOrganizationPerPackage pushes the package's repos into an organization of the git server named after the package
+	GitOrganizationPerPackage bool
+	// OverrideLock takes the cluster-wide deploy lock even when it is held by another operation
+	OverrideLock bool
 
 	// [Library Only] A map of component names to chart names containing Helm Chart values to override values on deploy
 	ValuesOverridesMap ValuesOverrides
@@ -99,6 +115,10 @@ type deployer struct {
 	c    *cluster.Cluster
 	vc   *variables.VariableConfig
 	vals value.Values
+	// gitRepos are the owner/name paths of the repos pushed to the git server, keyed by component name
+	gitRepos map[string][]string
+	// releaseLock releases the cluster-wide deploy lock once it has been acquired
+	releaseLock func(context.Context) error
 }
 
 // DeployResult is the result of a successful deploy
@@ -109,12 +129,18 @@ type DeployResult struct {
 }
 
 // Deploy takes a reference to a `layout.PackageLayout` and deploys the package. If successful, returns a list of components that were successfully deployed and the associated variable config.
-func Deploy(ctx context.Context, pkgLayout *layout.PackageLayout, opts DeployOptions) (DeployResult, error) {
+func Deploy(ctx context.Context, pkgLayout *layout.PackageLayout, opts DeployOptions) (_ DeployResult, err error) {
 	start := time.Now()
 	pkg := pkgLayout.AsV1alpha1()
 	if opts.Connected && pkg.IsInitConfig() {
 		return DeployResult{}, fmt.Errorf("--connected is not supported for init packages")
 	}
+	if opts.RegistryTarget != "" && pkg.IsInitConfig() {
+		return DeployResult{}, fmt.Errorf("--registry-target is not supported for init packages")
+	}
+	if (opts.GitOrganization != "" || opts.GitOrganizationPerPackage) && pkg.IsInitConfig() {
+		return DeployResult{}, fmt.Errorf("git organizations are not supported for init packages")
+	}
 
 	// Validate operational requirements before proceeding
 	if !opts.SkipVersionCheck {
@@ -152,7 +178,6 @@ func Deploy(ctx context.Context, pkgLayout *layout.PackageLayout, opts DeployOpt
 		opts.Timeout = config.ZarfDefaultTimeout
 	}
 
-	var err error
 	definition, err := filters.Apply(pkgLayout.PackageDefinition, filters.ByLocalOS(runtime.GOOS))
 	if err != nil {
 		return DeployResult{}, err
@@ -182,6 +207,10 @@ func Deploy(ctx context.Context, pkgLayout *layout.PackageLayout, opts DeployOpt
 		return DeployResult{}, fmt.Errorf("package references values that cannot be resolved (value templates must be explicitly defined, even if empty): %w", err)
 	}
 
+	defer func() {
+		// Release the lock even when the deploy was cancelled
+		err = errors.Join(err, d.unlock(context.WithoutCancel(ctx)))
+	}()
 	deployedComponents, err := d.deployComponents(ctx, pkgLayout, opts)
 	if err != nil {
 		return DeployResult{}, err
@@ -255,7 +284,11 @@ func (d *deployer) deployComponents(ctx context.Context, pkgLayout *layout.Packa
 				if err != nil {
 					return nil, fmt.Errorf("unable to connect to the Kubernetes cluster: %w", err)
 				}
-				if err := d.verifyPackageIsDeployable(ctx, pkgLayout); err != nil {
+				ctx, err = d.lock(ctx, deployLockOperation(pkg), opts.OverrideLock)
+				if err != nil {
+					return nil, err
+				}
+				if err := d.verifyPackageIsDeployable(ctx, pkgLayout, opts.AgentCertExpiryWarning); err != nil {
 					return nil, fmt.Errorf("package is not deployable to this system: %w", err)
 				}
 			}
@@ -329,6 +362,7 @@ func (d *deployer) deployComponents(ctx context.Context, pkgLayout *layout.Packa
 
 		// Update the package secret to indicate that we successfully deployed this component
 		deployedComponents[idx].InstalledCharts = state.MergeInstalledChartsForComponent(deployedComponents[idx].InstalledCharts, charts, false)
+		deployedComponents[idx].GitRepos = d.gitRepos[component.Name]
 		deployedComponents[idx].Status = state.ComponentStatusSucceeded
 		if d.isConnectedToCluster() {
 			if _, err := d.c.RecordPackageDeployment(ctx, pkg, pkgLayout.Digest(), deployedComponents, packageGeneration, state.WithPackageConnectivity(opts.Connected), state.WithPackageNamespaceOverride(opts.NamespaceOverride)); err != nil {
@@ -345,6 +379,46 @@ func (d *deployer) deployComponents(ctx context.Context, pkgLayout *layout.Packa
 	return deployedComponents, nil
 }
 
+// lock takes the cluster-wide deploy lock, which is held until unlock is called. The deploy should continue with the
+// returned context, which is cancelled when the lock is lost.
+func (d *deployer) lock(ctx context.Context, operation string, override bool) (context.Context, error) {
+	if d.releaseLock != nil {
+		return ctx, nil
+	}
+	lockCtx, release, err := d.c.AcquireDeployLock(ctx, cluster.DeployLockOptions{Operation: operation, Override: override})
+	if err != nil {
+		return nil, err
+	}
+	d.releaseLock = release
+	return lockCtx, nil
+}
+
+// unlock releases the cluster-wide deploy lock if it was taken.
+func (d *deployer) unlock(ctx context.Context) error {
+	if d.releaseLock == nil {
+		return nil
+	}
+	err := d.releaseLock(ctx)
+	d.releaseLock = nil
+	return err
+}
+
+// deployLockOperation describes the deploy of pkg in the cluster-wide deploy lock.
+func deployLockOperation(pkg v1alpha1.ZarfPackage) string {
+	if pkg.IsInitConfig() {
+		return "zarf init"
+	}
+	return fmt.Sprintf("zarf package deploy %s", pkg.Metadata.Name)
+}
+
+// gitOrganization returns the organization of the git server that the package's repos are pushed into, if any.
+func gitOrganization(pkg v1alpha1.ZarfPackage, opts DeployOptions) string {
+	if opts.GitOrganizationPerPackage {
+		return pkg.Metadata.Name
+	}
+	return opts.GitOrganization
+}
+
 // internalServicesFor returns the state services Zarf will deploy internally in this init run.
 func internalServicesFor(components []v1alpha1.ZarfComponent, opts DeployOptions) state.ServiceSet {
 	services := state.NewServiceSet()
@@ -383,15 +457,16 @@ func (d *deployer) deployInitComponent(ctx context.Context, pkgLayout *layout.Pa
 		}
 		var err error
 		d.s, err = d.c.InitState(ctx, cluster.InitStateOptions{
-			GitServer:           opts.GitServer,
-			RegistryInfo:        opts.RegistryInfo,
-			ArtifactServer:      opts.ArtifactServer,
-			ApplianceMode:       applianceMode,
-			StorageClass:        opts.StorageClass,
-			InjectorPort:        opts.InjectorPort,
-			AgentTLS:            opts.AgentTLS,
-			AgentMutationPolicy: opts.AgentMutationPolicy,
-			InternalServices:    internalServicesFor(pkg.Components, opts),
+			GitServer:                opts.GitServer,
+			RegistryInfo:             opts.RegistryInfo,
+			ArtifactServer:           opts.ArtifactServer,
+			ApplianceMode:            applianceMode,
+			StorageClass:             opts.StorageClass,
+			InjectorPort:             opts.InjectorPort,
+			AgentTLS:                 opts.AgentTLS,
+			AgentMutationPolicy:      opts.AgentMutationPolicy,
+			AgentImagePullSecretMode: opts.AgentImagePullSecretMode,
+			InternalServices:         internalServicesFor(pkg.Components, opts),
 		})
 		if err != nil {
 			return nil, fmt.Errorf("unable to initialize Zarf state: %w", err)
@@ -407,6 +482,10 @@ func (d *deployer) deployInitComponent(ctx context.Context, pkgLayout *layout.Pa
 
 	// Before deploying the seed registry, start the injector
 	if isSeedRegistry {
+		// The injector loads the seed image from the images directory.
+		if err := pkgLayout.ExtractImages(ctx); err != nil {
+			return nil, err
+		}
 		switch d.s.RegistryInfo.RegistryMode {
 		case state.RegistryModeProxy:
 			injectorImage, err := injectorDaemonsetImage(ctx, d.c, opts.InjectorImage)
@@ -472,8 +551,10 @@ func (d *deployer) deployComponent(ctx context.Context, pkgLayout *layout.Packag
 
 	hasImages := len(component.GetImages()) > 0 && !noImgPush && !opts.Connected
 	hasCharts := len(component.Charts) > 0
+	hasMirroredCharts := hasCharts && component.MirrorCharts && !noImgPush && !opts.Connected
 	hasManifests := len(component.Manifests) > 0
 	hasRepos := len(component.Repos) > 0 && !opts.Connected
+	hasArtifacts := len(component.Artifacts) > 0 && !opts.Connected
 	hasFiles := len(component.Files) > 0
 
 	onDeploy := component.Actions.OnDeploy
@@ -493,6 +574,14 @@ func (d *deployer) deployComponent(ctx context.Context, pkgLayout *layout.Packag
 		}
 	}
 
+	var registryInfo state.RegistryInfo
+	if hasImages || hasMirroredCharts {
+		registryInfo, err = d.s.RegistryInfoForTarget(opts.RegistryTarget)
+		if err != nil {
+			return nil, err
+		}
+	}
+
 	applicationTemplates, err := ptmpl.GetZarfTemplates(ctx, component.Name, d.s)
 	if err != nil {
 		return nil, err
@@ -510,6 +599,14 @@ func (d *deployer) deployComponent(ctx context.Context, pkgLayout *layout.Packag
 		}
 	}
 
+	pushOpts := images.PushOptions{
+		OCIConcurrency:        opts.OCIConcurrency,
+		PlainHTTP:             opts.PlainHTTP,
+		NoChecksum:            noImgChecksum,
+		Retries:               opts.Retries,
+		InsecureSkipTLSVerify: opts.InsecureSkipTLSVerify,
+		Cluster:               d.c,
+	}
 	if hasImages {
 		refs := []transform.Image{}
 		for _, img := range component.GetImages() {
@@ -519,24 +616,50 @@ func (d *deployer) deployComponent(ctx context.Context, pkgLayout *layout.Packag
 			}
 			refs = append(refs, ref)
 		}
-		pushOpts := images.PushOptions{
-			OCIConcurrency:        opts.OCIConcurrency,
-			PlainHTTP:             opts.PlainHTTP,
-			NoChecksum:            noImgChecksum,
-			Retries:               opts.Retries,
-			InsecureSkipTLSVerify: opts.InsecureSkipTLSVerify,
-			Cluster:               d.c,
-		}
-		err := images.Push(ctx, refs, pkgLayout.GetImageDirPath(), d.s.RegistryInfo, pushOpts)
+		err := pushPackageImages(ctx, pkgLayout, refs, registryInfo, pushOpts)
 		if err != nil {
 			return nil, fmt.Errorf("unable to push images to the registry: %w", err)
 		}
 	}
 
+	if hasMirroredCharts {
+		if err := pushComponentCharts(ctx, pkgLayout, component, registryInfo, pushOpts); err != nil {
+			return nil, fmt.Errorf("unable to push charts to the registry: %w", err)
+		}
+	}
+
 	if hasRepos {
-		if err := pushComponentReposToRegistry(ctx, component, pkgLayout, d.s.GitServer, d.c, opts.Retries); err != nil {
+		repoPushOpts := RepoPushOptions{
+			Cluster:               d.c,
+			Retries:               opts.Retries,
+			NoForce:               opts.GitNoForce,
+			Organization:          gitOrganization(pkgLayout.AsV1alpha1(), opts),
+			InsecureSkipTLSVerify: opts.InsecureSkipTLSVerify,
+		}
+		gitRepos, err := pushComponentReposToRegistry(ctx, component, pkgLayout, d.s.GitServer, repoPushOpts)
+		if err != nil {
 			return nil, fmt.Errorf("unable to push the repos to the repository: %w", err)
 		}
+		if d.gitRepos == nil {
+			d.gitRepos = map[string][]string{}
+		}
+		d.gitRepos[component.Name] = gitRepos
+	}
+
+	if hasArtifacts {
+		if d.s.ArtifactServer.PushToken == "" && d.s.GitServer.HostsArtifactServer(d.s.ArtifactServer) {
+			l.Info("creating an artifact registry token through the git server", "provider", d.s.GitServer.EffectiveProvider())
+			d.s.ArtifactServer.PushToken, err = d.c.UpdateInternalArtifactServerToken(ctx, d.s.GitServer)
+			if err != nil {
+				return nil, fmt.Errorf("unable to create an artifact registry token: %w", err)
+			}
+			if err := d.c.SaveState(ctx, d.s); err != nil {
+				return nil, err
+			}
+		}
+		if err := pushComponentArtifacts(ctx, component, pkgLayout, d.s.ArtifactServer, d.c, opts.Retries, opts.InsecureSkipTLSVerify); err != nil {
+			return nil, fmt.Errorf("unable to push the artifacts to the artifact server: %w", err)
+		}
 	}
 
 	g, gCtx := errgroup.WithContext(ctx)
@@ -653,6 +776,8 @@ func (d *deployer) installCharts(ctx context.Context, pkgLayout *layout.PackageL
 			PkgName:           pkg.Metadata.Name,
 			NamespaceOverride: opts.NamespaceOverride,
 			IsInteractive:     opts.IsInteractive,
+			RegistryTarget:    opts.RegistryTarget,
+			GitOrganization:   gitOrganization(pkg, opts),
 		}
 		helmChart, values, err := helm.LoadChartData(chart, layout.ChartPaths{ChartsDir: chartDir, ValuesDir: valuesDir}, valuesOverrides)
 		if err != nil {
@@ -744,6 +869,8 @@ func (d *deployer) installManifests(ctx context.Context, pkgLayout *layout.Packa
 			PkgName:           pkg.Metadata.Name,
 			NamespaceOverride: opts.NamespaceOverride,
 			IsInteractive:     opts.IsInteractive,
+			RegistryTarget:    opts.RegistryTarget,
+			GitOrganization:   gitOrganization(pkg, opts),
 		}
 
 		// Install the chart.
@@ -758,7 +885,7 @@ func (d *deployer) installManifests(ctx context.Context, pkgLayout *layout.Packa
 	return installedCharts, nil
 }
 
-func (d *deployer) verifyPackageIsDeployable(ctx context.Context, pkgLayout *layout.PackageLayout) error {
+func (d *deployer) verifyPackageIsDeployable(ctx context.Context, pkgLayout *layout.PackageLayout, certExpiryWarning time.Duration) error {
 	if err := verifyClusterCompatibility(ctx, d.c, pkgLayout); err != nil {
 		if errors.Is(err, lang.ErrUnableToCheckArch) {
 			logger.From(ctx).Warn("unable to validate package architecture", "error", err)
@@ -775,7 +902,7 @@ func (d *deployer) verifyPackageIsDeployable(ctx context.Context, pkgLayout *lay
 	if !s.AgentIsConfigured() {
 		return nil
 	}
-	return pki.CheckForExpiredCert(ctx, s.AgentTLS)
+	return pki.CheckForExpiringCert(ctx, s.AgentTLS, certExpiryWarning)
 }
 
 func setupState(ctx context.Context, c *cluster.Cluster, connected bool) (*state.State, error) {
@@ -117,7 +117,7 @@ func TestVerifyPackageIsDeployableSkipsAgentCertCheckWhenAgentIsNotConfigured(t
 	require.NoError(t, c.SaveState(ctx, &state.State{}))
 
 	d := deployer{c: c}
-	err = d.verifyPackageIsDeployable(ctx, &layout.PackageLayout{})
+	err = d.verifyPackageIsDeployable(ctx, &layout.PackageLayout{}, 0)
 	require.NoError(t, err)
 }
 
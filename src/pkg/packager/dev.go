@@ -37,6 +37,10 @@ type DevDeployOptions struct {
 	RegistryURL string
 	// RegistryOverrides overrides the basepath of an OCI image with a path to a different registry during package assembly
 	RegistryOverrides []images.RegistryOverride
+	// RegistryMirrors are tried in order for matching images during package assembly before falling back to the upstream registry
+	RegistryMirrors []images.RegistryMirror
+	// RegistryConcurrency limits the number of concurrent requests to each host during package assembly
+	RegistryConcurrency map[string]int
 	// CreateSetVariables are for package templates
 	CreateSetVariables map[string]string
 	// DeploySetVariables are for package variables
@@ -105,11 +109,13 @@ func DevDeploy(ctx context.Context, packagePath string, opts DevDeployOptions) (
 	}
 
 	createOpts := assemble.AssembleOptions{
-		Flavor:            opts.Flavor,
-		RegistryOverrides: opts.RegistryOverrides,
-		SkipSBOM:          true,
-		OCIConcurrency:    opts.OCIConcurrency,
-		CachePath:         opts.CachePath,
+		Flavor:              opts.Flavor,
+		RegistryOverrides:   opts.RegistryOverrides,
+		RegistryMirrors:     opts.RegistryMirrors,
+		RegistryConcurrency: opts.RegistryConcurrency,
+		SkipSBOM:            true,
+		OCIConcurrency:      opts.OCIConcurrency,
+		CachePath:           opts.CachePath,
 	}
 	pkgLayout, err := assemble.AssemblePackage(ctx, defined, packagePath, createOpts)
 	if err != nil {
@@ -151,6 +157,13 @@ func DevDeploy(ctx context.Context, packagePath string, opts DevDeployOptions) (
 			if err != nil {
 				return err
 			}
+			ctx, err = d.lock(ctx, fmt.Sprintf("zarf dev deploy %s", pkg.Metadata.Name), false)
+			if err != nil {
+				return err
+			}
+			defer func() {
+				err = errors.Join(err, d.unlock(context.WithoutCancel(ctx)))
+			}()
 			clusterState, err := d.c.LoadState(ctx)
 			if err != nil && !kerrors.IsNotFound(err) {
 				return err
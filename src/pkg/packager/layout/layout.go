@@ -54,6 +54,7 @@ const (
 	ManifestsComponentDir ComponentDir = "manifests"
 	DataComponentDir      ComponentDir = "data"
 	ValuesComponentDir    ComponentDir = "values"
+	ArtifactsComponentDir ComponentDir = "artifacts"
 )
 
 // ManifestFileName returns the file name, within a component's manifests directory, that stores the
@@ -128,6 +128,10 @@ func (p *PackageLayout) computeManifest(ctx context.Context) error {
 			if err != nil {
 				return fmt.Errorf("invalid checksum for %q: %w", rel, err)
 			}
+			if entry, ok := p.streamedEntry(filePath); ok {
+				fileSize = entry.Size
+				break
+			}
 			info, err := os.Stat(filePath)
 			if err != nil {
 				return err
@@ -269,6 +273,17 @@ func (p *PackageLayout) Fetch(_ context.Context, target ocispec.Descriptor) (io.
 		return io.NopCloser(bytes.NewReader(p.cache.configBytes)), nil
 	}
 	if filePath, ok := p.cache.blobs[target.Digest]; ok {
+		// Streamed blobs were only checked against their name when the package was loaded, so verify them as they are read
+		if entry, ok := p.streamedEntry(filePath); ok {
+			if entry.Size != target.Size {
+				return nil, fmt.Errorf("%s: size mismatch: %w", target.Digest, content.ErrMismatchedDigest)
+			}
+			rc, err := p.imageTar.Open(entry.Name)
+			if err != nil {
+				return nil, err
+			}
+			return images.NewVerifyingReadCloser(rc, target), nil
+		}
 		return os.Open(filePath)
 	}
 	return nil, errdef.ErrNotFound
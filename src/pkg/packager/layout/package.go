@@ -11,6 +11,7 @@ import (
 	"io/fs"
 	"maps"
 	"os"
+	"path"
 	"path/filepath"
 	"slices"
 	"strings"
@@ -25,6 +26,7 @@ import (
 	"github.com/zarf-dev/zarf/src/internal/pkgcfg"
 	"github.com/zarf-dev/zarf/src/internal/split"
 	"github.com/zarf-dev/zarf/src/pkg/archive"
+	"github.com/zarf-dev/zarf/src/pkg/images"
 	"github.com/zarf-dev/zarf/src/pkg/logger"
 	"github.com/zarf-dev/zarf/src/pkg/packager/filters"
 	"github.com/zarf-dev/zarf/src/pkg/signing"
@@ -38,6 +40,8 @@ type PackageLayout struct {
 	PackageDefinition api.PackageDefinition
 	digest            string
 	cache             *manifestCache
+	// imageTar indexes the package tarball when image blobs are read from it instead of the layout directory.
+	imageTar *archive.TarIndex
 }
 
 // Digest returns the OCI manifest digest for this package layout.
@@ -64,6 +68,9 @@ type PackageLayoutOptions struct {
 	IsPartial            bool
 	Filter               filters.ComponentFilterStrategy
 	VerifyBlobOptions    *signing.VerifyBlobOptions
+	// StreamImages leaves image blobs inside uncompressed package tarballs and reads them from the tarball when
+	// they are needed. It has no effect on compressed tarballs, which cannot be read at random.
+	StreamImages bool
 }
 
 // VerificationStrategy describes a strategy for determining whether to verify a package.
@@ -132,18 +139,32 @@ func LoadFromTar(ctx context.Context, tarPath string, opts PackageLayoutOptions)
 	if err != nil {
 		return nil, err
 	}
+	var imageTar *archive.TarIndex
+	decompressOpts := archive.DecompressOpts{}
+	if opts.StreamImages && strings.HasSuffix(tarPath, ".tar") {
+		imageTar, err = archive.IndexTar(tarPath)
+		if err != nil {
+			return nil, err
+		}
+		decompressOpts.SkipPrefixes = []string{filepath.ToSlash(ImagesBlobsDir) + "/"}
+		logger.From(ctx).Debug("reading image blobs from the package tarball", "path", tarPath)
+	}
 	// Decompress the archive
-	err = archive.Decompress(ctx, tarPath, dirPath, archive.DecompressOpts{})
+	err = archive.Decompress(ctx, tarPath, dirPath, decompressOpts)
 	if err != nil {
 		return nil, err
 	}
 
 	// 3) Delegate to the existing LoadFromDir
-	return LoadFromDir(ctx, dirPath, opts)
+	return loadFromDir(ctx, dirPath, imageTar, opts)
 }
 
 // LoadFromDir loads and validates a package from the given directory path.
 func LoadFromDir(ctx context.Context, dirPath string, opts PackageLayoutOptions) (*PackageLayout, error) {
+	return loadFromDir(ctx, dirPath, nil, opts)
+}
+
+func loadFromDir(ctx context.Context, dirPath string, imageTar *archive.TarIndex, opts PackageLayoutOptions) (*PackageLayout, error) {
 	l := logger.From(ctx)
 	if opts.Filter == nil {
 		opts.Filter = filters.Empty()
@@ -163,6 +184,7 @@ func LoadFromDir(ctx context.Context, dirPath string, opts PackageLayoutOptions)
 	pkgLayout := &PackageLayout{
 		dirPath:           dirPath,
 		PackageDefinition: definition,
+		imageTar:          imageTar,
 	}
 	err = validatePackageIntegrity(pkgLayout, opts.IsPartial)
 	if err != nil {
@@ -644,6 +666,61 @@ func (p *PackageLayout) HasImageIndex() (bool, error) {
 	return HasImageIndex(p.GetImageDirPath())
 }
 
+// ImagesStreamed reports whether image blobs are read from the package tarball rather than the images directory.
+func (p *PackageLayout) ImagesStreamed() bool {
+	return p.imageTar != nil
+}
+
+// GetImageTarStore returns a read-only OCI target over the images in the package tarball.
+// It is only available when ImagesStreamed is true.
+func (p *PackageLayout) GetImageTarStore() (*images.TarStore, error) {
+	if p.imageTar == nil {
+		return nil, errors.New("package images were extracted and are not streamed from the package tarball")
+	}
+	return images.NewTarStore(p.imageTar, ImagesDir)
+}
+
+// ExtractImages writes image blobs that are streamed from the package tarball into the images directory so
+// consumers that need the layout on disk can use GetImageDirPath. It is a no-op if the images are already extracted.
+func (p *PackageLayout) ExtractImages(ctx context.Context) error {
+	if p.imageTar == nil {
+		return nil
+	}
+	var names []string
+	for _, e := range p.imageTar.Entries(filepath.ToSlash(ImagesBlobsDir) + "/") {
+		names = append(names, e.Name)
+	}
+	if len(names) > 0 {
+		err := archive.Decompress(ctx, p.imageTar.Path(), p.dirPath, archive.DecompressOpts{Files: names})
+		if err != nil {
+			return fmt.Errorf("unable to extract images from %s: %w", p.imageTar.Path(), err)
+		}
+		// The blobs were only checked against their names when the package was loaded
+		for _, name := range names {
+			if path.Base(path.Dir(name)) != "sha256" {
+				continue
+			}
+			if err := helpers.SHAsMatch(filepath.Join(p.dirPath, filepath.FromSlash(name)), path.Base(name)); err != nil {
+				return fmt.Errorf("unable to verify image blob %s: %w", name, err)
+			}
+		}
+	}
+	p.imageTar = nil
+	return nil
+}
+
+// streamedEntry returns the tarball entry backing the given package path if it is not extracted to the layout directory.
+func (p *PackageLayout) streamedEntry(path string) (archive.TarEntry, bool) {
+	if p.imageTar == nil {
+		return archive.TarEntry{}, false
+	}
+	rel, err := filepath.Rel(p.dirPath, path)
+	if err != nil || !strings.HasPrefix(rel, ImagesBlobsDir+string(filepath.Separator)) {
+		return archive.TarEntry{}, false
+	}
+	return p.imageTar.Stat(filepath.ToSlash(rel))
+}
+
 // Archive creates a tarball from the package layout and returns the path to that tarball
 func (p *PackageLayout) Archive(ctx context.Context, dirPath string, maxPackageSize int) (string, error) {
 	filename, err := p.FileName()
@@ -656,6 +733,10 @@ func (p *PackageLayout) Archive(ctx context.Context, dirPath string, maxPackageS
 		return "", err
 	}
 
+	if p.imageTar != nil {
+		return "", fmt.Errorf("package images are streamed from %s and cannot be archived", p.imageTar.Path())
+	}
+
 	logger.From(ctx).Info("writing package to disk", "path", tarballPath)
 	files, err := os.ReadDir(p.dirPath)
 	if err != nil {
@@ -707,6 +788,11 @@ func (p *PackageLayout) Files() (map[string]string, error) {
 	if err != nil {
 		return nil, err
 	}
+	if p.imageTar != nil {
+		for _, e := range p.imageTar.Entries(filepath.ToSlash(ImagesBlobsDir) + "/") {
+			files[filepath.Join(p.dirPath, filepath.FromSlash(e.Name))] = e.Name
+		}
+	}
 	return files, nil
 }
 
@@ -811,6 +897,16 @@ func validatePackageIntegrity(pkgLayout *PackageLayout, isPartial bool) error {
 		if !ok {
 			return fmt.Errorf("file %s from checksum missing in layout", rel)
 		}
+		// Streamed image blobs are content addressed, so only check that the checksum agrees with the blob name
+		// instead of reading the whole tarball here. Their content is verified when it is read through Fetch or
+		// GetImageTarStore, and when it is extracted with ExtractImages.
+		if entry, streamed := pkgLayout.streamedEntry(path); streamed {
+			if filepath.Base(entry.Name) != sha {
+				return fmt.Errorf("expected sha256 of %s to be %s, found %s", rel, sha, filepath.Base(entry.Name))
+			}
+			delete(packageFiles, path)
+			continue
+		}
 		err = helpers.SHAsMatch(path, sha)
 		if err != nil {
 			return err
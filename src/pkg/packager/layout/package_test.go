@@ -4,17 +4,24 @@
 package layout
 
 import (
+	"archive/tar"
 	"context"
 	"crypto/sha256"
 	"encoding/hex"
+	"errors"
+	"io"
 	"os"
+	"path"
 	"path/filepath"
 	"strings"
 	"testing"
 
 	goyaml "github.com/goccy/go-yaml"
+	godigest "github.com/opencontainers/go-digest"
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
 	"github.com/sigstore/cosign/v3/pkg/cosign"
 	"github.com/stretchr/testify/require"
+	"oras.land/oras-go/v2/content"
 
 	"github.com/zarf-dev/zarf/src/api"
 	"github.com/zarf-dev/zarf/src/api/v1alpha1"
@@ -87,6 +94,101 @@ func TestPackageLayout(t *testing.T) {
 	}
 }
 
+func TestPackageLayoutStreamImages(t *testing.T) {
+	t.Parallel()
+
+	ctx := testutil.TestContext(t)
+	tarPath := filepath.Join("..", "testdata", "load-package", "uncompressed", "zarf-package-test-uncompressed-amd64-0.0.1.tar")
+
+	extracted, err := LoadFromTar(ctx, tarPath, PackageLayoutOptions{})
+	require.NoError(t, err)
+	t.Cleanup(func() { require.NoError(t, extracted.Cleanup()) })
+	require.False(t, extracted.ImagesStreamed())
+
+	pkgLayout, err := LoadFromTar(ctx, tarPath, PackageLayoutOptions{StreamImages: true})
+	require.NoError(t, err)
+	t.Cleanup(func() { require.NoError(t, pkgLayout.Cleanup()) })
+	require.True(t, pkgLayout.ImagesStreamed())
+	require.Equal(t, extracted.Digest(), pkgLayout.Digest())
+
+	blobs, err := os.ReadDir(filepath.Join(pkgLayout.DirPath(), ImagesBlobsDir))
+	require.ErrorIs(t, err, os.ErrNotExist)
+	require.Empty(t, blobs)
+	require.FileExists(t, filepath.Join(pkgLayout.DirPath(), IndexPath))
+
+	store, err := pkgLayout.GetImageTarStore()
+	require.NoError(t, err)
+	desc, err := store.Resolve(ctx, pkgLayout.AsV1alpha1().Components[0].GetImages()[0])
+	require.NoError(t, err)
+	ok, err := store.Exists(ctx, desc)
+	require.NoError(t, err)
+	require.True(t, ok)
+
+	_, err = pkgLayout.Archive(ctx, t.TempDir(), 0)
+	require.ErrorContains(t, err, "cannot be archived")
+
+	require.NoError(t, pkgLayout.ExtractImages(ctx))
+	require.False(t, pkgLayout.ImagesStreamed())
+	blobs, err = os.ReadDir(filepath.Join(pkgLayout.DirPath(), ImagesBlobsDir))
+	require.NoError(t, err)
+	require.Len(t, blobs, 3)
+
+	// Compressed tarballs cannot be read at random so their images are always extracted.
+	compressed, err := LoadFromTar(ctx, filepath.Join("..", "testdata", "load-package", "compressed", "zarf-package-test-amd64-0.0.1.tar.zst"), PackageLayoutOptions{StreamImages: true})
+	require.NoError(t, err)
+	t.Cleanup(func() { require.NoError(t, compressed.Cleanup()) })
+	require.False(t, compressed.ImagesStreamed())
+}
+
+func TestPackageLayoutStreamImagesVerifiesBlobs(t *testing.T) {
+	t.Parallel()
+
+	ctx := testutil.TestContext(t)
+	const layer = "66a3d608f3fa52124f8463e9467f170c784abd549e8216aa45c6960b00b4b79b"
+
+	// Flip a byte of the layer while keeping its name, size and the package checksums unchanged
+	src, err := os.Open(filepath.Join("..", "testdata", "load-package", "uncompressed", "zarf-package-test-uncompressed-amd64-0.0.1.tar"))
+	require.NoError(t, err)
+	defer src.Close()
+	tarPath := filepath.Join(t.TempDir(), "zarf-package-test-uncompressed-amd64-0.0.1.tar")
+	dst, err := os.Create(tarPath)
+	require.NoError(t, err)
+	tr := tar.NewReader(src)
+	tw := tar.NewWriter(dst)
+	for {
+		hdr, err := tr.Next()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		require.NoError(t, err)
+		b, err := io.ReadAll(tr)
+		require.NoError(t, err)
+		if path.Base(hdr.Name) == layer {
+			b[0] ^= 0xff
+		}
+		require.NoError(t, tw.WriteHeader(hdr))
+		_, err = tw.Write(b)
+		require.NoError(t, err)
+	}
+	require.NoError(t, tw.Close())
+	require.NoError(t, dst.Close())
+
+	pkgLayout, err := LoadFromTar(ctx, tarPath, PackageLayoutOptions{StreamImages: true})
+	require.NoError(t, err)
+	t.Cleanup(func() { require.NoError(t, pkgLayout.Cleanup()) })
+	require.True(t, pkgLayout.ImagesStreamed())
+
+	desc := ocispec.Descriptor{Digest: godigest.Digest("sha256:" + layer), Size: 3626260}
+	rc, err := pkgLayout.Fetch(ctx, desc)
+	require.NoError(t, err)
+	_, err = io.Copy(io.Discard, rc)
+	require.ErrorIs(t, err, content.ErrMismatchedDigest)
+	require.NoError(t, rc.Close())
+
+	err = pkgLayout.ExtractImages(ctx)
+	require.ErrorContains(t, err, "unable to verify image blob")
+}
+
 func TestPackageLayoutLoadFromDirPreservesMultiDocDefinition(t *testing.T) {
 	t.Parallel()
 
@@ -45,6 +45,9 @@ type LoadOptions struct {
 	CachePath string
 	// Connected skips pulling image layers from OCI sources
 	Connected bool
+	// StreamImages reads image blobs from local uncompressed package tarballs when they are pushed instead of
+	// extracting them to disk first
+	StreamImages bool
 	// Only applicable to OCI + HTTP
 	types.RemoteOptions
 	// VerificationStrategy for explicit definition
@@ -149,6 +152,8 @@ func LoadPackage(ctx context.Context, source string, opts LoadOptions) (_ *layou
 		VerifyBlobOptions:    opts.VerifyBlobOptions,
 		VerificationStrategy: opts.VerificationStrategy,
 		Filter:               opts.Filter,
+		// Only local tarballs outlive this function, the downloaded and reassembled ones are removed with tmpDir.
+		StreamImages: opts.StreamImages && srcType == "tarball",
 	}
 	pkgLayout, err := layout.LoadFromTar(ctx, tmpPath, layoutOpts)
 	if err != nil {
@@ -447,6 +447,10 @@ func overrideResources(comp v1alpha1.ZarfComponent, override v1alpha1.ZarfCompon
 	comp.Files = append(comp.Files, override.Files...)
 	comp.Images = append(comp.Images, override.Images...)
 	comp.Repos = append(comp.Repos, override.Repos...)
+	comp.RecursiveSubmodules = append(comp.RecursiveSubmodules, override.RecursiveSubmodules...)
+	comp.SkipLFSRepos = append(comp.SkipLFSRepos, override.SkipLFSRepos...)
+	comp.VerifyRepos = append(comp.VerifyRepos, override.VerifyRepos...)
+	comp.Artifacts = append(comp.Artifacts, override.Artifacts...)
 
 	// Merge charts with the same name to keep them unique
 	for _, overrideChart := range override.Charts {
@@ -498,6 +502,10 @@ func overrideResources(comp v1alpha1.ZarfComponent, override v1alpha1.ZarfCompon
 		}
 	}
 
+	if override.MirrorCharts {
+		comp.MirrorCharts = true
+	}
+
 	comp.HealthChecks = append(comp.HealthChecks, override.HealthChecks...)
 	comp.ImageArchives = append(comp.ImageArchives, override.ImageArchives...)
 
@@ -525,6 +533,13 @@ func fixPaths(child v1alpha1.ZarfComponent, relativeToHead, packagePath string)
 		child.ImageArchives[idx].Path = composed
 	}
 
+	for verifyIdx, verify := range child.VerifyRepos {
+		for keyIdx, key := range verify.Keys {
+			composed := makePathRelativeTo(key, relativeToHead)
+			child.VerifyRepos[verifyIdx].Keys[keyIdx] = composed
+		}
+	}
+
 	for chartIdx, chart := range child.Charts {
 		for valuesIdx, valuesFile := range chart.ValuesFiles {
 			composed := makePathRelativeTo(valuesFile, relativeToHead)
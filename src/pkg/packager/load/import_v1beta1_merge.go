@@ -25,10 +25,14 @@ func mergeComponentSpec(imported, override v1beta1.ComponentSpec) v1beta1.Compon
 	if override.Service != "" {
 		merged.Service = override.Service
 	}
+	if override.MirrorCharts {
+		merged.MirrorCharts = true
+	}
 
 	merged.Files = append(merged.Files, override.Files...)
 	merged.ImageArchives = append(merged.ImageArchives, override.ImageArchives...)
 	merged.Repositories = append(merged.Repositories, override.Repositories...)
+	merged.Artifacts = append(merged.Artifacts, override.Artifacts...)
 	merged.StateAccess = append(merged.StateAccess, override.StateAccess...)
 
 	merged.Images = mergeImages(merged.Images, override.Images)
@@ -174,6 +178,14 @@ func fixPathsV1Beta1(spec v1beta1.ComponentSpec, relativeToHead string) v1beta1.
 	for i := range spec.ImageArchives {
 		spec.ImageArchives[i].Path = makePathRelativeTo(spec.ImageArchives[i].Path, relativeToHead)
 	}
+	for i := range spec.Repositories {
+		if spec.Repositories[i].Verify == nil {
+			continue
+		}
+		for j := range spec.Repositories[i].Verify.Keys {
+			spec.Repositories[i].Verify.Keys[j] = makePathRelativeTo(spec.Repositories[i].Verify.Keys[j], relativeToHead)
+		}
+	}
 	for i := range spec.Charts {
 		if spec.Charts[i].Local != nil {
 			spec.Charts[i].Local.Path = makePathRelativeTo(spec.Charts[i].Local.Path, relativeToHead)
@@ -8,15 +8,22 @@ import (
 	"errors"
 	"fmt"
 	"os"
+	"path"
+	"path/filepath"
+	"slices"
+	"strconv"
 	"time"
 
 	"github.com/avast/retry-go/v4"
+	"oras.land/oras-go/v2/content/memory"
 
 	"github.com/zarf-dev/zarf/src/api/v1alpha1"
 	"github.com/zarf-dev/zarf/src/config"
+	"github.com/zarf-dev/zarf/src/internal/artifact"
 	"github.com/zarf-dev/zarf/src/internal/dns"
 	"github.com/zarf-dev/zarf/src/internal/git"
-	"github.com/zarf-dev/zarf/src/internal/gitea"
+	"github.com/zarf-dev/zarf/src/internal/gitprovider"
+	"github.com/zarf-dev/zarf/src/internal/packager/helm"
 	"github.com/zarf-dev/zarf/src/pkg/cluster"
 	"github.com/zarf-dev/zarf/src/pkg/images"
 	"github.com/zarf-dev/zarf/src/pkg/logger"
@@ -68,17 +75,64 @@ func PushImagesToRegistry(ctx context.Context, pkgLayout *layout.PackageLayout,
 		InsecureSkipTLSVerify: opts.InsecureSkipTLSVerify,
 		Cluster:               opts.Cluster,
 	}
-	err := images.Push(ctx, refs, pkgLayout.GetImageDirPath(), registryInfo, pushOpts)
+	err := pushPackageImages(ctx, pkgLayout, refs, registryInfo, pushOpts)
 	if err != nil {
 		return fmt.Errorf("failed to push images: %w", err)
 	}
 	return nil
 }
 
+// pushPackageImages pushes images from the package tarball when they are streamed, and from the images directory otherwise.
+func pushPackageImages(ctx context.Context, pkgLayout *layout.PackageLayout, refs []transform.Image, registryInfo state.RegistryInfo, pushOpts images.PushOptions) error {
+	if !pkgLayout.ImagesStreamed() {
+		return images.Push(ctx, refs, pkgLayout.GetImageDirPath(), registryInfo, pushOpts)
+	}
+	src, err := pkgLayout.GetImageTarStore()
+	if err != nil {
+		return err
+	}
+	return images.PushFromTarget(ctx, refs, src, registryInfo, pushOpts)
+}
+
+// pushComponentCharts pushes the packaged charts of a component to the registry as Helm OCI artifacts.
+func pushComponentCharts(ctx context.Context, pkgLayout *layout.PackageLayout, component v1alpha1.ZarfComponent, registryInfo state.RegistryInfo, pushOpts images.PushOptions) (err error) {
+	tmpDir, err := utils.MakeTempDir(config.CommonOptions.TempDirectory)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		err = errors.Join(err, os.RemoveAll(tmpDir))
+	}()
+	chartDir, err := pkgLayout.GetComponentDir(ctx, tmpDir, component.Name, layout.ChartsComponentDir)
+	if err != nil {
+		return err
+	}
+	store := memory.New()
+	refs := []transform.Image{}
+	for _, chart := range component.Charts {
+		chartRef, err := helm.StoreChartArtifact(ctx, store, chart, layout.ChartPaths{ChartsDir: chartDir}.Archive(chart.Name, chart.Version))
+		if err != nil {
+			return fmt.Errorf("unable to create an OCI artifact for chart %s: %w", chart.Name, err)
+		}
+		ref, err := transform.ParseImageRef(chartRef)
+		if err != nil {
+			return err
+		}
+		refs = append(refs, ref)
+	}
+	return images.PushFromTarget(ctx, refs, store, registryInfo, pushOpts)
+}
+
 // RepoPushOptions are optional parameters to push repos in a zarf package to a Git server
 type RepoPushOptions struct {
 	Cluster *cluster.Cluster
 	Retries int
+	// NoForce refuses to push refs that are not a fast-forward of the git server instead of force pushing them
+	NoForce bool
+	// Organization pushes the repos into this organization of the git server instead of the account of the push user
+	Organization string
+	// InsecureSkipTLSVerify skips verifying the certificate of the git server when uploading Git LFS objects
+	InsecureSkipTLSVerify bool
 }
 
 // PushReposToRepository pushes Git repositories in the package layout to the Git server
@@ -93,7 +147,7 @@ func PushReposToRepository(ctx context.Context, pkgLayout *layout.PackageLayout,
 		return fmt.Errorf("git server address must be specified")
 	}
 	for _, component := range pkgLayout.AsV1alpha1().Components {
-		err := pushComponentReposToRegistry(ctx, component, pkgLayout, gitInfo, opts.Cluster, opts.Retries)
+		_, err := pushComponentReposToRegistry(ctx, component, pkgLayout, gitInfo, opts)
 		if err != nil {
 			return err
 		}
@@ -101,81 +155,156 @@ func PushReposToRepository(ctx context.Context, pkgLayout *layout.PackageLayout,
 	return nil
 }
 
+// pushComponentReposToRegistry pushes the repos of a component and returns the owner/name paths they were pushed to.
 func pushComponentReposToRegistry(ctx context.Context, component v1alpha1.ZarfComponent,
-	pkgLayout *layout.PackageLayout, gitInfo state.GitServerInfo, c *cluster.Cluster, retries int) (err error) {
+	pkgLayout *layout.PackageLayout, gitInfo state.GitServerInfo, opts RepoPushOptions) (_ []string, err error) {
 	l := logger.From(ctx)
+	owner := gitInfo.PushUsername
+	if opts.Organization != "" {
+		owner = opts.Organization
+	}
+	lfsClient, err := git.NewLFSHTTPClient(opts.InsecureSkipTLSVerify)
+	if err != nil {
+		return nil, err
+	}
+	pushed := []string{}
 	for _, repoURL := range component.Repos {
 		tmpDir, err := utils.MakeTempDir(config.CommonOptions.TempDirectory)
 		if err != nil {
-			return err
+			return nil, err
 		}
 		defer func() {
 			err = errors.Join(err, os.RemoveAll(tmpDir))
 		}()
 		reposPath, err := pkgLayout.GetComponentDir(ctx, tmpDir, component.Name, layout.RepoComponentDir)
 		if err != nil {
-			return err
+			return nil, err
 		}
-		repository, err := git.Open(reposPath, repoURL)
+		parent, err := git.Open(reposPath, repoURL)
 		if err != nil {
-			return err
+			return nil, err
 		}
-		err = retry.Do(func() error {
-			if !dns.IsServiceURL(gitInfo.Address) {
-				l.Info("pushing repository to server", "repo", repoURL, "server", gitInfo.Address)
-				err = repository.Push(ctx, gitInfo.Address, gitInfo.PushUsername, gitInfo.PushPassword)
-				if err != nil {
-					return err
-				}
-				return nil
-			}
-
-			if c == nil {
-				return retry.Unrecoverable(errors.New("cannot push to internal Git server when cluster is nil"))
-			}
-			namespace, name, port, err := dns.ParseServiceURL(gitInfo.Address)
-			if err != nil {
-				return retry.Unrecoverable(err)
-			}
-			tunnel, err := c.NewTunnel(namespace, cluster.SvcResource, name, "", 0, port)
+		recursive := slices.Contains(component.RecursiveSubmodules, repoURL)
+		addresses := []string{repoURL}
+		if recursive {
+			submodules, err := parent.PackagedSubmodules(reposPath, repoURL)
 			if err != nil {
-				return err
+				return nil, err
 			}
-			_, err = tunnel.Connect(ctx)
+			addresses = append(addresses, submodules...)
+		}
+		for _, address := range addresses {
+			repository, err := git.Open(reposPath, address)
 			if err != nil {
-				return err
+				return nil, err
 			}
-			defer tunnel.Close()
-			// tunnel is create with the default listenAddress - there will only be one endpoint until otherwise supported
-			endpoints := tunnel.HTTPEndpoints()
-			if len(endpoints) == 0 {
-				return errors.New("no tunnel endpoints found")
+			if recursive {
+				// In-cluster clones reach the git server through its service address rather than the tunnel
+				if err := repository.RewriteSubmodules(address, gitInfo.Address, owner); err != nil {
+					return nil, fmt.Errorf("unable to rewrite the submodules of repo %s: %w", address, err)
+				}
 			}
-			giteaClient, err := gitea.NewClient(endpoints[0], gitInfo.PushUsername, gitInfo.PushPassword)
+			repoName, err := transform.GitURLtoRepoName(address)
 			if err != nil {
-				return err
+				return nil, err
 			}
-			return tunnel.Wrap(func() error {
-				l.Info("pushing repository to server", "repo", repoURL, "server", endpoints[0])
-				err = repository.Push(ctx, endpoints[0], gitInfo.PushUsername, gitInfo.PushPassword)
+			pushRepo := func(endpoint string, provider gitprovider.Provider) error {
+				if opts.Organization != "" {
+					orgProvider, ok := provider.(gitprovider.OrganizationProvider)
+					if !ok {
+						return retry.Unrecoverable(fmt.Errorf("the %s git provider does not support organizations", gitInfo.EffectiveProvider()))
+					}
+					// The pull user reads the repos of the organization through its read only team
+					if err := orgProvider.CreateOrganization(ctx, opts.Organization, gitInfo.PullUsername); err != nil {
+						return fmt.Errorf("unable to create the organization %s: %w", opts.Organization, err)
+					}
+					if err := orgProvider.CreateOrgRepository(ctx, opts.Organization, repoName); err != nil {
+						return fmt.Errorf("unable to create the repo %s: %w", repoName, err)
+					}
+				} else if err := provider.CreateRepository(ctx, repoName); err != nil {
+					return fmt.Errorf("unable to create the repo %s: %w", repoName, err)
+				}
+				l.Info("pushing repository to server", "repo", address, "server", endpoint, "owner", owner)
+				report, err := repository.Push(ctx, endpoint, gitInfo.PushUsername, gitInfo.PushPassword, git.PushOptions{NoForce: opts.NoForce, Owner: owner, LFSClient: lfsClient, ServerAddress: gitInfo.Address})
+				if errors.Is(err, git.ErrNonFastForward) {
+					return retry.Unrecoverable(err)
+				}
 				if err != nil {
 					return err
 				}
+				l.Info("pushed repository refs", "repo", address, "created", report.Created, "updated", report.Updated,
+					"forced", report.Forced, "unchanged", len(report.Unchanged))
 				// Add the read-only user to this repo
-				// TODO: This should not be done here. Or the function name should be changed.
-				repoName, err := transform.GitURLtoRepoName(repoURL)
-				if err != nil {
-					return retry.Unrecoverable(err)
+				if opts.Organization != "" || gitInfo.PullUsername == "" || gitInfo.PullUsername == gitInfo.PushUsername {
+					return nil
 				}
-				err = giteaClient.AddReadOnlyUserToRepository(ctx, repoName, gitInfo.PullUsername)
+				err = provider.AddReadOnlyUserToRepository(ctx, repoName, gitInfo.PullUsername)
 				if err != nil {
 					return fmt.Errorf("unable to add the read only user to the repo %s: %w", repoName, err)
 				}
 				return nil
-			})
+			}
+			err = retry.Do(func() error {
+				if !dns.IsServiceURL(gitInfo.Address) {
+					provider, err := gitprovider.New(gitInfo, gitInfo.Address)
+					if err != nil {
+						return retry.Unrecoverable(err)
+					}
+					return pushRepo(gitInfo.Address, provider)
+				}
+				if opts.Cluster == nil {
+					return retry.Unrecoverable(errors.New("cannot push to internal Git server when cluster is nil"))
+				}
+				return opts.Cluster.WithGitProvider(ctx, gitInfo, pushRepo)
+			}, retry.Context(ctx), retry.Attempts(uint(opts.Retries)), retry.Delay(500*time.Millisecond))
+			if err != nil {
+				return nil, fmt.Errorf("unable to push repo %s to the Git Server: %w", address, err)
+			}
+			pushed = append(pushed, path.Join(owner, repoName))
+		}
+	}
+	return pushed, nil
+}
+
+func pushComponentArtifacts(ctx context.Context, component v1alpha1.ZarfComponent, pkgLayout *layout.PackageLayout,
+	artifactServer state.ArtifactServerInfo, c *cluster.Cluster, retries int, insecureSkipTLSVerify bool) (err error) {
+	if !artifactServer.IsConfigured() {
+		return errors.New("no artifact server is configured")
+	}
+	if retries == 0 {
+		retries = config.ZarfDefaultRetries
+	}
+	tmpDir, err := utils.MakeTempDir(config.CommonOptions.TempDirectory)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		err = errors.Join(err, os.RemoveAll(tmpDir))
+	}()
+	artifactsPath, err := pkgLayout.GetComponentDir(ctx, tmpDir, component.Name, layout.ArtifactsComponentDir)
+	if err != nil {
+		return err
+	}
+	client, err := artifact.NewHTTPClient(insecureSkipTLSVerify)
+	if err != nil {
+		return err
+	}
+	for idx, a := range component.Artifacts {
+		src := filepath.Join(artifactsPath, strconv.Itoa(idx))
+		pushArtifact := func(address string) error {
+			return artifact.Push(ctx, client, a, src, address, artifactServer.PushUsername, artifactServer.PushToken)
+		}
+		err := retry.Do(func() error {
+			if !dns.IsServiceURL(artifactServer.Address) {
+				return pushArtifact(artifactServer.Address)
+			}
+			if c == nil {
+				return retry.Unrecoverable(errors.New("cannot push to internal artifact server when cluster is nil"))
+			}
+			return c.WithArtifactServer(ctx, artifactServer, pushArtifact)
 		}, retry.Context(ctx), retry.Attempts(uint(retries)), retry.Delay(500*time.Millisecond))
 		if err != nil {
-			return fmt.Errorf("unable to push repo %s to the Git Server: %w", repoURL, err)
+			return fmt.Errorf("unable to push %s artifact %s: %w", a.Ecosystem, a.Name, err)
 		}
 	}
 	return nil
@@ -37,12 +37,14 @@ type RemoveOptions struct {
 	Timeout           time.Duration
 	NamespaceOverride string
 	SkipVersionCheck  bool
+	// OverrideLock takes the cluster-wide deploy lock even when it is held by another operation
+	OverrideLock bool
 	// Values passed in at remove time. They can come from the CLI or set directly by API callers.
 	value.Values
 }
 
 // Remove removes a package that was already deployed onto a cluster, uninstalling all installed helm charts.
-func Remove(ctx context.Context, definition api.PackageDefinition, opts RemoveOptions) error {
+func Remove(ctx context.Context, definition api.PackageDefinition, opts RemoveOptions) (err error) {
 	l := logger.From(ctx)
 	pkg := definition.AsV1alpha1()
 
@@ -57,7 +59,7 @@ func Remove(ctx context.Context, definition api.PackageDefinition, opts RemoveOp
 		opts.Timeout = config.ZarfDefaultTimeout
 	}
 
-	definition, err := filters.Apply(definition, filters.ByLocalOS(runtime.GOOS))
+	definition, err = filters.Apply(definition, filters.ByLocalOS(runtime.GOOS))
 	if err != nil {
 		return err
 	}
@@ -94,7 +96,18 @@ func Remove(ctx context.Context, definition api.PackageDefinition, opts RemoveOp
 	// Get or build the secret for the deployed package
 	depPkg := &state.DeployedPackage{}
 	if requiresCluster {
-		var err error
+		lockCtx, release, lockErr := opts.Cluster.AcquireDeployLock(ctx, cluster.DeployLockOptions{
+			Operation: fmt.Sprintf("zarf package remove %s", pkg.Metadata.Name),
+			Override:  opts.OverrideLock,
+		})
+		if lockErr != nil {
+			return lockErr
+		}
+		ctx = lockCtx
+		defer func() {
+			// Release the lock even when the remove was cancelled
+			err = errors.Join(err, release(context.WithoutCancel(ctx)))
+		}()
 		depPkg, err = opts.Cluster.GetDeployedPackage(ctx, pkg.Metadata.Name, state.WithPackageNamespaceOverride(opts.NamespaceOverride))
 		if err != nil {
 			return fmt.Errorf("unable to load the secret for the package we are attempting to remove: %w", err)
@@ -5,6 +5,7 @@
 package pki
 
 import (
+	"bytes"
 	"context"
 	"crypto/rand"
 	"crypto/rsa"
@@ -40,6 +41,8 @@ type GeneratedPKI struct {
 	CA   []byte `json:"ca"`
 	Cert []byte `json:"cert"`
 	Key  []byte `json:"key"`
+	// CAKey is the key of a CA that Zarf generated, kept so that the certificate can be reissued from the same CA.
+	CAKey []byte `json:"caKey,omitempty"`
 }
 
 // GeneratePKI create a CA and signed server keypair.
@@ -80,9 +83,43 @@ func generatePKI(host string, notAfter time.Time, dnsNames ...string) (Generated
 	results.CA = encodeCertToPEM(ca)
 	results.Cert = encodeCertToPEM(hostCert)
 	results.Key = encodeKeyToPEM(hostKey)
+	results.CAKey = encodeKeyToPEM(caKey)
 	return results, nil
 }
 
+// ReissuePKI issues a new keypair for host from the CA of pk, which must include the CA key. The CA is unchanged so
+// clients that trust it keep trusting the new certificate. The certificate expires with the CA at the latest.
+func ReissuePKI(pk GeneratedPKI, host string, dnsNames ...string) (GeneratedPKI, error) {
+	if len(pk.CAKey) == 0 {
+		return GeneratedPKI{}, errors.New("the CA key is not available")
+	}
+	ca, caKey, err := parseCAFromPEM(pk.CA, pk.CAKey)
+	if err != nil {
+		return GeneratedPKI{}, err
+	}
+	caPublicKey, ok := ca.PublicKey.(*rsa.PublicKey)
+	if !ok || !caPublicKey.Equal(&caKey.PublicKey) {
+		return GeneratedPKI{}, errors.New("the CA key does not match the CA certificate")
+	}
+	notAfter := now().Add(validFor)
+	if ca.NotAfter.Before(notAfter) {
+		notAfter = ca.NotAfter
+	}
+	if !notAfter.After(now()) {
+		return GeneratedPKI{}, fmt.Errorf("the CA expired on %s", ca.NotAfter)
+	}
+	hostCert, hostKey, err := generateServerCert(host, ca, caKey, notAfter, dnsNames...)
+	if err != nil {
+		return GeneratedPKI{}, fmt.Errorf("unable to generate the cert for %s: %w", host, err)
+	}
+	return GeneratedPKI{
+		CA:    pk.CA,
+		CAKey: pk.CAKey,
+		Cert:  encodeCertToPEM(hostCert),
+		Key:   encodeKeyToPEM(hostKey),
+	}, nil
+}
+
 // newCertificate creates a new template.
 func newCertificate(notAfter time.Time) (*x509.Certificate, error) {
 	serialNumberLimit := new(big.Int).Lsh(big.NewInt(1), 128)
@@ -113,8 +150,8 @@ func newPrivateKey() (*rsa.PrivateKey, error) {
 
 // generateCA creates a new CA certificate, saves the certificate
 // and returns the x509 certificate and crypto private key. This
-// private key should never be saved to disk, but rather used to
-// immediately generate further certificates.
+// private key should never be saved to disk, it is only kept in
+// the Zarf state so that certificates can be reissued from the CA.
 func generateCA(notAfter time.Time) (*x509.Certificate, *rsa.PrivateKey, error) {
 	template, err := newCertificate(notAfter)
 	if err != nil {
@@ -335,6 +372,12 @@ func GetRemainingCertLifePercentage(certData []byte) (float64, error) {
 
 // CheckForExpiredCert checks if the certificate is expired
 func CheckForExpiredCert(ctx context.Context, pk GeneratedPKI) error {
+	return CheckForExpiringCert(ctx, pk, 0)
+}
+
+// CheckForExpiringCert checks if the certificate is expired and warns when less than 20% of its lifetime or less than
+// threshold remains.
+func CheckForExpiringCert(ctx context.Context, pk GeneratedPKI, threshold time.Duration) error {
 	cert, err := ParseCertFromPEM(pk.Cert)
 	if err != nil {
 		return err
@@ -349,12 +392,28 @@ func CheckForExpiredCert(ctx context.Context, pk GeneratedPKI) error {
 		return err
 	}
 
-	if remainingLife < 20 {
+	if remainingLife < 20 || cert.NotAfter.Sub(now()) < threshold {
 		logger.From(ctx).Warn("the Zarf agent certificate is expiring soon, run `zarf tools update-creds agent` to update", "expiration", cert.NotAfter)
 	}
 	return nil
 }
 
+// RotationCABundle returns a CA bundle that trusts both newCA and the current CA, the first certificate of
+// currentBundle. Serving the bundle while certificates signed by either CA are in use lets the agent certificate be
+// replaced without a window in which the webhook is untrusted. Older CAs from previous rotations are dropped.
+func RotationCABundle(newCA, currentBundle []byte) ([]byte, error) {
+	if _, err := ParseCertFromPEM(newCA); err != nil {
+		return nil, fmt.Errorf("invalid new CA: %w", err)
+	}
+	current, err := ParseCertFromPEM(currentBundle)
+	if err != nil {
+		return nil, fmt.Errorf("invalid current CA: %w", err)
+	}
+	bundle := bytes.TrimRight(newCA, "\n")
+	bundle = append(bundle, '\n')
+	return append(bundle, encodeCertToPEM(current)...), nil
+}
+
 // GenerateMTLSCerts generates a complete set of mTLS certificates including CA, server cert, and client cert.
 // Returns two GeneratedPKI structs: one for the server (containing server cert, key, and CA) and one for the client (containing client cert, key, and CA).
 func GenerateMTLSCerts(caSubject string, serverDNSNames []string, serverCommonName string, clientCommonName string) (server GeneratedPKI, client GeneratedPKI, err error) {
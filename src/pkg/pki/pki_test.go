@@ -24,6 +24,7 @@ func TestCheckForExpiredCert1(t *testing.T) {
 		timeAtCreation time.Time
 		certExpiration time.Time
 		timeAtCheck    time.Time
+		threshold      time.Duration
 		expectedLog    string
 		expectedErr    string
 	}{
@@ -40,6 +41,14 @@ func TestCheckForExpiredCert1(t *testing.T) {
 			certExpiration: time.Date(2025, 1, 1, 0, 5, 0, 0, time.UTC),
 			expectedLog:    "the Zarf agent certificate is expiring soon, run `zarf tools update-creds agent` to update",
 		},
+		{
+			name:           "less than the threshold left -> warning",
+			timeAtCreation: time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC),
+			timeAtCheck:    time.Date(2025, 1, 1, 0, 2, 0, 0, time.UTC),
+			certExpiration: time.Date(2025, 1, 1, 0, 5, 0, 0, time.UTC),
+			threshold:      5 * time.Minute,
+			expectedLog:    "the Zarf agent certificate is expiring soon, run `zarf tools update-creds agent` to update",
+		},
 		{
 			name:           "already expired -> error",
 			timeAtCreation: time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC),
@@ -69,7 +78,7 @@ func TestCheckForExpiredCert1(t *testing.T) {
 
 			// Check cert with fixed time
 			now = func() time.Time { return tt.timeAtCheck }
-			err = CheckForExpiredCert(ctx, pki)
+			err = CheckForExpiringCert(ctx, pki, tt.threshold)
 			if tt.expectedErr != "" {
 				require.ErrorContains(t, err, tt.expectedErr)
 				return
@@ -83,6 +92,78 @@ func TestCheckForExpiredCert1(t *testing.T) {
 	}
 }
 
+func TestRotationCABundle(t *testing.T) {
+	originalNow := now
+	defer func() { now = originalNow }()
+	now = time.Now
+
+	current, err := GeneratePKI("localhost")
+	require.NoError(t, err)
+	next, err := GeneratePKI("localhost")
+	require.NoError(t, err)
+
+	bundle, err := RotationCABundle(next.CA, current.CA)
+	require.NoError(t, err)
+	for _, pk := range []GeneratedPKI{current, next} {
+		pool := x509.NewCertPool()
+		require.True(t, pool.AppendCertsFromPEM(bundle))
+		cert, err := ParseCertFromPEM(pk.Cert)
+		require.NoError(t, err)
+		_, err = cert.Verify(x509.VerifyOptions{Roots: pool, DNSName: "localhost"})
+		require.NoError(t, err)
+	}
+
+	// A second rotation only keeps the CA that was current.
+	last, err := GeneratePKI("localhost")
+	require.NoError(t, err)
+	bundle, err = RotationCABundle(last.CA, bundle)
+	require.NoError(t, err)
+	require.Equal(t, 2, bytes.Count(bundle, []byte("BEGIN CERTIFICATE")))
+	require.True(t, bytes.HasPrefix(bundle, last.CA))
+	require.True(t, bytes.HasSuffix(bundle, next.CA))
+
+	_, err = RotationCABundle([]byte("not a cert"), current.CA)
+	require.Error(t, err)
+}
+
+func TestReissuePKI(t *testing.T) {
+	originalNow := now
+	defer func() { now = originalNow }()
+
+	creationTime := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	now = func() time.Time { return creationTime }
+	current, err := GeneratePKI("localhost")
+	require.NoError(t, err)
+	require.NotEmpty(t, current.CAKey)
+
+	// The reissued certificate is signed by the same CA but cannot outlive it.
+	now = func() time.Time { return creationTime.Add(300 * 24 * time.Hour) }
+	reissued, err := ReissuePKI(current, "localhost")
+	require.NoError(t, err)
+	require.Equal(t, current.CA, reissued.CA)
+	require.Equal(t, current.CAKey, reissued.CAKey)
+	require.NotEqual(t, current.Key, reissued.Key)
+	pool := x509.NewCertPool()
+	require.True(t, pool.AppendCertsFromPEM(current.CA))
+	cert, err := ParseCertFromPEM(reissued.Cert)
+	require.NoError(t, err)
+	_, err = cert.Verify(x509.VerifyOptions{Roots: pool, DNSName: "localhost", CurrentTime: now()})
+	require.NoError(t, err)
+	ca, err := ParseCertFromPEM(current.CA)
+	require.NoError(t, err)
+	require.Equal(t, ca.NotAfter, cert.NotAfter)
+
+	_, err = ReissuePKI(GeneratedPKI{CA: current.CA, Cert: current.Cert, Key: current.Key}, "localhost")
+	require.EqualError(t, err, "the CA key is not available")
+	other, err := GeneratePKI("localhost")
+	require.NoError(t, err)
+	_, err = ReissuePKI(GeneratedPKI{CA: current.CA, CAKey: other.CAKey}, "localhost")
+	require.EqualError(t, err, "the CA key does not match the CA certificate")
+	now = func() time.Time { return ca.NotAfter.Add(time.Hour) }
+	_, err = ReissuePKI(current, "localhost")
+	require.ErrorContains(t, err, "the CA expired")
+}
+
 func TestGeneratePKIWithOptions(t *testing.T) {
 	originalNow := now
 	defer func() { now = originalNow }()
@@ -8,6 +8,8 @@ import (
 	"errors"
 	"fmt"
 	"os"
+
+	"github.com/sigstore/sigstore-go/pkg/root"
 )
 
 // embeddedTrustedRoot is the Sigstore TrustedRoot JSON shipped with the binary.
@@ -16,6 +18,15 @@ import (
 //go:embed embedded_trusted_root.json
 var embeddedTrustedRoot []byte
 
+// TrustedRoot returns the embedded TrustedRoot.
+func TrustedRoot() (*root.TrustedRoot, error) {
+	trustedRoot, err := root.NewTrustedRootFromJSON(embeddedTrustedRoot)
+	if err != nil {
+		return nil, fmt.Errorf("parsing embedded trusted root: %w", err)
+	}
+	return trustedRoot, nil
+}
+
 // writeEmbeddedTrustedRoot stages the embedded TrustedRoot JSON to a tempfile so
 // cosign's VerifyBlobCmd (which only accepts file paths) can consume it.
 // Caller must invoke cleanup when done; cleanup returns the os.Remove error.
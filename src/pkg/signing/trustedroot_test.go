@@ -11,6 +11,14 @@ import (
 	"github.com/stretchr/testify/require"
 )
 
+func TestTrustedRoot(t *testing.T) {
+	t.Parallel()
+	trustedRoot, err := TrustedRoot()
+	require.NoError(t, err)
+	require.NotEmpty(t, trustedRoot.FulcioCertificateAuthorities())
+	require.NotEmpty(t, trustedRoot.RekorLogs())
+}
+
 func TestWriteEmbeddedTrustedRoot(t *testing.T) {
 	t.Parallel()
 
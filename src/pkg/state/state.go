@@ -6,8 +6,13 @@ package state
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"maps"
+	"net/url"
 	"regexp"
+	"slices"
+	"strings"
 
 	"github.com/defenseunicorns/pkg/helpers/v2"
 	"github.com/zarf-dev/zarf/src/api/v1alpha1"
@@ -27,6 +32,22 @@ const (
 	MutationPolicyAll MutationPolicy = "all"
 	// MutationPolicyLabeled mutates only resources (or namespaces) labeled zarf.dev/agent: mutate.
 	MutationPolicyLabeled MutationPolicy = "labeled"
+	// MutationPolicyAudit mutates only resources (or namespaces) labeled zarf.dev/agent: mutate and reports, without
+	// applying, the mutations that MutationPolicyAll would make to every other resource.
+	MutationPolicyAudit MutationPolicy = "audit"
+)
+
+// ImagePullSecretMode controls how the agent gives pods access to the Zarf image pull secret.
+type ImagePullSecretMode string
+
+const (
+	// ImagePullSecretModeReplace replaces the image pull secrets of every mutated pod with the Zarf pull secret.
+	ImagePullSecretModeReplace ImagePullSecretMode = "replace"
+	// ImagePullSecretModeMerge adds the Zarf pull secret to the image pull secrets a pod already declares.
+	ImagePullSecretModeMerge ImagePullSecretMode = "merge"
+	// ImagePullSecretModeServiceAccount leaves pods as is and adds the Zarf pull secret to the ServiceAccounts in
+	// Zarf-managed namespaces instead.
+	ImagePullSecretModeServiceAccount ImagePullSecretMode = "serviceaccount"
 )
 
 // Declares secrets and metadata keys and values.
@@ -168,7 +189,9 @@ type State struct {
 	AgentTLSUserProvided bool `json:"agentTLSUserProvided,omitempty"`
 	// AgentMutationPolicy controls the conditions required for the agent to mutate resources
 	AgentMutationPolicy MutationPolicy `json:"agentMutationPolicy"`
-	InjectorInfo        InjectorInfo   `json:"injectorInfo"`
+	// AgentImagePullSecretMode controls how the agent gives pods access to the Zarf image pull secret, empty means replace
+	AgentImagePullSecretMode ImagePullSecretMode `json:"agentImagePullSecretMode,omitempty"`
+	InjectorInfo             InjectorInfo        `json:"injectorInfo"`
 
 	// Information about the repository Zarf is configured to use
 	GitServer GitServerInfo `json:"gitServer"`
@@ -176,6 +199,10 @@ type State struct {
 	RegistryInfo RegistryInfo `json:"registryInfo"`
 	// Information about the artifact registry Zarf is configured to use
 	ArtifactServer ArtifactServerInfo `json:"artifactServer"`
+	// Additional registries that namespaces can route their images to instead of RegistryInfo
+	RegistryTargets []RegistryTarget `json:"registryTargets,omitempty"`
+	// Maps namespaces to the name of the registry target their images are routed to
+	NamespaceRegistryTargets map[string]string `json:"namespaceRegistryTargets,omitempty"`
 }
 
 // AgentIsConfigured returns true when Zarf has agent TLS configured.
@@ -207,6 +234,62 @@ type GitServerInfo struct {
 	PullPassword string `json:"pullPassword"`
 	// URL address of the git server
 	Address string `json:"address"`
+	// Provider is the API Zarf uses to create repositories and manage users on the git server
+	Provider GitProvider `json:"provider,omitempty"`
+}
+
+// GitProvider defines the API of the git server.
+type GitProvider string
+
+const (
+	// GitProviderGitea uses the Gitea API, which is used by the git server deployed through the default init package
+	GitProviderGitea GitProvider = "gitea"
+	// GitProviderGitLab uses the GitLab v4 API
+	GitProviderGitLab GitProvider = "gitlab"
+	// GitProviderPrecreated only pushes to repositories that were created ahead of time
+	GitProviderPrecreated GitProvider = "precreated"
+)
+
+// GitProviders returns the supported git providers.
+func GitProviders() []GitProvider {
+	return []GitProvider{GitProviderGitea, GitProviderGitLab, GitProviderPrecreated}
+}
+
+// EffectiveProvider returns the configured provider, defaulting to Gitea for the internal git server
+// and to pre-created repositories for external git servers.
+func (gs GitServerInfo) EffectiveProvider() GitProvider {
+	if gs.Provider != "" {
+		return gs.Provider
+	}
+	if gs.IsInternal() {
+		return GitProviderGitea
+	}
+	return GitProviderPrecreated
+}
+
+// ManagesUsers returns true if Zarf rotates the credentials of the git server users through the API of its provider.
+func (gs GitServerInfo) ManagesUsers() bool {
+	return gs.EffectiveProvider() != GitProviderPrecreated
+}
+
+// HostsArtifactServer returns true if the artifact server is the package registry of the git server, in which case
+// Zarf creates its push token through the API of the git provider.
+func (gs GitServerInfo) HostsArtifactServer(as ArtifactServerInfo) bool {
+	if as.IsInternal() {
+		return true
+	}
+	if gs.EffectiveProvider() != GitProviderGitLab {
+		return false
+	}
+	gitURL, err := url.Parse(gs.Address)
+	if err != nil {
+		return false
+	}
+	artifactURL, err := url.Parse(as.Address)
+	if err != nil {
+		return false
+	}
+	return gitURL.Host != "" && gitURL.Host == artifactURL.Host
 }
 
 // IsInternal returns true if the git server URL is equivalent to a git server deployed through the default init package
@@ -461,6 +544,107 @@ func (ri RegistryInfo) Htpasswd() (string, error) {
 	return fmt.Sprintf("%s\\n%s", pushUser, pullUser), nil
 }
 
+// RegistryTarget is a named registry, or a repository prefix within one, that namespaces can select for their images.
+// Targets are always treated as external registries.
+type RegistryTarget struct {
+	// Name of the target, referenced by namespaces and deploy
+	Name string `json:"name"`
+	// URL address of the registry
+	Address string `json:"address"`
+	// Repository path prefix that images are pushed under, e.g. tenant-a
+	Prefix string `json:"prefix,omitempty"`
+	// Username of a user with push access to the registry
+	PushUsername string `json:"pushUsername"`
+	// Password of a user with push access to the registry
+	PushPassword string `json:"pushPassword"`
+	// Username of a user with pull-only access to the registry. If not provided the push-user is used
+	PullUsername string `json:"pullUsername"`
+	// Password of a user with pull-only access to the registry. If not provided the push-user is used
+	PullPassword string `json:"pullPassword"`
+}
+
+// RegistryInfo returns the target as registry info. The prefix is part of the address so that images are transformed
+// to live under it.
+func (rt RegistryTarget) RegistryInfo() RegistryInfo {
+	address := rt.Address
+	if prefix := strings.Trim(rt.Prefix, "/"); prefix != "" {
+		address = address + "/" + prefix
+	}
+	ri := RegistryInfo{
+		Address:      address,
+		PushUsername: rt.PushUsername,
+		PushPassword: rt.PushPassword,
+		PullUsername: rt.PullUsername,
+		PullPassword: rt.PullPassword,
+		RegistryMode: RegistryModeExternal,
+	}
+	if ri.PullUsername == "" {
+		ri.PullUsername = ri.PushUsername
+		ri.PullPassword = ri.PushPassword
+	}
+	return ri
+}
+
+// GetRegistryTarget returns the registry target with the given name.
+func (s *State) GetRegistryTarget(name string) (RegistryTarget, error) {
+	for _, target := range s.RegistryTargets {
+		if target.Name == name {
+			return target, nil
+		}
+	}
+	return RegistryTarget{}, fmt.Errorf("registry target %q does not exist", name)
+}
+
+// SetRegistryTarget adds the target or replaces the target with the same name. When namespaces is not nil it replaces
+// the namespaces mapped to the target.
+func (s *State) SetRegistryTarget(target RegistryTarget, namespaces []string) error {
+	if target.Name == "" {
+		return errors.New("registry target name must be set")
+	}
+	if target.Address == "" {
+		return fmt.Errorf("registry target %q must have an address", target.Name)
+	}
+	idx := slices.IndexFunc(s.RegistryTargets, func(rt RegistryTarget) bool { return rt.Name == target.Name })
+	if idx >= 0 {
+		s.RegistryTargets[idx] = target
+	} else {
+		s.RegistryTargets = append(s.RegistryTargets, target)
+	}
+	if namespaces == nil {
+		return nil
+	}
+	maps.DeleteFunc(s.NamespaceRegistryTargets, func(_, name string) bool { return name == target.Name })
+	if len(namespaces) > 0 && s.NamespaceRegistryTargets == nil {
+		s.NamespaceRegistryTargets = map[string]string{}
+	}
+	for _, namespace := range namespaces {
+		s.NamespaceRegistryTargets[namespace] = target.Name
+	}
+	return nil
+}
+
+// RemoveRegistryTarget removes the named target and the namespaces mapped to it.
+func (s *State) RemoveRegistryTarget(name string) error {
+	if _, err := s.GetRegistryTarget(name); err != nil {
+		return err
+	}
+	s.RegistryTargets = slices.DeleteFunc(s.RegistryTargets, func(rt RegistryTarget) bool { return rt.Name == name })
+	maps.DeleteFunc(s.NamespaceRegistryTargets, func(_, target string) bool { return target == name })
+	return nil
+}
+
+// RegistryInfoForTarget returns the registry info for the named target, or RegistryInfo when name is empty.
+func (s *State) RegistryInfoForTarget(name string) (RegistryInfo, error) {
+	if name == "" {
+		return s.RegistryInfo, nil
+	}
+	target, err := s.GetRegistryTarget(name)
+	if err != nil {
+		return RegistryInfo{}, err
+	}
+	return target.RegistryInfo(), nil
+}
+
 // CheckIfRegistryAddressOrCredsChanged compares two RegistryInfo structs and returns true if the creds or address changed
 func CheckIfRegistryAddressOrCredsChanged(existing, given RegistryInfo) bool {
 	if given.PushUsername != "" && existing.PushUsername != given.PushUsername {
@@ -583,6 +767,8 @@ type MergeOptions struct {
 	AgentTLS *pki.GeneratedPKI
 	// AgentMutationPolicy controls whether the agent mutates by default (default-mutate) or only on explicit label (default-ignore).
 	AgentMutationPolicy MutationPolicy
+	// AgentImagePullSecretMode controls how the agent gives pods access to the Zarf image pull secret.
+	AgentImagePullSecretMode ImagePullSecretMode
 }
 
 // Merge merges init options for provided services into the provided state to create a new state struct
@@ -612,6 +798,9 @@ func Merge(oldState *State, opts MergeOptions) (*State, error) {
 	if opts.Services.Has(GitKey) {
 		// TODO: Replace use of reflections with explicit setting
 		newState.GitServer = helpers.MergeNonZero(newState.GitServer, opts.GitServer)
+		if !slices.Contains(GitProviders(), newState.GitServer.EffectiveProvider()) {
+			return nil, fmt.Errorf("unsupported git provider %q", newState.GitServer.Provider)
+		}
 
 		// Only autogenerate passwords if the user didn't provide one and the git server is internal
 		if opts.GitServer.PushPassword == "" && oldState.GitServer.IsInternal() {
@@ -649,6 +838,9 @@ func Merge(oldState *State, opts MergeOptions) (*State, error) {
 		if opts.AgentMutationPolicy != "" {
 			newState.AgentMutationPolicy = opts.AgentMutationPolicy
 		}
+		if opts.AgentImagePullSecretMode != "" {
+			newState.AgentImagePullSecretMode = opts.AgentImagePullSecretMode
+		}
 	}
 
 	return &newState, nil
@@ -670,6 +862,7 @@ func sanitizeState(s *State) *State {
 	s.AgentTLS.CA = []byte("**sanitized**")
 	s.AgentTLS.Cert = []byte("**sanitized**")
 	s.AgentTLS.Key = []byte("**sanitized**")
+	s.AgentTLS.CAKey = []byte("**sanitized**")
 
 	// Overwrite the GitServer passwords
 	s.GitServer.PushPassword = "**sanitized**"
@@ -683,6 +876,15 @@ func sanitizeState(s *State) *State {
 	// Overwrite the ArtifactServer secret
 	s.ArtifactServer.PushToken = "**sanitized**"
 
+	// Overwrite the registry target passwords, copying the slice so the original state is left untouched
+	targets := make([]RegistryTarget, len(s.RegistryTargets))
+	for i, target := range s.RegistryTargets {
+		target.PushPassword = "**sanitized**"
+		target.PullPassword = "**sanitized**"
+		targets[i] = target
+	}
+	s.RegistryTargets = targets
+
 	return s
 }
 
@@ -771,6 +973,8 @@ type DeployedComponent struct {
 	InstalledCharts    []InstalledChart `json:"installedCharts"`
 	Status             ComponentStatus  `json:"status"`
 	ObservedGeneration int              `json:"observedGeneration"`
+	// GitRepos are the owner/name paths of the repositories this component pushed to the Zarf git server
+	GitRepos []string `json:"gitRepos,omitempty"`
 }
 
 // ChartStatus is the status of a Helm Chart release
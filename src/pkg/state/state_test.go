@@ -25,6 +25,31 @@ func TestAgentIsConfigured(t *testing.T) {
 	require.True(t, (&State{AgentTLS: pki.GeneratedPKI{Cert: []byte("cert")}}).AgentIsConfigured())
 }
 
+func TestGitServerEffectiveProvider(t *testing.T) {
+	t.Parallel()
+
+	require.Equal(t, GitProviderGitea, GitServerInfo{Address: ZarfInClusterGitServiceURL}.EffectiveProvider())
+	require.Equal(t, GitProviderPrecreated, GitServerInfo{Address: "https://git.example.com"}.EffectiveProvider())
+	require.Equal(t, GitProviderGitLab, GitServerInfo{Address: "https://git.example.com", Provider: GitProviderGitLab}.EffectiveProvider())
+}
+
+func TestGitServerManagedCredentials(t *testing.T) {
+	t.Parallel()
+
+	internal := GitServerInfo{Address: ZarfInClusterGitServiceURL}
+	gitlab := GitServerInfo{Address: "https://git.example.com", Provider: GitProviderGitLab}
+	precreated := GitServerInfo{Address: "https://git.example.com"}
+
+	require.True(t, internal.ManagesUsers())
+	require.True(t, gitlab.ManagesUsers())
+	require.False(t, precreated.ManagesUsers())
+
+	require.True(t, internal.HostsArtifactServer(ArtifactServerInfo{Address: ZarfInClusterArtifactServiceURL}))
+	require.True(t, gitlab.HostsArtifactServer(ArtifactServerInfo{Address: "https://git.example.com/api/v4/projects/1/packages"}))
+	require.False(t, gitlab.HostsArtifactServer(ArtifactServerInfo{Address: "https://artifacts.example.com"}))
+	require.False(t, precreated.HostsArtifactServer(ArtifactServerInfo{Address: "https://git.example.com/api/packages"}))
+}
+
 func TestRegistryInfoKnownPlainHTTP(t *testing.T) {
 	t.Parallel()
 
@@ -49,6 +74,53 @@ func TestRegistryInfoKnownPlainHTTP(t *testing.T) {
 	}
 }
 
+func TestRegistryTargets(t *testing.T) {
+	t.Parallel()
+
+	s := &State{RegistryInfo: RegistryInfo{Address: "127.0.0.1:31999"}}
+	ri, err := s.RegistryInfoForTarget("")
+	require.NoError(t, err)
+	require.Equal(t, s.RegistryInfo, ri)
+	_, err = s.RegistryInfoForTarget("tenant-a")
+	require.EqualError(t, err, `registry target "tenant-a" does not exist`)
+
+	require.EqualError(t, s.SetRegistryTarget(RegistryTarget{Name: "tenant-a"}, nil), `registry target "tenant-a" must have an address`)
+	target := RegistryTarget{Name: "tenant-a", Address: "registry.example.com", Prefix: "/tenant-a/", PushUsername: "push", PushPassword: "push-password"}
+	require.NoError(t, s.SetRegistryTarget(target, []string{"app-a", "app-b"}))
+	require.NoError(t, s.SetRegistryTarget(RegistryTarget{Name: "tenant-b", Address: "registry.example.com"}, []string{"app-c"}))
+	require.Equal(t, map[string]string{"app-a": "tenant-a", "app-b": "tenant-a", "app-c": "tenant-b"}, s.NamespaceRegistryTargets)
+
+	ri, err = s.RegistryInfoForTarget("tenant-a")
+	require.NoError(t, err)
+	expected := RegistryInfo{
+		Address:      "registry.example.com/tenant-a",
+		PushUsername: "push",
+		PushPassword: "push-password",
+		PullUsername: "push",
+		PullPassword: "push-password",
+		RegistryMode: RegistryModeExternal,
+	}
+	require.Equal(t, expected, ri)
+
+	// Updating a target without namespaces keeps its mapping, updating it with namespaces replaces the mapping.
+	target.PullUsername = "pull"
+	require.NoError(t, s.SetRegistryTarget(target, nil))
+	require.Len(t, s.RegistryTargets, 2)
+	require.Equal(t, "pull", s.RegistryTargets[0].PullUsername)
+	require.Equal(t, "tenant-a", s.NamespaceRegistryTargets["app-b"])
+	require.NoError(t, s.SetRegistryTarget(target, []string{"app-d"}))
+	require.Equal(t, map[string]string{"app-c": "tenant-b", "app-d": "tenant-a"}, s.NamespaceRegistryTargets)
+
+	sanitized := sanitizeState(&State{RegistryTargets: s.RegistryTargets})
+	require.Equal(t, "**sanitized**", sanitized.RegistryTargets[0].PushPassword)
+	require.Equal(t, "push-password", s.RegistryTargets[0].PushPassword)
+
+	require.NoError(t, s.RemoveRegistryTarget("tenant-a"))
+	require.EqualError(t, s.RemoveRegistryTarget("tenant-a"), `registry target "tenant-a" does not exist`)
+	require.Equal(t, []RegistryTarget{{Name: "tenant-b", Address: "registry.example.com"}}, s.RegistryTargets)
+	require.Equal(t, map[string]string{"app-c": "tenant-b"}, s.NamespaceRegistryTargets)
+}
+
 func TestRegistryInfoResolvePlainHTTP(t *testing.T) {
 	t.Parallel()
 
@@ -37,7 +37,7 @@ func ImageTransformHost(targetHost, srcReference string) (string, error) {
 	}
 
 	// check if image has already been transformed
-	if strings.HasPrefix(targetHost, image.Host) {
+	if isTransformed(targetHost, image) {
 		return srcReference, nil
 	}
 
@@ -49,6 +49,15 @@ func ImageTransformHost(targetHost, srcReference string) (string, error) {
 	return fmt.Sprintf("%s/%s:%s", targetHost, image.Path, CRCTag(image.Name, image.Tag)), nil
 }
 
+// isTransformed reports whether image already points at targetHost. A target with a path, such as a registry
+// target with a prefix, only matches images under that path so other images on the same host are still moved.
+func isTransformed(targetHost string, image Image) bool {
+	if host, _, ok := strings.Cut(targetHost, "/"); ok {
+		return image.Host == host && strings.HasPrefix(image.Name, targetHost+"/")
+	}
+	return strings.HasPrefix(targetHost, image.Host)
+}
+
 // ImageTransformHostWithoutChecksum replaces the base url for an image but avoids adding a checksum of the original url (note image refs are not full URLs).
 func ImageTransformHostWithoutChecksum(targetHost, srcReference string) (string, error) {
 	image, err := ParseImageRef(srcReference)
@@ -57,7 +66,7 @@ func ImageTransformHostWithoutChecksum(targetHost, srcReference string) (string,
 	}
 
 	// check if image has already been transformed
-	if strings.HasPrefix(targetHost, image.Host) {
+	if isTransformed(targetHost, image) {
 		return srcReference, nil
 	}
 
@@ -54,6 +54,45 @@ func TestImageTransformHost(t *testing.T) {
 	}
 }
 
+func TestImageTransformHostPrefixedTarget(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		ref      string
+		expected string
+	}{
+		{
+			name:     "other registry",
+			ref:      "nginx:1.23.3",
+			expected: "registry.example.com/zarf/library/nginx:1.23.3-zarf-3793515731",
+		},
+		{
+			name:     "same host outside the prefix",
+			ref:      "registry.example.com/other-team/app:1.0.0",
+			expected: "registry.example.com/zarf/other-team/app:1.0.0-zarf-1196413581",
+		},
+		{
+			name:     "same host with a path sharing the prefix",
+			ref:      "registry.example.com/zarf-other/app:1.0.0",
+			expected: "registry.example.com/zarf/zarf-other/app:1.0.0-zarf-3543540504",
+		},
+		{
+			name:     "already under the prefix",
+			ref:      "registry.example.com/zarf/library/nginx:1.23.3-zarf-3793515731",
+			expected: "registry.example.com/zarf/library/nginx:1.23.3-zarf-3793515731",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			newRef, err := ImageTransformHost("registry.example.com/zarf", tt.ref)
+			require.NoError(t, err)
+			require.Equal(t, tt.expected, newRef)
+		})
+	}
+}
+
 func TestCRCTag(t *testing.T) {
 	img, err := ParseImageRef("nginx:1.23.3")
 	require.NoError(t, err)
@@ -42,6 +42,8 @@ func SetupInMemoryRegistry(ctx context.Context, t *testing.T, port int) string {
 	logrus.SetOutput(io.Discard)
 	config.HTTP.DrainTimeout = 10 * time.Second
 	config.Storage = map[string]configuration.Parameters{"inmemory": map[string]interface{}{}}
+	// Match the registry's default when loaded from a config file so catalog requests are not rejected.
+	config.Catalog.MaxEntries = 1000
 	ref, err := registry.NewRegistry(ctx, config)
 	require.NoError(t, err)
 	//nolint:errcheck // ignore
@@ -126,6 +128,8 @@ func SetupInMemoryRegistryTLSAuth(ctx context.Context, t *testing.T, username, p
 	config.Log.Level = "error"
 	config.HTTP.DrainTimeout = 10 * time.Second
 	config.Storage = map[string]configuration.Parameters{"inmemory": map[string]interface{}{}}
+	// Match the registry's default when loaded from a config file so catalog requests are not rejected.
+	config.Catalog.MaxEntries = 1000
 	logrus.SetOutput(io.Discard)
 
 	reg, err := registry.NewRegistry(ctx, config)